@@ -0,0 +1,5 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package cache contains the cache of compiled schemas shared by the validators, so schemas are only compiled once.
+package cache
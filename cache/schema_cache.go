@@ -0,0 +1,69 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package cache
+
+import (
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// SchemaCacheEntry is a schema that has been decoded, prepared for validation and compiled.
+type SchemaCacheEntry struct {
+	// CompiledSchema is the compiled schema, ready to validate values.
+	CompiledSchema *jsonschema.Schema
+
+	// DecodedSchema is the decoded JSON schema that was compiled. It must not be modified.
+	DecodedSchema any
+}
+
+// SchemaCache is a cache of compiled schemas that is safe for concurrent use. Entries are keyed by the content
+// of the schema, so a schema that changes when a document is rebuilt is compiled again.
+type SchemaCache struct {
+	entries sync.Map
+}
+
+// NewSchemaCache will create a new, empty SchemaCache.
+func NewSchemaCache() *SchemaCache {
+	return &SchemaCache{}
+}
+
+// Load will return the entry stored for a key, and true if an entry was found.
+func (c *SchemaCache) Load(key string) (*SchemaCacheEntry, bool) {
+	if c == nil {
+		return nil, false
+	}
+	entry, ok := c.entries.Load(key)
+	if !ok {
+		return nil, false
+	}
+	return entry.(*SchemaCacheEntry), true
+}
+
+// Store will store an entry for a key, replacing any existing entry.
+func (c *SchemaCache) Store(key string, entry *SchemaCacheEntry) {
+	if c == nil || entry == nil {
+		return
+	}
+	c.entries.Store(key, entry)
+}
+
+// Len will return the number of entries in the cache.
+func (c *SchemaCache) Len() int {
+	count := 0
+	if c != nil {
+		c.entries.Range(func(_, _ any) bool {
+			count++
+			return true
+		})
+	}
+	return count
+}
+
+// Clear will remove every entry from the cache.
+func (c *SchemaCache) Clear() {
+	if c != nil {
+		c.entries.Clear()
+	}
+}
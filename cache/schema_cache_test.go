@@ -0,0 +1,62 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaCache_LoadStore(t *testing.T) {
+	c := NewSchemaCache()
+	_, ok := c.Load("pizza")
+	assert.False(t, ok)
+
+	entry := &SchemaCacheEntry{DecodedSchema: map[string]any{"type": "string"}}
+	c.Store("pizza", entry)
+	found, ok := c.Load("pizza")
+	assert.True(t, ok)
+	assert.Same(t, entry, found)
+	assert.Equal(t, 1, c.Len())
+
+	c.Clear()
+	assert.Equal(t, 0, c.Len())
+	_, ok = c.Load("pizza")
+	assert.False(t, ok)
+}
+
+func TestSchemaCache_StoreNil(t *testing.T) {
+	c := NewSchemaCache()
+	c.Store("pizza", nil)
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestSchemaCache_NilCache(t *testing.T) {
+	var c *SchemaCache
+	c.Store("pizza", &SchemaCacheEntry{})
+	_, ok := c.Load("pizza")
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Len())
+	c.Clear()
+}
+
+func TestSchemaCache_Concurrent(t *testing.T) {
+	c := NewSchemaCache()
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := fmt.Sprintf("schema-%d", i%10)
+			c.Store(key, &SchemaCacheEntry{})
+			_, ok := c.Load(key)
+			assert.True(t, ok)
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 10, c.Len())
+}
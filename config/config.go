@@ -0,0 +1,419 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/pb33f/libopenapi-validator/cache"
+)
+
+// ValidationOptions is a container for the configuration of the validators. All options default to off,
+// so the behavior of a validator does not change unless an option is supplied. The only exception is the
+// schema cache, which is created by default.
+//
+// Generally the fluent With... style functions are used to establish the desired behavior.
+type ValidationOptions struct {
+	// StrictQueryParams will report query parameters that are present in the request, but are not defined
+	// by the operation or the path item.
+	StrictQueryParams bool
+
+	// DisableContentDecoding will validate request bodies that are compressed with gzip or deflate, as declared by
+	// the 'Content-Encoding' header, as they are sent. By default, they are decompressed before they are validated.
+	DisableContentDecoding bool
+
+	// KeepDecodedBody will replace a compressed request body with the decompressed body once it has been validated,
+	// and remove the 'Content-Encoding' header, so the handler of the request reads the decompressed body. By default,
+	// the compressed body is put back as it was sent, so any defaults applied to the body are not kept.
+	KeepDecodedBody bool
+
+	// MaxDecodedBodySize is the largest size, in bytes, that a compressed request body is read and decompressed to.
+	// A body that is larger fails validation, so a small compressed body cannot expand to fill the memory of the
+	// validator. Zero means DefaultMaxDecodedBodySize is used.
+	MaxDecodedBodySize int64
+
+	// StreamRequestBody will decode JSON request bodies with a streaming decoder as they are read, rather than reading
+	// the whole body into memory before decoding it. No more than MaxStreamedBodySize bytes of the body are held in
+	// memory, a body that is larger fails validation, and is put back in the request without being read any further.
+	StreamRequestBody bool
+
+	// MaxStreamedBodySize is the largest size, in bytes, of a JSON request body that is decoded when StreamRequestBody
+	// is set. Zero means DefaultMaxStreamedBodySize is used.
+	MaxStreamedBodySize int64
+
+	// StrictReadWriteOnly will report properties marked as readOnly that are sent in a request body, and
+	// properties marked as writeOnly that are returned in a response body.
+	StrictReadWriteOnly bool
+
+	// RequireReadOnly will keep properties that are both readOnly and required in the 'required' list of request
+	// body schemas, so a request that omits them fails validation. By default, readOnly properties are not required
+	// in a request, because they are generated by the server.
+	RequireReadOnly bool
+
+	// ApplyDefaults will fill in any missing properties of a request body that have a default defined by the schema,
+	// before the body is validated. JSON request bodies are replaced with the body that includes the defaults.
+	ApplyDefaults bool
+
+	// FormatAssertion will treat the 'format' keyword as an assertion rather than an annotation, so values that
+	// do not match a built-in format (date-time, email, uuid etc.) will fail validation.
+	FormatAssertion bool
+
+	// ContentAssertion will treat the 'contentEncoding', 'contentMediaType' and 'contentSchema' keywords as assertions
+	// rather than annotations, so string values are decoded (for example from base64), and the decoded content must
+	// match the media type (for example be valid JSON) and the content schema.
+	ContentAssertion bool
+
+	// SchemaDraft is the JSON schema draft used to compile schemas that do not declare a draft with '$schema'.
+	// An empty draft means the latest draft supported by the compiler (2020-12) is used.
+	SchemaDraft SchemaDraft
+
+	// SchemaCache holds the schemas that have been compiled, so they are only compiled once. A nil cache
+	// means schemas are compiled every time they are used.
+	SchemaCache *cache.SchemaCache
+
+	// Formats contains any custom string formats that schemas can use, registered formats are always
+	// checked, while unknown formats are ignored. Schemas are not cached when formats are assigned directly,
+	// rather than with WithCustomFormat or WithFormatRegistry.
+	Formats FormatRegistry
+
+	// UnknownFormatError will report schemas that use a 'format' which is neither built in nor registered as a
+	// custom format, rather than ignoring it. This catches misspelled formats, such as 'date-tim'.
+	UnknownFormatError bool
+
+	// ScopeExtractor returns the scopes granted to a request, it is used to check the scopes required by
+	// oauth2 and openIdConnect security requirements. Scopes are not checked when there is no extractor.
+	ScopeExtractor ScopeExtractor
+
+	// RegexEngine compiles the regular expressions used by the 'pattern' keyword of schemas. A nil engine means
+	// Go's regexp package (RE2) is used, which does not support lookarounds or backreferences. Schemas are not
+	// cached when an engine is assigned directly, rather than with WithRegexEngine.
+	RegexEngine RegexEngine
+
+	// SkipInvalidPatterns will ignore any 'pattern' keyword or 'patternProperties' key that the regex engine cannot
+	// compile, logging a warning, rather than reporting the schema as invalid.
+	SkipInvalidPatterns bool
+
+	// CoerceParameterTypes will convert the items of array parameters into the scalar type (integer, number or
+	// boolean) declared by the items schema, and validate each item against that schema, so constraints such as
+	// minimum, maximum and pattern are applied to the items. Scalar parameters are always converted.
+	CoerceParameterTypes bool
+
+	// FailFast will stop validating as soon as a ValidationError is found, and only return that error. The stages
+	// of a validation (parameters, security, request body, response headers and body) run in order, and the later
+	// stages are not run once an earlier one has failed. By default, every error that is found is returned.
+	FailFast bool
+
+	// Observer is called with the duration of each stage of a validation, such as finding the path or validating
+	// the request body. Stages are not timed when there is no observer.
+	Observer Observer
+
+	// formatIDs and regexEngineID identify the options that set the custom formats and the regex engine, so that
+	// compiled schemas are only shared by options that use the same functions.
+	formatIDs     map[string]uint64
+	regexEngineID uint64
+}
+
+// functionIDs hands out the identities of the options that set custom formats and regex engines.
+var functionIDs atomic.Uint64
+
+// The stages of a validation that are reported to an Observer.
+const (
+	StagePath         = "path"
+	StagePathParams   = "pathParams"
+	StageCookieParams = "cookieParams"
+	StageHeaderParams = "headerParams"
+	StageQueryParams  = "queryParams"
+	StageSecurity     = "security"
+	StageRequestBody  = "requestBody"
+	StageResponse     = "response"
+)
+
+// Observer receives the duration of each stage of a validation, for example to record metrics. The stages of a
+// request are validated concurrently by default, so an Observer must be safe for concurrent use.
+type Observer interface {
+	// OnStageComplete is called once a stage of a validation has completed, whether it found errors or not.
+	OnStageComplete(stage string, duration time.Duration)
+}
+
+// DefaultMaxDecodedBodySize is the largest size, in bytes, that a compressed request body is decompressed to, when
+// no MaxDecodedBodySize is set.
+const DefaultMaxDecodedBodySize int64 = 32 << 20
+
+// DefaultMaxStreamedBodySize is the largest size, in bytes, of a JSON request body that is decoded as it's streamed,
+// when no MaxStreamedBodySize is set.
+const DefaultMaxStreamedBodySize int64 = 32 << 20
+
+// SchemaDraft is a JSON schema draft that schemas can be compiled with, which controls the keywords that are
+// understood, for example 'prefixItems' and 'dependentRequired' are only understood by 2020-12.
+type SchemaDraft string
+
+// The JSON schema drafts that schemas can be compiled with.
+const (
+	SchemaDraft4    SchemaDraft = "draft-04"
+	SchemaDraft6    SchemaDraft = "draft-06"
+	SchemaDraft7    SchemaDraft = "draft-07"
+	SchemaDraft2019 SchemaDraft = "2019-09"
+	SchemaDraft2020 SchemaDraft = "2020-12"
+)
+
+// ScopeExtractor is a function that returns the scopes granted to a request, normally by reading the token
+// sent with the request. The validator does not introspect tokens, so the caller supplies this function.
+type ScopeExtractor func(request *http.Request) []string
+
+// Regexp is a compiled regular expression, used to check values against the 'pattern' keyword of a schema.
+type Regexp interface {
+	MatchString(s string) bool
+}
+
+// RegexEngine is a function that compiles a pattern into a Regexp. It allows an engine that supports ECMA 262
+// regular expressions to be used in place of Go's regexp package, which rejects some patterns that are valid
+// in JSON schema, such as lookaheads and backreferences.
+type RegexEngine func(pattern string) (Regexp, error)
+
+// Option enables an 'options pattern' approach to configuring validators.
+type Option func(*ValidationOptions)
+
+// NewValidationOptions creates a new ValidationOptions instance with default values, and then applies
+// any supplied options.
+func NewValidationOptions(opts ...Option) *ValidationOptions {
+	o := &ValidationOptions{SchemaCache: cache.NewSchemaCache()}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	return o
+}
+
+// WithStrictQueryParams enables the reporting of query parameters that are not defined in the specification.
+func WithStrictQueryParams() Option {
+	return func(o *ValidationOptions) {
+		o.StrictQueryParams = true
+	}
+}
+
+// WithoutContentDecoding disables decompressing gzip and deflate request bodies before they are validated.
+func WithoutContentDecoding() Option {
+	return func(o *ValidationOptions) {
+		o.DisableContentDecoding = true
+	}
+}
+
+// WithDecodedRequestBody enables replacing compressed request bodies with the decompressed body after validation.
+func WithDecodedRequestBody() Option {
+	return func(o *ValidationOptions) {
+		o.KeepDecodedBody = true
+	}
+}
+
+// WithMaxDecodedBodySize sets the largest size, in bytes, that a compressed request body is decompressed to.
+func WithMaxDecodedBodySize(size int64) Option {
+	return func(o *ValidationOptions) {
+		o.MaxDecodedBodySize = size
+	}
+}
+
+// WithStreamingRequestBody enables the decoding of JSON request bodies as they are streamed from the request, holding
+// no more than maxSize bytes of the body in memory. A maxSize of zero uses DefaultMaxStreamedBodySize.
+func WithStreamingRequestBody(maxSize int64) Option {
+	return func(o *ValidationOptions) {
+		o.StreamRequestBody = true
+		o.MaxStreamedBodySize = maxSize
+	}
+}
+
+// WithStrictReadWriteOnly enables the reporting of readOnly properties in requests, and writeOnly properties
+// in responses.
+func WithStrictReadWriteOnly() Option {
+	return func(o *ValidationOptions) {
+		o.StrictReadWriteOnly = true
+	}
+}
+
+// WithRequireReadOnly enables requiring readOnly properties in requests, when they are listed as required.
+func WithRequireReadOnly() Option {
+	return func(o *ValidationOptions) {
+		o.RequireReadOnly = true
+	}
+}
+
+// WithApplyDefaults enables filling in the defaults of missing request body properties before validation.
+func WithApplyDefaults() Option {
+	return func(o *ValidationOptions) {
+		o.ApplyDefaults = true
+	}
+}
+
+// WithFormatAssertions enables checking the 'format' keyword of schemas, formats are only annotations otherwise.
+func WithFormatAssertions() Option {
+	return func(o *ValidationOptions) {
+		o.FormatAssertion = true
+	}
+}
+
+// WithUnknownFormatError enables reporting schemas that use a format which is not built in or registered.
+func WithUnknownFormatError() Option {
+	return func(o *ValidationOptions) {
+		o.UnknownFormatError = true
+	}
+}
+
+// WithContentAssertions enables decoding and checking string content, using the 'contentEncoding',
+// 'contentMediaType' and 'contentSchema' keywords of schemas, which are only annotations otherwise.
+func WithContentAssertions() Option {
+	return func(o *ValidationOptions) {
+		o.ContentAssertion = true
+	}
+}
+
+// WithSchemaDraft will compile schemas with a JSON schema draft, unless a schema declares its own draft.
+func WithSchemaDraft(draft SchemaDraft) Option {
+	return func(o *ValidationOptions) {
+		o.SchemaDraft = draft
+	}
+}
+
+// WithSchemaCache will use the supplied cache for compiled schemas, which allows a cache to be shared between
+// validators. Schemas are cached separately for each set of options that changes how they are compiled (such as
+// the formats, regex engine and schema draft). Custom formats and regex engines are identified by the option that
+// set them, so validators only share schemas compiled with them when they are configured with the same option
+// values. Supplying nil disables the caching of compiled schemas.
+func WithSchemaCache(schemaCache *cache.SchemaCache) Option {
+	return func(o *ValidationOptions) {
+		o.SchemaCache = schemaCache
+	}
+}
+
+// WithScopeExtractor enables checking the scopes required by oauth2 and openIdConnect security requirements,
+// using the supplied function to read the scopes granted to a request.
+func WithScopeExtractor(extractor ScopeExtractor) Option {
+	return func(o *ValidationOptions) {
+		o.ScopeExtractor = extractor
+	}
+}
+
+// WithRegexEngine will use the supplied engine to compile the regular expressions used by the 'pattern' keyword,
+// rather than Go's regexp package.
+func WithRegexEngine(engine RegexEngine) Option {
+	id := functionIDs.Add(1)
+	return func(o *ValidationOptions) {
+		o.RegexEngine = engine
+		o.regexEngineID = id
+	}
+}
+
+// WithSkipInvalidPatterns enables ignoring schema patterns that the regex engine cannot compile, rather than
+// reporting them as errors.
+func WithSkipInvalidPatterns() Option {
+	return func(o *ValidationOptions) {
+		o.SkipInvalidPatterns = true
+	}
+}
+
+// WithParameterTypeCoercion enables converting the items of array parameters into their declared types, so they
+// can be validated against the items schema.
+func WithParameterTypeCoercion() Option {
+	return func(o *ValidationOptions) {
+		o.CoerceParameterTypes = true
+	}
+}
+
+// WithFailFast enables stopping at the first ValidationError, rather than collecting every error.
+func WithFailFast() Option {
+	return func(o *ValidationOptions) {
+		o.FailFast = true
+	}
+}
+
+// WithObserver will call the supplied Observer with the duration of each stage of a validation.
+func WithObserver(observer Observer) Option {
+	return func(o *ValidationOptions) {
+		o.Observer = observer
+	}
+}
+
+// WithExistingOpts copies an existing set of options, this is used to pass the options of a validator down
+// to the functions it uses to validate schemas.
+func WithExistingOpts(options *ValidationOptions) Option {
+	return func(o *ValidationOptions) {
+		if options != nil {
+			*o = *options
+		}
+	}
+}
+
+// WithFormatRegistry adds all the formats in a FormatRegistry to the custom formats used when validating schemas.
+func WithFormatRegistry(registry FormatRegistry) Option {
+	// the registry is copied, so the formats added by the option cannot change once it has been created.
+	formats := NewFormatRegistry()
+	for name, validator := range registry {
+		formats[name] = validator
+	}
+	id := functionIDs.Add(1)
+	return func(o *ValidationOptions) {
+		for name, validator := range formats {
+			o.registerFormat(name, validator, id)
+		}
+	}
+}
+
+// WithCustomFormat adds a single custom format that schemas can use, for example 'iban'.
+func WithCustomFormat(name string, validator FormatValidator) Option {
+	id := functionIDs.Add(1)
+	return func(o *ValidationOptions) {
+		o.registerFormat(name, validator, id)
+	}
+}
+
+// registerFormat will add a format to the options, without modifying any registry that was previously copied in.
+// The id identifies the option that added the format.
+func (o *ValidationOptions) registerFormat(name string, validator FormatValidator, id uint64) {
+	if validator == nil {
+		return
+	}
+	formats := NewFormatRegistry()
+	for n, v := range o.Formats {
+		formats[n] = v
+	}
+	formatIDs := make(map[string]uint64, len(o.formatIDs)+1)
+	for n, i := range o.formatIDs {
+		formatIDs[n] = i
+	}
+	o.Formats = formats.Register(name, validator)
+	formatIDs[name] = id
+	o.formatIDs = formatIDs
+}
+
+// CompilerFunctionsKey returns a key that identifies the custom formats and regex engine that schemas are compiled
+// with. Functions cannot be compared, so they are identified by the option that set them. Options only share a key
+// when they were configured with the same WithCustomFormat, WithFormatRegistry and WithRegexEngine option values,
+// never because two functions share the same code. False is returned when a format or the regex engine was assigned
+// to the options directly, as it cannot be identified.
+func (o *ValidationOptions) CompilerFunctionsKey() (string, bool) {
+	formats := make([]string, 0, len(o.Formats))
+	for name, validator := range o.Formats {
+		if validator == nil {
+			continue
+		}
+		id, ok := o.formatIDs[name]
+		if !ok {
+			return "", false
+		}
+		formats = append(formats, fmt.Sprintf("%s=%d", name, id))
+	}
+	sort.Strings(formats)
+	var engineID uint64
+	if o.RegexEngine != nil {
+		if o.regexEngineID == 0 {
+			return "", false
+		}
+		engineID = o.regexEngineID
+	}
+	return fmt.Sprintf("%d:[%s]", engineID, strings.Join(formats, ",")), true
+}
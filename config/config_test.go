@@ -0,0 +1,207 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pb33f/libopenapi-validator/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewValidationOptions_Defaults(t *testing.T) {
+	opts := NewValidationOptions()
+	assert.False(t, opts.StrictQueryParams)
+	assert.False(t, opts.FormatAssertion)
+	assert.False(t, opts.StrictReadWriteOnly)
+	assert.False(t, opts.RequireReadOnly)
+	assert.False(t, opts.DisableContentDecoding)
+	assert.False(t, opts.KeepDecodedBody)
+	assert.Zero(t, opts.MaxDecodedBodySize)
+	assert.False(t, opts.StreamRequestBody)
+	assert.Zero(t, opts.MaxStreamedBodySize)
+	assert.False(t, opts.ApplyDefaults)
+	assert.NotNil(t, opts.SchemaCache)
+	assert.Nil(t, opts.ScopeExtractor)
+}
+
+func TestNewValidationOptions_WithStrictQueryParams(t *testing.T) {
+	opts := NewValidationOptions(WithStrictQueryParams())
+	assert.True(t, opts.StrictQueryParams)
+}
+
+func TestNewValidationOptions_NilOption(t *testing.T) {
+	opts := NewValidationOptions(nil, WithStrictQueryParams())
+	assert.True(t, opts.StrictQueryParams)
+}
+
+func TestNewValidationOptions_WithCustomFormat(t *testing.T) {
+	opts := NewValidationOptions(WithCustomFormat("iban", func(any) error { return nil }))
+	assert.Len(t, opts.Formats, 1)
+	assert.NotNil(t, opts.Formats["iban"])
+}
+
+func TestNewValidationOptions_WithFormatRegistry(t *testing.T) {
+	registry := NewFormatRegistry().
+		Register("iban", func(any) error { return nil }).
+		Register("bic", func(any) error { return nil }).
+		Register("nothing", nil)
+
+	opts := NewValidationOptions(WithFormatRegistry(registry), WithCustomFormat("sku", func(any) error { return nil }))
+	assert.Len(t, opts.Formats, 3)
+	assert.Len(t, registry, 2) // the registry supplied is not modified by later options.
+}
+
+func TestNewValidationOptions_WithExistingOpts(t *testing.T) {
+	existing := NewValidationOptions(WithStrictQueryParams(), WithCustomFormat("iban", func(any) error { return nil }))
+	opts := NewValidationOptions(WithExistingOpts(existing))
+	assert.True(t, opts.StrictQueryParams)
+	assert.Len(t, opts.Formats, 1)
+
+	opts = NewValidationOptions(WithExistingOpts(nil))
+	assert.False(t, opts.StrictQueryParams)
+}
+
+func TestNewValidationOptions_WithFormatAssertions(t *testing.T) {
+	opts := NewValidationOptions(WithFormatAssertions())
+	assert.True(t, opts.FormatAssertion)
+}
+
+func TestNewValidationOptions_WithoutContentDecoding(t *testing.T) {
+	opts := NewValidationOptions(WithoutContentDecoding())
+	assert.True(t, opts.DisableContentDecoding)
+}
+
+func TestNewValidationOptions_WithDecodedRequestBody(t *testing.T) {
+	opts := NewValidationOptions(WithDecodedRequestBody())
+	assert.True(t, opts.KeepDecodedBody)
+}
+
+func TestNewValidationOptions_WithMaxDecodedBodySize(t *testing.T) {
+	opts := NewValidationOptions(WithMaxDecodedBodySize(1024))
+	assert.Equal(t, int64(1024), opts.MaxDecodedBodySize)
+}
+
+func TestNewValidationOptions_WithStreamingRequestBody(t *testing.T) {
+	opts := NewValidationOptions(WithStreamingRequestBody(2048))
+	assert.True(t, opts.StreamRequestBody)
+	assert.Equal(t, int64(2048), opts.MaxStreamedBodySize)
+}
+
+func TestNewValidationOptions_WithStrictReadWriteOnly(t *testing.T) {
+	opts := NewValidationOptions(WithStrictReadWriteOnly())
+	assert.True(t, opts.StrictReadWriteOnly)
+}
+
+func TestNewValidationOptions_WithRequireReadOnly(t *testing.T) {
+	opts := NewValidationOptions(WithRequireReadOnly())
+	assert.True(t, opts.RequireReadOnly)
+}
+
+func TestNewValidationOptions_WithApplyDefaults(t *testing.T) {
+	opts := NewValidationOptions(WithApplyDefaults())
+	assert.True(t, opts.ApplyDefaults)
+}
+
+func TestNewValidationOptions_WithSchemaDraft(t *testing.T) {
+	opts := NewValidationOptions(WithSchemaDraft(SchemaDraft7))
+	assert.Equal(t, SchemaDraft7, opts.SchemaDraft)
+}
+
+func TestNewValidationOptions_WithSchemaCache(t *testing.T) {
+	schemaCache := cache.NewSchemaCache()
+	opts := NewValidationOptions(WithSchemaCache(schemaCache))
+	assert.Same(t, schemaCache, opts.SchemaCache)
+
+	opts = NewValidationOptions(WithSchemaCache(nil))
+	assert.Nil(t, opts.SchemaCache)
+}
+
+func TestNewValidationOptions_WithScopeExtractor(t *testing.T) {
+	opts := NewValidationOptions(WithScopeExtractor(func(request *http.Request) []string {
+		return []string{"read:pets"}
+	}))
+	assert.NotNil(t, opts.ScopeExtractor)
+	assert.Equal(t, []string{"read:pets"}, opts.ScopeExtractor(nil))
+}
+
+type stubRegexp struct{}
+
+func (stubRegexp) MatchString(string) bool { return true }
+
+func TestNewValidationOptions_WithRegexEngine(t *testing.T) {
+	opts := NewValidationOptions()
+	assert.Nil(t, opts.RegexEngine)
+
+	opts = NewValidationOptions(WithRegexEngine(func(pattern string) (Regexp, error) {
+		return stubRegexp{}, nil
+	}))
+	assert.NotNil(t, opts.RegexEngine)
+	re, err := opts.RegexEngine("^(?=.*[A-Z]).+$")
+	assert.NoError(t, err)
+	assert.True(t, re.MatchString("anything"))
+}
+
+func TestValidationOptions_CompilerFunctionsKey(t *testing.T) {
+	key, ok := NewValidationOptions().CompilerFunctionsKey()
+	assert.True(t, ok)
+
+	// each option has its own identity, even when its function shares its code with another.
+	format := WithCustomFormat("iban", func(any) error { return nil })
+	first, ok := NewValidationOptions(format).CompilerFunctionsKey()
+	assert.True(t, ok)
+	assert.NotEqual(t, key, first)
+	second, _ := NewValidationOptions(format).CompilerFunctionsKey()
+	assert.Equal(t, first, second)
+	other, _ := NewValidationOptions(WithCustomFormat("iban", func(any) error { return nil })).CompilerFunctionsKey()
+	assert.NotEqual(t, first, other)
+
+	// options copied from existing options keep their identity.
+	existing := NewValidationOptions(format, WithRegexEngine(func(string) (Regexp, error) { return stubRegexp{}, nil }))
+	existingKey, _ := existing.CompilerFunctionsKey()
+	copiedKey, _ := NewValidationOptions(WithExistingOpts(existing)).CompilerFunctionsKey()
+	assert.Equal(t, existingKey, copiedKey)
+
+	// functions assigned directly cannot be identified.
+	opts := NewValidationOptions()
+	opts.RegexEngine = func(string) (Regexp, error) { return stubRegexp{}, nil }
+	_, ok = opts.CompilerFunctionsKey()
+	assert.False(t, ok)
+
+	opts = NewValidationOptions(format)
+	opts.Formats = NewFormatRegistry().Register("bic", func(any) error { return nil })
+	_, ok = opts.CompilerFunctionsKey()
+	assert.False(t, ok)
+}
+
+func TestNewValidationOptions_WithSkipInvalidPatterns(t *testing.T) {
+	assert.False(t, NewValidationOptions().SkipInvalidPatterns)
+	assert.True(t, NewValidationOptions(WithSkipInvalidPatterns()).SkipInvalidPatterns)
+}
+
+func TestNewValidationOptions_WithFailFast(t *testing.T) {
+	assert.False(t, NewValidationOptions().FailFast)
+	assert.True(t, NewValidationOptions(WithFailFast()).FailFast)
+}
+
+func TestNewValidationOptions_WithParameterTypeCoercion(t *testing.T) {
+	assert.False(t, NewValidationOptions().CoerceParameterTypes)
+	assert.True(t, NewValidationOptions(WithParameterTypeCoercion()).CoerceParameterTypes)
+}
+
+type stubObserver struct{}
+
+func (stubObserver) OnStageComplete(string, time.Duration) {}
+
+func TestNewValidationOptions_WithObserver(t *testing.T) {
+	assert.Nil(t, NewValidationOptions().Observer)
+	assert.Equal(t, stubObserver{}, NewValidationOptions(WithObserver(stubObserver{})).Observer)
+}
+
+func TestNewValidationOptions_WithContentAssertions(t *testing.T) {
+	assert.False(t, NewValidationOptions().ContentAssertion)
+	assert.True(t, NewValidationOptions(WithContentAssertions()).ContentAssertion)
+}
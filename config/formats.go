@@ -0,0 +1,26 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package config
+
+// FormatValidator will check that a value conforms to a string format. A nil error means the value is valid,
+// values that are not strings should be ignored, as formats only apply to strings.
+type FormatValidator func(value any) error
+
+// FormatRegistry is a collection of custom string formats, keyed by the name used by the 'format' keyword
+// in a schema. Formats registered here are consulted by the schema validator during format checks, and a
+// format with the same name as a built-in format (date-time, email, uuid etc.) will replace it.
+type FormatRegistry map[string]FormatValidator
+
+// NewFormatRegistry will create a new, empty FormatRegistry.
+func NewFormatRegistry() FormatRegistry {
+	return make(FormatRegistry)
+}
+
+// Register will add a format to the registry, replacing any existing format with the same name.
+func (r FormatRegistry) Register(name string, validator FormatValidator) FormatRegistry {
+	if validator != nil {
+		r[name] = validator
+	}
+	return r
+}
@@ -0,0 +1,5 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package config contains the options used to configure the behavior of the validators.
+package config
@@ -14,3 +14,11 @@ func PopulateValidationErrors(validationErrors []*ValidationError, request *http
 		validationError.RequestPath = request.URL.Path
 	}
 }
+
+// PopulateMediaType mutates the provided validation errors of a request or response body, setting the media type that
+// was selected from the specification to validate the body.
+func PopulateMediaType(validationErrors []*ValidationError, mediaType string) {
+	for _, validationError := range validationErrors {
+		validationError.MediaType = mediaType
+	}
+}
@@ -36,3 +36,16 @@ func TestPopulateValidationErrors(t *testing.T) {
 		require.Equal(t, "/test/path", validationError.RequestPath)
 	}
 }
+
+func TestPopulateMediaType(t *testing.T) {
+	validationErrors := []*ValidationError{
+		createMockValidationError(),
+		createMockValidationError(),
+	}
+
+	PopulateMediaType(validationErrors, "application/json")
+
+	for _, validationError := range validationErrors {
+		require.Equal(t, "application/json", validationError.MediaType)
+	}
+}
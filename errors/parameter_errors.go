@@ -3,6 +3,7 @@ package errors
 import (
 	"fmt"
 	"net/url"
+	"slices"
 	"strings"
 
 	"github.com/pb33f/libopenapi-validator/helpers"
@@ -74,6 +75,20 @@ func InvalidDeepObject(param *v3.Parameter, qp *helpers.QueryParam) *ValidationE
 	}
 }
 
+func IncorrectDeepObjectNesting(param *v3.Parameter, reason string, sch *base.Schema) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.ParameterValidation,
+		ValidationSubType: helpers.ParameterValidationQuery,
+		Message:           fmt.Sprintf("Query parameter '%s' is not a valid deepObject", param.Name),
+		Reason: fmt.Sprintf("The query parameter '%s' has the 'deepObject' style defined, "+
+			"however it cannot be decoded into an object: %s", param.Name, reason),
+		SpecLine: param.GoLow().Style.ValueNode.Line,
+		SpecCol:  param.GoLow().Style.ValueNode.Column,
+		Context:  sch,
+		HowToFix: HowToFixParamInvalidDeepObjectNesting,
+	}
+}
+
 func QueryParameterMissing(param *v3.Parameter) *ValidationError {
 	return &ValidationError{
 		ValidationType:    helpers.ParameterValidation,
@@ -87,6 +102,25 @@ func QueryParameterMissing(param *v3.Parameter) *ValidationError {
 	}
 }
 
+func UndefinedQueryParam(key string, pathItem *v3.PathItem) *ValidationError {
+	specLine, specCol := -1, -1
+	if pathItem != nil && pathItem.GoLow() != nil && pathItem.GoLow().KeyNode != nil {
+		specLine = pathItem.GoLow().KeyNode.Line
+		specCol = pathItem.GoLow().KeyNode.Column
+	}
+	return &ValidationError{
+		ValidationType:    helpers.ParameterValidation,
+		ValidationSubType: helpers.ParameterValidationQuery,
+		Message:           fmt.Sprintf("Query parameter '%s' is not defined", key),
+		Reason: fmt.Sprintf("The query parameter '%s' is present in the request, however it has not been "+
+			"defined by the operation or the path", key),
+		SpecLine: specLine,
+		SpecCol:  specCol,
+		Context:  pathItem,
+		HowToFix: fmt.Sprintf(HowToFixUndefinedQueryParam, key),
+	}
+}
+
 func HeaderParameterMissing(param *v3.Parameter) *ValidationError {
 	return &ValidationError{
 		ValidationType:    helpers.ParameterValidation,
@@ -100,6 +134,19 @@ func HeaderParameterMissing(param *v3.Parameter) *ValidationError {
 	}
 }
 
+func CookieParameterMissing(param *v3.Parameter) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.ParameterValidation,
+		ValidationSubType: helpers.ParameterValidationCookie,
+		Message:           fmt.Sprintf("Cookie parameter '%s' is missing", param.Name),
+		Reason: fmt.Sprintf("The cookie parameter '%s' is defined as being required, "+
+			"however it's missing from the requests", param.Name),
+		SpecLine: param.GoLow().Required.KeyNode.Line,
+		SpecCol:  param.GoLow().Required.KeyNode.Column,
+		HowToFix: HowToFixMissingValue,
+	}
+}
+
 func HeaderParameterCannotBeDecoded(param *v3.Parameter, val string) *ValidationError {
 	return &ValidationError{
 		ValidationType:    helpers.ParameterValidation,
@@ -113,6 +160,76 @@ func HeaderParameterCannotBeDecoded(param *v3.Parameter, val string) *Validation
 	}
 }
 
+func CookieParameterCannotBeDecoded(param *v3.Parameter, val string) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.ParameterValidation,
+		ValidationSubType: helpers.ParameterValidationCookie,
+		Message:           fmt.Sprintf("Cookie parameter '%s' cannot be decoded", param.Name),
+		Reason: fmt.Sprintf("The cookie parameter '%s' cannot be "+
+			"extracted into an object, '%s' is malformed", param.Name, val),
+		SpecLine: param.GoLow().Schema.Value.Schema().Type.KeyNode.Line,
+		SpecCol:  param.GoLow().Schema.Value.Schema().Type.KeyNode.Column,
+		HowToFix: HowToFixInvalidEncoding,
+	}
+}
+
+func IncorrectCookieParamStyle(param *v3.Parameter, sch *base.Schema) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.ParameterValidation,
+		ValidationSubType: helpers.ParameterValidationCookie,
+		Message:           fmt.Sprintf("Cookie parameter '%s' has an unsupported style", param.Name),
+		Reason: fmt.Sprintf("The cookie parameter '%s' is defined with the '%s' style, "+
+			"however cookie parameters can only be serialized using the 'form' style", param.Name, param.Style),
+		SpecLine: param.GoLow().Style.ValueNode.Line,
+		SpecCol:  param.GoLow().Style.ValueNode.Column,
+		Context:  sch,
+		HowToFix: HowToFixParamInvalidCookieStyle,
+	}
+}
+
+func IncorrectQueryParamArrayRepeat(param *v3.Parameter, qp *helpers.QueryParam, sch *base.Schema) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.ParameterValidation,
+		ValidationSubType: helpers.ParameterValidationQuery,
+		Message:           fmt.Sprintf("Query array parameter '%s' is repeated", param.Name),
+		Reason: fmt.Sprintf("The query parameter (which is an array) '%s' is defined as not being exploded, "+
+			"however the parameter has been supplied multiple times (%d), instead of a single comma separated "+
+			"value", param.Name, len(qp.Values)),
+		SpecLine: param.GoLow().Explode.ValueNode.Line,
+		SpecCol:  param.GoLow().Explode.ValueNode.Column,
+		Context:  sch,
+		HowToFix: fmt.Sprintf(HowToFixParamInvalidQueryArrayRepeat,
+			fmt.Sprintf("%s=%s", param.Name, strings.Join(qp.Values, helpers.Comma))),
+	}
+}
+
+func IncorrectCookieParamArrayRepeat(param *v3.Parameter, sch *base.Schema) *ValidationError {
+	reason := fmt.Sprintf("The cookie parameter (which is an array) '%s' is defined as exploded, "+
+		"however cookies cannot be repeated by name, the cookie has been sent more than once", param.Name)
+	if !param.IsExploded() {
+		reason = fmt.Sprintf("The cookie parameter (which is an array) '%s' is defined as not being exploded, "+
+			"however the cookie has been sent more than once, instead of a single comma separated value", param.Name)
+	}
+
+	// point at 'explode' when it's declared, otherwise at the schema of the parameter.
+	line, col := -1, -1
+	if low := param.GoLow(); low != nil && low.Explode.ValueNode != nil {
+		line, col = low.Explode.ValueNode.Line, low.Explode.ValueNode.Column
+	} else if low != nil && low.Schema.KeyNode != nil {
+		line, col = low.Schema.KeyNode.Line, low.Schema.KeyNode.Column
+	}
+	return &ValidationError{
+		ValidationType:    helpers.ParameterValidation,
+		ValidationSubType: helpers.ParameterValidationCookie,
+		Message:           fmt.Sprintf("Cookie array parameter '%s' is repeated", param.Name),
+		Reason:            reason,
+		SpecLine:          line,
+		SpecCol:           col,
+		Context:           sch,
+		HowToFix:          HowToFixParamInvalidCookieArrayRepeat,
+	}
+}
+
 func IncorrectHeaderParamEnum(param *v3.Parameter, ef string, sch *base.Schema) *ValidationError {
 	var enums []string
 	for i := range sch.Enum {
@@ -132,18 +249,33 @@ func IncorrectHeaderParamEnum(param *v3.Parameter, ef string, sch *base.Schema)
 	}
 }
 
+func IncorrectHeaderParamConst(param *v3.Parameter, ef string, sch *base.Schema) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.ParameterValidation,
+		ValidationSubType: helpers.ParameterValidationHeader,
+		Message:           fmt.Sprintf("Header parameter '%s' does not match the constant value", param.Name),
+		Reason: fmt.Sprintf("The header parameter '%s' has a constant value of '%s' set via const. "+
+			"The value '%s' does not match it.", param.Name, sch.Const.Value, ef),
+		SpecLine: sch.GoLow().Const.KeyNode.Line,
+		SpecCol:  sch.GoLow().Const.KeyNode.Column,
+		Context:  sch,
+		HowToFix: fmt.Sprintf(HowToFixParamInvalidConst, ef, sch.Const.Value),
+	}
+}
+
 func IncorrectQueryParamArrayBoolean(
-	param *v3.Parameter, item string, sch *base.Schema, itemsSchema *base.Schema) *ValidationError {
+	param *v3.Parameter, item string, index int, sch *base.Schema, itemsSchema *base.Schema) *ValidationError {
 	return &ValidationError{
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationQuery,
 		Message:           fmt.Sprintf("Query array parameter '%s' is not a valid boolean", param.Name),
 		Reason: fmt.Sprintf("The query parameter (which is an array) '%s' is defined as being a boolean, "+
-			"however the value '%s' is not a valid true/false value", param.Name, item),
-		SpecLine: sch.Items.A.GoLow().Schema().Type.KeyNode.Line,
-		SpecCol:  sch.Items.A.GoLow().Schema().Type.KeyNode.Column,
-		Context:  itemsSchema,
-		HowToFix: fmt.Sprintf(HowToFixParamInvalidBoolean, item),
+			"however the value '%s' at index %d is not a valid true/false value", param.Name, item, index),
+		SpecLine:  sch.Items.A.GoLow().Schema().Type.KeyNode.Line,
+		SpecCol:   sch.Items.A.GoLow().Schema().Type.KeyNode.Column,
+		ItemIndex: &index,
+		Context:   itemsSchema,
+		HowToFix:  fmt.Sprintf(HowToFixParamInvalidBoolean, item),
 	}
 }
 
@@ -163,28 +295,76 @@ func IncorrectCookieParamArrayBoolean(
 }
 
 func IncorrectQueryParamArrayNumber(
-	param *v3.Parameter, item string, sch *base.Schema, itemsSchema *base.Schema) *ValidationError {
+	param *v3.Parameter, item string, index int, sch *base.Schema, itemsSchema *base.Schema) *ValidationError {
+	numType := numberType(itemsSchema)
 	return &ValidationError{
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationQuery,
-		Message:           fmt.Sprintf("Query array parameter '%s' is not a valid number", param.Name),
-		Reason: fmt.Sprintf("The query parameter (which is an array) '%s' is defined as being a number, "+
-			"however the value '%s' is not a valid number", param.Name, item),
-		SpecLine: sch.Items.A.GoLow().Schema().Type.KeyNode.Line,
-		SpecCol:  sch.Items.A.GoLow().Schema().Type.KeyNode.Column,
-		Context:  itemsSchema,
-		HowToFix: fmt.Sprintf(HowToFixParamInvalidNumber, item),
+		Message:           fmt.Sprintf("Query array parameter '%s' is not a valid %s", param.Name, numType),
+		Reason: fmt.Sprintf("The query parameter (which is an array) '%s' is defined as being %s, "+
+			"however the value '%s' at index %d is not a valid %s", param.Name, withArticle(numType), item, index, numType),
+		SpecLine:  sch.Items.A.GoLow().Schema().Type.KeyNode.Line,
+		SpecCol:   sch.Items.A.GoLow().Schema().Type.KeyNode.Column,
+		ItemIndex: &index,
+		Context:   itemsSchema,
+		HowToFix:  fmt.Sprintf(HowToFixParamInvalidNumber, item),
+	}
+}
+
+func IncorrectQueryParamArrayMinItems(
+	param *v3.Parameter, items int, sch *base.Schema) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.ParameterValidation,
+		ValidationSubType: helpers.ParameterValidationQuery,
+		Message:           fmt.Sprintf("Query array parameter '%s' does not contain enough items", param.Name),
+		Reason: fmt.Sprintf("The query parameter (which is an array) '%s' is defined as having a minimum of %d "+
+			"item(s), however only %d were supplied", param.Name, *sch.MinItems, items),
+		SpecLine: sch.GoLow().MinItems.KeyNode.Line,
+		SpecCol:  sch.GoLow().MinItems.KeyNode.Column,
+		Context:  sch,
+		HowToFix: fmt.Sprintf(HowToFixParamInvalidArrayMinItems, *sch.MinItems, items),
+	}
+}
+
+func IncorrectQueryParamArrayMaxItems(
+	param *v3.Parameter, items int, sch *base.Schema) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.ParameterValidation,
+		ValidationSubType: helpers.ParameterValidationQuery,
+		Message:           fmt.Sprintf("Query array parameter '%s' contains too many items", param.Name),
+		Reason: fmt.Sprintf("The query parameter (which is an array) '%s' is defined as having a maximum of %d "+
+			"item(s), however %d were supplied", param.Name, *sch.MaxItems, items),
+		SpecLine: sch.GoLow().MaxItems.KeyNode.Line,
+		SpecCol:  sch.GoLow().MaxItems.KeyNode.Column,
+		Context:  sch,
+		HowToFix: fmt.Sprintf(HowToFixParamInvalidArrayMaxItems, *sch.MaxItems, items),
+	}
+}
+
+func IncorrectQueryParamArrayUniqueItems(
+	param *v3.Parameter, item string, sch *base.Schema) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.ParameterValidation,
+		ValidationSubType: helpers.ParameterValidationQuery,
+		Message:           fmt.Sprintf("Query array parameter '%s' contains duplicate items", param.Name),
+		Reason: fmt.Sprintf("The query parameter (which is an array) '%s' is defined as only containing unique "+
+			"items, however the value '%s' is repeated", param.Name, item),
+		SpecLine: sch.GoLow().UniqueItems.KeyNode.Line,
+		SpecCol:  sch.GoLow().UniqueItems.KeyNode.Column,
+		Context:  sch,
+		HowToFix: fmt.Sprintf(HowToFixParamInvalidArrayUnique, item),
 	}
 }
 
 func IncorrectCookieParamArrayNumber(
 	param *v3.Parameter, item string, sch *base.Schema, itemsSchema *base.Schema) *ValidationError {
+	numType := numberType(itemsSchema)
 	return &ValidationError{
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationCookie,
-		Message:           fmt.Sprintf("Cookie array parameter '%s' is not a valid number", param.Name),
-		Reason: fmt.Sprintf("The cookie parameter (which is an array) '%s' is defined as being a number, "+
-			"however the value '%s' is not a valid number", param.Name, item),
+		Message:           fmt.Sprintf("Cookie array parameter '%s' is not a valid %s", param.Name, numType),
+		Reason: fmt.Sprintf("The cookie parameter (which is an array) '%s' is defined as being %s, "+
+			"however the value '%s' is not a valid %s", param.Name, withArticle(numType), item, numType),
 		SpecLine: sch.Items.A.GoLow().Schema().Type.KeyNode.Line,
 		SpecCol:  sch.Items.A.GoLow().Schema().Type.KeyNode.Column,
 		Context:  itemsSchema,
@@ -199,8 +379,22 @@ func IncorrectParamEncodingJSON(param *v3.Parameter, ef string, sch *base.Schema
 		Message:           fmt.Sprintf("Query parameter '%s' is not valid JSON", param.Name),
 		Reason: fmt.Sprintf("The query parameter '%s' is defined as being a JSON object, "+
 			"however the value '%s' is not valid JSON", param.Name, ef),
-		SpecLine: param.GoLow().FindContent(helpers.JSONContentType).ValueNode.Line,
-		SpecCol:  param.GoLow().FindContent(helpers.JSONContentType).ValueNode.Column,
+		SpecLine: param.GoLow().Content.KeyNode.Line,
+		SpecCol:  param.GoLow().Content.KeyNode.Column,
+		Context:  sch,
+		HowToFix: HowToFixInvalidJSON,
+	}
+}
+
+func IncorrectCookieParamEncodingJSON(param *v3.Parameter, ef string, sch *base.Schema) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.ParameterValidation,
+		ValidationSubType: helpers.ParameterValidationCookie,
+		Message:           fmt.Sprintf("Cookie parameter '%s' is not valid JSON", param.Name),
+		Reason: fmt.Sprintf("The cookie parameter '%s' is defined as being a JSON object, "+
+			"however the value '%s' is not valid JSON", param.Name, ef),
+		SpecLine: param.GoLow().Content.KeyNode.Line,
+		SpecCol:  param.GoLow().Content.KeyNode.Column,
 		Context:  sch,
 		HowToFix: HowToFixInvalidJSON,
 	}
@@ -221,12 +415,13 @@ func IncorrectQueryParamBool(param *v3.Parameter, ef string, sch *base.Schema) *
 }
 
 func InvalidQueryParamNumber(param *v3.Parameter, ef string, sch *base.Schema) *ValidationError {
+	numType := numberType(sch)
 	return &ValidationError{
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationQuery,
-		Message:           fmt.Sprintf("Query parameter '%s' is not a valid number", param.Name),
-		Reason: fmt.Sprintf("The query parameter '%s' is defined as being a number, "+
-			"however the value '%s' is not a valid number", param.Name, ef),
+		Message:           fmt.Sprintf("Query parameter '%s' is not a valid %s", param.Name, numType),
+		Reason: fmt.Sprintf("The query parameter '%s' is defined as being %s, "+
+			"however the value '%s' is not a valid %s", param.Name, withArticle(numType), ef, numType),
 		SpecLine: param.GoLow().Schema.KeyNode.Line,
 		SpecCol:  param.GoLow().Schema.KeyNode.Column,
 		Context:  sch,
@@ -253,7 +448,21 @@ func IncorrectQueryParamEnum(param *v3.Parameter, ef string, sch *base.Schema) *
 	}
 }
 
-func IncorrectQueryParamEnumArray(param *v3.Parameter, ef string, sch *base.Schema) *ValidationError {
+func IncorrectQueryParamConst(param *v3.Parameter, ef string, sch *base.Schema) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.ParameterValidation,
+		ValidationSubType: helpers.ParameterValidationQuery,
+		Message:           fmt.Sprintf("Query parameter '%s' does not match the constant value", param.Name),
+		Reason: fmt.Sprintf("The query parameter '%s' has a constant value of '%s' set via const. "+
+			"The value '%s' does not match it.", param.Name, sch.Const.Value, ef),
+		SpecLine: sch.GoLow().Const.KeyNode.Line,
+		SpecCol:  sch.GoLow().Const.KeyNode.Column,
+		Context:  sch,
+		HowToFix: fmt.Sprintf(HowToFixParamInvalidConst, ef, sch.Const.Value),
+	}
+}
+
+func IncorrectQueryParamEnumArray(param *v3.Parameter, ef string, index int, sch *base.Schema) *ValidationError {
 	var enums []string
 	// look at that model fly!
 	for i := range param.GoLow().Schema.Value.Schema().Items.Value.A.Schema().Enum.Value {
@@ -266,11 +475,12 @@ func IncorrectQueryParamEnumArray(param *v3.Parameter, ef string, sch *base.Sche
 		ValidationSubType: helpers.ParameterValidationQuery,
 		Message:           fmt.Sprintf("Query array parameter '%s' does not match allowed values", param.Name),
 		Reason: fmt.Sprintf("The query array parameter '%s' has pre-defined "+
-			"values set via an enum. The value '%s' is not one of those values.", param.Name, ef),
-		SpecLine: param.GoLow().Schema.Value.Schema().Items.Value.A.Schema().Enum.KeyNode.Line,
-		SpecCol:  param.GoLow().Schema.Value.Schema().Items.Value.A.Schema().Enum.KeyNode.Line,
-		Context:  sch,
-		HowToFix: fmt.Sprintf(HowToFixParamInvalidEnum, ef, validEnums),
+			"values set via an enum. The value '%s' at index %d is not one of those values.", param.Name, ef, index),
+		SpecLine:  param.GoLow().Schema.Value.Schema().Items.Value.A.Schema().Enum.KeyNode.Line,
+		SpecCol:   param.GoLow().Schema.Value.Schema().Items.Value.A.Schema().Enum.KeyNode.Line,
+		ItemIndex: &index,
+		Context:   sch,
+		HowToFix:  fmt.Sprintf(HowToFixParamInvalidEnum, ef, validEnums),
 	}
 }
 
@@ -289,12 +499,13 @@ func IncorrectReservedValues(param *v3.Parameter, ef string, sch *base.Schema) *
 }
 
 func InvalidHeaderParamNumber(param *v3.Parameter, ef string, sch *base.Schema) *ValidationError {
+	numType := numberType(sch)
 	return &ValidationError{
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationHeader,
-		Message:           fmt.Sprintf("Header parameter '%s' is not a valid number", param.Name),
-		Reason: fmt.Sprintf("The header parameter '%s' is defined as being a number, "+
-			"however the value '%s' is not a valid number", param.Name, ef),
+		Message:           fmt.Sprintf("Header parameter '%s' is not a valid %s", param.Name, numType),
+		Reason: fmt.Sprintf("The header parameter '%s' is defined as being %s, "+
+			"however the value '%s' is not a valid %s", param.Name, withArticle(numType), ef, numType),
 		SpecLine: param.GoLow().Schema.KeyNode.Line,
 		SpecCol:  param.GoLow().Schema.KeyNode.Column,
 		Context:  sch,
@@ -303,12 +514,13 @@ func InvalidHeaderParamNumber(param *v3.Parameter, ef string, sch *base.Schema)
 }
 
 func InvalidCookieParamNumber(param *v3.Parameter, ef string, sch *base.Schema) *ValidationError {
+	numType := numberType(sch)
 	return &ValidationError{
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationCookie,
-		Message:           fmt.Sprintf("Cookie parameter '%s' is not a valid number", param.Name),
-		Reason: fmt.Sprintf("The cookie parameter '%s' is defined as being a number, "+
-			"however the value '%s' is not a valid number", param.Name, ef),
+		Message:           fmt.Sprintf("Cookie parameter '%s' is not a valid %s", param.Name, numType),
+		Reason: fmt.Sprintf("The cookie parameter '%s' is defined as being %s, "+
+			"however the value '%s' is not a valid %s", param.Name, withArticle(numType), ef, numType),
 		SpecLine: param.GoLow().Schema.KeyNode.Line,
 		SpecCol:  param.GoLow().Schema.KeyNode.Column,
 		Context:  sch,
@@ -363,33 +575,50 @@ func IncorrectCookieParamEnum(param *v3.Parameter, ef string, sch *base.Schema)
 	}
 }
 
+func IncorrectCookieParamConst(param *v3.Parameter, ef string, sch *base.Schema) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.ParameterValidation,
+		ValidationSubType: helpers.ParameterValidationCookie,
+		Message:           fmt.Sprintf("Cookie parameter '%s' does not match the constant value", param.Name),
+		Reason: fmt.Sprintf("The cookie parameter '%s' has a constant value of '%s' set via const. "+
+			"The value '%s' does not match it.", param.Name, sch.Const.Value, ef),
+		SpecLine: sch.GoLow().Const.KeyNode.Line,
+		SpecCol:  sch.GoLow().Const.KeyNode.Column,
+		Context:  sch,
+		HowToFix: fmt.Sprintf(HowToFixParamInvalidConst, ef, sch.Const.Value),
+	}
+}
+
 func IncorrectHeaderParamArrayBoolean(
-	param *v3.Parameter, item string, sch *base.Schema, itemsSchema *base.Schema) *ValidationError {
+	param *v3.Parameter, item string, index int, sch *base.Schema, itemsSchema *base.Schema) *ValidationError {
 	return &ValidationError{
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationHeader,
 		Message:           fmt.Sprintf("Header array parameter '%s' is not a valid boolean", param.Name),
 		Reason: fmt.Sprintf("The header parameter (which is an array) '%s' is defined as being a boolean, "+
-			"however the value '%s' is not a valid true/false value", param.Name, item),
-		SpecLine: sch.Items.A.GoLow().Schema().Type.KeyNode.Line,
-		SpecCol:  sch.Items.A.GoLow().Schema().Type.KeyNode.Column,
-		Context:  itemsSchema,
-		HowToFix: fmt.Sprintf(HowToFixParamInvalidBoolean, item),
+			"however the value '%s' at index %d is not a valid true/false value", param.Name, item, index),
+		SpecLine:  sch.Items.A.GoLow().Schema().Type.KeyNode.Line,
+		SpecCol:   sch.Items.A.GoLow().Schema().Type.KeyNode.Column,
+		ItemIndex: &index,
+		Context:   itemsSchema,
+		HowToFix:  fmt.Sprintf(HowToFixParamInvalidBoolean, item),
 	}
 }
 
 func IncorrectHeaderParamArrayNumber(
-	param *v3.Parameter, item string, sch *base.Schema, itemsSchema *base.Schema) *ValidationError {
+	param *v3.Parameter, item string, index int, sch *base.Schema, itemsSchema *base.Schema) *ValidationError {
+	numType := numberType(itemsSchema)
 	return &ValidationError{
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationHeader,
-		Message:           fmt.Sprintf("Header array parameter '%s' is not a valid number", param.Name),
-		Reason: fmt.Sprintf("The header parameter (which is an array) '%s' is defined as being a number, "+
-			"however the value '%s' is not a valid number", param.Name, item),
-		SpecLine: sch.Items.A.GoLow().Schema().Type.KeyNode.Line,
-		SpecCol:  sch.Items.A.GoLow().Schema().Type.KeyNode.Column,
-		Context:  itemsSchema,
-		HowToFix: fmt.Sprintf(HowToFixParamInvalidNumber, item),
+		Message:           fmt.Sprintf("Header array parameter '%s' is not a valid %s", param.Name, numType),
+		Reason: fmt.Sprintf("The header parameter (which is an array) '%s' is defined as being %s, "+
+			"however the value '%s' at index %d is not a valid %s", param.Name, withArticle(numType), item, index, numType),
+		SpecLine:  sch.Items.A.GoLow().Schema().Type.KeyNode.Line,
+		SpecCol:   sch.Items.A.GoLow().Schema().Type.KeyNode.Column,
+		ItemIndex: &index,
+		Context:   itemsSchema,
+		HowToFix:  fmt.Sprintf(HowToFixParamInvalidNumber, item),
 	}
 }
 
@@ -426,13 +655,28 @@ func IncorrectPathParamEnum(param *v3.Parameter, ef string, sch *base.Schema) *V
 	}
 }
 
+func IncorrectPathParamConst(param *v3.Parameter, ef string, sch *base.Schema) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.ParameterValidation,
+		ValidationSubType: helpers.ParameterValidationPath,
+		Message:           fmt.Sprintf("Path parameter '%s' does not match the constant value", param.Name),
+		Reason: fmt.Sprintf("The path parameter '%s' has a constant value of '%s' set via const. "+
+			"The value '%s' does not match it.", param.Name, sch.Const.Value, ef),
+		SpecLine: sch.GoLow().Const.KeyNode.Line,
+		SpecCol:  sch.GoLow().Const.KeyNode.Column,
+		Context:  sch,
+		HowToFix: fmt.Sprintf(HowToFixParamInvalidConst, ef, sch.Const.Value),
+	}
+}
+
 func IncorrectPathParamNumber(param *v3.Parameter, item string, sch *base.Schema) *ValidationError {
+	numType := numberType(sch)
 	return &ValidationError{
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationPath,
-		Message:           fmt.Sprintf("Path parameter '%s' is not a valid number", param.Name),
-		Reason: fmt.Sprintf("The path parameter '%s' is defined as being a number, "+
-			"however the value '%s' is not a valid number", param.Name, item),
+		Message:           fmt.Sprintf("Path parameter '%s' is not a valid %s", param.Name, numType),
+		Reason: fmt.Sprintf("The path parameter '%s' is defined as being %s, "+
+			"however the value '%s' is not a valid %s", param.Name, withArticle(numType), item, numType),
 		SpecLine: param.GoLow().Schema.KeyNode.Line,
 		SpecCol:  param.GoLow().Schema.KeyNode.Column,
 		Context:  sch,
@@ -442,12 +686,13 @@ func IncorrectPathParamNumber(param *v3.Parameter, item string, sch *base.Schema
 
 func IncorrectPathParamArrayNumber(
 	param *v3.Parameter, item string, sch *base.Schema, itemsSchema *base.Schema) *ValidationError {
+	numType := numberType(itemsSchema)
 	return &ValidationError{
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationPath,
-		Message:           fmt.Sprintf("Path array parameter '%s' is not a valid number", param.Name),
-		Reason: fmt.Sprintf("The path parameter (which is an array) '%s' is defined as being a number, "+
-			"however the value '%s' is not a valid number", param.Name, item),
+		Message:           fmt.Sprintf("Path array parameter '%s' is not a valid %s", param.Name, numType),
+		Reason: fmt.Sprintf("The path parameter (which is an array) '%s' is defined as being %s, "+
+			"however the value '%s' is not a valid %s", param.Name, withArticle(numType), item, numType),
 		SpecLine: sch.Items.A.GoLow().Schema().Type.KeyNode.Line,
 		SpecCol:  sch.Items.A.GoLow().Schema().Type.KeyNode.Column,
 		Context:  itemsSchema,
@@ -471,14 +716,40 @@ func IncorrectPathParamArrayBoolean(
 }
 
 func PathParameterMissing(param *v3.Parameter) *ValidationError {
+	// path parameters are always required, so 'required' may not have been declared.
+	keyNode := param.GoLow().Required.KeyNode
+	if keyNode == nil {
+		keyNode = param.GoLow().Name.KeyNode
+	}
+	var line, col int
+	if keyNode != nil {
+		line, col = keyNode.Line, keyNode.Column
+	}
 	return &ValidationError{
 		ValidationType:    helpers.ParameterValidation,
 		ValidationSubType: helpers.ParameterValidationPath,
 		Message:           fmt.Sprintf("Path parameter '%s' is missing", param.Name),
 		Reason: fmt.Sprintf("The path parameter '%s' is defined as being required, "+
 			"however it's missing from the requests", param.Name),
-		SpecLine: param.GoLow().Required.KeyNode.Line,
-		SpecCol:  param.GoLow().Required.KeyNode.Column,
+		SpecLine: line,
+		SpecCol:  col,
 		HowToFix: HowToFixMissingValue,
 	}
 }
+
+// numberType returns the numeric type declared by a schema, which is 'integer' when the schema only allows integers,
+// and 'number' otherwise.
+func numberType(sch *base.Schema) string {
+	if sch != nil && slices.Contains(sch.Type, helpers.Integer) && !slices.Contains(sch.Type, helpers.Number) {
+		return helpers.Integer
+	}
+	return helpers.Number
+}
+
+// withArticle returns a type name with the indefinite article that precedes it, for example 'an integer'.
+func withArticle(typeName string) string {
+	if strings.ContainsRune("aeiou", rune(typeName[0])) {
+		return "an " + typeName
+	}
+	return "a " + typeName
+}
@@ -159,6 +159,71 @@ func TestHeaderParameterCannotBeDecoded(t *testing.T) {
 	require.Equal(t, HowToFixInvalidEncoding, err.HowToFix)
 }
 
+func TestCookieParameterMissing(t *testing.T) {
+	param := createMockParameterWithSchema()
+
+	// Call the function
+	err := CookieParameterMissing(param)
+
+	// Validate the error
+	require.NotNil(t, err)
+	require.Equal(t, helpers.ParameterValidation, err.ValidationType)
+	require.Equal(t, helpers.ParameterValidationCookie, err.ValidationSubType)
+	require.Contains(t, err.Message, "Cookie parameter 'testParam' is missing")
+	require.Contains(t, err.Reason, "'testParam' is defined as being required")
+	require.Equal(t, 22, err.SpecLine)
+	require.Equal(t, 32, err.SpecCol)
+	require.Equal(t, HowToFixMissingValue, err.HowToFix)
+}
+
+func TestCookieParameterCannotBeDecoded(t *testing.T) {
+	param := createMockParameterWithSchema()
+	val := "malformed_cookie_value"
+
+	// Call the function
+	err := CookieParameterCannotBeDecoded(param, val)
+
+	// Validate the error
+	require.NotNil(t, err)
+	require.Equal(t, helpers.ParameterValidation, err.ValidationType)
+	require.Equal(t, helpers.ParameterValidationCookie, err.ValidationSubType)
+	require.Contains(t, err.Message, "Cookie parameter 'testParam' cannot be decoded")
+	require.Contains(t, err.Reason, "'malformed_cookie_value' is malformed")
+	require.Equal(t, HowToFixInvalidEncoding, err.HowToFix)
+}
+
+func TestIncorrectCookieParamStyle(t *testing.T) {
+	param := createMockParameterWithSchema()
+	param.Style = "matrix"
+
+	// Call the function
+	err := IncorrectCookieParamStyle(param, param.Schema.Schema())
+
+	// Validate the error
+	require.NotNil(t, err)
+	require.Equal(t, helpers.ParameterValidation, err.ValidationType)
+	require.Equal(t, helpers.ParameterValidationCookie, err.ValidationSubType)
+	require.Contains(t, err.Message, "Cookie parameter 'testParam' has an unsupported style")
+	require.Contains(t, err.Reason, "defined with the 'matrix' style")
+	require.Equal(t, HowToFixParamInvalidCookieStyle, err.HowToFix)
+}
+
+func TestIncorrectCookieParamArrayRepeat(t *testing.T) {
+	param := createMockParameterWithSchema()
+
+	// Call the function
+	err := IncorrectCookieParamArrayRepeat(param, param.Schema.Schema())
+
+	// Validate the error
+	require.NotNil(t, err)
+	require.Equal(t, helpers.ParameterValidation, err.ValidationType)
+	require.Equal(t, helpers.ParameterValidationCookie, err.ValidationSubType)
+	require.Contains(t, err.Message, "Cookie array parameter 'testParam' is repeated")
+	require.Equal(t, 18, err.SpecLine)
+	require.Equal(t, 30, err.SpecCol)
+	require.Equal(t, HowToFixParamInvalidCookieArrayRepeat, err.HowToFix)
+}
+
 func TestIncorrectHeaderParamEnum(t *testing.T) {
 	param := createMockParameterWithSchema()
 	schemaProxy := &lowbase.SchemaProxy{}
@@ -222,14 +287,15 @@ func TestIncorrectQueryParamArrayBoolean(t *testing.T) {
 	schema := base.NewSchema(s)
 
 	// Call the function with an invalid boolean value in the array
-	err := IncorrectQueryParamArrayBoolean(param, "notBoolean", schema, schema.Items.A.Schema())
+	err := IncorrectQueryParamArrayBoolean(param, "notBoolean", 2, schema, schema.Items.A.Schema())
 
 	// Validate the error
 	require.NotNil(t, err)
 	require.Equal(t, helpers.ParameterValidation, err.ValidationType)
 	require.Equal(t, helpers.ParameterValidationQuery, err.ValidationSubType)
 	require.Contains(t, err.Message, "Query array parameter 'testParam' is not a valid boolean")
-	require.Contains(t, err.Reason, "the value 'notBoolean' is not a valid true/false value")
+	require.Contains(t, err.Reason, "the value 'notBoolean' at index 2 is not a valid true/false value")
+	require.Equal(t, 2, *err.ItemIndex)
 	require.Contains(t, err.HowToFix, "true/false")
 }
 
@@ -373,17 +439,82 @@ func TestIncorrectQueryParamArrayNumber(t *testing.T) {
 	itemsSchema := base.NewSchema(baseSchema.Items.Value.A.Schema())
 
 	// Call the function with an invalid number value in the array
-	err := IncorrectQueryParamArrayNumber(param, "notNumber", s, itemsSchema)
+	err := IncorrectQueryParamArrayNumber(param, "notNumber", 3, s, itemsSchema)
 
 	// Validate the error
 	require.NotNil(t, err)
 	require.Equal(t, helpers.ParameterValidation, err.ValidationType)
 	require.Equal(t, helpers.ParameterValidationQuery, err.ValidationSubType)
 	require.Contains(t, err.Message, "Query array parameter 'testQueryParam' is not a valid number")
-	require.Contains(t, err.Reason, "the value 'notNumber' is not a valid number")
+	require.Contains(t, err.Reason, "the value 'notNumber' at index 3 is not a valid number")
+	require.Equal(t, 3, *err.ItemIndex)
 	require.Contains(t, err.HowToFix, "notNumber")
 }
 
+// Helper function to create a mock base.Schema for an array with item constraints
+func createMockArrayConstraintsSchema() *base.Schema {
+	items := `type: array
+minItems: 1
+maxItems: 3
+uniqueItems: true
+items:
+  type: string`
+	var n yaml.Node
+	_ = yaml.Unmarshal([]byte(items), &n)
+
+	schemaProxy := &lowbase.SchemaProxy{}
+	schemaProxy.Build(context.Background(), n.Content[0], n.Content[0], nil)
+	return base.NewSchema(schemaProxy.Schema())
+}
+
+func TestIncorrectQueryParamArrayMinItems(t *testing.T) {
+	param := createMockParameterForNumberArray()
+	s := createMockArrayConstraintsSchema()
+
+	err := IncorrectQueryParamArrayMinItems(param, 0, s)
+
+	// Validate the error
+	require.NotNil(t, err)
+	require.Equal(t, helpers.ParameterValidation, err.ValidationType)
+	require.Equal(t, helpers.ParameterValidationQuery, err.ValidationSubType)
+	require.Contains(t, err.Message, "Query array parameter 'testQueryParam' does not contain enough items")
+	require.Contains(t, err.Reason, "minimum of 1 item(s), however only 0 were supplied")
+	require.Equal(t, 2, err.SpecLine)
+	require.Equal(t, "The array must contain at least 1 item(s), however 0 were supplied", err.HowToFix)
+}
+
+func TestIncorrectQueryParamArrayMaxItems(t *testing.T) {
+	param := createMockParameterForNumberArray()
+	s := createMockArrayConstraintsSchema()
+
+	err := IncorrectQueryParamArrayMaxItems(param, 4, s)
+
+	// Validate the error
+	require.NotNil(t, err)
+	require.Equal(t, helpers.ParameterValidation, err.ValidationType)
+	require.Equal(t, helpers.ParameterValidationQuery, err.ValidationSubType)
+	require.Contains(t, err.Message, "Query array parameter 'testQueryParam' contains too many items")
+	require.Contains(t, err.Reason, "maximum of 3 item(s), however 4 were supplied")
+	require.Equal(t, 3, err.SpecLine)
+	require.Equal(t, "The array must contain no more than 3 item(s), however 4 were supplied", err.HowToFix)
+}
+
+func TestIncorrectQueryParamArrayUniqueItems(t *testing.T) {
+	param := createMockParameterForNumberArray()
+	s := createMockArrayConstraintsSchema()
+
+	err := IncorrectQueryParamArrayUniqueItems(param, "pizza", s)
+
+	// Validate the error
+	require.NotNil(t, err)
+	require.Equal(t, helpers.ParameterValidation, err.ValidationType)
+	require.Equal(t, helpers.ParameterValidationQuery, err.ValidationSubType)
+	require.Contains(t, err.Message, "Query array parameter 'testQueryParam' contains duplicate items")
+	require.Contains(t, err.Reason, "the value 'pizza' is repeated")
+	require.Equal(t, 4, err.SpecLine)
+	require.Contains(t, err.HowToFix, "pizza")
+}
+
 // Helper function to create a mock v3.Parameter for cookie number array validation
 func createMockParameterForCookieNumberArray() *v3.Parameter {
 	param := &lowv3.Parameter{
@@ -522,6 +653,22 @@ func TestIncorrectParamEncodingJSON(t *testing.T) {
 	require.Equal(t, HowToFixInvalidJSON, err.HowToFix)
 }
 
+func TestIncorrectCookieParamEncodingJSON(t *testing.T) {
+	param := createMockParameter()
+	baseSchema := createMockLowBaseSchema()
+
+	// Call the function with an invalid JSON value
+	err := IncorrectCookieParamEncodingJSON(param, "invalidJSON", base.NewSchema(baseSchema))
+
+	// Validate the error
+	require.NotNil(t, err)
+	require.Equal(t, helpers.ParameterValidation, err.ValidationType)
+	require.Equal(t, helpers.ParameterValidationCookie, err.ValidationSubType)
+	require.Contains(t, err.Message, "Cookie parameter 'testQueryParam' is not valid JSON")
+	require.Contains(t, err.Reason, "the value 'invalidJSON' is not valid JSON")
+	require.Equal(t, HowToFixInvalidJSON, err.HowToFix)
+}
+
 func TestIncorrectQueryParamBool(t *testing.T) {
 	param := createMockParameter()
 	baseSchema := createMockLowBaseSchema()
@@ -612,14 +759,15 @@ func TestIncorrectQueryParamEnumArray(t *testing.T) {
 	}
 
 	// Call the function with an invalid enum value
-	err := IncorrectQueryParamEnumArray(param, "invalidEnum", highSchema)
+	err := IncorrectQueryParamEnumArray(param, "invalidEnum", 1, highSchema)
 
 	// Validate the error
 	require.NotNil(t, err)
 	require.Equal(t, helpers.ParameterValidation, err.ValidationType)
 	require.Equal(t, helpers.ParameterValidationQuery, err.ValidationSubType)
 	require.Contains(t, err.Message, "Query array parameter 'testQueryParam' does not match allowed values")
-	require.Contains(t, err.Reason, "'invalidEnum' is not one of those values")
+	require.Contains(t, err.Reason, "'invalidEnum' at index 1 is not one of those values")
+	require.Equal(t, 1, *err.ItemIndex)
 	require.Contains(t, err.HowToFix, "fish, crab, lobster")
 }
 
@@ -695,6 +843,19 @@ func TestInvalidCookieParamNumber(t *testing.T) {
 	require.Contains(t, err.HowToFix, "milky")
 }
 
+func TestInvalidCookieParamNumber_Integer(t *testing.T) {
+	highSchema := base.NewSchema(&lowbase.Schema{})
+	highSchema.Type = []string{helpers.Integer}
+	param := createMockParameter()
+	param.Name = "cookies"
+
+	err := InvalidCookieParamNumber(param, "Milky", highSchema)
+
+	require.Equal(t, "Cookie parameter 'cookies' is not a valid integer", err.Message)
+	require.Equal(t, "The cookie parameter 'cookies' is defined as being an integer, "+
+		"however the value 'Milky' is not a valid integer", err.Reason)
+}
+
 func TestIncorrectHeaderParamBool(t *testing.T) {
 
 	enum := `name: blip`
@@ -790,7 +951,7 @@ func TestIncorrectHeaderParamArrayBoolean(t *testing.T) {
 	param := createMockParameter()
 	param.Name = "bubbles"
 
-	err := IncorrectHeaderParamArrayBoolean(param, "milky", highSchema, nil)
+	err := IncorrectHeaderParamArrayBoolean(param, "milky", 1, highSchema, nil)
 
 	// Validate the error
 	require.NotNil(t, err)
@@ -798,6 +959,8 @@ func TestIncorrectHeaderParamArrayBoolean(t *testing.T) {
 	require.Equal(t, helpers.ParameterValidationHeader, err.ValidationSubType)
 	require.Contains(t, err.Message, "Header array parameter 'bubbles' is not a valid boolean")
 	require.Contains(t, err.Reason, "The header parameter (which is an array) 'bubbles' is defined as being a boolean")
+	require.Contains(t, err.Reason, "'milky' at index 1")
+	require.Equal(t, 1, *err.ItemIndex)
 	require.Contains(t, err.HowToFix, "milky")
 }
 
@@ -817,7 +980,7 @@ func TestIncorrectHeaderParamArrayNumber(t *testing.T) {
 	param := createMockParameter()
 	param.Name = "bubbles"
 
-	err := IncorrectHeaderParamArrayNumber(param, "milky", highSchema, nil)
+	err := IncorrectHeaderParamArrayNumber(param, "milky", 2, highSchema, nil)
 
 	// Validate the error
 	require.NotNil(t, err)
@@ -825,6 +988,8 @@ func TestIncorrectHeaderParamArrayNumber(t *testing.T) {
 	require.Equal(t, helpers.ParameterValidationHeader, err.ValidationSubType)
 	require.Contains(t, err.Message, "Header array parameter 'bubbles' is not a valid number")
 	require.Contains(t, err.Reason, "The header parameter (which is an array) 'bubbles' is defined as being a number")
+	require.Contains(t, err.Reason, "'milky' at index 2")
+	require.Equal(t, 2, *err.ItemIndex)
 	require.Contains(t, err.HowToFix, "milky")
 }
 
@@ -990,3 +1155,110 @@ func TestPathParameterMissing(t *testing.T) {
 	require.Contains(t, err.Reason, "The path parameter 'testQueryParam' is defined as being required")
 	require.Contains(t, err.HowToFix, "Ensure the value has been set")
 }
+
+func TestPathParameterMissing_RequiredNotDeclared(t *testing.T) {
+	param := v3.NewParameter(&lowv3.Parameter{
+		Name: low.NodeReference[string]{Value: "burgerId", KeyNode: &yaml.Node{Line: 7, Column: 11}},
+	})
+
+	err := PathParameterMissing(param)
+
+	// path parameters are always required, so the name is used to locate the parameter.
+	require.NotNil(t, err)
+	require.Equal(t, "Path parameter 'burgerId' is missing", err.Message)
+	require.Equal(t, 7, err.SpecLine)
+	require.Equal(t, 11, err.SpecCol)
+	require.False(t, err.IsPathMissingError())
+}
+
+func TestIncorrectDeepObjectNesting(t *testing.T) {
+	param := createMockParameterWithDeepObjectStyle()
+
+	err := IncorrectDeepObjectNesting(param, "the key 'testParam[a][b]' is used as both a value and an object", nil)
+
+	// Validate the error
+	require.NotNil(t, err)
+	require.Equal(t, helpers.ParameterValidation, err.ValidationType)
+	require.Equal(t, helpers.ParameterValidationQuery, err.ValidationSubType)
+	require.Contains(t, err.Message, "Query parameter 'testParam' is not a valid deepObject")
+	require.Contains(t, err.Reason, "cannot be decoded into an object: the key 'testParam[a][b]'")
+	require.Equal(t, HowToFixParamInvalidDeepObjectNesting, err.HowToFix)
+}
+
+func TestUndefinedQueryParam(t *testing.T) {
+	err := UndefinedQueryParam("fihsy", nil)
+
+	// Validate the error
+	require.NotNil(t, err)
+	require.Equal(t, helpers.ParameterValidation, err.ValidationType)
+	require.Equal(t, helpers.ParameterValidationQuery, err.ValidationSubType)
+	require.Equal(t, "Query parameter 'fihsy' is not defined", err.Message)
+	require.Contains(t, err.Reason, "has not been defined by the operation or the path")
+	require.Equal(t, -1, err.SpecLine)
+	require.Equal(t, -1, err.SpecCol)
+	require.Contains(t, err.HowToFix, "fihsy")
+}
+
+func TestIncorrectQueryParamArrayRepeat(t *testing.T) {
+	param := createMockParameterWithSchema()
+	qp := &helpers.QueryParam{
+		Key:    "testParam",
+		Values: []string{"1,2", "3"},
+	}
+
+	err := IncorrectQueryParamArrayRepeat(param, qp, param.Schema.Schema())
+
+	// Validate the error
+	require.NotNil(t, err)
+	require.Equal(t, helpers.ParameterValidation, err.ValidationType)
+	require.Equal(t, helpers.ParameterValidationQuery, err.ValidationSubType)
+	require.Equal(t, "Query array parameter 'testParam' is repeated", err.Message)
+	require.Contains(t, err.Reason, "supplied multiple times (2)")
+	require.Equal(t, 18, err.SpecLine)
+	require.Equal(t, 30, err.SpecCol)
+	require.Contains(t, err.HowToFix, "testParam=1,2,3")
+}
+
+func createMockConstSchema() *base.Schema {
+	schemaProxy := &lowbase.SchemaProxy{}
+	schemaProxy.Build(context.Background(), &yaml.Node{}, &yaml.Node{}, nil)
+	schemaProxy.Schema().Type = low.NodeReference[lowbase.SchemaDynamicValue[string, []low.ValueReference[string]]]{
+		KeyNode:   &yaml.Node{},
+		ValueNode: &yaml.Node{},
+		Value: lowbase.SchemaDynamicValue[string, []low.ValueReference[string]]{
+			A: "string",
+		},
+	}
+	schemaProxy.Schema().Const = low.NodeReference[*yaml.Node]{
+		KeyNode:   &yaml.Node{Line: 10, Column: 20},
+		ValueNode: &yaml.Node{Value: "cat"},
+		Value:     &yaml.Node{Value: "cat"},
+	}
+	return base.NewSchema(schemaProxy.Schema())
+}
+
+func TestIncorrectParamConst(t *testing.T) {
+	param := createMockParameterWithSchema()
+	schema := createMockConstSchema()
+
+	tests := []struct {
+		err      *ValidationError
+		subType  string
+		location string
+	}{
+		{IncorrectHeaderParamConst(param, "dog", schema), helpers.ParameterValidationHeader, "Header"},
+		{IncorrectQueryParamConst(param, "dog", schema), helpers.ParameterValidationQuery, "Query"},
+		{IncorrectCookieParamConst(param, "dog", schema), helpers.ParameterValidationCookie, "Cookie"},
+		{IncorrectPathParamConst(param, "dog", schema), helpers.ParameterValidationPath, "Path"},
+	}
+	for _, tt := range tests {
+		require.NotNil(t, tt.err)
+		require.Equal(t, helpers.ParameterValidation, tt.err.ValidationType)
+		require.Equal(t, tt.subType, tt.err.ValidationSubType)
+		require.Equal(t, tt.location+" parameter 'testParam' does not match the constant value", tt.err.Message)
+		require.Contains(t, tt.err.Reason, "has a constant value of 'cat'")
+		require.Equal(t, 10, tt.err.SpecLine)
+		require.Equal(t, 20, tt.err.SpecCol)
+		require.Equal(t, "Instead of 'dog', use the constant value: 'cat'", tt.err.HowToFix)
+	}
+}
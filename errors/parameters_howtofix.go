@@ -10,6 +10,7 @@ const (
 	HowToFixParamInvalidString                      string = "Convert the value '%s' into a string (cannot start with a number, or be a floating point)"
 	HowToFixParamInvalidBoolean                     string = "Convert the value '%s' into a true/false value"
 	HowToFixParamInvalidEnum                        string = "Instead of '%s', use one of the allowed values: '%s'"
+	HowToFixParamInvalidConst                       string = "Instead of '%s', use the constant value: '%s'"
 	HowToFixParamInvalidFormEncode                  string = "Use a form style encoding for parameter values, for example: '%s'"
 	HowToFixInvalidSchema                           string = "Ensure that the object being submitted, matches the schema correctly"
 	HowToFixParamInvalidSpaceDelimitedObjectExplode string = "When using 'explode' with space delimited parameters, " +
@@ -18,12 +19,60 @@ const (
 		"they should be separated by pipes '|'. For example: '%s'"
 	HowToFixParamInvalidDeepObjectMultipleValues string = "There can only be a single value per property name, " +
 		"deepObject parameters should contain the property key in square brackets next to the parameter name. For example: '%s'"
-	HowToFixInvalidJSON         string = "The JSON submitted is invalid, please check the syntax"
-	HowToFixDecodingError              = "The object can't be decoded, so make sure it's being encoded correctly according to the spec."
-	HowToFixInvalidContentType         = "The content type is invalid, Use one of the %d supported types for this operation: %s"
-	HowToFixInvalidResponseCode        = "The service is responding with a code that is not defined in the spec, fix the service or add the code to the specification"
-	HowToFixInvalidEncoding            = "Ensure the correct encoding has been used on the object"
-	HowToFixMissingValue               = "Ensure the value has been set"
-	HowToFixPath                       = "Check the path is correct, and check that the correct HTTP method has been used (e.g. GET, POST, PUT, DELETE)"
-	HowToFixPathMethod                 = "Add the missing operation to the contract for the path"
+	HowToFixParamInvalidDeepObjectNesting string = "Nested deepObject properties should each be contained in square brackets, " +
+		"array indices must start at 0 and a property cannot be both a value and an object. For example: 'filter[tags][0]=a'"
+	HowToFixParamInvalidQueryArrayRepeat string = "Non-exploded arrays cannot be repeated, send the array as a " +
+		"single comma separated value, for example: '%s'"
+	HowToFixParamInvalidCookieStyle string = "Cookie parameters only support the 'form' style, use 'form' (or 'deepObject') " +
+		"as the style for cookie parameters"
+	HowToFixParamInvalidCookieArrayRepeat string = "Cookies cannot be repeated, send the array as a single cookie " +
+		"value, for example: 'id=3&id=4&id=5' or set 'explode' to false and use a comma separated value"
+	HowToFixParamInvalidArrayMinItems string = "The array must contain at least %d item(s), however %d were supplied"
+	HowToFixParamInvalidArrayMaxItems string = "The array must contain no more than %d item(s), however %d were supplied"
+	HowToFixParamInvalidArrayUnique   string = "Remove the duplicate value '%s', all items in the array must be unique"
+	HowToFixUndefinedQueryParam       string = "Remove the query parameter '%s' from the request, or check the name " +
+		"of the parameter is spelled correctly"
+	HowToFixInvalidJSON            string = "The JSON submitted is invalid, please check the syntax"
+	HowToFixDecodingError                 = "The object can't be decoded, so make sure it's being encoded correctly according to the spec."
+	HowToFixMissingRequestBody            = "The operation requires a request body, send a body with one of the %d supported types: %s"
+	HowToFixInvalidBodyEncoding           = "Compress the request body with the encoding in the 'Content-Encoding' header, or remove the header if the body is not compressed"
+	HowToFixBodyDecodedTooLarge           = "Send a request body that is no larger than %d bytes once it has been decompressed"
+	HowToFixBodyTooLarge                  = "Send a request body that is no larger than %d bytes"
+	HowToFixInvalidContentType            = "The content type is invalid, Use one of the %d supported types for this operation: %s"
+	HowToFixUnknownDiscriminator          = "Use one of the values that map to a schema for the discriminator: %s"
+	HowToFixInvalidPartContentType        = "The content type of the part is invalid, use one of the supported types for the part: %s"
+	HowToFixReadOnlyProperty              = "The property '%s' is read only, so it can only be returned in a response, remove it from the request"
+	HowToFixWriteOnlyProperty             = "The property '%s' is write only, so it can only be sent in a request, remove it from the response"
+	HowToFixInvalidResponseCode           = "The service is responding with a code that is not defined in the spec, fix the service or add the code to the specification"
+	HowToFixMissingResponseRequest        = "Set the 'Request' of the response to the request that produced it, so the operation can be found"
+	HowToFixMissingResponse               = "Supply the response to validate, a nil response cannot be validated"
+	HowToFixInvalidEncoding               = "Ensure the correct encoding has been used on the object"
+	HowToFixMissingValue                  = "Ensure the value has been set"
+	HowToFixPath                          = "Check the path is correct, and check that the correct HTTP method has been used (e.g. GET, POST, PUT, DELETE)"
+	HowToFixPathMethod                    = "Add the missing operation to the contract for the path"
+	HowToFixMissingWebhook                = "Check the name of the webhook is correct, and that it's defined in the 'webhooks' of the contract"
+	HowToFixWebhookMethod                 = "Add the missing operation to the contract for the webhook"
+	HowToFixValidationCancelled           = "Allow more time for the validation, or validate a smaller request"
+	HowToFixMissingDocument               = "Check the selector returns the name of a document that has been added to the validator"
+	HowToFixMissingCallback               = "Check the name of the callback is correct, and that it's declared in the 'callbacks' of an operation"
+	HowToFixCallbackMethod                = "Add the missing operation to the path items of the callback"
+	HowToFixMissingOperationID            = "Check the operationId is correct, and that an operation in the contract declares it"
+	HowToFixOperationIDMethod             = "Send the request with the '%s' method of the operation"
+	HowToFixUnresolvedSchema              = "Check every $ref in the schema points to a schema that exists in the specification"
+	HowToFixUncompilableSchema            = "Fix the schema so it is a valid JSON schema, check the keywords and their values are correct"
+	HowToFixMissingComponentSchema        = "Check the name of the schema is correct, and that it's defined in the 'components' of the contract"
+	HowToFixMissingSecurityScheme         = "Add the missing security scheme to the components"
+	HowToFixApiKeyHeader                  = "Add the API Key via '%s' as a header of the request"
+	HowToFixApiKeyQuery                   = "Add an API Key via '%s' to the query string of the URL, for example '%s'"
+	HowToFixApiKeyCookie                  = "Submit an API Key '%s' as a cookie with the request"
+	HowToFixAuthMissing                   = "Add an 'Authorization' header to this request"
+	HowToFixInsufficientScope             = "Send a token that has been granted the missing scope(s): %s"
+	HowToFixAuthWrongScheme               = "Send the credentials in the 'Authorization' header using the '%s' scheme, " +
+		"for example 'Authorization: %s <credentials>'"
+	HowToFixInvalidSchemaPattern = "Rewrite the pattern using syntax the regex engine supports (Go's regexp package " +
+		"does not support lookarounds or backreferences), supply a regex engine that supports it, or skip invalid patterns"
+	HowToFixUnknownSchemaFormat = "Check the spelling of the format, or register it as a custom format if it's " +
+		"intentionally not one of the built-in formats"
+	HowToFixInvalidExample = "Change the example so it's valid against the schema, or fix the schema if the " +
+		"example is correct"
 )
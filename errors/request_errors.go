@@ -36,6 +36,91 @@ func RequestContentTypeNotFound(op *v3.Operation, request *http.Request, specPat
 	}
 }
 
+// RequestBodyMissing will create a ValidationError for a request that has no body, when the operation it's
+// validated against requires one.
+func RequestBodyMissing(op *v3.Operation, request *http.Request, specPath string) *ValidationError {
+	var ctypes []string
+	for pair := orderedmap.First(op.RequestBody.Content); pair != nil; pair = pair.Next() {
+		ctypes = append(ctypes, pair.Key())
+	}
+	line, col := -1, -1
+	if low := op.RequestBody.GoLow(); low != nil && low.Required.KeyNode != nil {
+		line, col = low.Required.KeyNode.Line, low.Required.KeyNode.Column
+	}
+	return &ValidationError{
+		ValidationType:    helpers.RequestBodyValidation,
+		ValidationSubType: helpers.RequestMissingBody,
+		Message:           fmt.Sprintf("%s request body is missing for '%s'", request.Method, request.URL.Path),
+		Reason: fmt.Sprintf("The %s request has no body, however the request body "+
+			"is required by the operation", request.Method),
+		SpecLine:      line,
+		SpecCol:       col,
+		Context:       op,
+		HowToFix:      fmt.Sprintf(HowToFixMissingRequestBody, orderedmap.Len(op.RequestBody.Content), strings.Join(ctypes, ", ")),
+		RequestPath:   request.URL.Path,
+		RequestMethod: request.Method,
+		SpecPath:      specPath,
+	}
+}
+
+// RequestBodyEncodingInvalid will create a ValidationError for a request body that cannot be decompressed with the
+// encoding declared by the 'Content-Encoding' header of the request.
+func RequestBodyEncodingInvalid(request *http.Request, err error, specPath string) *ValidationError {
+	encoding := request.Header.Get(helpers.ContentEncodingHeader)
+	return &ValidationError{
+		ValidationType:    helpers.RequestBodyValidation,
+		ValidationSubType: helpers.RequestBodyContentEncoding,
+		Message: fmt.Sprintf("%s request body for '%s' cannot be decoded with content encoding '%s'",
+			request.Method, request.URL.Path, encoding),
+		Reason: fmt.Sprintf("The %s request body is encoded with '%s', however it cannot be decompressed: %s",
+			request.Method, encoding, err.Error()),
+		SpecLine:      -1,
+		SpecCol:       -1,
+		HowToFix:      HowToFixInvalidBodyEncoding,
+		RequestPath:   request.URL.Path,
+		RequestMethod: request.Method,
+		SpecPath:      specPath,
+	}
+}
+
+// RequestBodyDecodedTooLarge will create a ValidationError for a compressed request body that is larger than the
+// maximum decoded body size, once it has been decompressed.
+func RequestBodyDecodedTooLarge(request *http.Request, limit int64, specPath string) *ValidationError {
+	encoding := request.Header.Get(helpers.ContentEncodingHeader)
+	return &ValidationError{
+		ValidationType:    helpers.RequestBodyValidation,
+		ValidationSubType: helpers.RequestBodyContentEncoding,
+		Message: fmt.Sprintf("%s request body for '%s' is too large to decode with content encoding '%s'",
+			request.Method, request.URL.Path, encoding),
+		Reason: fmt.Sprintf("The %s request body is encoded with '%s', however it is larger than %d bytes "+
+			"once decompressed", request.Method, encoding, limit),
+		SpecLine:      -1,
+		SpecCol:       -1,
+		HowToFix:      fmt.Sprintf(HowToFixBodyDecodedTooLarge, limit),
+		RequestPath:   request.URL.Path,
+		RequestMethod: request.Method,
+		SpecPath:      specPath,
+	}
+}
+
+// RequestBodyTooLarge will create a ValidationError for a request body that is larger than the maximum size of a
+// body that is decoded as it's streamed.
+func RequestBodyTooLarge(request *http.Request, limit int64) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.RequestBodyValidation,
+		ValidationSubType: helpers.RequestBodyTooLarge,
+		Message: fmt.Sprintf("%s request body for '%s' is too large to validate",
+			request.Method, request.URL.Path),
+		Reason: fmt.Sprintf("The %s request body is larger than %d bytes, which is the largest body "+
+			"that is decoded as it's streamed", request.Method, limit),
+		SpecLine:      -1,
+		SpecCol:       -1,
+		HowToFix:      fmt.Sprintf(HowToFixBodyTooLarge, limit),
+		RequestPath:   request.URL.Path,
+		RequestMethod: request.Method,
+	}
+}
+
 func OperationNotFound(pathItem *v3.PathItem, request *http.Request, method string, specPath string) *ValidationError {
 	return &ValidationError{
 		ValidationType:    helpers.RequestValidation,
@@ -52,3 +137,197 @@ func OperationNotFound(pathItem *v3.PathItem, request *http.Request, method stri
 		SpecPath:      specPath,
 	}
 }
+
+func RequestPartContentTypeMismatch(request *http.Request, name, contentType string, encoding *v3.Encoding) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.RequestBodyValidation,
+		ValidationSubType: helpers.RequestBodyContentType,
+		Message: fmt.Sprintf("%s request part '%s' content type '%s' is not allowed",
+			request.Method, name, contentType),
+		Reason: fmt.Sprintf("The content type '%s' of the part '%s' does not match the "+
+			"content type '%s' defined by the encoding", contentType, name, encoding.ContentType),
+		SpecLine:      encoding.GoLow().ContentType.ValueNode.Line,
+		SpecCol:       encoding.GoLow().ContentType.ValueNode.Column,
+		Context:       encoding,
+		HowToFix:      fmt.Sprintf(HowToFixInvalidPartContentType, encoding.ContentType),
+		RequestPath:   request.URL.Path,
+		RequestMethod: request.Method,
+	}
+}
+
+func RequestReadOnlyProperty(request *http.Request, pointer string, renderedSchema []byte) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.RequestBodyValidation,
+		ValidationSubType: helpers.Schema,
+		Message: fmt.Sprintf("%s request body for '%s' contains the read only property '%s'",
+			request.Method, request.URL.Path, pointer),
+		Reason: fmt.Sprintf("The property '%s' is defined as readOnly, "+
+			"however it has been sent in the request body", pointer),
+		SpecLine: 1,
+		SpecCol:  0,
+		SchemaValidationErrors: []*SchemaValidationFailure{{
+			Reason:          fmt.Sprintf("property '%s' is readOnly", pointer),
+			Location:        pointer,
+			InstancePath:    pointer,
+			Keyword:         helpers.ReadOnly,
+			ReferenceSchema: string(renderedSchema),
+		}},
+		Context:       string(renderedSchema),
+		HowToFix:      fmt.Sprintf(HowToFixReadOnlyProperty, pointer),
+		RequestPath:   request.URL.Path,
+		RequestMethod: request.Method,
+	}
+}
+
+// WebhookNotFound will create a ValidationError for a request that is validated against a webhook that is not
+// defined in the 'webhooks' of the specification.
+func WebhookNotFound(name string, request *http.Request) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.RequestValidation,
+		ValidationSubType: helpers.RequestMissingWebhook,
+		Message:           fmt.Sprintf("Webhook '%s' not found", name),
+		Reason: fmt.Sprintf("The %s request was validated against the webhook '%s', "+
+			"however that webhook does not exist in the specification", request.Method, name),
+		SpecLine:      -1,
+		SpecCol:       -1,
+		HowToFix:      HowToFixMissingWebhook,
+		RequestPath:   request.URL.Path,
+		RequestMethod: request.Method,
+		SpecPath:      name,
+	}
+}
+
+// RequestValidationCancelled will create a ValidationError for a request whose validation was stopped, because
+// the context of the validation was cancelled or its deadline passed. The error of the context is the reason.
+func RequestValidationCancelled(request *http.Request, err error) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.RequestValidation,
+		ValidationSubType: helpers.RequestValidationCancelled,
+		Message:           fmt.Sprintf("%s request validation for '%s' was cancelled", request.Method, request.URL.Path),
+		Reason: fmt.Sprintf("The validation of the %s request was stopped before it was complete: %s",
+			request.Method, err.Error()),
+		SpecLine:      -1,
+		SpecCol:       -1,
+		HowToFix:      HowToFixValidationCancelled,
+		RequestPath:   request.URL.Path,
+		RequestMethod: request.Method,
+	}
+}
+
+// DocumentNotFound will create a ValidationError for a request that is routed to a document, by the selector of a
+// multi-document validator, that has not been added to the validator.
+func DocumentNotFound(name string, request *http.Request) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.RequestValidation,
+		ValidationSubType: helpers.RequestMissingDocument,
+		Message:           fmt.Sprintf("Document '%s' not found", name),
+		Reason: fmt.Sprintf("The %s request to '%s' was routed to the document '%s', "+
+			"however no document has been added with that name", request.Method, request.URL.Path, name),
+		SpecLine:      -1,
+		SpecCol:       -1,
+		HowToFix:      HowToFixMissingDocument,
+		RequestPath:   request.URL.Path,
+		RequestMethod: request.Method,
+	}
+}
+
+// WebhookOperationNotFound will create a ValidationError for a request with a method that is not defined by
+// the webhook it's validated against.
+func WebhookOperationNotFound(name string, pathItem *v3.PathItem, request *http.Request) *ValidationError {
+	line, col := -1, -1
+	if low := pathItem.GoLow(); low != nil && low.RootNode != nil {
+		line, col = low.RootNode.Line, low.RootNode.Column
+	}
+	return &ValidationError{
+		ValidationType:    helpers.RequestValidation,
+		ValidationSubType: helpers.RequestMissingOperation,
+		Message:           fmt.Sprintf("%s operation for webhook '%s' does not exist", request.Method, name),
+		Reason:            fmt.Sprintf("The webhook '%s' was found, but there was no '%s' method found in the spec", name, request.Method),
+		SpecLine:          line,
+		SpecCol:           col,
+		Context:           pathItem,
+		HowToFix:          HowToFixWebhookMethod,
+		RequestPath:       request.URL.Path,
+		RequestMethod:     request.Method,
+		SpecPath:          name,
+	}
+}
+
+// CallbackNotFound will create a ValidationError for a request that is validated against a callback that is not
+// declared by any operation in the specification.
+func CallbackNotFound(name string, request *http.Request) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.RequestValidation,
+		ValidationSubType: helpers.RequestMissingCallback,
+		Message:           fmt.Sprintf("Callback '%s' not found", name),
+		Reason: fmt.Sprintf("The %s request was validated against the callback '%s', "+
+			"however no operation declares that callback in the specification", request.Method, name),
+		SpecLine:      -1,
+		SpecCol:       -1,
+		HowToFix:      HowToFixMissingCallback,
+		RequestPath:   request.URL.Path,
+		RequestMethod: request.Method,
+	}
+}
+
+// CallbackOperationNotFound will create a ValidationError for a request with a method that is not defined by
+// any of the expressions of the callback it's validated against.
+func CallbackOperationNotFound(name string, callback *v3.Callback, request *http.Request) *ValidationError {
+	line, col := -1, -1
+	if low := callback.GoLow(); low != nil && low.RootNode != nil {
+		line, col = low.RootNode.Line, low.RootNode.Column
+	}
+	return &ValidationError{
+		ValidationType:    helpers.RequestValidation,
+		ValidationSubType: helpers.RequestMissingOperation,
+		Message:           fmt.Sprintf("%s operation for callback '%s' does not exist", request.Method, name),
+		Reason:            fmt.Sprintf("The callback '%s' was found, but there was no '%s' method found in the spec", name, request.Method),
+		SpecLine:          line,
+		SpecCol:           col,
+		Context:           callback,
+		HowToFix:          HowToFixCallbackMethod,
+		RequestPath:       request.URL.Path,
+		RequestMethod:     request.Method,
+	}
+}
+
+// OperationIDNotFound will create a ValidationError for a request that is validated against an operation, by the
+// operationId of the operation, when no operation in the specification declares that operationId.
+func OperationIDNotFound(operationID string, request *http.Request) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.RequestValidation,
+		ValidationSubType: helpers.RequestMissingOperationID,
+		Message:           fmt.Sprintf("Operation '%s' not found", operationID),
+		Reason: fmt.Sprintf("The %s request was validated against the operation '%s', "+
+			"however no operation declares that operationId in the specification", request.Method, operationID),
+		SpecLine:      -1,
+		SpecCol:       -1,
+		HowToFix:      HowToFixMissingOperationID,
+		RequestPath:   request.URL.Path,
+		RequestMethod: request.Method,
+	}
+}
+
+// OperationIDMethodMismatch will create a ValidationError for a request that is validated against an operation, by
+// the operationId of the operation, when the method of the request is not the method of the operation.
+func OperationIDMethodMismatch(operationID string, operation *v3.Operation, method string,
+	request *http.Request, specPath string) *ValidationError {
+	line, col := -1, -1
+	if low := operation.GoLow(); low != nil && low.KeyNode != nil {
+		line, col = low.KeyNode.Line, low.KeyNode.Column
+	}
+	return &ValidationError{
+		ValidationType:    helpers.RequestValidation,
+		ValidationSubType: helpers.RequestMissingOperation,
+		Message:           fmt.Sprintf("%s operation '%s' does not exist", request.Method, operationID),
+		Reason: fmt.Sprintf("The operation '%s' was found, but it's a '%s' operation, not a '%s' operation",
+			operationID, method, request.Method),
+		SpecLine:      line,
+		SpecCol:       col,
+		Context:       operation,
+		HowToFix:      fmt.Sprintf(HowToFixOperationIDMethod, method),
+		RequestPath:   request.URL.Path,
+		RequestMethod: request.Method,
+		SpecPath:      specPath,
+	}
+}
@@ -4,6 +4,8 @@
 package errors
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"testing"
 
@@ -76,6 +78,41 @@ func TestRequestContentTypeNotFound(t *testing.T) {
 	require.Contains(t, err.HowToFix, "application/json")
 }
 
+func TestRequestBodyMissing(t *testing.T) {
+	op := createMockOperationWithRequestBody()
+
+	request, _ := http.NewRequest(http.MethodPost, "/test", nil)
+
+	err := RequestBodyMissing(op, request, "/test")
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.RequestBodyValidation, err.ValidationType)
+	require.Equal(t, helpers.RequestMissingBody, err.ValidationSubType)
+	require.Equal(t, "POST request body is missing for '/test'", err.Message)
+	require.Equal(t, "The POST request has no body, however the request body is required by the operation", err.Reason)
+	require.Equal(t, -1, err.SpecLine)
+	require.Equal(t, -1, err.SpecCol)
+	require.Contains(t, err.HowToFix, "application/json")
+	require.Equal(t, "/test", err.SpecPath)
+}
+
+func TestRequestBodyEncodingInvalid(t *testing.T) {
+	request, _ := http.NewRequest(http.MethodPost, "/test", nil)
+	request.Header.Set(helpers.ContentEncodingHeader, "gzip")
+
+	err := RequestBodyEncodingInvalid(request, fmt.Errorf("gzip: invalid header"), "/test")
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.RequestBodyValidation, err.ValidationType)
+	require.Equal(t, helpers.RequestBodyContentEncoding, err.ValidationSubType)
+	require.Equal(t, "POST request body for '/test' cannot be decoded with content encoding 'gzip'", err.Message)
+	require.Equal(t, "The POST request body is encoded with 'gzip', however it cannot be decompressed: "+
+		"gzip: invalid header", err.Reason)
+	require.Equal(t, -1, err.SpecLine)
+	require.Equal(t, HowToFixInvalidBodyEncoding, err.HowToFix)
+	require.Equal(t, "/test", err.SpecPath)
+}
+
 func TestOperationNotFound(t *testing.T) {
 	// Create a mock path item
 	pathItem := createMockPathItem()
@@ -96,3 +133,201 @@ func TestOperationNotFound(t *testing.T) {
 	require.Equal(t, 25, err.SpecCol)
 	require.Equal(t, HowToFixPathMethod, err.HowToFix)
 }
+
+func TestRequestPartContentTypeMismatch(t *testing.T) {
+	// Create a mock encoding with a content type
+	encoding := v3.NewEncoding(&lowv3.Encoding{
+		ContentType: low.NodeReference[string]{
+			Value:     "image/png",
+			ValueNode: &yaml.Node{Line: 12, Column: 22},
+		},
+	})
+
+	// Create a mock request
+	request, _ := http.NewRequest(http.MethodPost, "/test", nil)
+
+	// Call the function
+	err := RequestPartContentTypeMismatch(request, "avatar", "text/plain", encoding)
+
+	// Validate the error
+	require.NotNil(t, err)
+	require.Equal(t, helpers.RequestBodyValidation, err.ValidationType)
+	require.Equal(t, helpers.RequestBodyContentType, err.ValidationSubType)
+	require.Contains(t, err.Message, "part 'avatar' content type 'text/plain' is not allowed")
+	require.Contains(t, err.Reason, "content type 'image/png' defined by the encoding")
+	require.Equal(t, 12, err.SpecLine)
+	require.Equal(t, 22, err.SpecCol)
+	require.Contains(t, err.HowToFix, "image/png")
+}
+
+func TestRequestReadOnlyProperty(t *testing.T) {
+	request, _ := http.NewRequest(http.MethodPost, "/test", nil)
+
+	err := RequestReadOnlyProperty(request, "/id", []byte("type: object"))
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.RequestBodyValidation, err.ValidationType)
+	require.Equal(t, helpers.Schema, err.ValidationSubType)
+	require.Equal(t, "POST request body for '/test' contains the read only property '/id'", err.Message)
+	require.Len(t, err.SchemaValidationErrors, 1)
+	require.Equal(t, "/id", err.SchemaValidationErrors[0].InstancePath)
+	require.Equal(t, helpers.ReadOnly, err.SchemaValidationErrors[0].Keyword)
+	require.Contains(t, err.HowToFix, "'/id' is read only")
+}
+
+func TestWebhookNotFound(t *testing.T) {
+	request, _ := http.NewRequest(http.MethodPost, "/hooks", nil)
+
+	err := WebhookNotFound("newBurger", request)
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.RequestValidation, err.ValidationType)
+	require.Equal(t, helpers.RequestMissingWebhook, err.ValidationSubType)
+	require.Equal(t, "Webhook 'newBurger' not found", err.Message)
+	require.Contains(t, err.Reason, "validated against the webhook 'newBurger'")
+	require.Equal(t, -1, err.SpecLine)
+	require.Equal(t, "newBurger", err.SpecPath)
+	require.Equal(t, http.MethodPost, err.RequestMethod)
+	require.Equal(t, HowToFixMissingWebhook, err.HowToFix)
+}
+
+func TestRequestValidationCancelled(t *testing.T) {
+	request, _ := http.NewRequest(http.MethodPost, "/burgers", nil)
+
+	err := RequestValidationCancelled(request, context.DeadlineExceeded)
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.RequestValidation, err.ValidationType)
+	require.Equal(t, helpers.RequestValidationCancelled, err.ValidationSubType)
+	require.Equal(t, "POST request validation for '/burgers' was cancelled", err.Message)
+	require.Equal(t, "The validation of the POST request was stopped before it was complete: "+
+		"context deadline exceeded", err.Reason)
+	require.Equal(t, "/burgers", err.RequestPath)
+	require.Equal(t, HowToFixValidationCancelled, err.HowToFix)
+}
+
+func TestDocumentNotFound(t *testing.T) {
+	request, _ := http.NewRequest(http.MethodGet, "/v3/burgers", nil)
+
+	err := DocumentNotFound("v3", request)
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.RequestValidation, err.ValidationType)
+	require.Equal(t, helpers.RequestMissingDocument, err.ValidationSubType)
+	require.Equal(t, "Document 'v3' not found", err.Message)
+	require.Equal(t, "The GET request to '/v3/burgers' was routed to the document 'v3', "+
+		"however no document has been added with that name", err.Reason)
+	require.Equal(t, -1, err.SpecLine)
+	require.Equal(t, "/v3/burgers", err.RequestPath)
+	require.Equal(t, HowToFixMissingDocument, err.HowToFix)
+}
+
+func TestWebhookOperationNotFound(t *testing.T) {
+	pathItem := v3.NewPathItem(&lowv3.PathItem{RootNode: &yaml.Node{Line: 3, Column: 5}})
+	request, _ := http.NewRequest(http.MethodPatch, "/hooks", nil)
+
+	err := WebhookOperationNotFound("newBurger", pathItem, request)
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.RequestValidation, err.ValidationType)
+	require.Equal(t, helpers.RequestMissingOperation, err.ValidationSubType)
+	require.Equal(t, "PATCH operation for webhook 'newBurger' does not exist", err.Message)
+	require.Contains(t, err.Reason, "there was no 'PATCH' method found in the spec")
+	require.Equal(t, 3, err.SpecLine)
+	require.Equal(t, 5, err.SpecCol)
+	require.Same(t, pathItem, err.Context)
+	require.Equal(t, HowToFixWebhookMethod, err.HowToFix)
+}
+
+func TestCallbackNotFound(t *testing.T) {
+	request, _ := http.NewRequest(http.MethodPost, "/notify", nil)
+
+	err := CallbackNotFound("burgerReady", request)
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.RequestValidation, err.ValidationType)
+	require.Equal(t, helpers.RequestMissingCallback, err.ValidationSubType)
+	require.Equal(t, "Callback 'burgerReady' not found", err.Message)
+	require.Contains(t, err.Reason, "no operation declares that callback")
+	require.Equal(t, -1, err.SpecLine)
+	require.Equal(t, HowToFixMissingCallback, err.HowToFix)
+}
+
+func TestCallbackOperationNotFound(t *testing.T) {
+	callback := v3.NewCallback(&lowv3.Callback{RootNode: &yaml.Node{Line: 8, Column: 11}})
+	request, _ := http.NewRequest(http.MethodPatch, "/notify", nil)
+
+	err := CallbackOperationNotFound("burgerReady", callback, request)
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.RequestMissingOperation, err.ValidationSubType)
+	require.Equal(t, "PATCH operation for callback 'burgerReady' does not exist", err.Message)
+	require.Equal(t, 8, err.SpecLine)
+	require.Equal(t, 11, err.SpecCol)
+	require.Same(t, callback, err.Context)
+	require.Equal(t, HowToFixCallbackMethod, err.HowToFix)
+}
+
+func TestOperationIDNotFound(t *testing.T) {
+	request, _ := http.NewRequest(http.MethodPost, "/burgers", nil)
+
+	err := OperationIDNotFound("createBurger", request)
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.RequestValidation, err.ValidationType)
+	require.Equal(t, helpers.RequestMissingOperationID, err.ValidationSubType)
+	require.Equal(t, "Operation 'createBurger' not found", err.Message)
+	require.Equal(t, "The POST request was validated against the operation 'createBurger', "+
+		"however no operation declares that operationId in the specification", err.Reason)
+	require.Equal(t, -1, err.SpecLine)
+	require.Equal(t, HowToFixMissingOperationID, err.HowToFix)
+	require.Equal(t, "/burgers", err.RequestPath)
+}
+
+func TestOperationIDMethodMismatch(t *testing.T) {
+	request, _ := http.NewRequest(http.MethodPost, "/burgers", nil)
+
+	err := OperationIDMethodMismatch("listBurgers", &v3.Operation{OperationId: "listBurgers"}, http.MethodGet,
+		request, "/burgers")
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.RequestValidation, err.ValidationType)
+	require.Equal(t, helpers.RequestMissingOperation, err.ValidationSubType)
+	require.Equal(t, "POST operation 'listBurgers' does not exist", err.Message)
+	require.Equal(t, "The operation 'listBurgers' was found, but it's a 'GET' operation, not a 'POST' operation",
+		err.Reason)
+	require.Equal(t, -1, err.SpecLine)
+	require.Equal(t, "Send the request with the 'GET' method of the operation", err.HowToFix)
+	require.Equal(t, "/burgers", err.SpecPath)
+}
+
+func TestRequestBodyDecodedTooLarge(t *testing.T) {
+	request, _ := http.NewRequest(http.MethodPost, "/burgers", nil)
+	request.Header.Set(helpers.ContentEncodingHeader, "gzip")
+
+	err := RequestBodyDecodedTooLarge(request, 1024, "/burgers")
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.RequestBodyValidation, err.ValidationType)
+	require.Equal(t, helpers.RequestBodyContentEncoding, err.ValidationSubType)
+	require.Equal(t, "POST request body for '/burgers' is too large to decode with content encoding 'gzip'", err.Message)
+	require.Equal(t, "The POST request body is encoded with 'gzip', however it is larger than 1024 bytes "+
+		"once decompressed", err.Reason)
+	require.Equal(t, "Send a request body that is no larger than 1024 bytes once it has been decompressed", err.HowToFix)
+	require.Equal(t, "/burgers", err.SpecPath)
+}
+
+func TestRequestBodyTooLarge(t *testing.T) {
+	request, _ := http.NewRequest(http.MethodPost, "/burgers", nil)
+
+	err := RequestBodyTooLarge(request, 1024)
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.RequestBodyValidation, err.ValidationType)
+	require.Equal(t, helpers.RequestBodyTooLarge, err.ValidationSubType)
+	require.Equal(t, "POST request body for '/burgers' is too large to validate", err.Message)
+	require.Equal(t, "The POST request body is larger than 1024 bytes, which is the largest body "+
+		"that is decoded as it's streamed", err.Reason)
+	require.Equal(t, "Send a request body that is no larger than 1024 bytes", err.HowToFix)
+	require.Equal(t, -1, err.SpecLine)
+}
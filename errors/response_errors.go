@@ -70,3 +70,71 @@ func ResponseCodeNotFound(op *v3.Operation, request *http.Request, code int) *Va
 		HowToFix: HowToFixInvalidResponseCode,
 	}
 }
+
+func ResponseHeaderMissing(request *http.Request, name string, header *v3.Header, code string) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.ResponseBodyValidation,
+		ValidationSubType: helpers.ParameterValidationHeader,
+		Message: fmt.Sprintf("%s / %s operation response header '%s' is missing",
+			request.Method, code, name),
+		Reason: fmt.Sprintf("The response header '%s' is defined as being required, "+
+			"however it's missing from the response", name),
+		SpecLine: header.GoLow().Required.KeyNode.Line,
+		SpecCol:  header.GoLow().Required.KeyNode.Column,
+		Context:  header,
+		HowToFix: HowToFixMissingValue,
+	}
+}
+
+func ResponseWriteOnlyProperty(request *http.Request, code int, pointer string, renderedSchema []byte) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.ResponseBodyValidation,
+		ValidationSubType: helpers.Schema,
+		Message: fmt.Sprintf("%d response body for '%s' contains the write only property '%s'",
+			code, request.URL.Path, pointer),
+		Reason: fmt.Sprintf("The property '%s' is defined as writeOnly, "+
+			"however it has been returned in the response body", pointer),
+		SpecLine: 1,
+		SpecCol:  0,
+		SchemaValidationErrors: []*SchemaValidationFailure{{
+			Reason:          fmt.Sprintf("property '%s' is writeOnly", pointer),
+			Location:        pointer,
+			InstancePath:    pointer,
+			Keyword:         helpers.WriteOnly,
+			ReferenceSchema: string(renderedSchema),
+		}},
+		Context:       string(renderedSchema),
+		HowToFix:      fmt.Sprintf(HowToFixWriteOnlyProperty, pointer),
+		RequestPath:   request.URL.Path,
+		RequestMethod: request.Method,
+	}
+}
+
+// ResponseRequestMissing will create a ValidationError for a response that is validated without the request that
+// produced it, there is no way to locate the operation the response belongs to without the request.
+func ResponseRequestMissing(response *http.Response) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.ResponseBodyValidation,
+		ValidationSubType: helpers.ResponseMissingRequest,
+		Message:           fmt.Sprintf("%d response has no request", response.StatusCode),
+		Reason: "The response does not reference the request that produced it, " +
+			"so the operation for the response cannot be found in the specification",
+		SpecLine: -1,
+		SpecCol:  -1,
+		HowToFix: HowToFixMissingResponseRequest,
+	}
+}
+
+// ResponseMissing will create a ValidationError for a nil response, there is nothing to validate, and no request
+// to locate the operation with.
+func ResponseMissing() *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.ResponseBodyValidation,
+		ValidationSubType: helpers.ResponseMissing,
+		Message:           "response is missing",
+		Reason:            "The response to validate is nil, so it cannot be validated against the specification",
+		SpecLine:          -1,
+		SpecCol:           -1,
+		HowToFix:          HowToFixMissingResponse,
+	}
+}
@@ -157,3 +157,72 @@ func TestResponseCodeNotFound(t *testing.T) {
 	require.Equal(t, 56, err.SpecCol)
 	require.Equal(t, HowToFixInvalidResponseCode, err.HowToFix)
 }
+
+func TestResponseRequestMissing(t *testing.T) {
+	response := &http.Response{StatusCode: http.StatusNotFound}
+
+	err := ResponseRequestMissing(response)
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.ResponseBodyValidation, err.ValidationType)
+	require.Equal(t, helpers.ResponseMissingRequest, err.ValidationSubType)
+	require.Equal(t, "404 response has no request", err.Message)
+	require.Contains(t, err.Reason, "does not reference the request that produced it")
+	require.Equal(t, -1, err.SpecLine)
+	require.Equal(t, -1, err.SpecCol)
+	require.Equal(t, HowToFixMissingResponseRequest, err.HowToFix)
+}
+
+func TestResponseMissing(t *testing.T) {
+	err := ResponseMissing()
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.ResponseBodyValidation, err.ValidationType)
+	require.Equal(t, helpers.ResponseMissing, err.ValidationSubType)
+	require.Equal(t, "response is missing", err.Message)
+	require.Contains(t, err.Reason, "The response to validate is nil")
+	require.Equal(t, -1, err.SpecLine)
+	require.Equal(t, -1, err.SpecCol)
+	require.Equal(t, HowToFixMissingResponse, err.HowToFix)
+}
+
+func TestResponseHeaderMissing(t *testing.T) {
+	// Create a mock header that is required
+	header := v3.NewHeader(&lowv3.Header{
+		Required: low.NodeReference[bool]{
+			Value:   true,
+			KeyNode: &yaml.Node{Line: 14, Column: 8},
+		},
+	})
+
+	// Create a mock request
+	request, _ := http.NewRequest(http.MethodGet, "/test", nil)
+
+	// Call the function
+	err := ResponseHeaderMissing(request, "X-Rate-Limit", header, "200")
+
+	// Validate the error
+	require.NotNil(t, err)
+	require.Equal(t, helpers.ResponseBodyValidation, err.ValidationType)
+	require.Equal(t, helpers.ParameterValidationHeader, err.ValidationSubType)
+	require.Equal(t, "GET / 200 operation response header 'X-Rate-Limit' is missing", err.Message)
+	require.Contains(t, err.Reason, "The response header 'X-Rate-Limit' is defined as being required")
+	require.Equal(t, 14, err.SpecLine)
+	require.Equal(t, 8, err.SpecCol)
+	require.Equal(t, HowToFixMissingValue, err.HowToFix)
+}
+
+func TestResponseWriteOnlyProperty(t *testing.T) {
+	request, _ := http.NewRequest(http.MethodGet, "/test", nil)
+
+	err := ResponseWriteOnlyProperty(request, 200, "/password", []byte("type: object"))
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.ResponseBodyValidation, err.ValidationType)
+	require.Equal(t, helpers.Schema, err.ValidationSubType)
+	require.Equal(t, "200 response body for '/test' contains the write only property '/password'", err.Message)
+	require.Len(t, err.SchemaValidationErrors, 1)
+	require.Equal(t, "/password", err.SchemaValidationErrors[0].InstancePath)
+	require.Equal(t, helpers.WriteOnly, err.SchemaValidationErrors[0].Keyword)
+	require.Contains(t, err.HowToFix, "'/password' is write only")
+}
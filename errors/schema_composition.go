@@ -0,0 +1,126 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package errors
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+)
+
+// IsCompositionFailure will return true if the kind of error reported by the schema validator is for a oneOf
+// or an anyOf schema.
+func IsCompositionFailure(k jsonschema.ErrorKind) bool {
+	switch k.(type) {
+	case *kind.OneOf, *kind.AnyOf:
+		return true
+	}
+	return false
+}
+
+// SetCompositionResult will record how many branches of a oneOf or anyOf schema matched on a failure, using the
+// kind of error reported by the schema validator. Failures for any other kind of error are left untouched.
+func SetCompositionResult(failure *SchemaValidationFailure, k jsonschema.ErrorKind) {
+	switch ek := k.(type) {
+	case *kind.OneOf:
+		matched := len(ek.Subschemas)
+		failure.MatchedBranches = &matched
+	case *kind.AnyOf:
+		matched := 0
+		failure.MatchedBranches = &matched
+	}
+}
+
+// AggregateCompositionFailures will fold the failures of each branch of a oneOf or anyOf schema into the failure
+// for the oneOf or anyOf schema itself, so a single failure is reported for each composition. The failures are
+// matched to their composition using their keyword location (DeepLocation) and the value that failed (InstancePath),
+// so the compositions of each element of an array only hold the failures of that element. The reason of the
+// composition is rewritten to list each branch and why it failed.
+func AggregateCompositionFailures(failures []*SchemaValidationFailure) []*SchemaValidationFailure {
+	var compositions []*SchemaValidationFailure
+	for _, f := range failures {
+		if f.MatchedBranches != nil {
+			compositions = append(compositions, f)
+		}
+	}
+	if len(compositions) == 0 {
+		return failures
+	}
+
+	var aggregated []*SchemaValidationFailure
+	for _, f := range failures {
+		parent, index := findComposition(compositions, f)
+		if parent == nil {
+			aggregated = append(aggregated, f)
+			continue
+		}
+		var branch *SchemaBranchFailure
+		for _, b := range parent.Branches {
+			if b.Index == index {
+				branch = b
+				break
+			}
+		}
+		if branch == nil {
+			branch = &SchemaBranchFailure{Index: index}
+			parent.Branches = append(parent.Branches, branch)
+		}
+		branch.SubErrors = append(branch.SubErrors, f)
+	}
+
+	// build the reasons for the deepest compositions first, as they are used by the compositions that contain them.
+	sort.SliceStable(compositions, func(i, j int) bool {
+		return len(compositions[i].DeepLocation) > len(compositions[j].DeepLocation)
+	})
+	for _, c := range compositions {
+		sort.SliceStable(c.Branches, func(i, j int) bool { return c.Branches[i].Index < c.Branches[j].Index })
+		var branchReasons []string
+		for _, b := range c.Branches {
+			var reasons []string
+			for _, sub := range b.SubErrors {
+				reasons = append(reasons, sub.Reason)
+			}
+			b.Reason = strings.Join(reasons, ", ")
+			branchReasons = append(branchReasons, fmt.Sprintf("branch %d: %s", b.Index, b.Reason))
+		}
+		keyword := c.DeepLocation[strings.LastIndex(c.DeepLocation, "/")+1:]
+		expected := "at least one is expected"
+		if keyword == "oneOf" {
+			expected = "exactly one is expected"
+		}
+		c.Reason = fmt.Sprintf("%s failed, %d branches matched (%s)", keyword, *c.MatchedBranches, expected)
+		if len(branchReasons) > 0 {
+			c.Reason = fmt.Sprintf("%s: %s", c.Reason, strings.Join(branchReasons, "; "))
+		}
+	}
+	return aggregated
+}
+
+// findComposition will find the closest composition that a failure belongs to, along with the index of the
+// branch of the composition the failure is in.
+func findComposition(compositions []*SchemaValidationFailure, failure *SchemaValidationFailure) (*SchemaValidationFailure, int) {
+	var parent *SchemaValidationFailure
+	index := -1
+	for _, c := range compositions {
+		if c == failure || !strings.HasPrefix(failure.DeepLocation, c.DeepLocation+"/") ||
+			(failure.InstancePath != c.InstancePath && !strings.HasPrefix(failure.InstancePath, c.InstancePath+"/")) {
+			continue
+		}
+		if parent != nil && len(parent.DeepLocation) >= len(c.DeepLocation) {
+			continue
+		}
+		segment := strings.TrimPrefix(failure.DeepLocation, c.DeepLocation+"/")
+		if i := strings.Index(segment, "/"); i >= 0 {
+			segment = segment[:i]
+		}
+		if idx, err := strconv.Atoi(segment); err == nil {
+			parent, index = c, idx
+		}
+	}
+	return parent, index
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package errors
+
+import (
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsCompositionFailure(t *testing.T) {
+	require.True(t, IsCompositionFailure(&kind.OneOf{}))
+	require.True(t, IsCompositionFailure(&kind.AnyOf{}))
+	require.False(t, IsCompositionFailure(&kind.AllOf{}))
+	require.False(t, IsCompositionFailure(&kind.Required{Missing: []string{"name"}}))
+}
+
+func TestAggregateCompositionFailures_NoneMatched(t *testing.T) {
+	oneOf := &SchemaValidationFailure{Reason: "oneOf failed, none matched", DeepLocation: "/properties/pet/oneOf"}
+	SetCompositionResult(oneOf, &kind.OneOf{})
+
+	failures := []*SchemaValidationFailure{
+		{Reason: "missing property 'name'", DeepLocation: "/required"},
+		oneOf,
+		{Reason: "missing property 'meows'", DeepLocation: "/properties/pet/oneOf/0/required"},
+		{Reason: "got string, want integer", DeepLocation: "/properties/pet/oneOf/1/properties/barks/type"},
+		{Reason: "missing property 'legs'", DeepLocation: "/properties/pet/oneOf/1/required"},
+	}
+
+	aggregated := AggregateCompositionFailures(failures)
+
+	require.Len(t, aggregated, 2)
+	require.Equal(t, "missing property 'name'", aggregated[0].Reason)
+	require.Equal(t, oneOf, aggregated[1])
+	require.Equal(t, 0, *oneOf.MatchedBranches)
+	require.Len(t, oneOf.Branches, 2)
+	require.Equal(t, 0, oneOf.Branches[0].Index)
+	require.Len(t, oneOf.Branches[0].SubErrors, 1)
+	require.Equal(t, 1, oneOf.Branches[1].Index)
+	require.Len(t, oneOf.Branches[1].SubErrors, 2)
+	require.Equal(t, "got string, want integer, missing property 'legs'", oneOf.Branches[1].Reason)
+	require.Equal(t, "oneOf failed, 0 branches matched (exactly one is expected): "+
+		"branch 0: missing property 'meows'; branch 1: got string, want integer, missing property 'legs'", oneOf.Reason)
+}
+
+func TestAggregateCompositionFailures_TwoMatched(t *testing.T) {
+	oneOf := &SchemaValidationFailure{Reason: "oneOf failed, subschemas 0, 1 matched", DeepLocation: "/oneOf"}
+	SetCompositionResult(oneOf, &kind.OneOf{Subschemas: []int{0, 1}})
+
+	aggregated := AggregateCompositionFailures([]*SchemaValidationFailure{oneOf})
+
+	require.Len(t, aggregated, 1)
+	require.Equal(t, 2, *aggregated[0].MatchedBranches)
+	require.Empty(t, aggregated[0].Branches)
+	require.Equal(t, "oneOf failed, 2 branches matched (exactly one is expected)", aggregated[0].Reason)
+}
+
+func TestAggregateCompositionFailures_Nested(t *testing.T) {
+	outer := &SchemaValidationFailure{DeepLocation: "/anyOf"}
+	SetCompositionResult(outer, &kind.AnyOf{})
+	inner := &SchemaValidationFailure{DeepLocation: "/anyOf/1/oneOf"}
+	SetCompositionResult(inner, &kind.OneOf{})
+
+	aggregated := AggregateCompositionFailures([]*SchemaValidationFailure{
+		outer,
+		{Reason: "got string, want object", DeepLocation: "/anyOf/0/type"},
+		inner,
+		{Reason: "got string, want integer", DeepLocation: "/anyOf/1/oneOf/0/type"},
+	})
+
+	require.Len(t, aggregated, 1)
+	require.Len(t, outer.Branches, 2)
+	require.Equal(t, inner, outer.Branches[1].SubErrors[0])
+	require.Equal(t, "oneOf failed, 0 branches matched (exactly one is expected): branch 0: got string, want integer",
+		inner.Reason)
+	require.Equal(t, "anyOf failed, 0 branches matched (at least one is expected): branch 0: got string, want object; "+
+		"branch 1: oneOf failed, 0 branches matched (exactly one is expected): branch 0: got string, want integer",
+		outer.Reason)
+}
+
+func TestAggregateCompositionFailures_NoComposition(t *testing.T) {
+	failures := []*SchemaValidationFailure{{Reason: "missing property 'name'", DeepLocation: "/required"}}
+	require.Equal(t, failures, AggregateCompositionFailures(failures))
+}
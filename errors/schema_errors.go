@@ -0,0 +1,152 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package errors
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pb33f/libopenapi-validator/helpers"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"gopkg.in/yaml.v3"
+)
+
+func UnknownDiscriminatorValue(schema *base.Schema, value, entity, validationType string) *ValidationError {
+	return &ValidationError{
+		ValidationType:    validationType,
+		ValidationSubType: helpers.Schema,
+		Message:           fmt.Sprintf("%s discriminator value '%s' does not map to a schema", entity, value),
+		Reason: fmt.Sprintf("The value '%s' of the discriminator property '%s' does not map to any "+
+			"of the schemas that can be selected", value, schema.Discriminator.PropertyName),
+		SpecLine: schema.GoLow().Discriminator.KeyNode.Line,
+		SpecCol:  schema.GoLow().Discriminator.KeyNode.Column,
+		Context:  schema,
+		HowToFix: fmt.Sprintf(HowToFixUnknownDiscriminator,
+			strings.Join(helpers.DiscriminatorValues(schema), ", ")),
+	}
+}
+
+// UnresolvedSchema will create a ValidationError for a schema in the specification that cannot be built, which
+// usually means it contains a $ref that cannot be resolved. The location is a JSON pointer to the schema.
+func UnresolvedSchema(location string, node *yaml.Node, err error) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.Schema,
+		ValidationSubType: helpers.UnresolvedSchema,
+		Message:           fmt.Sprintf("schema at '%s' cannot be resolved", location),
+		Reason:            fmt.Sprintf("The schema cannot be built: %s", err.Error()),
+		SpecLine:          nodeLine(node),
+		SpecCol:           nodeColumn(node),
+		SpecPath:          location,
+		HowToFix:          HowToFixUnresolvedSchema,
+	}
+}
+
+// UncompilableSchema will create a ValidationError for a schema in the specification that cannot be compiled into
+// a JSON schema, because it uses a keyword incorrectly. The location is a JSON pointer to the schema.
+func UncompilableSchema(location string, node *yaml.Node, schema *base.Schema, err error) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.Schema,
+		ValidationSubType: helpers.UncompilableSchema,
+		Message:           fmt.Sprintf("schema at '%s' cannot be compiled", location),
+		Reason:            fmt.Sprintf("The schema is not a valid JSON schema: %s", err.Error()),
+		SpecLine:          nodeLine(node),
+		SpecCol:           nodeColumn(node),
+		SpecPath:          location,
+		Context:           schema,
+		HowToFix:          HowToFixUncompilableSchema,
+	}
+}
+
+// InvalidSchemaPattern will create a ValidationError for a schema that contains a regular expression the regex
+// engine cannot compile. The entity describes what the schema belongs to, for example 'POST request body', and
+// can be empty for a schema that is validated on its own.
+func InvalidSchemaPattern(patternErr *helpers.InvalidPatternError, schema *base.Schema,
+	entity, validationType string) *ValidationError {
+	var node *yaml.Node
+	if schema != nil && schema.GoLow() != nil {
+		node = schema.GoLow().RootNode
+	}
+	return &ValidationError{
+		ValidationType:    validationType,
+		ValidationSubType: helpers.InvalidSchemaPattern,
+		Message: strings.TrimSpace(fmt.Sprintf("%s schema pattern '%s' cannot be compiled",
+			entity, patternErr.Pattern)),
+		Reason: fmt.Sprintf("The pattern at '%s' of the schema is not supported by the regex engine: %s",
+			patternErr.Location, patternErr.Err.Error()),
+		SpecLine: nodeLine(node),
+		SpecCol:  nodeColumn(node),
+		Context:  schema,
+		HowToFix: HowToFixInvalidSchemaPattern,
+	}
+}
+
+// UnknownSchemaFormat will create a ValidationError for a schema that uses a format which is neither built in nor
+// registered as a custom format. The entity describes what the schema belongs to, for example 'POST request body',
+// and can be empty for a schema that is validated on its own.
+func UnknownSchemaFormat(formatErr *helpers.UnknownFormatError, schema *base.Schema,
+	entity, validationType string) *ValidationError {
+	var node *yaml.Node
+	if schema != nil && schema.GoLow() != nil {
+		node = schema.GoLow().RootNode
+	}
+	return &ValidationError{
+		ValidationType:    validationType,
+		ValidationSubType: helpers.UnknownSchemaFormat,
+		Message: strings.TrimSpace(fmt.Sprintf("%s schema format '%s' is not known",
+			entity, formatErr.Format)),
+		Reason: fmt.Sprintf("The format at '%s' of the schema is not a built-in format, and has not been "+
+			"registered as a custom format", formatErr.Location),
+		SpecLine: nodeLine(node),
+		SpecCol:  nodeColumn(node),
+		Context:  schema,
+		HowToFix: HowToFixUnknownSchemaFormat,
+	}
+}
+
+// ComponentSchemaNotFound will create a ValidationError for a value that is validated against a schema component
+// that does not exist in the 'components' of the specification.
+func ComponentSchemaNotFound(name string) *ValidationError {
+	return &ValidationError{
+		ValidationType:    helpers.Schema,
+		ValidationSubType: helpers.MissingComponentSchema,
+		Message:           fmt.Sprintf("schema component '%s' not found", name),
+		Reason: fmt.Sprintf("The value was validated against the schema '#/components/schemas/%s', "+
+			"however that schema does not exist in the specification", name),
+		SpecLine: -1,
+		SpecCol:  -1,
+		SpecPath: fmt.Sprintf("/components/schemas/%s", helpers.EscapeJSONPointer(name)),
+		HowToFix: HowToFixMissingComponentSchema,
+	}
+}
+
+// InvalidExample will create a ValidationError for an example in the specification that is not valid against the
+// schema it is an example of. The location is a JSON pointer to the example, and the failures explain why the
+// example is not valid.
+func InvalidExample(location string, node *yaml.Node, failures []*SchemaValidationFailure) *ValidationError {
+	return &ValidationError{
+		ValidationType:         helpers.Schema,
+		ValidationSubType:      helpers.InvalidExample,
+		Message:                fmt.Sprintf("example at '%s' does not match its schema", location),
+		Reason:                 "The example failed to validate against the schema it is an example of",
+		SpecLine:               nodeLine(node),
+		SpecCol:                nodeColumn(node),
+		SpecPath:               location,
+		SchemaValidationErrors: failures,
+		HowToFix:               HowToFixInvalidExample,
+	}
+}
+
+func nodeLine(node *yaml.Node) int {
+	if node == nil {
+		return 0
+	}
+	return node.Line
+}
+
+func nodeColumn(node *yaml.Node) int {
+	if node == nil {
+		return 0
+	}
+	return node.Column
+}
@@ -0,0 +1,152 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package errors
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pb33f/libopenapi-validator/helpers"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestUnknownDiscriminatorValue(t *testing.T) {
+	// Create a mock schema with a discriminator
+	mapping := orderedmap.New[low.KeyReference[string], low.ValueReference[string]]()
+	mapping.Set(low.KeyReference[string]{Value: "kitty"}, low.ValueReference[string]{Value: "#/components/schemas/Cat"})
+	schema := base.NewSchema(&lowbase.Schema{
+		Discriminator: low.NodeReference[*lowbase.Discriminator]{
+			Value: &lowbase.Discriminator{
+				PropertyName: low.NodeReference[string]{Value: "petType"},
+				Mapping:      low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[string]]]{Value: mapping},
+			},
+			KeyNode: &yaml.Node{Line: 16, Column: 7},
+		},
+	})
+
+	// Call the function
+	err := UnknownDiscriminatorValue(schema, "Fish", "POST request body", helpers.RequestBodyValidation)
+
+	// Validate the error
+	require.NotNil(t, err)
+	require.Equal(t, helpers.RequestBodyValidation, err.ValidationType)
+	require.Equal(t, helpers.Schema, err.ValidationSubType)
+	require.Equal(t, "POST request body discriminator value 'Fish' does not map to a schema", err.Message)
+	require.Contains(t, err.Reason, "The value 'Fish' of the discriminator property 'petType'")
+	require.Equal(t, 16, err.SpecLine)
+	require.Equal(t, 7, err.SpecCol)
+	require.Equal(t, "Use one of the values that map to a schema for the discriminator: kitty", err.HowToFix)
+}
+
+func TestUnresolvedSchema(t *testing.T) {
+	err := UnresolvedSchema("/paths/~1pets/get/parameters/0/schema", &yaml.Node{Line: 12, Column: 9},
+		fmt.Errorf("cannot find reference #/components/schemas/Pet"))
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.Schema, err.ValidationType)
+	require.Equal(t, helpers.UnresolvedSchema, err.ValidationSubType)
+	require.Equal(t, "schema at '/paths/~1pets/get/parameters/0/schema' cannot be resolved", err.Message)
+	require.Equal(t, "The schema cannot be built: cannot find reference #/components/schemas/Pet", err.Reason)
+	require.Equal(t, "/paths/~1pets/get/parameters/0/schema", err.SpecPath)
+	require.Equal(t, 12, err.SpecLine)
+	require.Equal(t, 9, err.SpecCol)
+	require.Equal(t, HowToFixUnresolvedSchema, err.HowToFix)
+}
+
+func TestComponentSchemaNotFound(t *testing.T) {
+	err := ComponentSchemaNotFound("Pet/Food")
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.Schema, err.ValidationType)
+	require.Equal(t, helpers.MissingComponentSchema, err.ValidationSubType)
+	require.Equal(t, "schema component 'Pet/Food' not found", err.Message)
+	require.Contains(t, err.Reason, "'#/components/schemas/Pet/Food'")
+	require.Equal(t, "/components/schemas/Pet~1Food", err.SpecPath)
+	require.Equal(t, -1, err.SpecLine)
+	require.Equal(t, -1, err.SpecCol)
+	require.Equal(t, HowToFixMissingComponentSchema, err.HowToFix)
+}
+
+func TestUncompilableSchema(t *testing.T) {
+	schema := &base.Schema{}
+	err := UncompilableSchema("/paths/~1pets/get/parameters/0/schema", nil, schema, fmt.Errorf("bad type"))
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.Schema, err.ValidationType)
+	require.Equal(t, helpers.UncompilableSchema, err.ValidationSubType)
+	require.Equal(t, "schema at '/paths/~1pets/get/parameters/0/schema' cannot be compiled", err.Message)
+	require.Equal(t, "The schema is not a valid JSON schema: bad type", err.Reason)
+	require.Equal(t, 0, err.SpecLine)
+	require.Equal(t, 0, err.SpecCol)
+	require.Same(t, schema, err.Context)
+	require.Equal(t, HowToFixUncompilableSchema, err.HowToFix)
+}
+
+func TestInvalidSchemaPattern(t *testing.T) {
+	schema := base.NewSchema(&lowbase.Schema{RootNode: &yaml.Node{Line: 12, Column: 9}})
+	patternErr := &helpers.InvalidPatternError{
+		Pattern:  "^(?=.*[A-Z]).+$",
+		Location: "/properties/name/pattern",
+		Err:      fmt.Errorf("invalid or unsupported Perl syntax: `(?=`"),
+	}
+	err := InvalidSchemaPattern(patternErr, schema, "POST request body", helpers.RequestBodyValidation)
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.RequestBodyValidation, err.ValidationType)
+	require.Equal(t, helpers.InvalidSchemaPattern, err.ValidationSubType)
+	require.Equal(t, "POST request body schema pattern '^(?=.*[A-Z]).+$' cannot be compiled", err.Message)
+	require.Equal(t, "The pattern at '/properties/name/pattern' of the schema is not supported by the regex engine: "+
+		"invalid or unsupported Perl syntax: `(?=`", err.Reason)
+	require.Equal(t, 12, err.SpecLine)
+	require.Equal(t, 9, err.SpecCol)
+	require.Same(t, schema, err.Context)
+	require.Equal(t, HowToFixInvalidSchemaPattern, err.HowToFix)
+
+	err = InvalidSchemaPattern(patternErr, nil, "", helpers.Schema)
+	require.Equal(t, "schema pattern '^(?=.*[A-Z]).+$' cannot be compiled", err.Message)
+	require.Equal(t, 0, err.SpecLine)
+}
+
+func TestUnknownSchemaFormat(t *testing.T) {
+	schema := base.NewSchema(&lowbase.Schema{RootNode: &yaml.Node{Line: 7, Column: 3}})
+	formatErr := &helpers.UnknownFormatError{Format: "date-tim", Location: "/properties/bakedAt/format"}
+	err := UnknownSchemaFormat(formatErr, schema, "POST request body", helpers.RequestBodyValidation)
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.RequestBodyValidation, err.ValidationType)
+	require.Equal(t, helpers.UnknownSchemaFormat, err.ValidationSubType)
+	require.Equal(t, "POST request body schema format 'date-tim' is not known", err.Message)
+	require.Equal(t, "The format at '/properties/bakedAt/format' of the schema is not a built-in format, "+
+		"and has not been registered as a custom format", err.Reason)
+	require.Equal(t, 7, err.SpecLine)
+	require.Equal(t, 3, err.SpecCol)
+	require.Same(t, schema, err.Context)
+	require.Equal(t, HowToFixUnknownSchemaFormat, err.HowToFix)
+
+	err = UnknownSchemaFormat(formatErr, nil, "", helpers.Schema)
+	require.Equal(t, "schema format 'date-tim' is not known", err.Message)
+	require.Equal(t, 0, err.SpecLine)
+}
+
+func TestInvalidExample(t *testing.T) {
+	failures := []*SchemaValidationFailure{{Reason: "missing property 'name'"}}
+	err := InvalidExample("/paths/~1pets/post/requestBody/content/application~1json/example",
+		&yaml.Node{Line: 22, Column: 20}, failures)
+
+	require.NotNil(t, err)
+	require.Equal(t, helpers.Schema, err.ValidationType)
+	require.Equal(t, helpers.InvalidExample, err.ValidationSubType)
+	require.Equal(t, "example at '/paths/~1pets/post/requestBody/content/application~1json/example' "+
+		"does not match its schema", err.Message)
+	require.Equal(t, "/paths/~1pets/post/requestBody/content/application~1json/example", err.SpecPath)
+	require.Equal(t, 22, err.SpecLine)
+	require.Equal(t, 20, err.SpecCol)
+	require.Equal(t, failures, err.SchemaValidationErrors)
+	require.Equal(t, HowToFixInvalidExample, err.HowToFix)
+}
@@ -0,0 +1,312 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package errors
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi-validator/helpers"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaFailures will build a SchemaValidationFailure for each unit of output of a schema validation error that is
+// worth reporting, units that only say a schema failed to validate are noise and are left out. The describe function
+// (which may be nil) is called with each failure and the unit it was built from, so context such as the location of
+// the keyword in the rendered schema can be added. Each additional property that is not allowed is then reported
+// individually, a single failure is reported for each invalid property name, and for each oneOf / anyOf (listing
+// why each of the branches failed), and elements after the 'prefixItems' of an array are reported with their index
+// in the array. A failure is only reported once for each value (each element of an array), even if the schema
+// validator reports it more than once. The decoded schema is the schema that was validated against.
+func SchemaFailures(validationError *jsonschema.ValidationError, decodedSchema any,
+	describe func(failure *SchemaValidationFailure, unit jsonschema.OutputUnit)) []*SchemaValidationFailure {
+	if validationError == nil {
+		return nil
+	}
+	printer := message.NewPrinter(language.Tag{})
+	var failures []*SchemaValidationFailure
+	for _, unit := range validationError.BasicOutput().Errors {
+		if unit.Error == nil {
+			continue
+		}
+		reason := unit.Error.Kind.LocalizedString(printer)
+		if !IsCompositionFailure(unit.Error.Kind) && helpers.IgnoreRegex.MatchString(reason) {
+			continue
+		}
+		failure := &SchemaValidationFailure{
+			Reason:           reason,
+			Location:         unit.KeywordLocation,
+			DeepLocation:     unit.KeywordLocation,
+			AbsoluteLocation: unit.AbsoluteKeywordLocation,
+			OriginalError:    validationError,
+		}
+		PopulateSchemaFailure(failure, unit)
+		if describe != nil {
+			describe(failure, unit)
+		}
+		failures = append(failures, failure)
+	}
+	return AggregateCompositionFailures(SplitAdditionalPropertyFailures(
+		MergePropertyNameFailures(dedupeFailures(CorrectPrefixItemsFailures(failures, decodedSchema)))))
+}
+
+// dedupeFailures will remove any failure that repeats an earlier failure of the same keyword, for the same value.
+func dedupeFailures(failures []*SchemaValidationFailure) []*SchemaValidationFailure {
+	type failureKey struct{ instancePath, deepLocation, reason string }
+	seen := make(map[failureKey]bool, len(failures))
+	deduped := failures[:0]
+	for _, f := range failures {
+		key := failureKey{f.InstancePath, f.DeepLocation, f.Reason}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		deduped = append(deduped, f)
+	}
+	return deduped
+}
+
+// PopulateSchemaFailure will populate a failure with the details of a unit of output from the schema validator.
+// The JSON pointer to the value that failed, the keyword that failed and the expected and actual values (when the
+// keyword has them) are set. Failures for oneOf and anyOf schemas also have the number of matched branches set.
+func PopulateSchemaFailure(failure *SchemaValidationFailure, unit jsonschema.OutputUnit) {
+	failure.InstancePath = unit.InstanceLocation
+	if failure.DeepLocation == "" {
+		failure.DeepLocation = unit.KeywordLocation
+	}
+	if failure.AbsoluteLocation == "" {
+		failure.AbsoluteLocation = unit.AbsoluteKeywordLocation
+	}
+	if unit.Error == nil {
+		return
+	}
+	failure.Keyword = unit.KeywordLocation[strings.LastIndex(unit.KeywordLocation, "/")+1:]
+	if path := unit.Error.Kind.KeywordPath(); len(path) > 0 {
+		failure.Keyword = path[len(path)-1]
+	}
+
+	switch k := unit.Error.Kind.(type) {
+	case *kind.Required:
+		failure.Expected = k.Missing
+	case *kind.AdditionalProperties:
+		failure.Actual = k.Properties
+	case *kind.PropertyNames:
+		failure.Actual = k.Property
+	default:
+		// most kinds of error carry the value that was found, and the value that was expected.
+		v := reflect.ValueOf(unit.Error.Kind)
+		if v.Kind() == reflect.Pointer && v.Elem().Kind() == reflect.Struct {
+			if got := v.Elem().FieldByName("Got"); got.IsValid() {
+				failure.Actual = schemaFailureValue(got.Interface())
+			}
+			if want := v.Elem().FieldByName("Want"); want.IsValid() {
+				failure.Expected = schemaFailureValue(want.Interface())
+			}
+		}
+	}
+	SetCompositionResult(failure, unit.Error.Kind)
+}
+
+// SplitAdditionalPropertyFailures will replace each failure of an 'additionalProperties' keyword, that lists all
+// the properties that are not allowed, with a failure for each of those properties. Each failure names the property
+// that is not allowed, and points to its value, so they can be reported (and located) individually.
+func SplitAdditionalPropertyFailures(failures []*SchemaValidationFailure) []*SchemaValidationFailure {
+	var split []*SchemaValidationFailure
+	for _, f := range failures {
+		properties, ok := f.Actual.([]string)
+		if f.Keyword != "additionalProperties" || !ok || len(properties) == 0 {
+			split = append(split, f)
+			continue
+		}
+		for _, property := range properties {
+			propertyFailure := *f
+			propertyFailure.Reason = fmt.Sprintf("additional property '%s' is not allowed", property)
+			propertyFailure.InstancePath = f.InstancePath + helpers.Slash + helpers.EscapeJSONPointer(property)
+			propertyFailure.Actual = property
+			split = append(split, &propertyFailure)
+		}
+	}
+	return split
+}
+
+// MergePropertyNameFailures will replace each failure of a 'propertyNames' keyword, and the failures of the property
+// name that caused it, with a single failure naming the property. The schema validator reports the property name as
+// a value of its own, so the failures have no instance path, and the location of the keyword is repeated. The merged
+// failure points to the property, when the object can be located from the properties of the schema.
+func MergePropertyNameFailures(failures []*SchemaValidationFailure) []*SchemaValidationFailure {
+	var merged []*SchemaValidationFailure
+	for i := 0; i < len(failures); i++ {
+		f := failures[i]
+		property, ok := f.Actual.(string)
+		if f.Keyword != "propertyNames" || !ok {
+			merged = append(merged, f)
+			continue
+		}
+		location := strings.TrimSuffix(f.DeepLocation, "/propertyNames")
+		var causes []string
+		for i+1 < len(failures) && failures[i+1].InstancePath == "" &&
+			strings.HasPrefix(failures[i+1].DeepLocation, location+helpers.Slash) {
+			causes = append(causes, failures[i+1].Reason)
+			i++
+		}
+		propertyFailure := *f
+		propertyFailure.Reason = fmt.Sprintf("property name '%s' is not valid", property)
+		if len(causes) > 0 {
+			propertyFailure.Reason = fmt.Sprintf("%s: %s", propertyFailure.Reason, strings.Join(causes, ", "))
+		}
+		propertyFailure.Location = strings.TrimSuffix(f.Location, "/propertyNames")
+		propertyFailure.DeepLocation = location
+		if object, found := propertiesInstancePath(location); found {
+			propertyFailure.InstancePath = object + helpers.Slash + helpers.EscapeJSONPointer(property)
+		}
+		merged = append(merged, &propertyFailure)
+	}
+	return merged
+}
+
+// CorrectPrefixItemsFailures will correct the instance path of each failure of an array element that follows the
+// elements described by 'prefixItems'. The schema validator counts those elements from the end of the prefix, rather
+// than from the start of the array, so the third element of an array with two prefix items is reported as the first.
+// The decoded schema is the schema that was validated against, it's used to count the prefix items.
+func CorrectPrefixItemsFailures(failures []*SchemaValidationFailure, decodedSchema any) []*SchemaValidationFailure {
+	for _, f := range failures {
+		f.InstancePath = prefixItemsInstancePath(f.DeepLocation, f.InstancePath, decodedSchema)
+	}
+	return failures
+}
+
+// prefixItemsInstancePath follows a keyword location through a decoded schema, alongside the instance path of the
+// value it validated, and offsets the index of any element validated by an 'items' keyword by the number of prefix
+// items. The walk stops at the keyword that failed, or where the schema cannot be followed any further.
+func prefixItemsInstancePath(keywordLocation, instancePath string, schema any) string {
+	if instancePath == "" || !strings.Contains(keywordLocation, "/items") {
+		return instancePath
+	}
+	keywords := strings.Split(strings.TrimPrefix(keywordLocation, helpers.Slash), helpers.Slash)
+	instance := strings.Split(strings.TrimPrefix(instancePath, helpers.Slash), helpers.Slash)
+	position := 0 // the segment of the instance path validated by the current schema.
+walk:
+	for i := 0; i < len(keywords); i++ {
+		object, ok := schema.(map[string]any)
+		if !ok {
+			break
+		}
+		keyword := keywords[i]
+		switch keyword {
+		case "items":
+			if prefix, ok := object["prefixItems"].([]any); ok && position < len(instance) {
+				if index, err := strconv.Atoi(instance[position]); err == nil {
+					instance[position] = strconv.Itoa(index + len(prefix))
+				}
+			}
+			schema = object[keyword]
+			position++
+		case "additionalProperties", "additionalItems", "contains", "unevaluatedItems", "unevaluatedProperties":
+			schema = object[keyword]
+			position++
+		case "$ref", "not", "if", "then", "else":
+			schema = object[keyword]
+		case "properties", "patternProperties", "prefixItems", "allOf", "anyOf", "oneOf", "dependentSchemas":
+			if i+1 >= len(keywords) {
+				break walk
+			}
+			schema = schemaChild(object[keyword], keywords[i+1])
+			i++
+			switch keyword {
+			case "properties", "patternProperties", "prefixItems":
+				position++
+			}
+		default:
+			// any other keyword is the assertion that failed, which does not contain a schema.
+			break walk
+		}
+	}
+	return helpers.Slash + strings.Join(instance, helpers.Slash)
+}
+
+// schemaChild returns the schema keyed by a JSON pointer segment within a map of schemas, or indexed by it within
+// a list of schemas.
+func schemaChild(container any, segment string) any {
+	switch c := container.(type) {
+	case map[string]any:
+		return c[strings.ReplaceAll(strings.ReplaceAll(segment, "~1", helpers.Slash), "~0", "~")]
+	case []any:
+		if index, err := strconv.Atoi(segment); err == nil && index >= 0 && index < len(c) {
+			return c[index]
+		}
+	}
+	return nil
+}
+
+// propertiesInstancePath returns the JSON pointer to the value validated by the schema at a keyword location, when
+// the schema is only nested within 'properties' and composition keywords, which do not change the value.
+func propertiesInstancePath(keywordLocation string) (string, bool) {
+	segments := strings.Split(strings.TrimPrefix(keywordLocation, helpers.Slash), helpers.Slash)
+	if len(segments) == 0 || segments[len(segments)-1] != "propertyNames" {
+		return "", false
+	}
+	segments = segments[:len(segments)-1]
+	path := ""
+	for i := 0; i < len(segments); i++ {
+		switch segments[i] {
+		case "properties":
+			if i+1 >= len(segments) {
+				return "", false
+			}
+			path += helpers.Slash + segments[i+1]
+			i++
+		case "allOf", "anyOf", "oneOf":
+			i++
+		case "$ref", "if", "then", "else":
+		default:
+			return "", false
+		}
+	}
+	return path, true
+}
+
+// PopulateInstanceLines will set the line and column of the value that failed validation on each failure (and on
+// the failures of any oneOf / anyOf branches), by locating the instance path of the failure in the object that was
+// validated. If the object cannot be parsed, or a value cannot be located, the line and column are left unset.
+func PopulateInstanceLines(failures []*SchemaValidationFailure, object []byte) {
+	if len(failures) == 0 || len(object) == 0 {
+		return
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(object, &root); err != nil {
+		return
+	}
+	populateInstanceLines(failures, &root)
+}
+
+func populateInstanceLines(failures []*SchemaValidationFailure, root *yaml.Node) {
+	for _, f := range failures {
+		if node := helpers.LocateJSONPointer(root, f.InstancePath); node != nil {
+			f.InstanceLine = node.Line
+			f.InstanceColumn = node.Column
+		}
+		for _, b := range f.Branches {
+			populateInstanceLines(b.SubErrors, root)
+		}
+	}
+}
+
+// schemaFailureValue converts the numbers used by the schema validator into a float, so they can be rendered.
+func schemaFailureValue(value any) any {
+	if r, ok := value.(*big.Rat); ok {
+		if r == nil {
+			return nil
+		}
+		f, _ := r.Float64()
+		return f
+	}
+	return value
+}
@@ -0,0 +1,286 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package errors
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPopulateSchemaFailure_Type(t *testing.T) {
+	failure := &SchemaValidationFailure{}
+	PopulateSchemaFailure(failure, jsonschema.OutputUnit{
+		KeywordLocation:  "/properties/burger/properties/patties/type",
+		InstanceLocation: "/burger/patties",
+		Error:            &jsonschema.OutputError{Kind: &kind.Type{Got: "string", Want: []string{"integer"}}},
+	})
+
+	require.Equal(t, "/burger/patties", failure.InstancePath)
+	require.Equal(t, "/properties/burger/properties/patties/type", failure.DeepLocation)
+	require.Equal(t, "type", failure.Keyword)
+	require.Equal(t, []string{"integer"}, failure.Expected)
+	require.Equal(t, "string", failure.Actual)
+	require.Nil(t, failure.MatchedBranches)
+}
+
+func TestPopulateSchemaFailure_Maximum(t *testing.T) {
+	failure := &SchemaValidationFailure{}
+	PopulateSchemaFailure(failure, jsonschema.OutputUnit{
+		KeywordLocation:  "/properties/patties/maximum",
+		InstanceLocation: "/patties",
+		Error:            &jsonschema.OutputError{Kind: &kind.Maximum{Got: big.NewRat(5, 1), Want: big.NewRat(3, 1)}},
+	})
+
+	require.Equal(t, "maximum", failure.Keyword)
+	require.Equal(t, 3.0, failure.Expected)
+	require.Equal(t, 5.0, failure.Actual)
+}
+
+func TestPopulateSchemaFailure_Required(t *testing.T) {
+	failure := &SchemaValidationFailure{}
+	PopulateSchemaFailure(failure, jsonschema.OutputUnit{
+		KeywordLocation: "/required",
+		Error:           &jsonschema.OutputError{Kind: &kind.Required{Missing: []string{"name"}}},
+	})
+
+	require.Equal(t, "", failure.InstancePath)
+	require.Equal(t, "required", failure.Keyword)
+	require.Equal(t, []string{"name"}, failure.Expected)
+	require.Nil(t, failure.Actual)
+}
+
+func TestPopulateSchemaFailure_OneOf(t *testing.T) {
+	failure := &SchemaValidationFailure{}
+	PopulateSchemaFailure(failure, jsonschema.OutputUnit{
+		KeywordLocation: "/oneOf",
+		Error:           &jsonschema.OutputError{Kind: &kind.OneOf{}},
+	})
+
+	require.Equal(t, "oneOf", failure.Keyword)
+	require.Equal(t, 0, *failure.MatchedBranches)
+}
+
+func TestPopulateInstanceLines(t *testing.T) {
+	branchFailure := &SchemaValidationFailure{InstancePath: "/pet/legs"}
+	failures := []*SchemaValidationFailure{
+		{InstancePath: "/name"},
+		{InstancePath: "/missing"},
+		{InstancePath: "/pet", Branches: []*SchemaBranchFailure{{SubErrors: []*SchemaValidationFailure{branchFailure}}}},
+	}
+
+	PopulateInstanceLines(failures, []byte("{\n  \"name\": 1,\n  \"pet\": {\n    \"legs\": \"four\"\n  }\n}"))
+
+	require.Equal(t, 2, failures[0].InstanceLine)
+	require.Equal(t, 11, failures[0].InstanceColumn)
+	require.Equal(t, 0, failures[1].InstanceLine)
+	require.Equal(t, 3, failures[2].InstanceLine)
+	require.Equal(t, 4, branchFailure.InstanceLine)
+	require.Equal(t, 13, branchFailure.InstanceColumn)
+
+	// an object that cannot be parsed is ignored.
+	failures = []*SchemaValidationFailure{{InstancePath: "/name"}}
+	PopulateInstanceLines(failures, []byte("{\"name\": [}"))
+	require.Equal(t, 0, failures[0].InstanceLine)
+}
+
+func TestMergePropertyNameFailures(t *testing.T) {
+	failures := []*SchemaValidationFailure{
+		{Reason: "missing property 'name'", Keyword: "required", InstancePath: "/burger"},
+		{
+			Reason:       "invalid propertyName 'Extra_Cheese'",
+			Keyword:      "propertyNames",
+			Location:     "/properties/burger/allOf/0/propertyNames/propertyNames",
+			DeepLocation: "/properties/burger/allOf/0/propertyNames/propertyNames",
+			Actual:       "Extra_Cheese",
+		},
+		{
+			Reason:       "'Extra_Cheese' does not match pattern '^[a-z]+$'",
+			Keyword:      "pattern",
+			DeepLocation: "/properties/burger/allOf/0/propertyNames/pattern",
+		},
+		{
+			Reason:       "invalid propertyName 'x'",
+			Keyword:      "propertyNames",
+			DeepLocation: "/items/propertyNames/propertyNames",
+			Actual:       "x",
+		},
+	}
+
+	merged := MergePropertyNameFailures(failures)
+
+	require.Len(t, merged, 3)
+	require.Same(t, failures[0], merged[0])
+	require.Equal(t, "property name 'Extra_Cheese' is not valid: 'Extra_Cheese' does not match pattern '^[a-z]+$'",
+		merged[1].Reason)
+	require.Equal(t, "/properties/burger/allOf/0/propertyNames", merged[1].Location)
+	require.Equal(t, "/properties/burger/allOf/0/propertyNames", merged[1].DeepLocation)
+	require.Equal(t, "/burger/Extra_Cheese", merged[1].InstancePath)
+
+	// the object cannot be located through 'items', so there is no instance path.
+	require.Equal(t, "property name 'x' is not valid", merged[2].Reason)
+	require.Equal(t, "/items/propertyNames", merged[2].DeepLocation)
+	require.Empty(t, merged[2].InstancePath)
+}
+
+func TestCorrectPrefixItemsFailures(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"route": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type":        "array",
+					"prefixItems": []any{map[string]any{"type": "number"}, map[string]any{"type": "number"}},
+					"items":       map[string]any{"type": "string"},
+				},
+			},
+			"a/b": map[string]any{
+				"allOf": []any{map[string]any{
+					"prefixItems": []any{map[string]any{"type": "number"}},
+					"items":       false,
+				}},
+			},
+		},
+	}
+	failures := []*SchemaValidationFailure{
+		{Keyword: "type", DeepLocation: "/properties/route/items/items/type", InstancePath: "/route/3/0"},
+		{Keyword: "type", DeepLocation: "/properties/route/items/prefixItems/1/type", InstancePath: "/route/3/1"},
+		{Keyword: "items", DeepLocation: "/properties/a~1b/allOf/0/items", InstancePath: "/a~1b/0"},
+		{Keyword: "required", DeepLocation: "/required", InstancePath: ""},
+	}
+
+	corrected := CorrectPrefixItemsFailures(failures, schema)
+
+	require.Len(t, corrected, 4)
+	// the outer array has no prefix items, only the index of the inner array is offset.
+	require.Equal(t, "/route/3/2", corrected[0].InstancePath)
+	require.Equal(t, "/route/3/1", corrected[1].InstancePath)
+	require.Equal(t, "/a~1b/1", corrected[2].InstancePath)
+	require.Empty(t, corrected[3].InstancePath)
+}
+
+func TestSplitAdditionalPropertyFailures(t *testing.T) {
+	failures := []*SchemaValidationFailure{
+		{Reason: "missing property 'name'", Keyword: "required", Expected: []string{"name"}},
+		{
+			Reason:       "additional properties 'fries', 'a/b' not allowed",
+			Keyword:      "additionalProperties",
+			InstancePath: "/burger",
+			DeepLocation: "/properties/burger/additionalProperties",
+			Actual:       []string{"fries", "a/b"},
+		},
+	}
+
+	split := SplitAdditionalPropertyFailures(failures)
+
+	require.Len(t, split, 3)
+	require.Same(t, failures[0], split[0])
+	require.Equal(t, "additional property 'fries' is not allowed", split[1].Reason)
+	require.Equal(t, "/burger/fries", split[1].InstancePath)
+	require.Equal(t, "fries", split[1].Actual)
+	require.Equal(t, "/properties/burger/additionalProperties", split[1].DeepLocation)
+	require.Equal(t, "additional property 'a/b' is not allowed", split[2].Reason)
+	require.Equal(t, "/burger/a~1b", split[2].InstancePath)
+
+	// the original failure is left alone.
+	require.Equal(t, []string{"fries", "a/b"}, failures[1].Actual)
+}
+
+// validateAgainst will validate a JSON value against a JSON schema, and return the validation error.
+func validateAgainst(t *testing.T, schema, value string) (*jsonschema.ValidationError, any) {
+	decodedSchema, err := jsonschema.UnmarshalJSON(strings.NewReader(schema))
+	require.NoError(t, err)
+	compiler := jsonschema.NewCompiler()
+	require.NoError(t, compiler.AddResource("schema.json", decodedSchema))
+	compiled, err := compiler.Compile("schema.json")
+	require.NoError(t, err)
+
+	var decoded any
+	require.NoError(t, json.Unmarshal([]byte(value), &decoded))
+	var validationError *jsonschema.ValidationError
+	require.ErrorAs(t, compiled.Validate(decoded), &validationError)
+	return validationError, decodedSchema
+}
+
+func TestSchemaFailures(t *testing.T) {
+	validationError, decodedSchema := validateAgainst(t,
+		`{"type": "object", "properties": {"patties": {"type": "integer"}}, "additionalProperties": false}`,
+		`{"patties": "two", "cheese": true}`)
+
+	var described []string
+	failures := SchemaFailures(validationError, decodedSchema,
+		func(failure *SchemaValidationFailure, unit jsonschema.OutputUnit) {
+			described = append(described, unit.KeywordLocation)
+			failure.ReferenceSchema = "described"
+		})
+
+	require.Len(t, failures, 2)
+	require.ElementsMatch(t, []string{"/properties/patties/type", "/additionalProperties"}, described)
+	for _, failure := range failures {
+		require.Equal(t, "described", failure.ReferenceSchema)
+		require.Same(t, validationError, failure.OriginalError)
+		switch failure.Keyword {
+		case "type":
+			require.Equal(t, "/patties", failure.InstancePath)
+			require.Equal(t, "/properties/patties/type", failure.Location)
+			require.Equal(t, "got string, want integer", failure.Reason)
+		case "additionalProperties":
+			require.Equal(t, "/cheese", failure.InstancePath)
+			require.Equal(t, "cheese", failure.Actual)
+		default:
+			t.Fatalf("unexpected failure for the keyword '%s'", failure.Keyword)
+		}
+	}
+
+	require.Nil(t, SchemaFailures(nil, decodedSchema, nil))
+}
+
+func TestSchemaFailures_ArrayItems(t *testing.T) {
+	validationError, decodedSchema := validateAgainst(t,
+		`{"type": "array", "items": {"type": "string", "minLength": 3, "pattern": "^[0-9]+$",
+			"oneOf": [{"maxLength": 1}, {"minLength": 5}]}}`,
+		`["123", "ab", "cd"]`)
+
+	failures := SchemaFailures(validationError, decodedSchema, nil)
+
+	// the first element only fails the oneOf, the others fail two keywords and the oneOf, and each failure is
+	// reported once, with the oneOf only holding the failures of its own element.
+	require.Len(t, failures, 7)
+	counts := make(map[string]int)
+	for _, f := range failures {
+		counts[f.InstancePath+" "+f.Keyword]++
+		if f.Keyword != "oneOf" {
+			continue
+		}
+		require.Len(t, f.Branches, 2)
+		for _, b := range f.Branches {
+			require.Len(t, b.SubErrors, 1)
+			require.Equal(t, f.InstancePath, b.SubErrors[0].InstancePath)
+		}
+	}
+	require.Equal(t, map[string]int{
+		"/0 oneOf":     1,
+		"/1 minLength": 1, "/1 pattern": 1, "/1 oneOf": 1,
+		"/2 minLength": 1, "/2 pattern": 1, "/2 oneOf": 1,
+	}, counts)
+}
+
+func TestDedupeFailures(t *testing.T) {
+	failures := dedupeFailures([]*SchemaValidationFailure{
+		{InstancePath: "/0", DeepLocation: "/items/minLength", Reason: "minLength: got 2, want 3"},
+		{InstancePath: "/0", DeepLocation: "/items/pattern", Reason: "'ab' does not match pattern '^[0-9]+$'"},
+		{InstancePath: "/0", DeepLocation: "/items/minLength", Reason: "minLength: got 2, want 3"},
+		{InstancePath: "/1", DeepLocation: "/items/minLength", Reason: "minLength: got 2, want 3"},
+	})
+
+	require.Len(t, failures, 3)
+	require.Equal(t, "/items/pattern", failures[1].DeepLocation)
+	require.Equal(t, "/1", failures[2].InstancePath)
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pb33f/libopenapi-validator/helpers"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// SecuritySchemeMissing will return a ValidationError for a security requirement that references a scheme
+// that is not defined in the components of the document.
+func SecuritySchemeMissing(schemeName string, requirement *base.SecurityRequirement) *ValidationError {
+	line, col := requirementPosition(requirement)
+	return &ValidationError{
+		ValidationType: helpers.SecurityValidation,
+		Message:        fmt.Sprintf("Security scheme '%s' is missing", schemeName),
+		Reason: fmt.Sprintf("The security scheme '%s' is defined as being required, "+
+			"however it's missing from the components", schemeName),
+		SpecLine: line,
+		SpecCol:  col,
+		HowToFix: HowToFixMissingSecurityScheme,
+	}
+}
+
+// SecurityApiKeyMissing will return a ValidationError for an 'apiKey' security scheme, where the key could not be
+// found in the location (header, query or cookie) the scheme declares.
+func SecurityApiKeyMissing(scheme *v3.SecurityScheme, requirement *base.SecurityRequirement,
+	request *http.Request) *ValidationError {
+	line, col := requirementPosition(requirement)
+	validationError := &ValidationError{
+		ValidationType:    helpers.SecurityValidation,
+		ValidationSubType: helpers.SecurityValidationApiKey,
+		SpecLine:          line,
+		SpecCol:           col,
+		Context:           scheme,
+	}
+	switch scheme.In {
+	case "query":
+		copyUrl := *request.URL
+		fixed := &copyUrl
+		q := fixed.Query()
+		q.Add(scheme.Name, "your-api-key")
+		fixed.RawQuery = q.Encode()
+
+		validationError.Message = fmt.Sprintf("API Key %s not found in query", scheme.Name)
+		validationError.Reason = "API Key not found in URL query for security scheme 'apiKey' with type 'query'"
+		validationError.HowToFix = fmt.Sprintf(HowToFixApiKeyQuery, scheme.Name, fixed.String())
+	case "cookie":
+		validationError.Message = fmt.Sprintf("API Key %s not found in cookies", scheme.Name)
+		validationError.Reason = "API Key not found in http request cookies for security scheme 'apiKey' with type 'cookie'"
+		validationError.HowToFix = fmt.Sprintf(HowToFixApiKeyCookie, scheme.Name)
+	default:
+		validationError.Message = fmt.Sprintf("API Key %s not found in header", scheme.Name)
+		validationError.Reason = "API Key not found in http header for security scheme 'apiKey' with type 'header'"
+		validationError.HowToFix = fmt.Sprintf(HowToFixApiKeyHeader, scheme.Name)
+	}
+	return validationError
+}
+
+// SecurityAuthMissing will return a ValidationError for an 'http' security scheme, where the request has no
+// Authorization header.
+func SecurityAuthMissing(scheme *v3.SecurityScheme, requirement *base.SecurityRequirement) *ValidationError {
+	line, col := requirementPosition(requirement)
+	return &ValidationError{
+		ValidationType:    helpers.SecurityValidation,
+		ValidationSubType: scheme.Scheme,
+		Message:           fmt.Sprintf("Authorization header for '%s' scheme", scheme.Scheme),
+		Reason:            "Authorization header was not found",
+		SpecLine:          line,
+		SpecCol:           col,
+		Context:           scheme,
+		HowToFix:          HowToFixAuthMissing,
+	}
+}
+
+// SecurityAuthWrongScheme will return a ValidationError for an 'http' security scheme, where the Authorization
+// header of the request does not use the scheme declared by the specification.
+func SecurityAuthWrongScheme(scheme *v3.SecurityScheme, requirement *base.SecurityRequirement,
+	authorization string) *ValidationError {
+	line, col := requirementPosition(requirement)
+	used, _, _ := strings.Cut(authorization, " ")
+	expected := helpers.AuthorizationSchemePrefix(scheme.Scheme)
+	return &ValidationError{
+		ValidationType:    helpers.SecurityValidation,
+		ValidationSubType: scheme.Scheme,
+		Message:           fmt.Sprintf("Authorization header does not use the '%s' scheme", scheme.Scheme),
+		Reason: fmt.Sprintf("The security scheme requires the '%s' authorization scheme, "+
+			"however the Authorization header uses '%s'", scheme.Scheme, used),
+		SpecLine: line,
+		SpecCol:  col,
+		Context:  scheme,
+		HowToFix: fmt.Sprintf(HowToFixAuthWrongScheme, expected, expected),
+	}
+}
+
+// SecurityInsufficientScope will return a ValidationError for an 'oauth2' or 'openIdConnect' security scheme,
+// where the scopes granted to the request do not include all the scopes the security requirement lists.
+func SecurityInsufficientScope(schemeName string, scheme *v3.SecurityScheme, requirement *base.SecurityRequirement,
+	missingScopes []string) *ValidationError {
+	line, col := requirementPosition(requirement)
+	missing := strings.Join(missingScopes, ", ")
+	return &ValidationError{
+		ValidationType:    helpers.SecurityValidation,
+		ValidationSubType: scheme.Type,
+		Message:           fmt.Sprintf("Insufficient scope for security scheme '%s'", schemeName),
+		Reason: fmt.Sprintf("The security scheme '%s' requires the scope(s) '%s', "+
+			"however they have not been granted to the request", schemeName, missing),
+		SpecLine: line,
+		SpecCol:  col,
+		Context:  scheme,
+		HowToFix: fmt.Sprintf(HowToFixInsufficientScope, missing),
+	}
+}
+
+func requirementPosition(requirement *base.SecurityRequirement) (int, int) {
+	if requirement == nil || requirement.GoLow() == nil || requirement.GoLow().Requirements.ValueNode == nil {
+		return -1, -1
+	}
+	node := requirement.GoLow().Requirements.ValueNode
+	return node.Line, node.Column
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package errors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/pb33f/libopenapi-validator/helpers"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecuritySchemeMissing(t *testing.T) {
+	err := SecuritySchemeMissing("ApiKeyAuth", nil)
+
+	assert.Equal(t, helpers.SecurityValidation, err.ValidationType)
+	assert.Equal(t, "Security scheme 'ApiKeyAuth' is missing", err.Message)
+	assert.Equal(t, HowToFixMissingSecurityScheme, err.HowToFix)
+	assert.Equal(t, -1, err.SpecLine)
+	assert.Equal(t, -1, err.SpecCol)
+}
+
+func TestSecurityApiKeyMissing(t *testing.T) {
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/products?page=1", nil)
+
+	err := SecurityApiKeyMissing(&v3.SecurityScheme{Type: "apiKey", In: "header", Name: "X-API-Key"}, nil, request)
+	assert.Equal(t, helpers.SecurityValidationApiKey, err.ValidationSubType)
+	assert.Equal(t, "API Key X-API-Key not found in header", err.Message)
+	assert.Equal(t, "Add the API Key via 'X-API-Key' as a header of the request", err.HowToFix)
+
+	err = SecurityApiKeyMissing(&v3.SecurityScheme{Type: "apiKey", In: "query", Name: "api_key"}, nil, request)
+	assert.Equal(t, "API Key api_key not found in query", err.Message)
+	assert.Equal(t, "Add an API Key via 'api_key' to the query string of the URL, "+
+		"for example 'https://things.com/products?api_key=your-api-key&page=1'", err.HowToFix)
+	assert.Equal(t, "https://things.com/products?page=1", request.URL.String())
+
+	err = SecurityApiKeyMissing(&v3.SecurityScheme{Type: "apiKey", In: "cookie", Name: "session"}, nil, request)
+	assert.Equal(t, "API Key session not found in cookies", err.Message)
+	assert.Equal(t, "Submit an API Key 'session' as a cookie with the request", err.HowToFix)
+}
+
+func TestSecurityAuthMissing(t *testing.T) {
+	err := SecurityAuthMissing(&v3.SecurityScheme{Type: "http", Scheme: "bearer"}, nil)
+
+	assert.Equal(t, helpers.SecurityValidation, err.ValidationType)
+	assert.Equal(t, "bearer", err.ValidationSubType)
+	assert.Equal(t, "Authorization header for 'bearer' scheme", err.Message)
+	assert.Equal(t, HowToFixAuthMissing, err.HowToFix)
+}
+
+func TestSecurityAuthWrongScheme(t *testing.T) {
+	err := SecurityAuthWrongScheme(&v3.SecurityScheme{Type: "http", Scheme: "bearer"}, nil, "Basic dXNlcjpwYXNz")
+
+	assert.Equal(t, "bearer", err.ValidationSubType)
+	assert.Equal(t, "Authorization header does not use the 'bearer' scheme", err.Message)
+	assert.Equal(t, "The security scheme requires the 'bearer' authorization scheme, "+
+		"however the Authorization header uses 'Basic'", err.Reason)
+	assert.Equal(t, "Send the credentials in the 'Authorization' header using the 'Bearer' scheme, "+
+		"for example 'Authorization: Bearer <credentials>'", err.HowToFix)
+}
+
+func TestSecurityInsufficientScope(t *testing.T) {
+	err := SecurityInsufficientScope("petstore_auth", &v3.SecurityScheme{Type: "oauth2"}, nil,
+		[]string{"write:pets", "read:pets"})
+
+	assert.Equal(t, helpers.SecurityValidation, err.ValidationType)
+	assert.Equal(t, "oauth2", err.ValidationSubType)
+	assert.Equal(t, "Insufficient scope for security scheme 'petstore_auth'", err.Message)
+	assert.Equal(t, "The security scheme 'petstore_auth' requires the scope(s) 'write:pets, read:pets', "+
+		"however they have not been granted to the request", err.Reason)
+	assert.Equal(t, "Send a token that has been granted the missing scope(s): write:pets, read:pets", err.HowToFix)
+}
@@ -5,6 +5,9 @@ package errors
 
 import (
 	"fmt"
+	"strings"
+
+	"github.com/pb33f/libopenapi-validator/helpers"
 	"github.com/santhosh-tekuri/jsonschema/v6"
 )
 
@@ -23,6 +26,28 @@ type SchemaValidationFailure struct {
 	// AbsoluteLocation is the absolute path to the validation failure as exposed by the jsonschema library.
 	AbsoluteLocation string `json:"absoluteLocation,omitempty" yaml:"absoluteLocation,omitempty"`
 
+	// InstancePath is the JSON pointer to the value that failed validation, within the object being validated.
+	InstancePath string `json:"instancePath,omitempty" yaml:"instancePath,omitempty"`
+
+	// InstanceLine is the line number of the value that failed validation, within the object being validated (for
+	// example, the request or response body). This is only populated if the value can be located.
+	InstanceLine int `json:"instanceLine,omitempty" yaml:"instanceLine,omitempty"`
+
+	// InstanceColumn is the column number of the value that failed validation, within the object being validated.
+	// This is only populated if the value can be located.
+	InstanceColumn int `json:"instanceColumn,omitempty" yaml:"instanceColumn,omitempty"`
+
+	// Keyword is the schema keyword that failed validation, for example 'type', 'required' or 'maximum'.
+	Keyword string `json:"keyword,omitempty" yaml:"keyword,omitempty"`
+
+	// Expected is the value expected by the keyword that failed, for example the types allowed by a 'type'
+	// keyword, or the properties missing for a 'required' keyword. Not all keywords have an expected value.
+	Expected any `json:"expected,omitempty" yaml:"expected,omitempty"`
+
+	// Actual is the value that failed the keyword, for example the type of the value for a 'type' keyword.
+	// Not all keywords have an actual value.
+	Actual any `json:"actual,omitempty" yaml:"actual,omitempty"`
+
 	// Line is the line number where the violation occurred. This may a local line number
 	// if the validation is a schema (only schemas are validated locally, so the line number will be relative to
 	// the Context object held by the ValidationError object).
@@ -42,13 +67,49 @@ type SchemaValidationFailure struct {
 	// ReferenceExample is an example object generated from the schema that was referenced in the validation failure.
 	ReferenceExample string `json:"referenceExample,omitempty" yaml:"referenceExample,omitempty"`
 
+	// MatchedBranches is the number of branches of a oneOf or anyOf schema that the value matched. This is only
+	// populated when the failure is for a oneOf (which expects exactly one branch to match) or an anyOf schema.
+	MatchedBranches *int `json:"matchedBranches,omitempty" yaml:"matchedBranches,omitempty"`
+
+	// Branches holds the reason each branch of a oneOf or anyOf schema failed to match the value. This is only
+	// populated when the failure is for a oneOf or an anyOf schema.
+	Branches []*SchemaBranchFailure `json:"branches,omitempty" yaml:"branches,omitempty"`
+
 	// The original error object, which is a jsonschema.ValidationError object.
 	OriginalError *jsonschema.ValidationError `json:"-" yaml:"-"`
 }
 
-// Error returns a string representation of the error
+// SchemaBranchFailure describes why a single branch of a oneOf or anyOf schema failed to match a value.
+type SchemaBranchFailure struct {
+	// Index is the index of the branch in the oneOf or anyOf schema.
+	Index int `json:"index" yaml:"index"`
+
+	// Reason is a human-readable message describing why the branch failed to match.
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+
+	// SubErrors are the individual failures of the branch, as reported by the schema validator.
+	SubErrors []*SchemaValidationFailure `json:"subErrors,omitempty" yaml:"subErrors,omitempty"`
+}
+
+// Error returns a string representation of the failure. The reason and keyword location are always included,
+// followed by the JSON pointer to the value that failed, the keyword that failed and the expected and actual
+// values, when they are known.
 func (s *SchemaValidationFailure) Error() string {
-	return fmt.Sprintf("Reason: %s, Location: %s", s.Reason, s.Location)
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Reason: %s, Location: %s", s.Reason, s.Location))
+	if s.InstancePath != "" {
+		b.WriteString(fmt.Sprintf(", Instance: %s", s.InstancePath))
+	}
+	if s.Keyword != "" {
+		b.WriteString(fmt.Sprintf(", Keyword: %s", s.Keyword))
+	}
+	if s.Expected != nil {
+		b.WriteString(fmt.Sprintf(", Expected: %v", s.Expected))
+	}
+	if s.Actual != nil {
+		b.WriteString(fmt.Sprintf(", Actual: %v", s.Actual))
+	}
+	return b.String()
 }
 
 // ValidationError is a struct that contains all the information about a validation error.
@@ -78,40 +139,106 @@ type ValidationError struct {
 	// RequestPath is the path of the request
 	RequestPath string `json:"requestPath" yaml:"requestPath"`
 
-	// SpecPath is the path from the specification that corresponds to the request
+	// SpecPath is the path template from the specification that corresponds to the request, for example
+	// '/burgers/{burgerId}'. Together with the RequestMethod, it identifies the operation that produced the error.
 	SpecPath string `json:"specPath" yaml:"specPath"`
 
 	// RequestMethod is the HTTP method of the request
 	RequestMethod string `json:"requestMethod" yaml:"requestMethod"`
 
+	// MediaType is the media type (or media range, for example 'application/*') of the content that was selected
+	// from the specification to validate a request or response body. This is only populated for errors of a body.
+	MediaType string `json:"mediaType,omitempty" yaml:"mediaType,omitempty"`
+
 	// SchemaValidationErrors is a slice of SchemaValidationFailure objects that describe the validation errors
 	// This is only populated whe the validation type is against a schema.
 	SchemaValidationErrors []*SchemaValidationFailure `json:"validationErrors,omitempty" yaml:"validationErrors,omitempty"`
 
+	// ItemIndex is the index of the array item that failed validation. This is only populated when a single
+	// item of an array parameter is invalid.
+	ItemIndex *int `json:"itemIndex,omitempty" yaml:"itemIndex,omitempty"`
+
 	// Context is the object that the validation error occurred on. This is usually a pointer to a schema
 	// or a parameter object.
 	Context interface{} `json:"-" yaml:"-"`
 }
 
-// Error returns a string representation of the error
+// Error returns a string representation of the error. The message and reason are always included, followed by
+// the part of the request or response the error was found in (for example 'query' or 'request body'), the media type
+// of a body, each of the schema validation failures, and the line and column in the specification, when they are known.
 func (v *ValidationError) Error() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Error: %s, Reason: %s", v.Message, v.Reason))
+	if in := v.in(); in != "" {
+		b.WriteString(fmt.Sprintf(", In: %s", in))
+	}
+	if v.MediaType != "" {
+		b.WriteString(fmt.Sprintf(", Media Type: %s", v.MediaType))
+	}
 	if v.SchemaValidationErrors != nil {
-		if v.SpecLine > 0 && v.SpecCol > 0 {
-			return fmt.Sprintf("Error: %s, Reason: %s, Validation Errors: %s, Line: %d, Column: %d",
-				v.Message, v.Reason, v.SchemaValidationErrors, v.SpecLine, v.SpecCol)
-		} else {
-			return fmt.Sprintf("Error: %s, Reason: %s, Validation Errors: %s",
-				v.Message, v.Reason, v.SchemaValidationErrors)
+		b.WriteString(fmt.Sprintf(", Validation Errors: %s", v.SchemaValidationErrors))
+	}
+	if v.SpecLine > 0 && v.SpecCol > 0 {
+		b.WriteString(fmt.Sprintf(", Line: %d, Column: %d", v.SpecLine, v.SpecCol))
+	}
+	return b.String()
+}
+
+// in returns the part of the request or response that the error was found in, or an empty string if the error
+// is not about a single part, for example a missing path or operation.
+func (v *ValidationError) in() string {
+	switch v.ValidationType {
+	case helpers.ParameterValidation:
+		switch v.ValidationSubType {
+		case helpers.ParameterValidationPath, helpers.ParameterValidationQuery,
+			helpers.ParameterValidationHeader, helpers.ParameterValidationCookie:
+			return v.ValidationSubType
 		}
-	} else {
-		if v.SpecLine > 0 && v.SpecCol > 0 {
-			return fmt.Sprintf("Error: %s, Reason: %s, Line: %d, Column: %d",
-				v.Message, v.Reason, v.SpecLine, v.SpecCol)
-		} else {
-			return fmt.Sprintf("Error: %s, Reason: %s",
-				v.Message, v.Reason)
+	case helpers.RequestBodyValidation:
+		return "request body"
+	case helpers.ResponseBodyValidation:
+		if v.ValidationSubType == helpers.ParameterValidationHeader {
+			return "response header"
 		}
+		return "response body"
+	}
+	return ""
+}
+
+// ValidationErrors is a collection of ValidationError objects that implements the error interface, so all the
+// errors found by a validation can be handled as a single error. Each ValidationError can still be extracted
+// with errors.As.
+type ValidationErrors []*ValidationError
+
+// Join will combine validation errors into a single error, nil is returned if there are no errors.
+func Join(validationErrors []*ValidationError) error {
+	if len(validationErrors) == 0 {
+		return nil
+	}
+	return ValidationErrors(validationErrors)
+}
+
+// Error returns a multi-line string representation of the errors, with each error on its own line.
+func (v ValidationErrors) Error() string {
+	if len(v) == 1 {
+		return v[0].Error()
+	}
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("%d validation errors:", len(v)))
+	for _, err := range v {
+		b.WriteString("\n  - ")
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+// Unwrap returns each ValidationError, so they can be inspected with errors.Is and errors.As.
+func (v ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(v))
+	for i, err := range v {
+		errs[i] = err
 	}
+	return errs
 }
 
 // IsPathMissingError returns true if the error has a ValidationType of "path" and a ValidationSubType of "missing"
@@ -4,9 +4,12 @@
 package errors
 
 import (
+	stdErrors "errors"
 	"fmt"
-	"github.com/stretchr/testify/require"
 	"testing"
+
+	"github.com/pb33f/libopenapi-validator/helpers"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSchemaValidationFailure_Error(t *testing.T) {
@@ -78,6 +81,63 @@ func TestValidationError_Error_WithSchemaValidationErrors_AndSpecLineColumn(t *t
 	require.Equal(t, expectedError, v.Error())
 }
 
+func TestValidationError_Error_RequestBodyFailure(t *testing.T) {
+	v := &ValidationError{
+		Message:           "POST request body for '/burgers' failed to validate schema",
+		Reason:            "The request body is defined as an object. However, it does not meet the schema requirements",
+		ValidationType:    helpers.RequestBodyValidation,
+		ValidationSubType: helpers.Schema,
+		SpecLine:          14,
+		SpecCol:           11,
+		SchemaValidationErrors: []*SchemaValidationFailure{{
+			Reason:       "got string, want integer",
+			Location:     "/properties/patties/type",
+			InstancePath: "/patties",
+			Keyword:      "type",
+			Expected:     []string{"integer"},
+			Actual:       "string",
+		}},
+	}
+
+	require.Equal(t, "Error: POST request body for '/burgers' failed to validate schema, "+
+		"Reason: The request body is defined as an object. However, it does not meet the schema requirements, "+
+		"In: request body, Validation Errors: [Reason: got string, want integer, Location: /properties/patties/type, "+
+		"Instance: /patties, Keyword: type, Expected: [integer], Actual: string], Line: 14, Column: 11", v.Error())
+
+	v.MediaType = "application/json"
+	require.Contains(t, v.Error(), "In: request body, Media Type: application/json, Validation Errors: ")
+}
+
+func TestValidationError_Error_ParameterFailure(t *testing.T) {
+	v := &ValidationError{
+		Message:           "Query parameter 'limit' failed to validate",
+		Reason:            "The query parameter 'limit' failed to validate against the schema",
+		ValidationType:    helpers.ParameterValidation,
+		ValidationSubType: helpers.ParameterValidationQuery,
+		SchemaValidationErrors: []*SchemaValidationFailure{{
+			Reason:   "maximum: got 100, want 50",
+			Location: "/maximum",
+			Keyword:  "maximum",
+			Expected: 50,
+			Actual:   100,
+		}},
+	}
+
+	require.Equal(t, "Error: Query parameter 'limit' failed to validate, "+
+		"Reason: The query parameter 'limit' failed to validate against the schema, In: query, "+
+		"Validation Errors: [Reason: maximum: got 100, want 50, Location: /maximum, Keyword: maximum, "+
+		"Expected: 50, Actual: 100]", v.Error())
+
+	v = &ValidationError{
+		Message:           "Header parameter 'X-Sauce' is missing",
+		Reason:            "The header parameter 'X-Sauce' is defined as being required",
+		ValidationType:    helpers.ResponseBodyValidation,
+		ValidationSubType: helpers.ParameterValidationHeader,
+	}
+	require.Equal(t, "Error: Header parameter 'X-Sauce' is missing, "+
+		"Reason: The header parameter 'X-Sauce' is defined as being required, In: response header", v.Error())
+}
+
 func TestValidationError_IsPathMissingError(t *testing.T) {
 	// Test the IsPathMissingError method
 	v := &ValidationError{
@@ -115,3 +175,29 @@ func TestValidationError_IsOperationMissingError(t *testing.T) {
 	v.ValidationSubType = "missingOperation"
 	require.False(t, v.IsOperationMissingError())
 }
+
+func TestJoin(t *testing.T) {
+	require.NoError(t, Join(nil))
+	require.NoError(t, Join([]*ValidationError{}))
+
+	single := &ValidationError{Message: "Query parameter 'fishy' is missing", Reason: "The parameter is required"}
+	err := Join([]*ValidationError{single})
+	require.Error(t, err)
+	require.Equal(t, single.Error(), err.Error())
+
+	other := &ValidationError{Message: "POST request body failed to validate schema", Reason: "Bad body"}
+	err = Join([]*ValidationError{single, other})
+	require.Equal(t, "2 validation errors:\n"+
+		"  - Error: Query parameter 'fishy' is missing, Reason: The parameter is required\n"+
+		"  - Error: POST request body failed to validate schema, Reason: Bad body", err.Error())
+
+	// the individual errors can be extracted from the combined error.
+	var validationErrors ValidationErrors
+	require.True(t, stdErrors.As(err, &validationErrors))
+	require.Len(t, validationErrors, 2)
+
+	var validationError *ValidationError
+	require.True(t, stdErrors.As(err, &validationError))
+	require.Same(t, single, validationError)
+	require.True(t, stdErrors.Is(err, other))
+}
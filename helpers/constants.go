@@ -4,49 +4,80 @@
 package helpers
 
 const (
-	ParameterValidation       = "parameter"
-	ParameterValidationPath   = "path"
-	ParameterValidationQuery  = "query"
-	ParameterValidationHeader = "header"
-	ParameterValidationCookie = "cookie"
-	RequestValidation         = "request"
-	RequestBodyValidation     = "requestBody"
-	Schema                    = "schema"
-	ResponseBodyValidation    = "response"
-	RequestBodyContentType    = "contentType"
-	RequestMissingOperation   = "missingOperation"
-	ResponseBodyResponseCode  = "statusCode"
-	SpaceDelimited            = "spaceDelimited"
-	PipeDelimited             = "pipeDelimited"
-	DefaultDelimited          = "default"
-	MatrixStyle               = "matrix"
-	LabelStyle                = "label"
-	Pipe                      = "|"
-	Comma                     = ","
-	Space                     = " "
-	SemiColon                 = ";"
-	Asterisk                  = "*"
-	Period                    = "."
-	Equals                    = "="
-	Integer                   = "integer"
-	Number                    = "number"
-	Slash                     = "/"
-	Object                    = "object"
-	String                    = "string"
-	Array                     = "array"
-	Boolean                   = "boolean"
-	DeepObject                = "deepObject"
-	Header                    = "header"
-	Cookie                    = "cookie"
-	Path                      = "path"
-	Form                      = "form"
-	Query                     = "query"
-	JSONContentType           = "application/json"
-	JSONType                  = "json"
-	ContentTypeHeader         = "Content-Type"
-	AuthorizationHeader       = "Authorization"
-	Charset                   = "charset"
-	Boundary                  = "boundary"
-	Preferred                 = "preferred"
-	FailSegment               = "**&&FAIL&&**"
+	ParameterValidation          = "parameter"
+	ParameterValidationPath      = "path"
+	ParameterValidationQuery     = "query"
+	ParameterValidationHeader    = "header"
+	ParameterValidationCookie    = "cookie"
+	RequestValidation            = "request"
+	RequestBodyValidation        = "requestBody"
+	Schema                       = "schema"
+	ResponseBodyValidation       = "response"
+	RequestBodyContentType       = "contentType"
+	RequestMissingOperation      = "missingOperation"
+	RequestMissingWebhook        = "missingWebhook"
+	RequestMissingCallback       = "missingCallback"
+	RequestMissingOperationID    = "missingOperationId"
+	RequestMissingDocument       = "missingDocument"
+	RequestValidationCancelled   = "cancelled"
+	RequestMissingBody           = "missingBody"
+	RequestBodyContentEncoding   = "contentEncoding"
+	RequestBodyTooLarge          = "tooLarge"
+	ResponseMissingRequest       = "missingRequest"
+	ResponseMissing              = "missingResponse"
+	ResponseBodyResponseCode     = "statusCode"
+	SecurityValidation           = "security"
+	SecurityValidationApiKey     = "apiKey"
+	SpaceDelimited               = "spaceDelimited"
+	PipeDelimited                = "pipeDelimited"
+	DefaultDelimited             = "default"
+	MatrixStyle                  = "matrix"
+	LabelStyle                   = "label"
+	Pipe                         = "|"
+	Comma                        = ","
+	Space                        = " "
+	SemiColon                    = ";"
+	Asterisk                     = "*"
+	Period                       = "."
+	Equals                       = "="
+	Ampersand                    = "&"
+	Integer                      = "integer"
+	Number                       = "number"
+	Slash                        = "/"
+	Object                       = "object"
+	String                       = "string"
+	Array                        = "array"
+	Boolean                      = "boolean"
+	DeepObject                   = "deepObject"
+	Header                       = "header"
+	Cookie                       = "cookie"
+	Path                         = "path"
+	Form                         = "form"
+	Query                        = "query"
+	JSONContentType              = "application/json"
+	JSONType                     = "json"
+	XMLType                      = "xml"
+	YAMLType                     = "yaml"
+	TextType                     = "text/"
+	FormURLEncodedContentType    = "application/x-www-form-urlencoded"
+	MultipartFormDataContentType = "multipart/form-data"
+	ContentTypeHeader            = "Content-Type"
+	ContentEncodingHeader        = "Content-Encoding"
+	AuthorizationHeader          = "Authorization"
+	Charset                      = "charset"
+	Boundary                     = "boundary"
+	Preferred                    = "preferred"
+	FailSegment                  = "**&&FAIL&&**"
+	ReadOnly                     = "readOnly"
+	WriteOnly                    = "writeOnly"
+	DependentRequired            = "dependentRequired"
+	ContentEncoding              = "contentEncoding"
+	ContentMediaType             = "contentMediaType"
+	ContentSchema                = "contentSchema"
+	UnresolvedSchema             = "unresolvedSchema"
+	UncompilableSchema           = "uncompilableSchema"
+	InvalidSchemaPattern         = "invalidSchemaPattern"
+	UnknownSchemaFormat          = "unknownSchemaFormat"
+	MissingComponentSchema       = "missingComponentSchema"
+	InvalidExample               = "invalidExample"
 )
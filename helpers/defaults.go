@@ -0,0 +1,66 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package helpers
+
+// ApplyDefaults will walk a decoded JSON schema alongside a decoded value, and return a copy of the value where
+// any missing property that has a default defined by the schema is set to that default. Defaults are found in the
+// properties of object schemas (including those composed with allOf), and the items of array schemas.
+// The value supplied is never modified, the boolean returned is true if any defaults were applied.
+func ApplyDefaults(schema, value any) (any, bool) {
+	applied := false
+	return applyDefaults(schema, deepCopy(value), &applied), applied
+}
+
+func applyDefaults(schema, value any, applied *bool) any {
+	sch, ok := schema.(map[string]any)
+	if !ok {
+		return value
+	}
+	switch v := value.(type) {
+	case map[string]any:
+		props, _ := sch["properties"].(map[string]any)
+		for name, propSchema := range props {
+			if current, found := v[name]; found {
+				v[name] = applyDefaults(propSchema, current, applied)
+				continue
+			}
+			if ps, isMap := propSchema.(map[string]any); isMap {
+				if def, hasDefault := ps["default"]; hasDefault {
+					v[name] = applyDefaults(propSchema, deepCopy(def), applied)
+					*applied = true
+				}
+			}
+		}
+	case []any:
+		for i := range v {
+			v[i] = applyDefaults(sch["items"], v[i], applied)
+		}
+	}
+	if allOf, isList := sch["allOf"].([]any); isList {
+		for _, sub := range allOf {
+			value = applyDefaults(sub, value, applied)
+		}
+	}
+	return value
+}
+
+// deepCopy will copy a decoded JSON value, so it can be modified without changing the original.
+func deepCopy(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		c := make(map[string]any, len(v))
+		for k, val := range v {
+			c[k] = deepCopy(val)
+		}
+		return c
+	case []any:
+		c := make([]any, len(v))
+		for i, val := range v {
+			c[i] = deepCopy(val)
+		}
+		return c
+	default:
+		return v
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package helpers
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyDefaults(t *testing.T) {
+	schema, err := jsonschema.UnmarshalJSON(strings.NewReader(`{
+  "type": "object",
+  "properties": {
+    "name": {"type": "string", "default": "Big Mac"},
+    "size": {"type": "string", "default": "large"},
+    "patty": {"type": "object", "default": {"grams": 100}, "properties": {"cooked": {"default": "medium"}}},
+    "toppings": {"type": "array", "items": {"type": "object", "properties": {"grams": {"default": 5}}}}
+  },
+  "allOf": [{"properties": {"sauce": {"type": "string", "default": "ketchup"}}}]
+}`))
+	require.NoError(t, err)
+
+	value := map[string]any{"size": "small", "toppings": []any{map[string]any{"grams": 10}, map[string]any{}}}
+	result, applied := ApplyDefaults(schema, value)
+
+	require.True(t, applied)
+	encoded, _ := json.Marshal(result)
+	require.JSONEq(t, `{"name": "Big Mac", "size": "small", "sauce": "ketchup",
+		"patty": {"grams": 100, "cooked": "medium"},
+		"toppings": [{"grams": 10}, {"grams": 5}]}`, string(encoded))
+
+	// the original value is never modified.
+	require.Equal(t, map[string]any{"size": "small", "toppings": []any{map[string]any{"grams": 10}, map[string]any{}}}, value)
+}
+
+func TestApplyDefaults_NothingApplied(t *testing.T) {
+	schema := map[string]any{"properties": map[string]any{"name": map[string]any{"type": "string"}}}
+
+	result, applied := ApplyDefaults(schema, map[string]any{})
+	require.False(t, applied)
+	require.Equal(t, map[string]any{}, result)
+
+	result, applied = ApplyDefaults(schema, "not an object")
+	require.False(t, applied)
+	require.Equal(t, "not an object", result)
+}
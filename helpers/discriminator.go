@@ -0,0 +1,100 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package helpers
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// ResolveDiscriminator will resolve the sub-schema of a oneOf or anyOf schema that an object should be validated
+// against, using the discriminator of the schema. The explicit mapping of the discriminator is checked first,
+// followed by the name of each referenced sub-schema (the last segment of the reference).
+//
+// The value of the discriminator property is returned along with the resolved schema, which is nil if the value
+// does not map to any of the sub-schemas. If the schema has no discriminator, or the object does not contain the
+// discriminator property, the last return value is false.
+func ResolveDiscriminator(schema *base.Schema, decodedObj interface{}) (*base.Schema, string, bool) {
+	if schema == nil || schema.Discriminator == nil || schema.Discriminator.PropertyName == "" {
+		return nil, "", false
+	}
+	candidates := discriminatorCandidates(schema)
+	if len(candidates) == 0 {
+		return nil, "", false
+	}
+	obj, ok := decodedObj.(map[string]interface{})
+	if !ok {
+		return nil, "", false
+	}
+	raw, ok := obj[schema.Discriminator.PropertyName]
+	if !ok {
+		return nil, "", false
+	}
+	value := fmt.Sprint(raw)
+
+	// an explicit mapping takes precedence over the name of the schema.
+	if schema.Discriminator.Mapping != nil {
+		if ref, found := schema.Discriminator.Mapping.Get(value); found {
+			for _, proxy := range candidates {
+				if matchesReference(proxy, ref) {
+					return proxy.Schema(), value, true
+				}
+			}
+			return nil, value, true
+		}
+	}
+	for _, proxy := range candidates {
+		if proxy.IsReference() && referenceName(proxy.GetReference()) == value {
+			return proxy.Schema(), value, true
+		}
+	}
+	return nil, value, true
+}
+
+// DiscriminatorValues will return all the values of the discriminator of a schema that map to a sub-schema,
+// the values of the explicit mapping are returned first, followed by the names of any referenced sub-schemas.
+func DiscriminatorValues(schema *base.Schema) []string {
+	if schema == nil || schema.Discriminator == nil {
+		return nil
+	}
+	var values []string
+	for pair := orderedmap.First(schema.Discriminator.Mapping); pair != nil; pair = pair.Next() {
+		values = append(values, pair.Key())
+	}
+	for _, proxy := range discriminatorCandidates(schema) {
+		if proxy.IsReference() && !slices.Contains(values, referenceName(proxy.GetReference())) {
+			values = append(values, referenceName(proxy.GetReference()))
+		}
+	}
+	return values
+}
+
+// discriminatorCandidates returns the sub-schemas a discriminator can select, oneOf is preferred over anyOf.
+func discriminatorCandidates(schema *base.Schema) []*base.SchemaProxy {
+	if len(schema.OneOf) > 0 {
+		return schema.OneOf
+	}
+	return schema.AnyOf
+}
+
+// matchesReference checks if a schema proxy is a reference to a mapping value, which can be either a full
+// reference, or the name of the schema.
+func matchesReference(proxy *base.SchemaProxy, ref string) bool {
+	if !proxy.IsReference() {
+		return false
+	}
+	if proxy.GetReference() == ref {
+		return true
+	}
+	return !strings.Contains(ref, Slash) && referenceName(proxy.GetReference()) == ref
+}
+
+// referenceName returns the last segment of a reference, which is the name of the schema.
+func referenceName(ref string) string {
+	return ref[strings.LastIndex(ref, Slash)+1:]
+}
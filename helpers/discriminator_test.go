@@ -0,0 +1,89 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package helpers
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/stretchr/testify/require"
+)
+
+func buildDiscriminatorSchema(t *testing.T) *base.Schema {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Cat'
+        - $ref: '#/components/schemas/Dog'
+      discriminator:
+        propertyName: petType
+        mapping:
+          kitty: '#/components/schemas/Cat'
+          puppy: Dog
+    Cat:
+      type: object
+      properties:
+        meows:
+          type: boolean
+    Dog:
+      type: object
+      properties:
+        barks:
+          type: boolean`
+
+	doc, err := libopenapi.NewDocument([]byte(spec))
+	require.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	return m.Model.Components.Schemas.GetOrZero("Pet").Schema()
+}
+
+func TestResolveDiscriminator(t *testing.T) {
+	schema := buildDiscriminatorSchema(t)
+
+	selected, value, ok := ResolveDiscriminator(schema, map[string]interface{}{"petType": "kitty"})
+	require.True(t, ok)
+	require.Equal(t, "kitty", value)
+	require.NotNil(t, selected)
+	require.NotNil(t, selected.Properties.GetOrZero("meows"))
+
+	selected, _, ok = ResolveDiscriminator(schema, map[string]interface{}{"petType": "puppy"})
+	require.True(t, ok)
+	require.NotNil(t, selected.Properties.GetOrZero("barks"))
+
+	// implicit mapping, using the name of the schema.
+	selected, _, ok = ResolveDiscriminator(schema, map[string]interface{}{"petType": "Dog"})
+	require.True(t, ok)
+	require.NotNil(t, selected.Properties.GetOrZero("barks"))
+
+	selected, value, ok = ResolveDiscriminator(schema, map[string]interface{}{"petType": "Fish"})
+	require.True(t, ok)
+	require.Equal(t, "Fish", value)
+	require.Nil(t, selected)
+}
+
+func TestResolveDiscriminator_NotResolvable(t *testing.T) {
+	schema := buildDiscriminatorSchema(t)
+
+	_, _, ok := ResolveDiscriminator(schema, map[string]interface{}{"meows": true})
+	require.False(t, ok)
+
+	_, _, ok = ResolveDiscriminator(schema, []interface{}{"kitty"})
+	require.False(t, ok)
+
+	_, _, ok = ResolveDiscriminator(&base.Schema{Type: []string{Object}}, map[string]interface{}{"petType": "kitty"})
+	require.False(t, ok)
+
+	_, _, ok = ResolveDiscriminator(nil, nil)
+	require.False(t, ok)
+}
+
+func TestDiscriminatorValues(t *testing.T) {
+	schema := buildDiscriminatorSchema(t)
+	require.Equal(t, []string{"kitty", "puppy", "Cat", "Dog"}, DiscriminatorValues(schema))
+	require.Nil(t, DiscriminatorValues(&base.Schema{}))
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package helpers
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LocateJSONPointer will locate the node referenced by a JSON pointer (for example '/burger/toppings/1') within a
+// parsed YAML or JSON document. An empty pointer references the root of the document. If the node cannot be found,
+// nil is returned.
+func LocateJSONPointer(root *yaml.Node, pointer string) *yaml.Node {
+	node := root
+	if node != nil && node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			return nil
+		}
+		node = node.Content[0]
+	}
+	if node == nil || pointer == "" {
+		return node
+	}
+	for _, segment := range strings.Split(strings.TrimPrefix(pointer, Slash), Slash) {
+		segment = strings.ReplaceAll(strings.ReplaceAll(segment, "~1", Slash), "~0", "~")
+		switch node.Kind {
+		case yaml.MappingNode:
+			var found *yaml.Node
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == segment {
+					found = node.Content[i+1]
+					break
+				}
+			}
+			if found == nil {
+				return nil
+			}
+			node = found
+		case yaml.SequenceNode:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(node.Content) {
+				return nil
+			}
+			node = node.Content[index]
+		default:
+			return nil
+		}
+	}
+	return node
+}
+
+// EscapeJSONPointer will escape a property name, so it can be used as a segment of a JSON pointer.
+func EscapeJSONPointer(segment string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(segment, "~", "~0"), Slash, "~1")
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestLocateJSONPointer(t *testing.T) {
+	body := `{
+  "burger": {
+    "toppings": [
+      {"name": "pickles"},
+      {"name": "onions", "a/b": true, "c~d": 1}
+    ]
+  }
+}`
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(body), &root))
+
+	node := LocateJSONPointer(&root, "")
+	require.NotNil(t, node)
+	require.Equal(t, 1, node.Line)
+
+	node = LocateJSONPointer(&root, "/burger/toppings/1/name")
+	require.NotNil(t, node)
+	require.Equal(t, "onions", node.Value)
+	require.Equal(t, 5, node.Line)
+	require.Equal(t, 16, node.Column)
+
+	node = LocateJSONPointer(&root, "/burger/toppings/1/a~1b")
+	require.NotNil(t, node)
+	require.Equal(t, "true", node.Value)
+
+	node = LocateJSONPointer(&root, "/burger/toppings/1/c~0d")
+	require.NotNil(t, node)
+	require.Equal(t, "1", node.Value)
+
+	require.Nil(t, LocateJSONPointer(&root, "/burger/toppings/2"))
+	require.Nil(t, LocateJSONPointer(&root, "/burger/toppings/one"))
+	require.Nil(t, LocateJSONPointer(&root, "/burger/bun"))
+	require.Nil(t, LocateJSONPointer(&root, "/burger/toppings/0/name/first"))
+	require.Nil(t, LocateJSONPointer(nil, "/burger"))
+	require.Nil(t, LocateJSONPointer(&yaml.Node{Kind: yaml.DocumentNode}, ""))
+}
+
+func TestEscapeJSONPointer(t *testing.T) {
+	require.Equal(t, "burger", EscapeJSONPointer("burger"))
+	require.Equal(t, "a~1b~0c", EscapeJSONPointer("a/b~c"))
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package helpers
+
+import (
+	"slices"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// IsOpenAPI30Schema will return true if the schema was defined by an OpenAPI 3.0 document. If the version of the
+// document cannot be determined, false is returned.
+func IsOpenAPI30Schema(schema *base.Schema) bool {
+	if schema == nil || schema.GoLow() == nil || schema.GoLow().Index == nil {
+		return false
+	}
+	indexConfig := schema.GoLow().Index.GetConfig()
+	if indexConfig == nil || indexConfig.SpecInfo == nil {
+		return false
+	}
+	return indexConfig.SpecInfo.VersionNumeric >= 3.0 && indexConfig.SpecInfo.VersionNumeric < 3.1
+}
+
+// ApplyNullable will walk a decoded JSON schema, and translate the OpenAPI 3.0 'nullable' keyword into something
+// JSON schema understands, as 'nullable' is not a JSON schema keyword, null values would be rejected otherwise.
+//
+// A schema with a type has 'null' added to its types (and its enum, if it has one). A schema without a type, that
+// is composed of other schemas (allOf, anyOf, oneOf), is wrapped so it also accepts null. The schema is modified
+// in place.
+func ApplyNullable(schema any) {
+	walkSchema(schema, func(sch map[string]any) {
+		if nullable, _ := sch["nullable"].(bool); !nullable {
+			return
+		}
+		delete(sch, "nullable")
+		switch t := sch["type"].(type) {
+		case string:
+			sch["type"] = []any{t, "null"}
+		case []any:
+			if !slices.Contains(t, any("null")) {
+				sch["type"] = append(t, "null")
+			}
+		case nil:
+			if sch["allOf"] == nil && sch["anyOf"] == nil && sch["oneOf"] == nil {
+				return // no type, so null is already allowed.
+			}
+			inner := make(map[string]any, len(sch))
+			for k, v := range sch {
+				inner[k] = v
+				delete(sch, k)
+			}
+			sch["anyOf"] = []any{map[string]any{"type": "null"}, inner}
+			return
+		}
+		if enum, isList := sch["enum"].([]any); isList && !slices.Contains(enum, nil) {
+			sch["enum"] = append(enum, nil)
+		}
+	})
+}
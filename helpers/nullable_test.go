@@ -0,0 +1,56 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package helpers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsOpenAPI30Schema(t *testing.T) {
+	for version, expected := range map[string]bool{"3.0.3": true, "3.1.0": false} {
+		spec := `openapi: ` + version + `
+components:
+  schemas:
+    Burger:
+      type: string`
+		doc, _ := libopenapi.NewDocument([]byte(spec))
+		m, _ := doc.BuildV3Model()
+
+		schema := m.Model.Components.Schemas.GetOrZero("Burger").Schema()
+		require.Equal(t, expected, IsOpenAPI30Schema(schema), version)
+	}
+	require.False(t, IsOpenAPI30Schema(nil))
+}
+
+func TestApplyNullable(t *testing.T) {
+	decoded, err := jsonschema.UnmarshalJSON(strings.NewReader(`{
+  "type": "object",
+  "properties": {
+    "name": {"type": "string", "nullable": true},
+    "size": {"type": "string", "enum": ["small", "large"], "nullable": true},
+    "tags": {"type": ["array"], "nullable": true, "items": {"type": "integer", "nullable": true}},
+    "patty": {"allOf": [{"type": "object"}], "nullable": true},
+    "anything": {"nullable": true},
+    "strict": {"type": "string", "nullable": false}
+  }
+}`))
+	require.NoError(t, err)
+
+	ApplyNullable(decoded)
+
+	props := decoded.(map[string]any)["properties"].(map[string]any)
+	require.Equal(t, []any{"string", "null"}, props["name"].(map[string]any)["type"])
+	require.Equal(t, []any{"small", "large", nil}, props["size"].(map[string]any)["enum"])
+	require.Equal(t, []any{"array", "null"}, props["tags"].(map[string]any)["type"])
+	require.Equal(t, []any{"integer", "null"}, props["tags"].(map[string]any)["items"].(map[string]any)["type"])
+	require.Equal(t, []any{map[string]any{"type": "null"}, map[string]any{"allOf": []any{map[string]any{"type": "object"}}}},
+		props["patty"].(map[string]any)["anyOf"])
+	require.Equal(t, map[string]any{}, props["anything"])
+	require.Equal(t, "string", props["strict"].(map[string]any)["type"])
+}
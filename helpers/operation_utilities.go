@@ -4,7 +4,10 @@
 package helpers
 
 import (
+	"mime"
+
 	"github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
 	"net/http"
 	"strings"
 )
@@ -57,3 +60,118 @@ func ExtractContentType(contentType string) (string, string, string) {
 	}
 	return contentType, charset, boundary
 }
+
+// FindMediaType will find the media type that matches a content type, from a map of media types keyed by their
+// media range. An exact match is preferred, followed by a structured suffix match (for example
+// 'application/vnd.api+json' matches 'application/json'), a wildcard subtype with a suffix ('application/*+json'),
+// a wildcard subtype ('application/*'), and then a wildcard for everything ('*/*'). Media ranges are matched
+// case-insensitively. Parameters (such as a charset) do not prevent a match, however when a media range declares
+// parameters, it's preferred over one that does not if the content type has the same parameter values.
+func FindMediaType(content *orderedmap.Map[string, *v3.MediaType], contentType string) (*v3.MediaType, bool) {
+	_, found, ok := FindMediaTypeKey(content, contentType)
+	return found, ok
+}
+
+// FindMediaTypeKey will find the media type that matches a content type in the same way as FindMediaType, and also
+// return the media range the media type is keyed by, for example 'application/*' for a content type of
+// 'application/json'.
+func FindMediaTypeKey(content *orderedmap.Map[string, *v3.MediaType],
+	contentType string) (string, *v3.MediaType, bool) {
+	if content == nil {
+		return "", nil, false
+	}
+	var key string
+	var found *v3.MediaType
+	best := 0
+	for pair := orderedmap.First(content); pair != nil; pair = pair.Next() {
+		if score := mediaRangeScore(pair.Key(), contentType); score > best {
+			key, found, best = pair.Key(), pair.Value(), score
+		}
+	}
+	return key, found, found != nil
+}
+
+// MatchesMediaRange will check if a content type matches a media range, the media range can be an exact
+// type, a type with a structured suffix, a wildcard subtype (for example 'image/*') or a wildcard for everything
+// ('*/*'). Media ranges are matched case-insensitively, and parameters are ignored.
+func MatchesMediaRange(mediaRange, contentType string) bool {
+	return mediaRangeScore(mediaRange, contentType) > 0
+}
+
+// mediaRangeScore will score how closely a content type matches a media range, zero means it does not match,
+// and the higher the score, the closer the match.
+func mediaRangeScore(mediaRange, contentType string) int {
+	rangeType, rangeParams := parseMediaType(mediaRange)
+	ctType, ctParams := parseMediaType(contentType)
+	if rangeType == "" || ctType == "" {
+		return 0
+	}
+	mainType, subType, _ := strings.Cut(ctType, "/")
+	_, suffix, hasSuffix := strings.Cut(subType, "+")
+
+	switch {
+	case rangeType == ctType:
+		if len(rangeParams) > 0 && paramsMatch(rangeParams, ctParams) {
+			return 6
+		}
+		return 5
+	case hasSuffix && rangeType == mainType+"/"+suffix:
+		return 4
+	case hasSuffix && rangeType == mainType+"/*+"+suffix:
+		return 3
+	case rangeType == mainType+"/*":
+		return 2
+	case rangeType == "*/*":
+		return 1
+	}
+	return 0
+}
+
+// parseMediaType will split a media type into its lower case type, and its parameters.
+func parseMediaType(mediaType string) (string, map[string]string) {
+	if parsed, params, err := mime.ParseMediaType(mediaType); err == nil {
+		return parsed, params
+	}
+	// fall back to ignoring any parameters that cannot be parsed.
+	parsed, _, _ := strings.Cut(mediaType, SemiColon)
+	return strings.ToLower(strings.TrimSpace(parsed)), nil
+}
+
+// paramsMatch will check every parameter of a media range has the same value in the content type, values are
+// compared case-insensitively.
+func paramsMatch(rangeParams, ctParams map[string]string) bool {
+	for name, value := range rangeParams {
+		if !strings.EqualFold(ctParams[name], value) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsJSONContentType will check if a content type is JSON, either as the subtype ('application/json'), or as a
+// structured suffix ('application/vnd.api+json'). Parameters are ignored.
+func IsJSONContentType(contentType string) bool {
+	return hasStructuredType(contentType, JSONType)
+}
+
+// IsXMLContentType will check if a content type is XML, either as the subtype ('application/xml', 'text/xml'),
+// or as a structured suffix ('application/atom+xml'). Parameters are ignored.
+func IsXMLContentType(contentType string) bool {
+	return hasStructuredType(contentType, XMLType)
+}
+
+// IsYAMLContentType will check if a content type is YAML, either as the subtype ('application/yaml',
+// 'application/x-yaml'), or as a structured suffix ('application/vnd.config+yaml'). Parameters are ignored.
+func IsYAMLContentType(contentType string) bool {
+	return hasStructuredType(contentType, YAMLType)
+}
+
+// hasStructuredType will check if the subtype of a content type is a structured syntax, or uses it as a suffix.
+func hasStructuredType(contentType, syntax string) bool {
+	mediaType, _ := parseMediaType(contentType)
+	_, subType, found := strings.Cut(mediaType, "/")
+	if !found {
+		return false
+	}
+	return subType == syntax || subType == "x-"+syntax || strings.HasSuffix(subType, "+"+syntax)
+}
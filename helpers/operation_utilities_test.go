@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
 	"github.com/stretchr/testify/require"
 )
 
@@ -90,3 +91,132 @@ func TestExtractContentType(t *testing.T) {
 	require.Empty(t, charset)
 	require.Empty(t, boundary)
 }
+
+func TestFindMediaType(t *testing.T) {
+	jsonType := &v3.MediaType{}
+	appType := &v3.MediaType{}
+	anyType := &v3.MediaType{}
+
+	content := orderedmap.New[string, *v3.MediaType]()
+	content.Set("*/*", anyType)
+	content.Set("application/*", appType)
+	content.Set("application/json", jsonType)
+
+	// exact matches are preferred, regardless of the case.
+	mt, ok := FindMediaType(content, "Application/JSON")
+	require.True(t, ok)
+	require.Same(t, jsonType, mt)
+
+	// then a wildcard subtype.
+	mt, ok = FindMediaType(content, "application/xml")
+	require.True(t, ok)
+	require.Same(t, appType, mt)
+
+	// then a wildcard for everything.
+	mt, ok = FindMediaType(content, "text/plain")
+	require.True(t, ok)
+	require.Same(t, anyType, mt)
+
+	content.Delete("*/*")
+	mt, ok = FindMediaType(content, "text/plain")
+	require.False(t, ok)
+	require.Nil(t, mt)
+
+	mt, ok = FindMediaType(nil, "text/plain")
+	require.False(t, ok)
+	require.Nil(t, mt)
+
+	// the media range the media type is keyed by can also be found.
+	key, mt, ok := FindMediaTypeKey(content, "application/xml")
+	require.True(t, ok)
+	require.Equal(t, "application/*", key)
+	require.Same(t, appType, mt)
+
+	key, _, ok = FindMediaTypeKey(content, "text/plain")
+	require.False(t, ok)
+	require.Empty(t, key)
+}
+
+func TestMatchesMediaRange(t *testing.T) {
+	require.True(t, MatchesMediaRange("image/png", "image/png"))
+	require.True(t, MatchesMediaRange("Image/PNG", "image/png"))
+	require.True(t, MatchesMediaRange("image/*", "image/jpeg"))
+	require.True(t, MatchesMediaRange("*/*", "text/plain"))
+	require.False(t, MatchesMediaRange("image/*", "text/plain"))
+	require.False(t, MatchesMediaRange("image/png", "image/jpeg"))
+	require.True(t, MatchesMediaRange("application/json", "application/json; charset=utf-8"))
+	require.True(t, MatchesMediaRange("application/json", "application/vnd.api+json"))
+	require.True(t, MatchesMediaRange("application/*+json", "application/vnd.api+json"))
+	require.False(t, MatchesMediaRange("application/*+json", "application/xml"))
+	require.False(t, MatchesMediaRange("application/json", "text/plain+json"))
+	require.False(t, MatchesMediaRange("", "text/plain"))
+}
+
+func TestFindMediaType_Parameters(t *testing.T) {
+	jsonType := &v3.MediaType{}
+	utf16Type := &v3.MediaType{}
+
+	content := orderedmap.New[string, *v3.MediaType]()
+	content.Set("application/json", jsonType)
+	content.Set("application/json; charset=utf-16", utf16Type)
+
+	// parameters do not prevent a match.
+	mt, ok := FindMediaType(content, "application/json; charset=utf-8")
+	require.True(t, ok)
+	require.Same(t, jsonType, mt)
+
+	// however a media type declaring the same parameters is preferred.
+	mt, ok = FindMediaType(content, "application/json; charset=UTF-16")
+	require.True(t, ok)
+	require.Same(t, utf16Type, mt)
+
+	// parameters that cannot be parsed are ignored.
+	mt, ok = FindMediaType(content, "application/json; charset")
+	require.True(t, ok)
+	require.Same(t, jsonType, mt)
+}
+
+func TestFindMediaType_StructuredSuffix(t *testing.T) {
+	jsonType := &v3.MediaType{}
+	suffixType := &v3.MediaType{}
+	appType := &v3.MediaType{}
+
+	content := orderedmap.New[string, *v3.MediaType]()
+	content.Set("application/*", appType)
+	content.Set("application/*+json", suffixType)
+	content.Set("application/json", jsonType)
+
+	// the structured suffix matches the type it's based on.
+	mt, ok := FindMediaType(content, "application/vnd.api+json; charset=utf-8")
+	require.True(t, ok)
+	require.Same(t, jsonType, mt)
+
+	// followed by a wildcard subtype with the same suffix.
+	content.Delete("application/json")
+	mt, ok = FindMediaType(content, "application/vnd.api+json")
+	require.True(t, ok)
+	require.Same(t, suffixType, mt)
+
+	mt, ok = FindMediaType(content, "application/vnd.api+xml")
+	require.True(t, ok)
+	require.Same(t, appType, mt)
+}
+
+func TestIsStructuredContentType(t *testing.T) {
+	require.True(t, IsJSONContentType("application/json"))
+	require.True(t, IsJSONContentType("Application/JSON; charset=utf-8"))
+	require.True(t, IsJSONContentType("application/vnd.company.v1+json"))
+	require.True(t, IsJSONContentType("application/x-json"))
+	require.False(t, IsJSONContentType("application/not-json"))
+	require.False(t, IsJSONContentType("application/jsonl"))
+	require.False(t, IsJSONContentType("json"))
+
+	require.True(t, IsXMLContentType("text/xml"))
+	require.True(t, IsXMLContentType("application/atom+xml"))
+	require.False(t, IsXMLContentType("application/json"))
+
+	require.True(t, IsYAMLContentType("application/yaml"))
+	require.True(t, IsYAMLContentType("application/x-yaml"))
+	require.True(t, IsYAMLContentType("application/vnd.config+yaml"))
+	require.False(t, IsYAMLContentType("text/plain"))
+}
@@ -8,59 +8,86 @@ import (
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
 	"net/http"
+	"net/url"
 	"slices"
 	"strconv"
 	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
 // QueryParam is a struct that holds the key, values and property name for a query parameter
 // it's used for complex query types that need to be parsed and tracked differently depending
 // on the encoding styles used.
 type QueryParam struct {
-	Key      string
-	Values   []string
-	Property string
+	Key       string
+	Values    []string
+	RawValues []string
+	Property  string
+	Path      []string
 }
 
 // ExtractParamsForOperation will extract the parameters for the operation based on the request method.
-// Both the path level params and the method level params will be returned.
+// Both the path level params and the method level params will be returned. A path level param is
+// overridden by a method level param that shares the same name and location.
 func ExtractParamsForOperation(request *http.Request, item *v3.PathItem) []*v3.Parameter {
-	params := item.Parameters
+	var opParams []*v3.Parameter
 	switch request.Method {
 	case http.MethodGet:
 		if item.Get != nil {
-			params = append(params, item.Get.Parameters...)
+			opParams = item.Get.Parameters
 		}
 	case http.MethodPost:
 		if item.Post != nil {
-			params = append(params, item.Post.Parameters...)
+			opParams = item.Post.Parameters
 		}
 	case http.MethodPut:
 		if item.Put != nil {
-			params = append(params, item.Put.Parameters...)
+			opParams = item.Put.Parameters
 		}
 	case http.MethodDelete:
 		if item.Delete != nil {
-			params = append(params, item.Delete.Parameters...)
+			opParams = item.Delete.Parameters
 		}
 	case http.MethodOptions:
 		if item.Options != nil {
-			params = append(params, item.Options.Parameters...)
+			opParams = item.Options.Parameters
 		}
 	case http.MethodHead:
 		if item.Head != nil {
-			params = append(params, item.Head.Parameters...)
+			opParams = item.Head.Parameters
 		}
 	case http.MethodPatch:
 		if item.Patch != nil {
-			params = append(params, item.Patch.Parameters...)
+			opParams = item.Patch.Parameters
 		}
 	case http.MethodTrace:
 		if item.Trace != nil {
-			params = append(params, item.Trace.Parameters...)
+			opParams = item.Trace.Parameters
 		}
 	}
-	return params
+
+	params := make([]*v3.Parameter, 0, len(item.Parameters)+len(opParams))
+	for _, pathParam := range item.Parameters {
+		if !slices.ContainsFunc(opParams, func(opParam *v3.Parameter) bool {
+			return isSameParameter(pathParam, opParam)
+		}) {
+			params = append(params, pathParam)
+		}
+	}
+	return append(params, opParams...)
+}
+
+// isSameParameter returns true if both parameters share a name and location. Header names are
+// case-insensitive, so they are compared as such.
+func isSameParameter(a, b *v3.Parameter) bool {
+	if a == nil || b == nil || a.In != b.In {
+		return false
+	}
+	if a.In == Header {
+		return strings.EqualFold(a.Name, b.Name)
+	}
+	return a.Name == b.Name
 }
 
 // ExtractSecurityForOperation will extract the security requirements for the operation based on the request method.
@@ -103,6 +130,38 @@ func ExtractSecurityForOperation(request *http.Request, item *v3.PathItem) []*ba
 	return schemes
 }
 
+// FindHeaderValue will find the value of a header, matching the name case-insensitively. Headers are stored
+// using their canonical name (via http.CanonicalHeaderKey) when set through http.Header, however headers can be
+// added to the map directly, in which case the casing is whatever the caller used. The boolean returned is true
+// if the header is present, which allows an empty header to be told apart from an absent one.
+func FindHeaderValue(header http.Header, name string) (string, bool) {
+	if v, ok := header[http.CanonicalHeaderKey(name)]; ok && len(v) > 0 {
+		return v[0], true
+	}
+	for k, v := range header {
+		if strings.EqualFold(k, name) && len(v) > 0 {
+			return v[0], true
+		}
+	}
+	return "", false
+}
+
+// AuthorizationSchemePrefix will return the name of an http authentication scheme in the form it is normally
+// written in an Authorization header, for example 'bearer' becomes 'Bearer'.
+func AuthorizationSchemePrefix(scheme string) string {
+	if scheme == "" {
+		return scheme
+	}
+	return strings.ToUpper(scheme[:1]) + strings.ToLower(scheme[1:])
+}
+
+// HasAuthorizationScheme will check the value of an Authorization header starts with the supplied http
+// authentication scheme, followed by a space. Scheme names are matched case-insensitively, as per RFC 7235.
+func HasAuthorizationScheme(authorization, scheme string) bool {
+	used, _, found := strings.Cut(authorization, " ")
+	return found && strings.EqualFold(used, scheme)
+}
+
 func cast(v string) any {
 
 	if v == "true" || v == "false" {
@@ -120,6 +179,104 @@ func cast(v string) any {
 	return v
 }
 
+// IsScalarSchema will check if a schema describes a single value, rather than an object or an array. A schema
+// without a type, but with properties or items, is treated as an object or an array.
+func IsScalarSchema(schema *base.Schema) bool {
+	if schema == nil {
+		return false
+	}
+	if slices.Contains(schema.Type, Object) || slices.Contains(schema.Type, Array) {
+		return false
+	}
+	return len(schema.Type) > 0 || (schema.Properties == nil && schema.Items == nil)
+}
+
+// CoerceValue will convert a string value into the type defined by a schema, so it can be validated against the
+// schema. If the value cannot be converted, it's left as a string, so it fails validation.
+func CoerceValue(value string, sch *base.Schema) interface{} {
+	if sch == nil || slices.Contains(sch.Type, String) {
+		return value
+	}
+	for _, ty := range sch.Type {
+		switch ty {
+		case Integer:
+			if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+				return i
+			}
+		case Number:
+			if f, err := strconv.ParseFloat(value, 64); err == nil {
+				return f
+			}
+		case Boolean:
+			if b, err := strconv.ParseBool(value); err == nil {
+				return b
+			}
+		}
+	}
+	return value
+}
+
+// MatchesEnum will check if a raw parameter value matches one of the values of an enum. The value type is the type
+// the parameter is being validated as, and the value is compared with each enum value as that type, so a number
+// matches an enum value that is numerically equal ('1.0' matches 1), and a boolean is compared as a boolean.
+// Strings, and values that cannot be parsed as the type, are compared as they are.
+func MatchesEnum(value, valueType string, enum []*yaml.Node) bool {
+	value = strings.TrimSpace(value)
+	for _, enumVal := range enum {
+		if enumVal == nil {
+			continue
+		}
+		switch valueType {
+		case Integer, Number:
+			parsed, err := strconv.ParseFloat(value, 64)
+			entry, entryErr := strconv.ParseFloat(enumVal.Value, 64)
+			if err == nil && entryErr == nil {
+				if parsed == entry {
+					return true
+				}
+				continue
+			}
+		case Boolean:
+			parsed, err := strconv.ParseBool(value)
+			entry, entryErr := strconv.ParseBool(enumVal.Value)
+			if err == nil && entryErr == nil {
+				if parsed == entry {
+					return true
+				}
+				continue
+			}
+		}
+		if value == enumVal.Value {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesConst will check if a raw parameter value matches the value of a const, the value is compared as the
+// type the parameter is being validated as, in the same way as MatchesEnum.
+func MatchesConst(value, valueType string, constant *yaml.Node) bool {
+	return MatchesEnum(value, valueType, []*yaml.Node{constant})
+}
+
+// ExtractRawQueryValues will extract the values of a raw (still encoded) query string, keyed by the decoded key.
+// Values are kept exactly as they were sent, which makes it possible to determine if reserved characters
+// were percent-encoded or not.
+func ExtractRawQueryValues(rawQuery string) map[string][]string {
+	values := make(map[string][]string)
+	for _, segment := range strings.Split(rawQuery, Ampersand) {
+		if segment == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(segment, Equals)
+		if decodedKey, err := url.QueryUnescape(key); err == nil {
+			key = decodedKey
+		}
+		values[key] = append(values[key], value)
+	}
+	return values
+}
+
 // ConstructParamMapFromDeepObjectEncoding will construct a map from the query parameters that are encoded as
 // deep objects. It's kind of a crazy way to do things, but hey, each to their own.
 func ConstructParamMapFromDeepObjectEncoding(values []*QueryParam, sch *base.Schema) map[string]interface{} {
@@ -176,6 +333,147 @@ func ConstructParamMapFromDeepObjectEncoding(values []*QueryParam, sch *base.Sch
 	return decoded
 }
 
+// ExtractDeepObjectPath will extract the name and the path of bracketed segments from a deepObject encoded
+// query key, for example 'filter[tags][0]' will return 'filter' and ['tags', '0']. If the key does not contain
+// any bracketed segments, the path returned will be empty.
+func ExtractDeepObjectPath(key string) (string, []string) {
+	start := strings.IndexRune(key, '[')
+	if start <= 0 {
+		return key, nil
+	}
+	name := key[:start]
+	var path []string
+	remaining := key[start:]
+	for strings.HasPrefix(remaining, "[") {
+		end := strings.IndexRune(remaining, ']')
+		if end < 0 {
+			break
+		}
+		path = append(path, remaining[1:end])
+		remaining = remaining[end+1:]
+	}
+	return name, path
+}
+
+// IsNestedDeepObjectEncoding will check if any of the query parameters are encoded as deep objects that
+// contain more than a single level of properties, for example 'filter[tags][0]=a'.
+func IsNestedDeepObjectEncoding(values []*QueryParam) bool {
+	for _, v := range values {
+		if len(v.Path) > 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// ConstructNestedParamMapFromDeepObjectEncoding will construct a map from query parameters that are encoded as
+// nested deep objects, for example 'filter[status]=open&filter[tags][0]=a'. Each bracketed segment is a level
+// of nesting, and levels that only contain numeric indices are reconstructed as arrays. An error is returned if
+// a segment is empty, if array indices are missing, or if a key is used as both a value and an object.
+func ConstructNestedParamMapFromDeepObjectEncoding(values []*QueryParam) (map[string]interface{}, error) {
+	decoded := make(map[string]interface{})
+	for _, v := range values {
+		if len(v.Path) == 0 || len(v.Values) == 0 {
+			continue
+		}
+		root, ok := decoded[v.Key].(map[string]interface{})
+		if !ok {
+			root = make(map[string]interface{})
+			decoded[v.Key] = root
+		}
+		var value interface{}
+		if len(v.Values) > 1 {
+			rawValues := make([]interface{}, len(v.Values))
+			for i := range v.Values {
+				rawValues[i] = cast(v.Values[i])
+			}
+			value = rawValues
+		} else {
+			value = cast(v.Values[0])
+		}
+
+		current := root
+		for i, segment := range v.Path {
+			if segment == "" {
+				return nil, fmt.Errorf("the key '%s' contains an empty property name", deepObjectKey(v))
+			}
+			if i == len(v.Path)-1 {
+				if _, exists := current[segment].(map[string]interface{}); exists {
+					return nil, fmt.Errorf("the key '%s' is used as both a value and an object", deepObjectKey(v))
+				}
+				current[segment] = value
+				break
+			}
+			next, exists := current[segment]
+			if !exists {
+				nested := make(map[string]interface{})
+				current[segment] = nested
+				current = nested
+				continue
+			}
+			nested, isMap := next.(map[string]interface{})
+			if !isMap {
+				return nil, fmt.Errorf("the key '%s' is used as both a value and an object", deepObjectKey(v))
+			}
+			current = nested
+		}
+	}
+	for k, v := range decoded {
+		converted, err := convertIndexedMaps(k, v)
+		if err != nil {
+			return nil, err
+		}
+		decoded[k] = converted
+	}
+	return decoded, nil
+}
+
+// convertIndexedMaps will walk a decoded deepObject and convert any map that only contains numeric
+// indices into an array. The root object is never converted.
+func convertIndexedMaps(path string, value interface{}) (interface{}, error) {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return value, nil
+	}
+	for k, v := range m {
+		converted, err := convertIndexedMaps(fmt.Sprintf("%s[%s]", path, k), v)
+		if err != nil {
+			return nil, err
+		}
+		indexed, isMap := converted.(map[string]interface{})
+		if !isMap || len(indexed) == 0 {
+			m[k] = converted
+			continue
+		}
+		numeric := true
+		for idx := range indexed {
+			if _, e := strconv.Atoi(idx); e != nil {
+				numeric = false
+				break
+			}
+		}
+		if !numeric {
+			m[k] = converted
+			continue
+		}
+		arr := make([]interface{}, len(indexed))
+		for idx, item := range indexed {
+			i, _ := strconv.Atoi(idx)
+			if i < 0 || i >= len(indexed) {
+				return nil, fmt.Errorf("the array '%s[%s]' is missing one or more indices, "+
+					"indices must start at 0 and be sequential", path, k)
+			}
+			arr[i] = item
+		}
+		m[k] = arr
+	}
+	return m, nil
+}
+
+func deepObjectKey(v *QueryParam) string {
+	return fmt.Sprintf("%s[%s]", v.Key, strings.Join(v.Path, "]["))
+}
+
 // ConstructParamMapFromQueryParamInput will construct a param map from an existing map of *QueryParam slices.
 func ConstructParamMapFromQueryParamInput(values map[string][]*QueryParam) map[string]interface{} {
 	decoded := make(map[string]interface{})
@@ -237,7 +535,7 @@ func ConstructMapFromCSV(csv string) map[string]interface{} {
 			if len(exploded) == i+1 {
 				break
 			}
-			decoded[exploded[i]] = cast(exploded[i+1])
+			decoded[strings.TrimSpace(exploded[i])] = cast(strings.TrimSpace(exploded[i+1]))
 		}
 	}
 	return decoded
@@ -248,9 +546,10 @@ func ConstructKVFromCSV(values string) map[string]interface{} {
 	props := make(map[string]interface{})
 	exploded := strings.Split(values, Comma)
 	for i := range exploded {
-		obK := strings.Split(exploded[i], Equals)
+		// only split on the first equals, so values can contain an equals sign (base64 padding for example).
+		obK := strings.SplitN(exploded[i], Equals, 2)
 		if len(obK) == 2 {
-			props[obK[0]] = cast(obK[1])
+			props[strings.TrimSpace(obK[0])] = cast(strings.TrimSpace(obK[1]))
 		}
 	}
 	return props
@@ -282,6 +581,43 @@ func ConstructKVFromMatrixCSV(values string) map[string]interface{} {
 	return props
 }
 
+// ConstructKVFromFormEncoding will construct a map from an ampersand separated value string that denotes key value
+// pairs. This is how an exploded 'form' style object is serialized, for example: 'R=100&G=200&B=150'
+func ConstructKVFromFormEncoding(values string) map[string]interface{} {
+	props := make(map[string]interface{})
+	exploded := strings.Split(values, Ampersand)
+	for i := range exploded {
+		obK := strings.Split(exploded[i], Equals)
+		if len(obK) == 2 && obK[0] != "" {
+			props[obK[0]] = cast(obK[1])
+		}
+	}
+	return props
+}
+
+// ConstructKVFromDeepObjectEncoding will construct a map from an ampersand separated value string that denotes
+// key value pairs using the 'deepObject' style, for example: 'color[R]=100&color[G]=200'. Only properties
+// that belong to the named parameter are extracted.
+func ConstructKVFromDeepObjectEncoding(name, values string) map[string]interface{} {
+	props := make(map[string]interface{})
+	exploded := strings.Split(values, Ampersand)
+	for i := range exploded {
+		obK := strings.Split(exploded[i], Equals)
+		if len(obK) != 2 {
+			continue
+		}
+		key := obK[0]
+		if !strings.HasPrefix(key, name+"[") || !strings.HasSuffix(key, "]") {
+			continue
+		}
+		property := key[len(name)+1 : len(key)-1]
+		if property != "" {
+			props[property] = cast(obK[1])
+		}
+	}
+	return props
+}
+
 // ConstructParamMapFromFormEncodingArray will construct a map from the query parameters that are encoded as
 // form encoded values.
 func ConstructParamMapFromFormEncodingArray(values []*QueryParam) map[string]interface{} {
@@ -302,6 +638,58 @@ func ConstructParamMapFromFormEncodingArray(values []*QueryParam) map[string]int
 	return decoded
 }
 
+// ConstructParamMapFromExplodedFormObject will construct a map from the query parameters of an object that is
+// encoded using the exploded 'form' style, where each property is sent as a separate query key. Each value is
+// coerced using the schema of its property, or the additionalProperties schema, values that cannot be coerced are
+// cast to the type they look like. A property defined as an array collects every value sent for the key, only the
+// first value is used for any other property.
+func ConstructParamMapFromExplodedFormObject(values []*QueryParam, sch *base.Schema) map[string]interface{} {
+	decoded := make(map[string]interface{})
+	for _, v := range values {
+		if len(v.Values) == 0 {
+			continue
+		}
+		var propSchema *base.Schema
+		if sch.Properties != nil {
+			if prop, ok := sch.Properties.Get(v.Key); ok && prop != nil {
+				propSchema = prop.Schema()
+			}
+		}
+		if propSchema == nil && sch.AdditionalProperties != nil && sch.AdditionalProperties.IsA() {
+			propSchema = sch.AdditionalProperties.A.Schema()
+		}
+		if propSchema != nil && slices.Contains(propSchema.Type, Array) {
+			var itemSchema *base.Schema
+			if propSchema.Items != nil && propSchema.Items.IsA() {
+				itemSchema = propSchema.Items.A.Schema()
+			}
+			items, _ := decoded[v.Key].([]interface{})
+			for _, item := range v.Values {
+				items = append(items, coerceFormValue(item, itemSchema))
+			}
+			decoded[v.Key] = items
+			continue
+		}
+		if _, ok := decoded[v.Key]; !ok {
+			decoded[v.Key] = coerceFormValue(v.Values[0], propSchema)
+		}
+	}
+	return decoded
+}
+
+// coerceFormValue will coerce a value using a schema, falling back to casting the value when the schema has no type
+// the value can be coerced to, so it fails validation as the type it looks like.
+func coerceFormValue(value string, sch *base.Schema) interface{} {
+	if sch != nil && slices.Contains(sch.Type, String) {
+		return value
+	}
+	coerced := CoerceValue(value, sch)
+	if str, ok := coerced.(string); ok {
+		return cast(str)
+	}
+	return coerced
+}
+
 // DoesFormParamContainDelimiter will determine if a form parameter contains a delimiter.
 func DoesFormParamContainDelimiter(value, style string) bool {
 	if strings.Contains(value, Comma) && (style == "" || style == Form) {
@@ -323,6 +711,20 @@ func ExplodeQueryValue(value, style string) []string {
 
 }
 
+// ExplodeCookieValue will explode an exploded 'form' style cookie value into its individual items. The first
+// item is the value of the cookie, each following item is prefixed with the name of the cookie,
+// for example: '3&id=4&id=5'. If no ampersands are present, the value is treated as CSV.
+func ExplodeCookieValue(name, value string) []string {
+	if !strings.Contains(value, Ampersand) {
+		return strings.Split(value, Comma)
+	}
+	exploded := strings.Split(value, Ampersand)
+	for i := range exploded {
+		exploded[i] = strings.TrimPrefix(exploded[i], name+Equals)
+	}
+	return exploded
+}
+
 func CollapseCSVIntoFormStyle(key string, value string) string {
 	return fmt.Sprintf("&%s=%s", key,
 		strings.Join(strings.Split(value, ","), fmt.Sprintf("&%s=", key)))
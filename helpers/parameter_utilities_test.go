@@ -6,8 +6,11 @@ package helpers
 import (
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 	"net/http"
+	"slices"
 	"testing"
 )
 
@@ -58,6 +61,41 @@ func TestExtractParamsForOperation(t *testing.T) {
 
 }
 
+// Test ExtractParamsForOperation merges path level params with method level params
+func TestExtractParamsForOperation_PathLevelParams(t *testing.T) {
+	pathItem := &v3.PathItem{
+		Parameters: []*v3.Parameter{
+			{Name: "id", In: "path"},
+			{Name: "limit", In: "query", Description: "path"},
+			{Name: "X-Trace", In: "header", Description: "path"},
+			{Name: "limit", In: "header"},
+		},
+		Get: &v3.Operation{Parameters: []*v3.Parameter{
+			{Name: "limit", In: "query", Description: "operation"},
+			{Name: "x-trace", In: "header", Description: "operation"},
+		}},
+	}
+
+	// a method without an operation inherits only the path level params
+	request, _ := http.NewRequest(http.MethodPost, "/", nil)
+	params := ExtractParamsForOperation(request, pathItem)
+	require.Len(t, params, 4)
+
+	// method level params override path level params with the same name and location
+	request, _ = http.NewRequest(http.MethodGet, "/", nil)
+	params = ExtractParamsForOperation(request, pathItem)
+	require.Len(t, params, 4)
+	require.Equal(t, "id", params[0].Name)
+	require.Equal(t, "limit", params[1].Name)
+	require.Equal(t, "header", params[1].In)
+	require.Equal(t, "operation", params[2].Description)
+	require.Equal(t, "operation", params[3].Description)
+
+	// the path item itself is left untouched
+	require.Len(t, pathItem.Parameters, 4)
+	require.Equal(t, "path", pathItem.Parameters[1].Description)
+}
+
 // Test cast with different values (bool, int, float, string)
 func TestCast(t *testing.T) {
 	require.Equal(t, true, cast("true"))
@@ -302,6 +340,42 @@ func TestConstructParamMapFromQueryParamInput(t *testing.T) {
 }
 
 // Test ConstructParamMapFromPipeEncoding
+func TestConstructParamMapFromExplodedFormObject(t *testing.T) {
+	props := orderedmap.New[string, *base.SchemaProxy]()
+	props.Set("name", base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}}))
+	props.Set("limit", base.CreateSchemaProxy(&base.Schema{Type: []string{"integer"}}))
+	props.Set("tags", base.CreateSchemaProxy(&base.Schema{
+		Type:  []string{"array"},
+		Items: &base.DynamicValue[*base.SchemaProxy, bool]{A: base.CreateSchemaProxy(&base.Schema{Type: []string{"number"}})},
+	}))
+	schema := &base.Schema{
+		Type:       []string{"object"},
+		Properties: props,
+		AdditionalProperties: &base.DynamicValue[*base.SchemaProxy, bool]{
+			A: base.CreateSchemaProxy(&base.Schema{Type: []string{"boolean"}}),
+		},
+	}
+
+	values := []*QueryParam{
+		{Key: "name", Values: []string{"123", "456"}},
+		{Key: "limit", Values: []string{"10"}},
+		{Key: "tags", Values: []string{"1", "2.5"}},
+		{Key: "salt", Values: []string{"true"}},
+		{Key: "vinegar", Values: []string{}},
+	}
+
+	decoded := ConstructParamMapFromExplodedFormObject(values, schema)
+	require.Len(t, decoded, 4)
+	require.Equal(t, "123", decoded["name"]) // only the first value is used, and not cast
+	require.Equal(t, int64(10), decoded["limit"])
+	require.Equal(t, []interface{}{float64(1), 2.5}, decoded["tags"])
+	require.Equal(t, true, decoded["salt"])
+
+	// values that cannot be coerced are cast to the type they look like
+	decoded = ConstructParamMapFromExplodedFormObject([]*QueryParam{{Key: "limit", Values: []string{"false"}}}, schema)
+	require.Equal(t, false, decoded["limit"])
+}
+
 func TestConstructParamMapFromPipeEncoding(t *testing.T) {
 	params := []*QueryParam{
 		{Key: "key1", Values: []string{"name|value"}},
@@ -329,6 +403,10 @@ func TestConstructMapFromCSV(t *testing.T) {
 	result = ConstructMapFromCSV("key1,value1,key2")
 	require.Equal(t, "value1", result["key1"])
 
+	// whitespace around the delimiters is ignored
+	result = ConstructMapFromCSV("key1, value1, key2, 2")
+	require.Equal(t, "value1", result["key1"])
+	require.Equal(t, int64(2), result["key2"])
 }
 
 // Test ConstructKVFromCSV
@@ -336,6 +414,41 @@ func TestConstructKVFromCSV(t *testing.T) {
 	result := ConstructKVFromCSV("key1=value1,key2=value2")
 	require.Equal(t, "value1", result["key1"])
 	require.Equal(t, "value2", result["key2"])
+
+	// values can contain an equals sign, and whitespace around the delimiters is ignored
+	result = ConstructKVFromCSV("key1=dmFsdWU=, key2=2")
+	require.Equal(t, "dmFsdWU=", result["key1"])
+	require.Equal(t, int64(2), result["key2"])
+}
+
+// Test ConstructKVFromFormEncoding
+func TestConstructKVFromFormEncoding(t *testing.T) {
+	result := ConstructKVFromFormEncoding("key1=value1&key2=2&=nope&broken")
+	require.Len(t, result, 2)
+	require.Equal(t, "value1", result["key1"])
+	require.Equal(t, int64(2), result["key2"])
+}
+
+// Test ConstructKVFromDeepObjectEncoding
+func TestConstructKVFromDeepObjectEncoding(t *testing.T) {
+	result := ConstructKVFromDeepObjectEncoding("color", "color[R]=100&color[G]=true&other[B]=1&color[]=2&color=3")
+	require.Len(t, result, 2)
+	require.Equal(t, int64(100), result["R"])
+	require.Equal(t, true, result["G"])
+}
+
+// Test ExtractRawQueryValues
+func TestExtractRawQueryValues(t *testing.T) {
+	result := ExtractRawQueryValues("a=1%2C2&a=3,4&b%5Bc%5D=x/y&&d")
+	require.Equal(t, []string{"1%2C2", "3,4"}, result["a"])
+	require.Equal(t, []string{"x/y"}, result["b[c]"])
+	require.Equal(t, []string{""}, result["d"])
+}
+
+// Test ExplodeCookieValue
+func TestExplodeCookieValue(t *testing.T) {
+	require.Equal(t, []string{"3", "4", "5"}, ExplodeCookieValue("id", "3&id=4&id=5"))
+	require.Equal(t, []string{"3", "4", "5"}, ExplodeCookieValue("id", "3,4,5"))
 }
 
 // Test CollapseCSVIntoFormStyle
@@ -478,3 +591,165 @@ func TestConstructParamMapFromFormEncodingArray(t *testing.T) {
 	require.Equal(t, true, decoded["param1"].(map[string]interface{})["key2"])       // cast to bool
 	require.Equal(t, "hello", decoded["param1"].(map[string]interface{})["key3"])    // string remains string
 }
+
+func TestExtractDeepObjectPath(t *testing.T) {
+	name, path := ExtractDeepObjectPath("filter[tags][0]")
+	require.Equal(t, "filter", name)
+	require.Equal(t, []string{"tags", "0"}, path)
+
+	name, path = ExtractDeepObjectPath("filter[status]")
+	require.Equal(t, "filter", name)
+	require.Equal(t, []string{"status"}, path)
+
+	name, path = ExtractDeepObjectPath("filter")
+	require.Equal(t, "filter", name)
+	require.Nil(t, path)
+}
+
+func TestIsNestedDeepObjectEncoding(t *testing.T) {
+	require.False(t, IsNestedDeepObjectEncoding([]*QueryParam{
+		{Key: "filter", Values: []string{"open"}, Path: []string{"status"}},
+	}))
+	require.True(t, IsNestedDeepObjectEncoding([]*QueryParam{
+		{Key: "filter", Values: []string{"open"}, Path: []string{"status"}},
+		{Key: "filter", Values: []string{"a"}, Path: []string{"tags", "0"}},
+	}))
+}
+
+func TestConstructNestedParamMapFromDeepObjectEncoding(t *testing.T) {
+	values := []*QueryParam{
+		{Key: "filter", Values: []string{"open"}, Path: []string{"status"}},
+		{Key: "filter", Values: []string{"b"}, Path: []string{"tags", "1"}},
+		{Key: "filter", Values: []string{"a"}, Path: []string{"tags", "0"}},
+		{Key: "filter", Values: []string{"5"}, Path: []string{"owner", "id"}},
+	}
+	decoded, err := ConstructNestedParamMapFromDeepObjectEncoding(values)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		"filter": map[string]interface{}{
+			"status": "open",
+			"tags":   []interface{}{"a", "b"},
+			"owner":  map[string]interface{}{"id": int64(5)},
+		},
+	}, decoded)
+}
+
+func TestConstructNestedParamMapFromDeepObjectEncoding_MissingIndex(t *testing.T) {
+	values := []*QueryParam{
+		{Key: "filter", Values: []string{"a"}, Path: []string{"tags", "0"}},
+		{Key: "filter", Values: []string{"c"}, Path: []string{"tags", "2"}},
+	}
+	_, err := ConstructNestedParamMapFromDeepObjectEncoding(values)
+	require.EqualError(t, err, "the array 'filter[tags]' is missing one or more indices, "+
+		"indices must start at 0 and be sequential")
+}
+
+func TestConstructNestedParamMapFromDeepObjectEncoding_Conflict(t *testing.T) {
+	values := []*QueryParam{
+		{Key: "filter", Values: []string{"open"}, Path: []string{"status"}},
+		{Key: "filter", Values: []string{"a"}, Path: []string{"status", "name"}},
+	}
+	_, err := ConstructNestedParamMapFromDeepObjectEncoding(values)
+	require.EqualError(t, err, "the key 'filter[status][name]' is used as both a value and an object")
+
+	// the order of the keys should not matter.
+	slices.Reverse(values)
+	_, err = ConstructNestedParamMapFromDeepObjectEncoding(values)
+	require.EqualError(t, err, "the key 'filter[status]' is used as both a value and an object")
+}
+
+func TestConstructNestedParamMapFromDeepObjectEncoding_EmptySegment(t *testing.T) {
+	values := []*QueryParam{
+		{Key: "filter", Values: []string{"a"}, Path: []string{"", "name"}},
+	}
+	_, err := ConstructNestedParamMapFromDeepObjectEncoding(values)
+	require.EqualError(t, err, "the key 'filter[][name]' contains an empty property name")
+}
+
+func TestFindHeaderValue(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Request-Id", "abc")
+	value, found := FindHeaderValue(header, "x-request-id")
+	require.True(t, found)
+	require.Equal(t, "abc", value)
+
+	// headers added directly to the map are not canonicalized.
+	header = http.Header{"x-request-id": []string{"def"}}
+	value, found = FindHeaderValue(header, "X-Request-ID")
+	require.True(t, found)
+	require.Equal(t, "def", value)
+
+	value, found = FindHeaderValue(header, "X-Trace")
+	require.False(t, found)
+	require.Equal(t, "", value)
+
+	// an empty header is still present.
+	header.Set("X-Trace", "")
+	value, found = FindHeaderValue(header, "X-Trace")
+	require.True(t, found)
+	require.Equal(t, "", value)
+}
+
+func TestCoerceValue(t *testing.T) {
+	require.Equal(t, "12", CoerceValue("12", nil))
+	require.Equal(t, "12", CoerceValue("12", &base.Schema{Type: []string{String, Integer}}))
+	require.Equal(t, int64(12), CoerceValue("12", &base.Schema{Type: []string{Integer}}))
+	require.Equal(t, 1.5, CoerceValue("1.5", &base.Schema{Type: []string{Number}}))
+	require.Equal(t, true, CoerceValue("true", &base.Schema{Type: []string{Boolean}}))
+	require.Equal(t, "nope", CoerceValue("nope", &base.Schema{Type: []string{Boolean}}))
+	require.Equal(t, int64(3), CoerceValue("3", &base.Schema{Type: []string{Boolean, Integer}}))
+}
+
+func TestAuthorizationSchemePrefix(t *testing.T) {
+	require.Equal(t, "Bearer", AuthorizationSchemePrefix("bearer"))
+	require.Equal(t, "Basic", AuthorizationSchemePrefix("BASIC"))
+	require.Equal(t, "", AuthorizationSchemePrefix(""))
+}
+
+func TestHasAuthorizationScheme(t *testing.T) {
+	require.True(t, HasAuthorizationScheme("Bearer abc", "bearer"))
+	require.True(t, HasAuthorizationScheme("basic abc", "Basic"))
+	require.False(t, HasAuthorizationScheme("Basic abc", "bearer"))
+	require.False(t, HasAuthorizationScheme("Bearerabc", "bearer"))
+	require.False(t, HasAuthorizationScheme("abc", "bearer"))
+}
+
+func TestIsScalarSchema(t *testing.T) {
+	require.False(t, IsScalarSchema(nil))
+	require.True(t, IsScalarSchema(&base.Schema{Type: []string{String}}))
+	require.True(t, IsScalarSchema(&base.Schema{Type: []string{Integer, "null"}}))
+	require.True(t, IsScalarSchema(&base.Schema{}))
+	require.False(t, IsScalarSchema(&base.Schema{Type: []string{Object}}))
+	require.False(t, IsScalarSchema(&base.Schema{Type: []string{Array}}))
+	require.False(t, IsScalarSchema(&base.Schema{Items: &base.DynamicValue[*base.SchemaProxy, bool]{B: true}}))
+}
+
+func TestMatchesEnum(t *testing.T) {
+	enum := func(values ...string) []*yaml.Node {
+		var nodes []*yaml.Node
+		for _, v := range values {
+			nodes = append(nodes, &yaml.Node{Kind: yaml.ScalarNode, Value: v})
+		}
+		return nodes
+	}
+
+	require.True(t, MatchesEnum("2", Integer, enum("1", "2", "3")))
+	require.True(t, MatchesEnum("2.0", Integer, enum("1", "2", "3")))
+	require.False(t, MatchesEnum("9", Integer, enum("1", "2", "3")))
+	require.True(t, MatchesEnum("1.50", Number, enum("1.5", "2.5")))
+	require.False(t, MatchesEnum("1.6", Number, enum("1.5", "2.5")))
+	require.True(t, MatchesEnum("TRUE", Boolean, enum("true")))
+	require.False(t, MatchesEnum("false", Boolean, enum("true")))
+	require.True(t, MatchesEnum(" chicken ", String, enum("beef", "chicken")))
+	require.False(t, MatchesEnum("2.0", String, enum("2")))
+	require.False(t, MatchesEnum("2", Integer, nil))
+}
+
+func TestMatchesConst(t *testing.T) {
+	require.True(t, MatchesConst("cat", String, &yaml.Node{Value: "cat"}))
+	require.False(t, MatchesConst("dog", String, &yaml.Node{Value: "cat"}))
+	require.True(t, MatchesConst("5.0", Integer, &yaml.Node{Value: "5"}))
+	require.False(t, MatchesConst("6", Integer, &yaml.Node{Value: "5"}))
+	require.True(t, MatchesConst("True", Boolean, &yaml.Node{Value: "true"}))
+	require.False(t, MatchesConst("cat", String, nil))
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package helpers
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+// RelaxRequiredProperties will walk a decoded JSON schema, and remove any property that is marked with the
+// supplied keyword (ReadOnly or WriteOnly) from the 'required' list of the schema that defines it. A readOnly
+// property is only required in a response, and a writeOnly property is only required in a request.
+// The schema is modified in place.
+func RelaxRequiredProperties(schema any, keyword string) {
+	walkSchema(schema, func(sch map[string]any) {
+		// the branches of an allOf are merged, so a branch can require a property defined by another branch.
+		props := mergedProperties(sch)
+		relaxRequired(sch, props, keyword)
+		if allOf, isList := sch["allOf"].([]any); isList {
+			for _, branch := range allOf {
+				if b, isMap := branch.(map[string]any); isMap {
+					relaxRequired(b, props, keyword)
+				}
+			}
+		}
+	})
+}
+
+// relaxRequired will remove any property marked with the keyword from the 'required' list of a schema.
+func relaxRequired(sch, props map[string]any, keyword string) {
+	if required, isList := sch["required"].([]any); isList {
+		sch["required"] = slices.DeleteFunc(required, func(name any) bool {
+			n, isString := name.(string)
+			return isString && isMarked(props[n], keyword)
+		})
+	}
+}
+
+// mergedProperties will return the properties defined by a schema, and by each of the branches of its allOf.
+func mergedProperties(sch map[string]any) map[string]any {
+	merged := make(map[string]any)
+	if allOf, isList := sch["allOf"].([]any); isList {
+		for _, branch := range allOf {
+			if b, isMap := branch.(map[string]any); isMap {
+				for name, prop := range mergedProperties(b) {
+					merged[name] = prop
+				}
+			}
+		}
+	}
+	if props, isMap := sch["properties"].(map[string]any); isMap {
+		for name, prop := range props {
+			merged[name] = prop
+		}
+	}
+	return merged
+}
+
+// FindMarkedProperties will walk a decoded JSON schema alongside a decoded value, and return a JSON pointer for
+// each property of the value that is marked with the supplied keyword (ReadOnly or WriteOnly) by the schema.
+func FindMarkedProperties(schema, value any, keyword string) []string {
+	var found []string
+	findMarkedProperties(schema, value, keyword, "", &found)
+	slices.Sort(found)
+	return found
+}
+
+func findMarkedProperties(schema, value any, keyword, pointer string, found *[]string) {
+	sch, ok := schema.(map[string]any)
+	if !ok {
+		return
+	}
+	switch v := value.(type) {
+	case map[string]any:
+		for name, propValue := range v {
+			propPointer := pointer + Slash + EscapeJSONPointer(name)
+			propSchema := propertySchema(sch, name)
+			if isMarked(propSchema, keyword) {
+				if !slices.Contains(*found, propPointer) {
+					*found = append(*found, propPointer)
+				}
+				continue
+			}
+			findMarkedProperties(propSchema, propValue, keyword, propPointer, found)
+		}
+	case []any:
+		prefixItems, _ := sch["prefixItems"].([]any)
+		for i, item := range v {
+			itemSchema := sch["items"]
+			if i < len(prefixItems) {
+				itemSchema = prefixItems[i]
+			}
+			findMarkedProperties(itemSchema, item, keyword, pointer+Slash+strconv.Itoa(i), found)
+		}
+	}
+	for _, kw := range []string{"allOf", "anyOf", "oneOf"} {
+		if list, isList := sch[kw].([]any); isList {
+			for _, sub := range list {
+				findMarkedProperties(sub, value, keyword, pointer, found)
+			}
+		}
+	}
+}
+
+// isMarked will return true if the schema has the supplied keyword set to true.
+func isMarked(schema any, keyword string) bool {
+	sch, ok := schema.(map[string]any)
+	if !ok {
+		return false
+	}
+	marked, _ := sch[keyword].(bool)
+	return marked
+}
+
+// propertySchema will return the schema a property of an object is validated against, using a decoded JSON schema.
+// Properties defined by 'properties' are preferred, then the first of 'patternProperties' that matches the name of
+// the property, and then 'additionalProperties'.
+func propertySchema(schema map[string]any, name string) any {
+	if props, ok := schema["properties"].(map[string]any); ok {
+		if propSchema, defined := props[name]; defined {
+			return propSchema
+		}
+	}
+	if patterns, ok := schema["patternProperties"].(map[string]any); ok {
+		keys := make([]string, 0, len(patterns))
+		for pattern := range patterns {
+			keys = append(keys, pattern)
+		}
+		slices.Sort(keys)
+		for _, pattern := range keys {
+			if rx, err := regexp.Compile(pattern); err == nil && rx.MatchString(name) {
+				return patterns[pattern]
+			}
+		}
+	}
+	return schema["additionalProperties"]
+}
@@ -0,0 +1,105 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package helpers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/stretchr/testify/require"
+)
+
+const readWriteSchema = `{
+  "type": "object",
+  "required": ["id", "name", "password"],
+  "properties": {
+    "id": {"type": "integer", "readOnly": true},
+    "name": {"type": "string"},
+    "password": {"type": "string", "writeOnly": true},
+    "a/b": {"type": "string", "readOnly": true},
+    "toppings": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "required": ["id"],
+        "properties": {"id": {"type": "integer", "readOnly": true}}
+      }
+    },
+    "extra": {"allOf": [{"properties": {"secret": {"type": "string", "writeOnly": true}}}]}
+  }
+}`
+
+func decodeReadWriteSchema(t *testing.T) map[string]any {
+	decoded, err := jsonschema.UnmarshalJSON(strings.NewReader(readWriteSchema))
+	require.NoError(t, err)
+	return decoded.(map[string]any)
+}
+
+func TestRelaxRequiredProperties(t *testing.T) {
+	schema := decodeReadWriteSchema(t)
+	RelaxRequiredProperties(schema, ReadOnly)
+
+	require.Equal(t, []any{"name", "password"}, schema["required"])
+	toppings := schema["properties"].(map[string]any)["toppings"].(map[string]any)
+	require.Empty(t, toppings["items"].(map[string]any)["required"])
+
+	schema = decodeReadWriteSchema(t)
+	RelaxRequiredProperties(schema, WriteOnly)
+	require.Equal(t, []any{"id", "name"}, schema["required"])
+}
+
+func TestRelaxRequiredProperties_NotASchema(t *testing.T) {
+	require.NotPanics(t, func() {
+		RelaxRequiredProperties(nil, ReadOnly)
+		RelaxRequiredProperties(true, ReadOnly)
+	})
+}
+
+func TestFindMarkedProperties(t *testing.T) {
+	schema := decodeReadWriteSchema(t)
+	value := map[string]any{
+		"id":       1,
+		"name":     "Big Mac",
+		"password": "secret",
+		"a/b":      "escaped",
+		"toppings": []any{map[string]any{"name": "cheese"}, map[string]any{"id": 2}},
+		"extra":    map[string]any{"secret": "shh"},
+	}
+
+	require.Equal(t, []string{"/a~1b", "/id", "/toppings/1/id"}, FindMarkedProperties(schema, value, ReadOnly))
+	require.Equal(t, []string{"/extra/secret", "/password"}, FindMarkedProperties(schema, value, WriteOnly))
+	require.Empty(t, FindMarkedProperties(schema, map[string]any{"name": "Big Mac"}, ReadOnly))
+	require.Empty(t, FindMarkedProperties(nil, value, ReadOnly))
+}
+
+func TestFindMarkedProperties_PatternProperties(t *testing.T) {
+	schema := map[string]any{
+		"properties":           map[string]any{"name": map[string]any{"type": "string"}},
+		"patternProperties":    map[string]any{"^x-": map[string]any{"readOnly": true}},
+		"additionalProperties": map[string]any{"writeOnly": true},
+	}
+	value := map[string]any{"name": "Big Mac", "x-id": 1, "secret": "shh"}
+
+	require.Equal(t, []string{"/x-id"}, FindMarkedProperties(schema, value, ReadOnly))
+	require.Equal(t, []string{"/secret"}, FindMarkedProperties(schema, value, WriteOnly))
+}
+
+func TestRelaxRequiredProperties_AllOf(t *testing.T) {
+	schema := map[string]any{
+		"required": []any{"id", "name"},
+		"allOf": []any{
+			map[string]any{"properties": map[string]any{"id": map[string]any{"readOnly": true}}},
+			map[string]any{
+				"required":   []any{"id", "patties"},
+				"properties": map[string]any{"patties": map[string]any{"type": "integer"}},
+			},
+		},
+	}
+
+	RelaxRequiredProperties(schema, ReadOnly)
+
+	require.Equal(t, []any{"name"}, schema["required"])
+	require.Equal(t, []any{"patties"}, schema["allOf"].([]any)[1].(map[string]any)["required"])
+}
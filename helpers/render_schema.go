@@ -0,0 +1,177 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package helpers
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+)
+
+// unmodelledKeywords are the JSON schema keywords that are not part of the libopenapi schema model, so they are
+// dropped when a schema is rendered. The 'contentSchema' keyword is restored as it's written, so any $ref inside
+// it is not resolved.
+var unmodelledKeywords = []string{DependentRequired, ContentEncoding, ContentMediaType, ContentSchema}
+
+// RenderSchemaInline will render a schema inline, in the same way as base.Schema.RenderInline, and restore the
+// keywords of the schema and its sub-schemas that are not part of the libopenapi schema model, such as
+// 'dependentRequired' and 'contentEncoding'. Without them, those keywords would never be enforced.
+//
+// The rendered schema is only re-encoded when a keyword has been restored, so the rendering of any other schema
+// is unchanged.
+func RenderSchemaInline(schema *base.Schema) ([]byte, error) {
+	rendered, err := schema.RenderInline()
+	if err != nil {
+		return rendered, err
+	}
+	var doc yaml.Node
+	if yaml.Unmarshal(rendered, &doc) != nil || len(doc.Content) == 0 {
+		return rendered, nil
+	}
+	if !restoreKeywords(schema, doc.Content[0]) {
+		return rendered, nil
+	}
+	restored, err := yaml.Marshal(&doc)
+	if err != nil {
+		return rendered, nil
+	}
+	return restored, nil
+}
+
+// restoreKeywords will copy the unmodelled keywords from the source of a schema into the rendered node of that
+// schema, and then do the same for each sub-schema found in the rendered node. True is returned if any keyword
+// was restored.
+func restoreKeywords(schema *base.Schema, node *yaml.Node) bool {
+	if schema == nil || node == nil || node.Kind != yaml.MappingNode {
+		return false
+	}
+	restored := false
+	if low := schema.GoLow(); low != nil && low.RootNode != nil {
+		for _, keyword := range unmodelledKeywords {
+			if mappingValue(node, keyword) != nil {
+				continue
+			}
+			if source := mappingValue(low.RootNode, keyword); source != nil {
+				node.Content = append(node.Content,
+					&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: keyword}, source)
+				restored = true
+			}
+		}
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyword, sub := node.Content[i].Value, node.Content[i+1]
+		switch keyword {
+		case "not", "if", "then", "else", "contains", "propertyNames", "unevaluatedItems":
+			restored = restoreKeywords(proxySchema(singleSchema(schema, keyword)), sub) || restored
+		case "items", "additionalProperties", "unevaluatedProperties":
+			restored = restoreKeywords(proxySchema(dynamicSchema(schema, keyword)), sub) || restored
+		case "allOf", "anyOf", "oneOf", "prefixItems":
+			proxies := listSchemas(schema, keyword)
+			for j := 0; j < len(sub.Content) && j < len(proxies); j++ {
+				restored = restoreKeywords(proxySchema(proxies[j]), sub.Content[j]) || restored
+			}
+		case "properties", "patternProperties", "dependentSchemas":
+			proxies := mapSchemas(schema, keyword)
+			if proxies == nil {
+				continue
+			}
+			for j := 0; j+1 < len(sub.Content); j += 2 {
+				proxy := proxies.GetOrZero(sub.Content[j].Value)
+				restored = restoreKeywords(proxySchema(proxy), sub.Content[j+1]) || restored
+			}
+		}
+	}
+	return restored
+}
+
+// mappingValue returns the value of a key in a mapping node, or nil if the node is not a mapping or has no such key.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// proxySchema returns the schema of a proxy, or nil if there is no proxy.
+func proxySchema(proxy *base.SchemaProxy) *base.Schema {
+	if proxy == nil {
+		return nil
+	}
+	return proxy.Schema()
+}
+
+// singleSchema returns the sub-schema held by a keyword of a schema that contains a single schema.
+func singleSchema(schema *base.Schema, keyword string) *base.SchemaProxy {
+	switch keyword {
+	case "not":
+		return schema.Not
+	case "if":
+		return schema.If
+	case "then":
+		return schema.Then
+	case "else":
+		return schema.Else
+	case "contains":
+		return schema.Contains
+	case "propertyNames":
+		return schema.PropertyNames
+	case "unevaluatedItems":
+		return schema.UnevaluatedItems
+	}
+	return nil
+}
+
+// dynamicSchema returns the sub-schema held by a keyword of a schema that contains either a schema or a boolean.
+func dynamicSchema(schema *base.Schema, keyword string) *base.SchemaProxy {
+	var value *base.DynamicValue[*base.SchemaProxy, bool]
+	switch keyword {
+	case "items":
+		value = schema.Items
+	case "additionalProperties":
+		value = schema.AdditionalProperties
+	case "unevaluatedProperties":
+		value = schema.UnevaluatedProperties
+	}
+	if value == nil || !value.IsA() {
+		return nil
+	}
+	return value.A
+}
+
+// listSchemas returns the list of sub-schemas held by a keyword of a schema.
+func listSchemas(schema *base.Schema, keyword string) []*base.SchemaProxy {
+	switch keyword {
+	case "allOf":
+		return schema.AllOf
+	case "anyOf":
+		return schema.AnyOf
+	case "oneOf":
+		return schema.OneOf
+	case "prefixItems":
+		return schema.PrefixItems
+	}
+	return nil
+}
+
+// mapSchemas returns the map of sub-schemas held by a keyword of a schema.
+func mapSchemas(schema *base.Schema, keyword string) *orderedmap.Map[string, *base.SchemaProxy] {
+	switch keyword {
+	case "properties":
+		return schema.Properties
+	case "patternProperties":
+		return schema.PatternProperties
+	case "dependentSchemas":
+		return schema.DependentSchemas
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package helpers
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRenderSchemaInline(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Card:
+      type: object
+      properties:
+        number:
+          type: string
+        cvv:
+          type: string
+      dependentRequired:
+        number: [cvv]
+    Payment:
+      type: object
+      properties:
+        card:
+          $ref: '#/components/schemas/Card'
+        amount:
+          type: number
+        currency:
+          type: string
+      dependentRequired:
+        amount: [currency]
+      dependentSchemas:
+        currency:
+          required: [amount]
+    Plain:
+      type: object
+      properties:
+        name:
+          type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	rendered, err := RenderSchemaInline(m.Model.Components.Schemas.GetOrZero("Payment").Schema())
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, yaml.Unmarshal(rendered, &decoded))
+	require.Equal(t, map[string]any{"amount": []any{"currency"}}, decoded[DependentRequired])
+	require.Equal(t, map[string]any{"required": []any{"amount"}}, decoded["dependentSchemas"].(map[string]any)["currency"])
+
+	// the keyword is restored for referenced sub-schemas too.
+	card := decoded["properties"].(map[string]any)["card"].(map[string]any)
+	require.Equal(t, map[string]any{"number": []any{"cvv"}}, card[DependentRequired])
+
+	// a schema without the keyword is rendered as it is.
+	plain := m.Model.Components.Schemas.GetOrZero("Plain").Schema()
+	rendered, err = RenderSchemaInline(plain)
+	require.NoError(t, err)
+	expected, _ := plain.RenderInline()
+	require.Equal(t, expected, rendered)
+}
+
+func TestRenderSchemaInline_ContentKeywords(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Recipe:
+      type: object
+      properties:
+        steps:
+          type: string
+          contentEncoding: base64
+          contentMediaType: application/json
+          contentSchema:
+            type: array`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	rendered, err := RenderSchemaInline(m.Model.Components.Schemas.GetOrZero("Recipe").Schema())
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, yaml.Unmarshal(rendered, &decoded))
+	steps := decoded["properties"].(map[string]any)["steps"].(map[string]any)
+	require.Equal(t, "base64", steps[ContentEncoding])
+	require.Equal(t, "application/json", steps[ContentMediaType])
+	require.Equal(t, map[string]any{"type": "array"}, steps[ContentSchema])
+}
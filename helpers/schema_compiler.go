@@ -0,0 +1,168 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package helpers
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/pb33f/libopenapi-validator/cache"
+	"github.com/pb33f/libopenapi-validator/config"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// builtInFormats are the names of the formats the jsonschema compiler understands without registration.
+var builtInFormats = []string{
+	"json-pointer", "relative-json-pointer", "uuid", "duration", "period", "ipv4", "ipv6", "hostname",
+	"email", "date", "time", "date-time", "uri", "iri", "uri-reference", "iri-reference", "uri-template", "semver",
+}
+
+// NewCompiler will create a new jsonschema compiler, configured with the loader used for remote references and
+// any custom formats, regex engine, content assertion and schema draft defined by the supplied options.
+//
+// Formats are annotations by default, unless format assertion is enabled by the options. When custom formats are
+// registered without format assertion, format assertion is still switched on in the compiler, and the built-in
+// formats are replaced with formats that accept anything. This means the custom formats are checked, and the
+// built-in formats keep behaving as annotations. Unknown formats are always ignored.
+func NewCompiler(options *config.ValidationOptions) *jsonschema.Compiler {
+	compiler := jsonschema.NewCompiler()
+	compiler.UseLoader(NewCompilerLoader())
+	if options != nil && options.RegexEngine != nil {
+		compiler.UseRegexpEngine(regexpEngine(options.RegexEngine))
+	}
+	if options != nil && options.ContentAssertion {
+		compiler.AssertContent()
+	}
+	if options != nil {
+		if draft, ok := schemaDrafts[options.SchemaDraft]; ok {
+			compiler.DefaultDraft(draft)
+		}
+	}
+	if options == nil || (!options.FormatAssertion && len(options.Formats) == 0) {
+		return compiler
+	}
+	compiler.AssertFormat()
+	if !options.FormatAssertion {
+		for _, name := range builtInFormats {
+			if _, ok := options.Formats[name]; !ok {
+				compiler.RegisterFormat(&jsonschema.Format{Name: name, Validate: func(any) error { return nil }})
+			}
+		}
+	}
+	for name, validator := range options.Formats {
+		compiler.RegisterFormat(&jsonschema.Format{Name: name, Validate: validator})
+	}
+	return compiler
+}
+
+// schemaDrafts maps the schema drafts of the options to the drafts of the jsonschema compiler.
+var schemaDrafts = map[config.SchemaDraft]*jsonschema.Draft{
+	config.SchemaDraft4:    jsonschema.Draft4,
+	config.SchemaDraft6:    jsonschema.Draft6,
+	config.SchemaDraft7:    jsonschema.Draft7,
+	config.SchemaDraft2019: jsonschema.Draft2019,
+	config.SchemaDraft2020: jsonschema.Draft2020,
+}
+
+// regexpEngine will adapt a RegexEngine from the options into the engine used by the jsonschema compiler.
+func regexpEngine(engine config.RegexEngine) jsonschema.RegexpEngine {
+	return func(pattern string) (jsonschema.Regexp, error) {
+		re, err := engine(pattern)
+		if err != nil {
+			return nil, err
+		}
+		return &customRegexp{Regexp: re, pattern: pattern}, nil
+	}
+}
+
+// customRegexp is a Regexp compiled by a custom engine, the jsonschema compiler also needs the source pattern.
+type customRegexp struct {
+	config.Regexp
+	pattern string
+}
+
+func (r *customRegexp) String() string {
+	return r.pattern
+}
+
+// CompileSchema will decode a rendered JSON schema, prepare it for validation and compile it. The name is used as
+// the name of the schema resource. The validation type controls how the schema is prepared, request bodies do not
+// require readOnly properties and responses do not require writeOnly properties. The 'nullable' keyword of
+// OpenAPI 3.0 schemas is translated into null types.
+//
+// The compiled schema and the prepared, decoded schema are returned. Both are cached in the schema cache of the
+// options, keyed by the name, the validation type, the options that change how the schema is compiled and the
+// content of the schema, so a schema is only compiled once, even when the cache is shared by validators with
+// different options.
+// The decoded schema is shared, and must not be modified. A pattern that cannot be compiled by the regex engine
+// is returned as an *InvalidPatternError, and a format that is not known is returned as an *UnknownFormatError
+// when the options report unknown formats.
+func CompileSchema(name string, schema *base.Schema, jsonSchema []byte, validationType string,
+	options *config.ValidationOptions) (*jsonschema.Schema, any, error) {
+
+	is30 := IsOpenAPI30Schema(schema)
+	var schemaCache *cache.SchemaCache
+	var key string
+	if options != nil && options.SchemaCache != nil {
+		if optionsKey, ok := compilerOptionsKey(options); ok {
+			schemaCache = options.SchemaCache
+			key = fmt.Sprintf("%s:%s:%t:%s:%x", name, validationType, is30, optionsKey, sha256.Sum256(jsonSchema))
+			if entry, ok := schemaCache.Load(key); ok {
+				return entry.CompiledSchema, entry.DecodedSchema, nil
+			}
+		}
+	}
+
+	decodedSchema, _ := jsonschema.UnmarshalJSON(strings.NewReader(string(jsonSchema)))
+
+	// a pattern the regex engine cannot compile would fail the whole schema, so it's reported on its own, or removed
+	// from the schema if the options skip invalid patterns.
+	if err := CheckSchemaPatterns(decodedSchema, options); err != nil {
+		return nil, decodedSchema, err
+	}
+	if err := CheckSchemaFormats(decodedSchema, options); err != nil {
+		return nil, decodedSchema, err
+	}
+	switch validationType {
+	case RequestBodyValidation:
+		// readOnly properties are only required in responses, so they are not required in a request, unless the
+		// options require them.
+		if options == nil || !options.RequireReadOnly {
+			RelaxRequiredProperties(decodedSchema, ReadOnly)
+		}
+	case ResponseBodyValidation:
+		// writeOnly properties are only required in requests, so they are not required in a response.
+		RelaxRequiredProperties(decodedSchema, WriteOnly)
+	}
+
+	// OpenAPI 3.0 schemas use 'nullable' to allow null values, which JSON schema does not understand.
+	if is30 {
+		ApplyNullable(decodedSchema)
+	}
+
+	compiler := NewCompiler(options)
+	resource := fmt.Sprintf("%s.json", name)
+	_ = compiler.AddResource(resource, decodedSchema)
+	compiled, err := compiler.Compile(resource)
+	if err != nil {
+		return nil, decodedSchema, err
+	}
+	schemaCache.Store(key, &cache.SchemaCacheEntry{CompiledSchema: compiled, DecodedSchema: decodedSchema})
+	return compiled, decodedSchema, nil
+}
+
+// compilerOptionsKey will build the part of a schema cache key that identifies the options a schema is compiled
+// with. Custom formats and regex engines are identified by the options that set them, false is returned when they
+// cannot be identified, so the schema is not cached.
+func compilerOptionsKey(options *config.ValidationOptions) (string, bool) {
+	functionsKey, ok := options.CompilerFunctionsKey()
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("%t:%s:%t:%t:%t:%t:%s", options.RequireReadOnly, options.SchemaDraft,
+		options.FormatAssertion, options.ContentAssertion, options.UnknownFormatError, options.SkipInvalidPatterns,
+		functionsKey), true
+}
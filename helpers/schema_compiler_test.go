@@ -0,0 +1,293 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package helpers
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi-validator/config"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/stretchr/testify/require"
+)
+
+func compileTestSchema(t *testing.T, compiler *jsonschema.Compiler, schema string) *jsonschema.Schema {
+	decoded, err := jsonschema.UnmarshalJSON(strings.NewReader(schema))
+	require.NoError(t, err)
+	require.NoError(t, compiler.AddResource("schema.json", decoded))
+	jsch, err := compiler.Compile("schema.json")
+	require.NoError(t, err)
+	return jsch
+}
+
+func TestNewCompiler_NoFormats(t *testing.T) {
+	jsch := compileTestSchema(t, NewCompiler(nil), `{"type": "string", "format": "email"}`)
+
+	// formats are annotations by default.
+	require.NoError(t, jsch.Validate("not an email"))
+}
+
+func TestNewCompiler_CustomFormat(t *testing.T) {
+	opts := config.NewValidationOptions(config.WithCustomFormat("sku", func(v any) error {
+		if s, ok := v.(string); ok && !strings.HasPrefix(s, "SKU-") {
+			return fmt.Errorf("'%s' is not a sku", s)
+		}
+		return nil
+	}))
+	jsch := compileTestSchema(t, NewCompiler(opts), `{"type": "object", "properties": {
+		"sku": {"type": "string", "format": "sku"},
+		"email": {"type": "string", "format": "email"},
+		"thing": {"type": "string", "format": "unknown"}}}`)
+
+	require.NoError(t, jsch.Validate(map[string]any{"sku": "SKU-1234", "email": "nope", "thing": "anything"}))
+	require.Error(t, jsch.Validate(map[string]any{"sku": "1234"}))
+}
+
+func TestNewCompiler_FormatAssertion(t *testing.T) {
+	tests := []struct {
+		format string
+		valid  string
+		bad    string
+	}{
+		{"date-time", "2024-01-31T12:30:00Z", "31/01/2024 12:30"},
+		{"email", "burgers@pb33f.io", "not an email"},
+		{"uuid", "3e4666bf-d5e5-4aa7-b8ce-cefe41c7568a", "not-a-uuid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			schema := fmt.Sprintf(`{"type": "string", "format": "%s"}`, tt.format)
+
+			// annotation mode, anything goes.
+			jsch := compileTestSchema(t, NewCompiler(config.NewValidationOptions()), schema)
+			require.NoError(t, jsch.Validate(tt.valid))
+			require.NoError(t, jsch.Validate(tt.bad))
+
+			// assertion mode.
+			jsch = compileTestSchema(t, NewCompiler(config.NewValidationOptions(config.WithFormatAssertions())), schema)
+			require.NoError(t, jsch.Validate(tt.valid))
+			require.Error(t, jsch.Validate(tt.bad))
+		})
+	}
+}
+
+func TestNewCompiler_FormatAssertion_CustomFormat(t *testing.T) {
+	opts := config.NewValidationOptions(config.WithFormatAssertions(),
+		config.WithCustomFormat("sku", func(v any) error { return fmt.Errorf("never a sku") }))
+	jsch := compileTestSchema(t, NewCompiler(opts), `{"type": "object", "properties": {
+		"sku": {"type": "string", "format": "sku"},
+		"email": {"type": "string", "format": "email"},
+		"thing": {"type": "string", "format": "unknown"}}}`)
+
+	require.NoError(t, jsch.Validate(map[string]any{"email": "burgers@pb33f.io", "thing": "anything"}))
+	require.Error(t, jsch.Validate(map[string]any{"email": "nope"}))
+	require.Error(t, jsch.Validate(map[string]any{"sku": "1234"}))
+}
+
+func TestCompileSchema_Cached(t *testing.T) {
+	options := config.NewValidationOptions()
+	jsonSchema := []byte(`{"type": "object", "required": ["id"], "properties": {"id": {"type": "string", "readOnly": true}}}`)
+
+	first, decoded, err := CompileSchema("requestBody", nil, jsonSchema, RequestBodyValidation, options)
+	require.NoError(t, err)
+	require.NoError(t, first.Validate(map[string]any{}))
+	require.NotNil(t, decoded)
+	require.Equal(t, 1, options.SchemaCache.Len())
+
+	second, _, err := CompileSchema("requestBody", nil, jsonSchema, RequestBodyValidation, options)
+	require.NoError(t, err)
+	require.Same(t, first, second)
+
+	// the same schema used for a response is prepared differently, so it is cached separately.
+	response, _, err := CompileSchema("requestBody", nil, jsonSchema, ResponseBodyValidation, options)
+	require.NoError(t, err)
+	require.NotSame(t, first, response)
+	require.Error(t, response.Validate(map[string]any{}))
+	require.Equal(t, 2, options.SchemaCache.Len())
+}
+
+func TestCompileSchema_SharedCache(t *testing.T) {
+	schemaCache := config.NewValidationOptions().SchemaCache
+	jsonSchema := []byte(`{"type": "string", "format": "email"}`)
+	rejectAll := func(any) error { return fmt.Errorf("rejected") }
+	engine := func(pattern string) (config.Regexp, error) { return upperCaseRegexp{}, nil }
+
+	// validators that share a cache, but compile schemas differently, must not use each other's schemas.
+	optionSets := [][]config.Option{
+		nil,
+		{config.WithFormatAssertions()},
+		{config.WithContentAssertions()},
+		{config.WithUnknownFormatError()},
+		{config.WithSkipInvalidPatterns()},
+		{config.WithRequireReadOnly()},
+		{config.WithSchemaDraft(config.SchemaDraft7)},
+		{config.WithCustomFormat("email", rejectAll)},
+		{config.WithRegexEngine(engine)},
+	}
+	var compiled []*jsonschema.Schema
+	for _, opts := range optionSets {
+		options := config.NewValidationOptions(append(opts, config.WithSchemaCache(schemaCache))...)
+		jsch, _, err := CompileSchema("schema", nil, jsonSchema, RequestBodyValidation, options)
+		require.NoError(t, err)
+		for _, other := range compiled {
+			require.NotSame(t, other, jsch)
+		}
+		compiled = append(compiled, jsch)
+
+		// the same options use the schema that has been cached.
+		again, _, err := CompileSchema("schema", nil, jsonSchema, RequestBodyValidation,
+			config.NewValidationOptions(append(opts, config.WithSchemaCache(schemaCache))...))
+		require.NoError(t, err)
+		require.Same(t, jsch, again)
+	}
+	require.Equal(t, len(optionSets), schemaCache.Len())
+
+	// formats are annotations by default, and assertions once enabled.
+	require.NoError(t, compiled[0].Validate("burger"))
+	require.Error(t, compiled[1].Validate("burger"))
+	require.Error(t, compiled[7].Validate("burger@example.com"))
+}
+
+func TestCompileSchema_SharedCacheClosures(t *testing.T) {
+	schemaCache := config.NewValidationOptions().SchemaCache
+	jsonSchema := []byte(`{"type": "string", "format": "code", "pattern": "^[a-z]+$"}`)
+
+	// closures built by the same function share their code, but not the state they capture.
+	minLength := func(length int) config.FormatValidator {
+		return func(value any) error {
+			if s, ok := value.(string); ok && len(s) < length {
+				return fmt.Errorf("shorter than %d", length)
+			}
+			return nil
+		}
+	}
+	matching := func(matches bool) config.RegexEngine {
+		return func(pattern string) (config.Regexp, error) { return constantRegexp(matches), nil }
+	}
+
+	compile := func(opts ...config.Option) *jsonschema.Schema {
+		options := config.NewValidationOptions(append(opts, config.WithSchemaCache(schemaCache))...)
+		jsch, _, err := CompileSchema("schema", nil, jsonSchema, RequestBodyValidation, options)
+		require.NoError(t, err)
+		return jsch
+	}
+
+	short, long := config.WithCustomFormat("code", minLength(2)), config.WithCustomFormat("code", minLength(10))
+	require.NoError(t, compile(short).Validate("burger"))
+	require.Error(t, compile(long).Validate("burger"))
+	require.NoError(t, compile(short).Validate("burger"))
+
+	matchAll, matchNone := config.WithRegexEngine(matching(true)), config.WithRegexEngine(matching(false))
+	require.NoError(t, compile(matchAll).Validate("burger"))
+	require.Error(t, compile(matchNone).Validate("burger"))
+
+	// the same option values use the schema that has been cached.
+	require.Same(t, compile(short, matchAll), compile(short, matchAll))
+	require.NotSame(t, compile(short, matchAll), compile(short, matchNone))
+	require.Equal(t, 6, schemaCache.Len())
+}
+
+func TestCompileSchema_UnidentifiedFunctions(t *testing.T) {
+	jsonSchema := []byte(`{"type": "string", "format": "code"}`)
+
+	// functions assigned directly cannot be identified, so the schemas compiled with them are not cached.
+	options := config.NewValidationOptions()
+	options.Formats = config.NewFormatRegistry().Register("code", func(any) error { return nil })
+	first, _, err := CompileSchema("schema", nil, jsonSchema, RequestBodyValidation, options)
+	require.NoError(t, err)
+	second, _, err := CompileSchema("schema", nil, jsonSchema, RequestBodyValidation, options)
+	require.NoError(t, err)
+	require.NotSame(t, first, second)
+
+	options = config.NewValidationOptions()
+	options.RegexEngine = func(pattern string) (config.Regexp, error) { return constantRegexp(true), nil }
+	_, _, err = CompileSchema("schema", nil, jsonSchema, RequestBodyValidation, options)
+	require.NoError(t, err)
+	require.Equal(t, 0, options.SchemaCache.Len())
+}
+
+func TestCompileSchema_NoCache(t *testing.T) {
+	options := config.NewValidationOptions(config.WithSchemaCache(nil))
+	jsonSchema := []byte(`{"type": "string"}`)
+
+	first, _, err := CompileSchema("schema", nil, jsonSchema, Schema, options)
+	require.NoError(t, err)
+	second, _, err := CompileSchema("schema", nil, jsonSchema, Schema, options)
+	require.NoError(t, err)
+	require.NotSame(t, first, second)
+}
+
+func TestCompileSchema_Invalid(t *testing.T) {
+	options := config.NewValidationOptions()
+	_, _, err := CompileSchema("schema", nil, []byte(`{"type": 12}`), Schema, options)
+	require.Error(t, err)
+	require.Equal(t, 0, options.SchemaCache.Len())
+}
+
+// upperCaseRegexp stands in for an ECMA regex engine, matching the lookahead pattern used by the tests.
+type upperCaseRegexp struct{}
+
+func (upperCaseRegexp) MatchString(s string) bool {
+	return strings.ToLower(s) != s
+}
+
+// constantRegexp matches every value, or no value at all.
+type constantRegexp bool
+
+func (r constantRegexp) MatchString(string) bool {
+	return bool(r)
+}
+
+func TestNewCompiler_SchemaDraft(t *testing.T) {
+	schema := `{"type": "array", "prefixItems": [{"type": "number"}]}`
+
+	// 'prefixItems' was added in 2020-12, older drafts ignore it.
+	jsch := compileTestSchema(t, NewCompiler(config.NewValidationOptions(
+		config.WithSchemaDraft(config.SchemaDraft7))), schema)
+	require.NoError(t, jsch.Validate([]any{"burger"}))
+
+	jsch = compileTestSchema(t, NewCompiler(config.NewValidationOptions(
+		config.WithSchemaDraft(config.SchemaDraft2020))), schema)
+	require.Error(t, jsch.Validate([]any{"burger"}))
+
+	// a schema that declares its own draft is compiled with that draft.
+	jsch = compileTestSchema(t, NewCompiler(config.NewValidationOptions(
+		config.WithSchemaDraft(config.SchemaDraft7))),
+		`{"$schema": "https://json-schema.org/draft/2020-12/schema", "prefixItems": [{"type": "number"}]}`)
+	require.Error(t, jsch.Validate([]any{"burger"}))
+}
+
+func TestNewCompiler_RegexEngine(t *testing.T) {
+	schema := `{"type": "string", "pattern": "^(?=.*[A-Z]).+$"}`
+
+	// Go's regexp package does not support lookaheads.
+	decoded, err := jsonschema.UnmarshalJSON(strings.NewReader(schema))
+	require.NoError(t, err)
+	compiler := NewCompiler(config.NewValidationOptions())
+	require.NoError(t, compiler.AddResource("schema.json", decoded))
+	_, err = compiler.Compile("schema.json")
+	require.Error(t, err)
+
+	var patterns []string
+	opts := config.NewValidationOptions(config.WithRegexEngine(func(pattern string) (config.Regexp, error) {
+		patterns = append(patterns, pattern)
+		return upperCaseRegexp{}, nil
+	}))
+	jsch := compileTestSchema(t, NewCompiler(opts), schema)
+	require.Contains(t, patterns, "^(?=.*[A-Z]).+$")
+	require.NoError(t, jsch.Validate("Burger"))
+	require.Error(t, jsch.Validate("burger"))
+}
+
+func TestNewCompiler_RegexEngine_Error(t *testing.T) {
+	opts := config.NewValidationOptions(config.WithRegexEngine(func(pattern string) (config.Regexp, error) {
+		return nil, fmt.Errorf("unsupported pattern '%s'", pattern)
+	}))
+	decoded, err := jsonschema.UnmarshalJSON(strings.NewReader(`{"type": "string", "pattern": "^a$"}`))
+	require.NoError(t, err)
+	compiler := NewCompiler(opts)
+	require.NoError(t, compiler.AddResource("schema.json", decoded))
+	_, err = compiler.Compile("schema.json")
+	require.Error(t, err)
+}
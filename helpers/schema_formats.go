@@ -0,0 +1,84 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package helpers
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/pb33f/libopenapi-validator/config"
+)
+
+// UnknownFormatError is returned when a schema uses a 'format' that is neither built in, nor registered as a custom
+// format, and the options report unknown formats as errors.
+type UnknownFormatError struct {
+	// Format is the name of the format that is not known.
+	Format string
+
+	// Location is a JSON pointer to the keyword in the schema that holds the format.
+	Location string
+}
+
+func (e *UnknownFormatError) Error() string {
+	return fmt.Sprintf("format '%s' at '%s' is not a known format", e.Format, e.Location)
+}
+
+// CheckSchemaFormats will look up every 'format' keyword in a decoded schema, and return an UnknownFormatError for
+// the first format that is not built in or registered with the options. Unknown formats are ignored by the compiler,
+// so a misspelled format would never be checked. Nothing is reported unless the options enable UnknownFormatError.
+func CheckSchemaFormats(decodedSchema any, options *config.ValidationOptions) error {
+	if options == nil || !options.UnknownFormatError {
+		return nil
+	}
+	known := func(format string) bool {
+		if slices.Contains(builtInFormats, format) {
+			return true
+		}
+		_, ok := options.Formats[format]
+		return ok
+	}
+	return checkSchemaFormats(decodedSchema, "", known)
+}
+
+func checkSchemaFormats(node any, location string, known func(string) bool) error {
+	switch n := node.(type) {
+	case []any:
+		for i, item := range n {
+			if err := checkSchemaFormats(item, location+Slash+strconv.Itoa(i), known); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		for _, key := range slices.Sorted(maps.Keys(n)) {
+			keyLocation := location + Slash + EscapeJSONPointer(key)
+			switch {
+			case slices.Contains(valueKeywords, key):
+				continue
+			case key == "format":
+				format, ok := n[key].(string)
+				if ok && !known(format) {
+					return &UnknownFormatError{Format: format, Location: keyLocation}
+				}
+			case slices.Contains(schemaNameKeywords, key):
+				schemas, ok := n[key].(map[string]any)
+				if !ok {
+					continue
+				}
+				for _, name := range slices.Sorted(maps.Keys(schemas)) {
+					nameLocation := keyLocation + Slash + EscapeJSONPointer(name)
+					if err := checkSchemaFormats(schemas[name], nameLocation, known); err != nil {
+						return err
+					}
+				}
+			default:
+				if err := checkSchemaFormats(n[key], keyLocation, known); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
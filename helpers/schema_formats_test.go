@@ -0,0 +1,53 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package helpers
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi-validator/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSchemaFormats_Disabled(t *testing.T) {
+	decoded := decodeTestSchema(t, `{"type": "string", "format": "date-tim"}`)
+
+	require.NoError(t, CheckSchemaFormats(decoded, nil))
+	require.NoError(t, CheckSchemaFormats(decoded, config.NewValidationOptions()))
+}
+
+func TestCheckSchemaFormats_Unknown(t *testing.T) {
+	decoded := decodeTestSchema(t, `{"type": "object", "properties": {
+		"format": {"type": "string", "format": "date-time", "examples": [{"format": "nope"}]},
+		"bakedAt": {"type": "string", "format": "date-tim"}}}`)
+
+	err := CheckSchemaFormats(decoded, config.NewValidationOptions(config.WithUnknownFormatError()))
+	var formatErr *UnknownFormatError
+	require.ErrorAs(t, err, &formatErr)
+	require.Equal(t, "date-tim", formatErr.Format)
+	require.Equal(t, "/properties/bakedAt/format", formatErr.Location)
+	require.Equal(t, "format 'date-tim' at '/properties/bakedAt/format' is not a known format", formatErr.Error())
+}
+
+func TestCheckSchemaFormats_CustomFormat(t *testing.T) {
+	decoded := decodeTestSchema(t, `{"type": "string", "format": "iban"}`)
+
+	opts := config.NewValidationOptions(config.WithUnknownFormatError(),
+		config.WithCustomFormat("iban", func(any) error { return nil }))
+	require.NoError(t, CheckSchemaFormats(decoded, opts))
+}
+
+func TestCompileSchema_UnknownFormat(t *testing.T) {
+	jsonSchema := []byte(`{"type": "array", "items": {"type": "string", "format": "emial"}}`)
+
+	_, _, err := CompileSchema("test", nil, jsonSchema, RequestBodyValidation,
+		config.NewValidationOptions(config.WithUnknownFormatError()))
+	var formatErr *UnknownFormatError
+	require.ErrorAs(t, err, &formatErr)
+	require.Equal(t, "/items/format", formatErr.Location)
+
+	jsch, _, err := CompileSchema("test", nil, jsonSchema, RequestBodyValidation, config.NewValidationOptions())
+	require.NoError(t, err)
+	require.NotNil(t, jsch)
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package helpers
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/pb33f/libopenapi-validator/config"
+)
+
+// InvalidPatternError is returned when a schema contains a regular expression, in a 'pattern' keyword or as a key
+// of 'patternProperties', that the regex engine cannot compile.
+type InvalidPatternError struct {
+	// Pattern is the regular expression that cannot be compiled.
+	Pattern string
+
+	// Location is a JSON pointer to the keyword in the schema that holds the pattern.
+	Location string
+
+	// Err is the error returned by the regex engine.
+	Err error
+}
+
+func (e *InvalidPatternError) Error() string {
+	return fmt.Sprintf("pattern '%s' at '%s' cannot be compiled: %s", e.Pattern, e.Location, e.Err.Error())
+}
+
+func (e *InvalidPatternError) Unwrap() error {
+	return e.Err
+}
+
+// schemaNameKeywords are the keywords that hold a map of names to schemas, rather than a schema.
+var schemaNameKeywords = []string{"properties", "patternProperties", "$defs", "definitions", "dependentSchemas"}
+
+// valueKeywords are the keywords that hold values rather than schemas, so they are never searched for patterns.
+var valueKeywords = []string{"enum", "const", "default", "example", "examples"}
+
+// CheckSchemaPatterns will compile every regular expression in a decoded schema with the regex engine of the
+// options, so a pattern that the engine does not support is reported as an InvalidPatternError, rather than
+// failing the compilation of the whole schema. When the options skip invalid patterns, they are removed from
+// the schema and a warning is logged instead, so the rest of the schema is still validated.
+func CheckSchemaPatterns(decodedSchema any, options *config.ValidationOptions) error {
+	compile := func(pattern string) error {
+		_, err := regexp.Compile(pattern)
+		return err
+	}
+	skip := false
+	if options != nil {
+		if options.RegexEngine != nil {
+			compile = func(pattern string) error {
+				_, err := options.RegexEngine(pattern)
+				return err
+			}
+		}
+		skip = options.SkipInvalidPatterns
+	}
+	return checkSchemaPatterns(decodedSchema, "", compile, skip)
+}
+
+func checkSchemaPatterns(node any, location string, compile func(string) error, skip bool) error {
+	switch n := node.(type) {
+	case []any:
+		for i, item := range n {
+			if err := checkSchemaPatterns(item, location+Slash+strconv.Itoa(i), compile, skip); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		for _, key := range slices.Sorted(maps.Keys(n)) {
+			keyLocation := location + Slash + EscapeJSONPointer(key)
+			switch {
+			case slices.Contains(valueKeywords, key):
+				continue
+			case key == "pattern":
+				pattern, ok := n[key].(string)
+				if !ok {
+					continue
+				}
+				keep, err := checkPattern(pattern, keyLocation, compile, skip)
+				if err != nil {
+					return err
+				}
+				if !keep {
+					delete(n, key)
+				}
+			case slices.Contains(schemaNameKeywords, key):
+				schemas, ok := n[key].(map[string]any)
+				if !ok {
+					continue
+				}
+				for _, name := range slices.Sorted(maps.Keys(schemas)) {
+					nameLocation := keyLocation + Slash + EscapeJSONPointer(name)
+					if key == "patternProperties" {
+						keep, err := checkPattern(name, nameLocation, compile, skip)
+						if err != nil {
+							return err
+						}
+						if !keep {
+							delete(schemas, name)
+							continue
+						}
+					}
+					if err := checkSchemaPatterns(schemas[name], nameLocation, compile, skip); err != nil {
+						return err
+					}
+				}
+			default:
+				if err := checkSchemaPatterns(n[key], keyLocation, compile, skip); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// checkPattern will compile a single pattern, returning an InvalidPatternError if it cannot be compiled. If invalid
+// patterns are skipped, a warning is logged instead, and false is returned so the pattern is removed.
+func checkPattern(pattern, location string, compile func(string) error, skip bool) (bool, error) {
+	err := compile(pattern)
+	if err == nil {
+		return true, nil
+	}
+	if skip {
+		slog.Warn("skipping schema pattern that cannot be compiled", "pattern", pattern,
+			"location", location, "error", err.Error())
+		return false, nil
+	}
+	return false, &InvalidPatternError{Pattern: pattern, Location: location, Err: err}
+}
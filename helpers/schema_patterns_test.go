@@ -0,0 +1,92 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package helpers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi-validator/config"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeTestSchema(t *testing.T, schema string) any {
+	decoded, err := jsonschema.UnmarshalJSON(strings.NewReader(schema))
+	require.NoError(t, err)
+	return decoded
+}
+
+func TestCheckSchemaPatterns_Valid(t *testing.T) {
+	decoded := decodeTestSchema(t, `{"type": "object", "properties": {
+		"pattern": {"type": "string", "pattern": "^[a-z]+$"},
+		"code": {"type": "string", "enum": ["(?=x)"], "default": "(?=x)", "examples": [{"pattern": "(?=x)"}]}}}`)
+
+	// properties named 'pattern' and values that look like patterns are not checked.
+	require.NoError(t, CheckSchemaPatterns(decoded, nil))
+}
+
+func TestCheckSchemaPatterns_Invalid(t *testing.T) {
+	decoded := decodeTestSchema(t, `{"type": "object", "properties": {
+		"name": {"type": "string", "pattern": "^(?=.*[A-Z]).+$"}}}`)
+
+	err := CheckSchemaPatterns(decoded, config.NewValidationOptions())
+	require.Error(t, err)
+
+	var patternErr *InvalidPatternError
+	require.ErrorAs(t, err, &patternErr)
+	require.Equal(t, "^(?=.*[A-Z]).+$", patternErr.Pattern)
+	require.Equal(t, "/properties/name/pattern", patternErr.Location)
+	require.Contains(t, patternErr.Error(), "pattern '^(?=.*[A-Z]).+$' at '/properties/name/pattern' cannot be compiled")
+}
+
+func TestCheckSchemaPatterns_InvalidPatternProperties(t *testing.T) {
+	decoded := decodeTestSchema(t, `{"type": "object", "patternProperties": {"^x-(?!internal)": {"type": "string"}}}`)
+
+	err := CheckSchemaPatterns(decoded, nil)
+	var patternErr *InvalidPatternError
+	require.ErrorAs(t, err, &patternErr)
+	require.Equal(t, "^x-(?!internal)", patternErr.Pattern)
+	require.Equal(t, "/patternProperties/^x-(?!internal)", patternErr.Location)
+}
+
+func TestCheckSchemaPatterns_Skip(t *testing.T) {
+	decoded := decodeTestSchema(t, `{"type": "object",
+		"properties": {"name": {"type": "string", "pattern": "^(?=.*[A-Z]).+$", "maxLength": 5}},
+		"patternProperties": {"^x-(?!internal)": {"type": "string"}, "^y-": {"type": "integer"}}}`)
+
+	opts := config.NewValidationOptions(config.WithSkipInvalidPatterns())
+	require.NoError(t, CheckSchemaPatterns(decoded, opts))
+
+	// the invalid patterns are removed, everything else is kept.
+	schema := decoded.(map[string]any)
+	name := schema["properties"].(map[string]any)["name"].(map[string]any)
+	require.NotContains(t, name, "pattern")
+	require.Contains(t, name, "maxLength")
+	patternProperties := schema["patternProperties"].(map[string]any)
+	require.NotContains(t, patternProperties, "^x-(?!internal)")
+	require.Contains(t, patternProperties, "^y-")
+}
+
+func TestCheckSchemaPatterns_RegexEngine(t *testing.T) {
+	decoded := decodeTestSchema(t, `{"type": "string", "pattern": "^(?=.*[A-Z]).+$"}`)
+	opts := config.NewValidationOptions(config.WithRegexEngine(func(pattern string) (config.Regexp, error) {
+		return upperCaseRegexp{}, nil
+	}))
+	require.NoError(t, CheckSchemaPatterns(decoded, opts))
+}
+
+func TestCompileSchema_InvalidPattern(t *testing.T) {
+	jsonSchema := []byte(`{"type": "string", "pattern": "^(?=.*[A-Z]).+$"}`)
+
+	_, _, err := CompileSchema("test", nil, jsonSchema, RequestBodyValidation, config.NewValidationOptions())
+	var patternErr *InvalidPatternError
+	require.ErrorAs(t, err, &patternErr)
+	require.Equal(t, "/pattern", patternErr.Location)
+
+	jsch, _, err := CompileSchema("test", nil, jsonSchema, RequestBodyValidation,
+		config.NewValidationOptions(config.WithSkipInvalidPatterns()))
+	require.NoError(t, err)
+	require.NoError(t, jsch.Validate("no capitals"))
+}
@@ -0,0 +1,40 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package helpers
+
+// schemaKeywords are the keywords of a schema that contain a single sub-schema.
+var schemaKeywords = []string{"additionalProperties", "items", "not", "if", "then", "else", "contains"}
+
+// schemaListKeywords are the keywords of a schema that contain a list of sub-schemas.
+var schemaListKeywords = []string{"allOf", "anyOf", "oneOf", "prefixItems"}
+
+// schemaMapKeywords are the keywords of a schema that contain a map of sub-schemas.
+var schemaMapKeywords = []string{"properties", "patternProperties", "$defs", "definitions"}
+
+// walkSchema will call visit for a decoded JSON schema, and then for each of its sub-schemas. Sub-schemas are
+// located by the keywords that contain them, so values such as enums and examples are never visited.
+func walkSchema(schema any, visit func(sch map[string]any)) {
+	sch, ok := schema.(map[string]any)
+	if !ok {
+		return
+	}
+	visit(sch)
+	for _, kw := range schemaKeywords {
+		walkSchema(sch[kw], visit)
+	}
+	for _, kw := range schemaListKeywords {
+		if list, isList := sch[kw].([]any); isList {
+			for _, sub := range list {
+				walkSchema(sub, visit)
+			}
+		}
+	}
+	for _, kw := range schemaMapKeywords {
+		if subs, isMap := sch[kw].(map[string]any); isMap {
+			for _, sub := range subs {
+				walkSchema(sub, visit)
+			}
+		}
+	}
+}
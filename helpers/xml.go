@@ -0,0 +1,234 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package helpers
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// xmlElement is a generic representation of an XML element, so it can be mapped to a schema after it's parsed.
+type xmlElement struct {
+	name     xml.Name
+	attrs    []xml.Attr
+	children []*xmlElement
+	text     strings.Builder
+}
+
+// DecodeXML will decode an XML document into an object that can be validated against a schema. The root element is
+// mapped to the schema, and the 'xml' object of each property is used to find the element or attribute holding its
+// value: the name, namespace and attribute values are honored, and arrays can be wrapped in a parent element or
+// repeated inline. Values are coerced into the types defined by the schema. Elements that are not defined by the
+// schema are decoded as strings, or objects if they have children, so they can be reported by the schema.
+func DecodeXML(body []byte, schema *base.Schema) (interface{}, error) {
+	root, err := parseXML(body)
+	if err != nil {
+		return nil, err
+	}
+	return decodeXMLElement(root, schema), nil
+}
+
+// parseXML will parse an XML document into a tree of elements, returning the root element.
+func parseXML(body []byte) (*xmlElement, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	var root *xmlElement
+	var stack []*xmlElement
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			el := &xmlElement{name: t.Name, attrs: t.Attr}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, el)
+			} else if root == nil {
+				root = el
+			} else {
+				return nil, fmt.Errorf("XML document has more than one root element")
+			}
+			stack = append(stack, el)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(t)
+			}
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("XML document has no root element")
+	}
+	return root, nil
+}
+
+// decodeXMLElement will decode an element into the type defined by the schema.
+func decodeXMLElement(el *xmlElement, schema *base.Schema) interface{} {
+	if schema == nil {
+		if len(el.children) == 0 {
+			return strings.TrimSpace(el.text.String())
+		}
+		return decodeXMLObject(el, nil)
+	}
+	switch {
+	case slices.Contains(schema.Type, Object) || len(xmlProperties(schema)) > 0:
+		return decodeXMLObject(el, schema)
+	case slices.Contains(schema.Type, Array):
+		itemsSchema := xmlItemsSchema(schema)
+		items := make([]interface{}, 0, len(el.children))
+		for _, child := range el.children {
+			items = append(items, decodeXMLElement(child, itemsSchema))
+		}
+		return items
+	}
+	return CoerceValue(strings.TrimSpace(el.text.String()), schema)
+}
+
+// decodeXMLObject will decode the attributes and child elements of an element into the properties of an object.
+func decodeXMLObject(el *xmlElement, schema *base.Schema) map[string]interface{} {
+	decoded := make(map[string]interface{})
+	consumed := make([]bool, len(el.children))
+
+	for _, prop := range xmlProperties(schema) {
+		name, propSchema := prop.name, prop.schema
+		xmlName, namespace := name, ""
+		if propSchema != nil && propSchema.XML != nil {
+			if propSchema.XML.Name != "" {
+				xmlName = propSchema.XML.Name
+			}
+			namespace = propSchema.XML.Namespace
+		}
+
+		switch {
+		case propSchema != nil && propSchema.XML != nil && propSchema.XML.Attribute:
+			for _, attr := range el.attrs {
+				if matchesXMLName(attr.Name, xmlName, namespace) {
+					decoded[name] = CoerceValue(attr.Value, propSchema)
+					break
+				}
+			}
+
+		case propSchema != nil && slices.Contains(propSchema.Type, Array):
+			itemsSchema := xmlItemsSchema(propSchema)
+			itemName, itemNamespace := xmlName, namespace
+			if itemsSchema != nil && itemsSchema.XML != nil {
+				if itemsSchema.XML.Name != "" {
+					itemName = itemsSchema.XML.Name
+				}
+				if itemsSchema.XML.Namespace != "" {
+					itemNamespace = itemsSchema.XML.Namespace
+				}
+			}
+
+			// wrapped arrays are held by a parent element, otherwise the items are repeated inline.
+			parent, children, taken := el, el.children, consumed
+			if propSchema.XML != nil && propSchema.XML.Wrapped {
+				i := findXMLChild(el, consumed, xmlName, namespace)
+				if i < 0 {
+					continue
+				}
+				consumed[i] = true
+				parent = el.children[i]
+				children = parent.children
+				taken = make([]bool, len(children))
+			}
+			items := make([]interface{}, 0)
+			for i, child := range children {
+				if !taken[i] && matchesXMLName(child.name, itemName, itemNamespace) {
+					taken[i] = true
+					items = append(items, decodeXMLElement(child, itemsSchema))
+				}
+			}
+			if len(items) > 0 || parent != el {
+				decoded[name] = items
+			}
+
+		default:
+			if i := findXMLChild(el, consumed, xmlName, namespace); i >= 0 {
+				consumed[i] = true
+				decoded[name] = decodeXMLElement(el.children[i], propSchema)
+			}
+		}
+	}
+
+	// any elements that are not defined by the schema are kept, repeated elements become an array.
+	for i, child := range el.children {
+		if consumed[i] {
+			continue
+		}
+		value := decodeXMLElement(child, nil)
+		if existing, ok := decoded[child.name.Local]; ok {
+			if list, isList := existing.([]interface{}); isList {
+				decoded[child.name.Local] = append(list, value)
+			} else {
+				decoded[child.name.Local] = []interface{}{existing, value}
+			}
+			continue
+		}
+		decoded[child.name.Local] = value
+	}
+	return decoded
+}
+
+type xmlProperty struct {
+	name   string
+	schema *base.Schema
+}
+
+// xmlProperties will return the properties of a schema, including the properties of any allOf schemas.
+func xmlProperties(schema *base.Schema) []xmlProperty {
+	if schema == nil {
+		return nil
+	}
+	var props []xmlProperty
+	if schema.Properties != nil {
+		for name, proxy := range schema.Properties.FromOldest() {
+			var propSchema *base.Schema
+			if proxy != nil {
+				propSchema = proxy.Schema()
+			}
+			props = append(props, xmlProperty{name: name, schema: propSchema})
+		}
+	}
+	for _, proxy := range schema.AllOf {
+		if proxy != nil {
+			props = append(props, xmlProperties(proxy.Schema())...)
+		}
+	}
+	return props
+}
+
+// xmlItemsSchema will return the schema of the items of an array, if it has one.
+func xmlItemsSchema(schema *base.Schema) *base.Schema {
+	if schema.Items != nil && schema.Items.IsA() && schema.Items.A != nil {
+		return schema.Items.A.Schema()
+	}
+	return nil
+}
+
+// findXMLChild will return the index of the first child element with a name, that has not already been consumed.
+func findXMLChild(el *xmlElement, consumed []bool, name, namespace string) int {
+	for i, child := range el.children {
+		if !consumed[i] && matchesXMLName(child.name, name, namespace) {
+			return i
+		}
+	}
+	return -1
+}
+
+// matchesXMLName will check the local name of an element or attribute, and its namespace if one is defined.
+func matchesXMLName(name xml.Name, local, namespace string) bool {
+	return name.Local == local && (namespace == "" || name.Space == namespace)
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package helpers
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/stretchr/testify/require"
+)
+
+func buildXMLSchema(t *testing.T, schema string) *base.Schema {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Thing:
+` + schema
+	doc, err := libopenapi.NewDocument([]byte(spec))
+	require.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	require.Nil(t, errs)
+	return m.Model.Components.Schemas.GetOrZero("Thing").Schema()
+}
+
+func TestDecodeXML_AttributesAndElements(t *testing.T) {
+	schema := buildXMLSchema(t, `      type: object
+      properties:
+        id:
+          type: integer
+          xml:
+            attribute: true
+        name:
+          type: string
+          xml:
+            name: full-name
+        price:
+          type: number
+        active:
+          type: boolean`)
+
+	decoded, err := DecodeXML([]byte(`<thing id="7"><full-name>Widget</full-name>`+
+		`<price>1.5</price><active>true</active></thing>`), schema)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		"id":     int64(7),
+		"name":   "Widget",
+		"price":  1.5,
+		"active": true,
+	}, decoded)
+}
+
+func TestDecodeXML_WrappedArray(t *testing.T) {
+	schema := buildXMLSchema(t, `      type: object
+      properties:
+        tags:
+          type: array
+          xml:
+            name: tag-list
+            wrapped: true
+          items:
+            type: string
+            xml:
+              name: tag`)
+
+	decoded, err := DecodeXML([]byte(`<thing><tag-list><tag>a</tag><tag>b</tag></tag-list></thing>`), schema)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"tags": []interface{}{"a", "b"}}, decoded)
+
+	// an empty wrapper is an empty array.
+	decoded, err = DecodeXML([]byte(`<thing><tag-list/></thing>`), schema)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"tags": []interface{}{}}, decoded)
+}
+
+func TestDecodeXML_UnwrappedArray(t *testing.T) {
+	schema := buildXMLSchema(t, `      type: object
+      properties:
+        counts:
+          type: array
+          items:
+            type: integer
+            xml:
+              name: count`)
+
+	decoded, err := DecodeXML([]byte(`<thing><count>1</count><count>2</count></thing>`), schema)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"counts": []interface{}{int64(1), int64(2)}}, decoded)
+}
+
+func TestDecodeXML_Namespace(t *testing.T) {
+	schema := buildXMLSchema(t, `      type: object
+      properties:
+        count:
+          type: integer
+          xml:
+            namespace: https://example.com/schema
+            prefix: ex`)
+
+	decoded, err := DecodeXML([]byte(`<thing xmlns:ex="https://example.com/schema">`+
+		`<ex:count>3</ex:count></thing>`), schema)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"count": int64(3)}, decoded)
+
+	// an element in a different namespace is not the property, so it's kept as an unknown element.
+	decoded, err = DecodeXML([]byte(`<thing><count>3</count></thing>`), schema)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"count": "3"}, decoded)
+}
+
+func TestDecodeXML_UnknownElements(t *testing.T) {
+	schema := buildXMLSchema(t, `      type: object
+      properties:
+        name:
+          type: string`)
+
+	decoded, err := DecodeXML([]byte(`<thing><name>Widget</name><extra>1</extra><extra>2</extra>`+
+		`<nested><inner>x</inner></nested></thing>`), schema)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		"name":   "Widget",
+		"extra":  []interface{}{"1", "2"},
+		"nested": map[string]interface{}{"inner": "x"},
+	}, decoded)
+}
+
+func TestDecodeXML_Invalid(t *testing.T) {
+	schema := buildXMLSchema(t, `      type: object`)
+
+	_, err := DecodeXML([]byte(`<thing><name>Widget</thing>`), schema)
+	require.Error(t, err)
+
+	_, err = DecodeXML([]byte(`<thing/><thing/>`), schema)
+	require.EqualError(t, err, "XML document has more than one root element")
+
+	_, err = DecodeXML([]byte(`  `), schema)
+	require.EqualError(t, err, "XML document has no root element")
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package helpers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DecodeYAML will decode a YAML document into an object that can be validated against a schema, in the same way
+// a JSON document would be. Anchors, aliases and merge keys are resolved, and keys that are not strings are
+// converted to strings, as they are in JSON. Timestamps are left as strings, so they can be checked by the 'date'
+// and 'date-time' formats. Duplicate keys, and more than one document, are errors.
+func DecodeYAML(body []byte) (interface{}, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(body))
+	var node yaml.Node
+	if err := decoder.Decode(&node); err != nil {
+		if err == io.EOF {
+			return nil, nil // the body is empty
+		}
+		return nil, err
+	}
+	var next yaml.Node
+	if err := decoder.Decode(&next); err != io.EOF {
+		return nil, fmt.Errorf("YAML body contains more than one document")
+	}
+
+	untagTimestamps(&node)
+	var decoded interface{}
+	if err := node.Decode(&decoded); err != nil {
+		return nil, err
+	}
+	return stringifyYAMLKeys(decoded), nil
+}
+
+// untagTimestamps will mark any timestamps as strings, so they are not decoded into a time.Time.
+func untagTimestamps(node *yaml.Node) {
+	if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!timestamp" {
+		node.Tag = "!!str"
+	}
+	for _, child := range node.Content {
+		untagTimestamps(child)
+	}
+}
+
+// stringifyYAMLKeys will convert any maps with keys that are not strings into maps with string keys.
+func stringifyYAMLKeys(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = stringifyYAMLKeys(item)
+		}
+		return v
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			converted[fmt.Sprint(key)] = stringifyYAMLKeys(item)
+		}
+		return converted
+	case []interface{}:
+		for i, item := range v {
+			v[i] = stringifyYAMLKeys(item)
+		}
+		return v
+	}
+	return value
+}
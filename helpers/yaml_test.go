@@ -0,0 +1,66 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeYAML(t *testing.T) {
+	decoded, err := DecodeYAML([]byte("name: config\nreplicas: 3\nratio: 0.5\nenabled: true\ntags: [a, b]\n"))
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		"name":     "config",
+		"replicas": 3,
+		"ratio":    0.5,
+		"enabled":  true,
+		"tags":     []interface{}{"a", "b"},
+	}, decoded)
+}
+
+func TestDecodeYAML_AnchorsAndMergeKeys(t *testing.T) {
+	decoded, err := DecodeYAML([]byte(`defaults: &defaults
+  replicas: 1
+  region: eu
+service:
+  <<: *defaults
+  replicas: 3
+regions: [*defaults]
+`))
+	require.NoError(t, err)
+	defaults := map[string]interface{}{"replicas": 1, "region": "eu"}
+	require.Equal(t, map[string]interface{}{
+		"defaults": defaults,
+		"service":  map[string]interface{}{"replicas": 3, "region": "eu"},
+		"regions":  []interface{}{defaults},
+	}, decoded)
+}
+
+func TestDecodeYAML_KeysAndTimestamps(t *testing.T) {
+	decoded, err := DecodeYAML([]byte("1: one\ntrue: yes\ncreated: 2001-12-14\nnested:\n  2: two\n"))
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		"1":       "one",
+		"true":    "yes",
+		"created": "2001-12-14",
+		"nested":  map[string]interface{}{"2": "two"},
+	}, decoded)
+}
+
+func TestDecodeYAML_Errors(t *testing.T) {
+	_, err := DecodeYAML([]byte("name: one\nname: two\n"))
+	require.ErrorContains(t, err, `mapping key "name" already defined at line 1`)
+
+	_, err = DecodeYAML([]byte("name: one\n---\nname: two\n"))
+	require.EqualError(t, err, "YAML body contains more than one document")
+
+	_, err = DecodeYAML([]byte("name: [one\n"))
+	require.Error(t, err)
+
+	decoded, err := DecodeYAML(nil)
+	require.NoError(t, err)
+	require.Nil(t, decoded)
+}
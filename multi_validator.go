@@ -0,0 +1,126 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validator
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pb33f/libopenapi-validator/errors"
+)
+
+// DocumentSelector returns the name of the document that a request should be validated against, for example by
+// reading the base path of the request, or a version header. The name must match the name a Validator was added
+// to a MultiDocumentValidator with.
+type DocumentSelector func(request *http.Request) string
+
+// MultiDocumentValidator validates requests and responses against one of several OpenAPI 3+ documents, such as
+// different versions of an API served by the same process. The document is chosen for each request by a
+// DocumentSelector, and the request is then validated by the Validator of that document.
+//
+// If the selector returns the name of a document that has not been added, a single error is returned.
+// A MultiDocumentValidator is safe for concurrent use, as long as each of its validators is.
+type MultiDocumentValidator interface {
+
+	// ValidateHttpRequest will validate an *http.Request object against the document chosen by the selector.
+	ValidateHttpRequest(request *http.Request) (bool, []*errors.ValidationError)
+
+	// ValidateHttpRequestSync will validate an *http.Request object against the document chosen by the selector,
+	// synchronously and without spawning any goroutines.
+	ValidateHttpRequestSync(request *http.Request) (bool, []*errors.ValidationError)
+
+	// ValidateHttpRequestCtx will validate an *http.Request object against the document chosen by the selector,
+	// synchronously, stopping once the context is cancelled.
+	ValidateHttpRequestCtx(ctx context.Context, request *http.Request) (bool, []*errors.ValidationError)
+
+	// ValidateHttpResponse will validate an *http.Response object against the document chosen by the selector,
+	// using the request to choose the document and to extract the correct response from it.
+	ValidateHttpResponse(request *http.Request, response *http.Response) (bool, []*errors.ValidationError)
+
+	// ValidateHttpRequestResponse will validate both the *http.Request and *http.Response objects against the
+	// document chosen by the selector.
+	ValidateHttpRequestResponse(request *http.Request, response *http.Response) (bool, []*errors.ValidationError)
+
+	// GetValidator will return the Validator added with a name, or nil if no Validator has that name.
+	GetValidator(name string) Validator
+}
+
+// NewMultiDocumentValidator will create a new MultiDocumentValidator from a set of validators, keyed by the names
+// the selector returns. Each Validator is created on its own with NewValidator, so the options of each document can
+// be different. The map is copied, so it can't be changed once the MultiDocumentValidator has been created.
+func NewMultiDocumentValidator(validators map[string]Validator, selector DocumentSelector) MultiDocumentValidator {
+	docs := make(map[string]Validator, len(validators))
+	for name, v := range validators {
+		docs[name] = v
+	}
+	return &multiDocumentValidator{validators: docs, selector: selector}
+}
+
+type multiDocumentValidator struct {
+	validators map[string]Validator
+	selector   DocumentSelector
+}
+
+func (m *multiDocumentValidator) GetValidator(name string) Validator {
+	return m.validators[name]
+}
+
+// selectValidator will return the Validator for the document chosen for a request, or an error if the document
+// has not been added.
+func (m *multiDocumentValidator) selectValidator(request *http.Request) (Validator, []*errors.ValidationError) {
+	name := m.selector(request)
+	if v, ok := m.validators[name]; ok && v != nil {
+		return v, nil
+	}
+	return nil, []*errors.ValidationError{errors.DocumentNotFound(name, request)}
+}
+
+func (m *multiDocumentValidator) ValidateHttpRequest(request *http.Request) (bool, []*errors.ValidationError) {
+	v, errs := m.selectValidator(request)
+	if v == nil {
+		return false, errs
+	}
+	return v.ValidateHttpRequest(request)
+}
+
+func (m *multiDocumentValidator) ValidateHttpRequestSync(request *http.Request) (bool, []*errors.ValidationError) {
+	v, errs := m.selectValidator(request)
+	if v == nil {
+		return false, errs
+	}
+	return v.ValidateHttpRequestSync(request)
+}
+
+func (m *multiDocumentValidator) ValidateHttpRequestCtx(
+	ctx context.Context,
+	request *http.Request) (bool, []*errors.ValidationError) {
+
+	v, errs := m.selectValidator(request)
+	if v == nil {
+		return false, errs
+	}
+	return v.ValidateHttpRequestCtx(ctx, request)
+}
+
+func (m *multiDocumentValidator) ValidateHttpResponse(
+	request *http.Request,
+	response *http.Response) (bool, []*errors.ValidationError) {
+
+	v, errs := m.selectValidator(request)
+	if v == nil {
+		return false, errs
+	}
+	return v.ValidateHttpResponse(request, response)
+}
+
+func (m *multiDocumentValidator) ValidateHttpRequestResponse(
+	request *http.Request,
+	response *http.Response) (bool, []*errors.ValidationError) {
+
+	v, errs := m.selectValidator(request)
+	if v == nil {
+		return false, errs
+	}
+	return v.ValidateHttpRequestResponse(request, response)
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validator
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi-validator/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMultiDocumentTestValidators(t *testing.T) map[string]Validator {
+	v1Spec := `openapi: 3.1.0
+servers:
+  - url: https://things.com/v1
+paths:
+  /burgers:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string`
+
+	v2Spec := `openapi: 3.1.0
+servers:
+  - url: https://things.com/v2
+paths:
+  /burgers:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name, patties]
+              properties:
+                name:
+                  type: string
+                patties:
+                  type: integer
+      responses:
+        '201':
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [id]
+                properties:
+                  id:
+                    type: integer`
+
+	validators := make(map[string]Validator)
+	for name, spec := range map[string]string{"v1": v1Spec, "v2": v2Spec} {
+		doc, err := libopenapi.NewDocument([]byte(spec))
+		require.NoError(t, err)
+		v, errs := NewValidator(doc)
+		require.Empty(t, errs)
+		validators[name] = v
+	}
+	return validators
+}
+
+func TestNewMultiDocumentValidator_RouteByPath(t *testing.T) {
+	selector := func(request *http.Request) string {
+		return strings.Split(strings.TrimPrefix(request.URL.Path, "/"), "/")[0]
+	}
+	v := NewMultiDocumentValidator(newMultiDocumentTestValidators(t), selector)
+
+	newRequest := func(url string) *http.Request {
+		request, _ := http.NewRequest(http.MethodPost, url, bytes.NewBufferString(`{"name": "big mac"}`))
+		request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+		return request
+	}
+
+	// the v1 document does not require any patties.
+	valid, errors := v.ValidateHttpRequestSync(newRequest("https://things.com/v1/burgers"))
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	// the v2 document does.
+	valid, errors = v.ValidateHttpRequest(newRequest("https://things.com/v2/burgers"))
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	require.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "missing property 'patties'", errors[0].SchemaValidationErrors[0].Reason)
+
+	// there is no v3 document.
+	valid, errors = v.ValidateHttpRequest(newRequest("https://things.com/v3/burgers"))
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, helpers.RequestMissingDocument, errors[0].ValidationSubType)
+	assert.Equal(t, "Document 'v3' not found", errors[0].Message)
+
+	assert.NotNil(t, v.GetValidator("v1"))
+	assert.Nil(t, v.GetValidator("v3"))
+}
+
+func TestNewMultiDocumentValidator_RouteByHeader(t *testing.T) {
+	selector := func(request *http.Request) string {
+		return request.Header.Get("X-Api-Version")
+	}
+	v := NewMultiDocumentValidator(newMultiDocumentTestValidators(t), selector)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/v2/burgers",
+		bytes.NewBufferString(`{"name": "big mac", "patties": 2}`))
+	request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+	request.Header.Set("X-Api-Version", "v2")
+
+	res := httptest.NewRecorder()
+	res.Header().Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+	res.WriteHeader(http.StatusCreated)
+	_, _ = res.Write([]byte(`{"id": "one"}`))
+	response := res.Result()
+	response.Request = request
+
+	valid, errors := v.ValidateHttpRequestResponse(request, response)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, helpers.ResponseBodyValidation, errors[0].ValidationType)
+
+	// without the header, no document is selected.
+	request.Header.Del("X-Api-Version")
+	valid, errors = v.ValidateHttpResponse(request, response)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, helpers.RequestMissingDocument, errors[0].ValidationSubType)
+}
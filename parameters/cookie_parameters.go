@@ -4,14 +4,17 @@
 package parameters
 
 import (
+	"encoding/json"
 	"fmt"
+	"github.com/pb33f/libopenapi-validator/config"
 	"github.com/pb33f/libopenapi-validator/errors"
 	"github.com/pb33f/libopenapi-validator/helpers"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
 	"net/http"
+	"net/url"
 	"strconv"
-	"strings"
 	"github.com/pb33f/libopenapi-validator/paths"
 )
 
@@ -42,86 +45,178 @@ func (v *paramValidator) ValidateCookieParamsWithPathItem(request *http.Request,
 	var validationErrors []*errors.ValidationError
 	for _, p := range params {
 		if p.In == helpers.Cookie {
+			// a required cookie must be present, an empty value still counts as being present.
+			if p.Required != nil && *p.Required && countCookies(request, p.Name) == 0 {
+				validationErrors = append(validationErrors, errors.CookieParameterMissing(p))
+				continue
+			}
+			repeatReported := false
 			for _, cookie := range request.Cookies() {
 				if cookie.Name == p.Name { // cookies are case-sensitive, an exact match is required
 
+					// a parameter must contain either a schema property, or a content property, but not both.
+					// the content map must only contain one entry.
 					var sch *base.Schema
+					var contentType string
 					if p.Schema != nil {
 						sch = p.Schema.Schema()
+					} else {
+						for pair := orderedmap.First(p.Content); pair != nil; pair = pair.Next() {
+							if pair.Value().Schema != nil {
+								sch = pair.Value().Schema.Schema()
+							}
+							contentType = pair.Key()
+							break
+						}
+					}
+					if sch == nil {
+						continue
+					}
+
+					// content encoded values are decoded using the media type, and then validated as a whole.
+					if helpers.IsJSONContentType(contentType) {
+						decodedValue, _ := url.QueryUnescape(cookie.Value)
+						var decoded interface{}
+						if err := json.Unmarshal([]byte(decodedValue), &decoded); err != nil {
+							validationErrors = append(validationErrors,
+								errors.IncorrectCookieParamEncodingJSON(p, cookie.Value, sch))
+							continue
+						}
+						validationErrors = append(validationErrors,
+							validateDecodedParameter(sch, decoded,
+								"Cookie parameter",
+								"The cookie parameter",
+								p.Name,
+								helpers.ParameterValidation,
+								helpers.ParameterValidationCookie,
+								config.WithExistingOpts(v.options))...)
+						continue
 					}
 					pType := sch.Type
 
 					for _, ty := range pType {
 						switch ty {
 						case helpers.Integer, helpers.Number:
-							if _, err := strconv.ParseFloat(cookie.Value, 64); err != nil {
+							cookieNumber, err := strconv.ParseFloat(cookie.Value, 64)
+							if err != nil {
 								validationErrors = append(validationErrors,
-									errors.InvalidCookieParamNumber(p, strings.ToLower(cookie.Value), sch))
+									errors.InvalidCookieParamNumber(p, cookie.Value, sch))
 								break
 							}
 							// check if enum is in range
-							if sch.Enum != nil {
-								matchFound := false
-								for _, enumVal := range sch.Enum {
-									if strings.TrimSpace(cookie.Value) == fmt.Sprint(enumVal.Value) {
-										matchFound = true
-										break
-									}
-								}
-								if !matchFound {
-									validationErrors = append(validationErrors,
-										errors.IncorrectCookieParamEnum(p, strings.ToLower(cookie.Value), sch))
-								}
+							if sch.Enum != nil && !helpers.MatchesEnum(cookie.Value, ty, sch.Enum) {
+								validationErrors = append(validationErrors,
+									errors.IncorrectCookieParamEnum(p, cookie.Value, sch))
+								break
 							}
+							if sch.Const != nil && !helpers.MatchesConst(cookie.Value, ty, sch.Const) {
+								validationErrors = append(validationErrors,
+									errors.IncorrectCookieParamConst(p, cookie.Value, sch))
+								break
+							}
+							// validate the number against the schema, so numeric constraints such as
+							// minimum, maximum and multipleOf are checked.
+							validationErrors = append(validationErrors,
+								ValidateSingleParameterSchema(sch, cookieNumber,
+									"Cookie parameter",
+									"The cookie parameter",
+									p.Name,
+									helpers.ParameterValidation,
+									helpers.ParameterValidationCookie,
+									config.WithExistingOpts(v.options))...)
 						case helpers.Boolean:
 							if _, err := strconv.ParseBool(cookie.Value); err != nil {
 								validationErrors = append(validationErrors,
-									errors.IncorrectCookieParamBool(p, strings.ToLower(cookie.Value), sch))
+									errors.IncorrectCookieParamBool(p, cookie.Value, sch))
+								break
+							}
+							if sch.Enum != nil && !helpers.MatchesEnum(cookie.Value, ty, sch.Enum) {
+								validationErrors = append(validationErrors,
+									errors.IncorrectCookieParamEnum(p, cookie.Value, sch))
+							}
+							if sch.Const != nil && !helpers.MatchesConst(cookie.Value, ty, sch.Const) {
+								validationErrors = append(validationErrors,
+									errors.IncorrectCookieParamConst(p, cookie.Value, sch))
 							}
 						case helpers.Object:
-							if !p.IsExploded() {
-								encodedObj := helpers.ConstructMapFromCSV(cookie.Value)
-
-								// if a schema was extracted
-								if sch != nil {
-									validationErrors = append(validationErrors,
-										ValidateParameterSchema(sch, encodedObj, "",
-											"Cookie parameter",
-											"The cookie parameter",
-											p.Name,
-											helpers.ParameterValidation,
-											helpers.ParameterValidationQuery)...)
+							// cookies only support the 'form' style according to the spec, however 'deepObject'
+							// encoding is common enough in the wild that it's decoded as well.
+							var encodedObj map[string]interface{}
+							switch p.Style {
+							case "", helpers.Form:
+								if !p.IsExploded() {
+									encodedObj = helpers.ConstructMapFromCSV(cookie.Value)
+									break
 								}
+								encodedObj = helpers.ConstructKVFromFormEncoding(cookie.Value)
+							case helpers.DeepObject:
+								encodedObj = helpers.ConstructKVFromDeepObjectEncoding(p.Name, cookie.Value)
+							default:
+								validationErrors = append(validationErrors, errors.IncorrectCookieParamStyle(p, sch))
+								continue
+							}
+
+							// exploded and deepObject values are key=value pairs, if nothing could be extracted
+							// then the value was not encoded correctly.
+							if len(encodedObj) == 0 && (p.IsExploded() || p.Style == helpers.DeepObject) {
+								validationErrors = append(validationErrors,
+									errors.CookieParameterCannotBeDecoded(p, cookie.Value))
+								continue
+							}
+
+							// if a schema was extracted
+							if sch != nil {
+								validationErrors = append(validationErrors,
+									ValidateParameterSchema(sch, encodedObj, "",
+										"Cookie parameter",
+										"The cookie parameter",
+										p.Name,
+										helpers.ParameterValidation,
+										helpers.ParameterValidationCookie,
+										config.WithExistingOpts(v.options))...)
 							}
 						case helpers.Array:
 
-							if !p.IsExploded() {
-								// well we're already in an array, so we need to check the items schema
-								// to ensure this array items matches the type
-								// only check if items is a schema, not a boolean
-								if sch.Items.IsA() {
-									validationErrors = append(validationErrors,
-										ValidateCookieArray(sch, p, cookie.Value)...)
-								}
+							// cookies cannot be repeated by name, an exploded array would be serialized by repeating
+							// the cookie, and a non-exploded array is a single comma separated value. So a repeated
+							// cookie is flagged (once) either way, and each value that was sent is decoded.
+							if !repeatReported && countCookies(request, p.Name) > 1 {
+								validationErrors = append(validationErrors, errors.IncorrectCookieParamArrayRepeat(p, sch))
+								repeatReported = true
+							}
+
+							// well we're already in an array, so we need to check the items schema
+							// to ensure this array items matches the type
+							// only check if items is a schema, not a boolean
+							if sch.Items != nil && sch.Items.IsA() {
+								validationErrors = append(validationErrors,
+									ValidateCookieArray(sch, p, cookie.Value, config.WithExistingOpts(v.options))...)
 							}
 
 						case helpers.String:
 
 							// check if the schema has an enum, and if so, match the value against one of
 							// the defined enum values.
-							if sch.Enum != nil {
-								matchFound := false
-								for _, enumVal := range sch.Enum {
-									if strings.TrimSpace(cookie.Value) == fmt.Sprint(enumVal.Value) {
-										matchFound = true
-										break
-									}
-								}
-								if !matchFound {
-									validationErrors = append(validationErrors,
-										errors.IncorrectCookieParamEnum(p, strings.ToLower(cookie.Value), sch))
-								}
+							if sch.Enum != nil && !helpers.MatchesEnum(cookie.Value, ty, sch.Enum) {
+								validationErrors = append(validationErrors,
+									errors.IncorrectCookieParamEnum(p, cookie.Value, sch))
+								break
+							}
+							if sch.Const != nil && !helpers.MatchesConst(cookie.Value, ty, sch.Const) {
+								validationErrors = append(validationErrors,
+									errors.IncorrectCookieParamConst(p, cookie.Value, sch))
+								break
 							}
+							// validate the string against the schema, so string constraints such as
+							// minLength, maxLength and pattern are checked.
+							validationErrors = append(validationErrors,
+								ValidateSingleParameterSchema(sch, cookie.Value,
+									"Cookie parameter",
+									"The cookie parameter",
+									p.Name,
+									helpers.ParameterValidation,
+									helpers.ParameterValidationCookie,
+									config.WithExistingOpts(v.options))...)
 						}
 					}
 				}
@@ -136,3 +231,14 @@ func (v *paramValidator) ValidateCookieParamsWithPathItem(request *http.Request,
 	}
 	return true, nil
 }
+
+// countCookies returns the number of cookies in the request that match the supplied name.
+func countCookies(request *http.Request, name string) int {
+	count := 0
+	for _, cookie := range request.Cookies() {
+		if cookie.Name == name {
+			count++
+		}
+	}
+	return count
+}
@@ -5,9 +5,15 @@ package parameters
 
 import (
 	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi-validator/config"
+	"github.com/pb33f/libopenapi-validator/helpers"
 	"github.com/pb33f/libopenapi-validator/paths"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -40,6 +46,92 @@ paths:
 	assert.Equal(t, "", errors[0].SpecPath)
 }
 
+func TestNewValidator_CookieRequiredMissing(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: PattyPreference
+          in: cookie
+          required: true
+          schema:
+            type: number`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "BunPreference", Value: "1"})
+
+	valid, errors := v.ValidateCookieParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Cookie parameter 'PattyPreference' is missing", errors[0].Message)
+	assert.Equal(t, "/burgers/beef", errors[0].SpecPath)
+}
+
+func TestNewValidator_CookieRequiredPresentButEmpty(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: PattyPreference
+          in: cookie
+          required: true
+          schema:
+            type: number`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: ""})
+
+	valid, errors := v.ValidateCookieParams(request)
+
+	// the cookie is present, so it's not reported as missing, only as an invalid number.
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Cookie parameter 'PattyPreference' is not a valid number", errors[0].Message)
+}
+
+func TestNewValidator_CookieRequiredPresentAndValid(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: PattyPreference
+          in: cookie
+          required: true
+          schema:
+            type: number
+        - name: BunPreference
+          in: cookie
+          schema:
+            type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "2"})
+
+	valid, errors := v.ValidateCookieParams(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
 func TestNewValidator_CookieParamNumberValid(t *testing.T) {
 
 	spec := `openapi: 3.1.0
@@ -49,24 +141,664 @@ paths:
       parameters:
         - name: PattyPreference
           in: cookie
-          required: true
+          required: true
+          schema:
+            type: number`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "1"})
+
+	valid, errors := v.ValidateCookieParams(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_CookieParamNumberValidFloat(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: PattyPreference
+          in: cookie
+          required: true
+          schema:
+            type: number`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "123.455"})
+
+	valid, errors := v.ValidateCookieParams(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_CookieParamNumberInvalid(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: PattyPreference
+          in: cookie
+          required: true
+          schema:
+            type: number`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "false"})
+
+	valid, errors := v.ValidateCookieParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Convert the value 'false' into a number", errors[0].HowToFix)
+}
+
+func TestNewValidator_CookieParamIntegerInvalidKeepsCase(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: PattyCount
+          in: cookie
+          required: true
+          schema:
+            type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "PattyCount", Value: "TwoPatties"})
+
+	valid, errors := v.ValidateCookieParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Cookie parameter 'PattyCount' is not a valid integer", errors[0].Message)
+	assert.Equal(t, "The cookie parameter 'PattyCount' is defined as being an integer, "+
+		"however the value 'TwoPatties' is not a valid integer", errors[0].Reason)
+	assert.Equal(t, "Convert the value 'TwoPatties' into a number", errors[0].HowToFix)
+}
+
+func TestNewValidator_CookieParamBooleanValid(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: PattyPreference
+          in: cookie
+          required: true
+          schema:
+            type: boolean`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "true"})
+
+	valid, errors := v.ValidateCookieParams(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_CookieParamEnumValidString(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: PattyPreference
+          in: cookie
+          required: true
+          schema:
+            type: string
+            enum:
+              - beef
+              - chicken
+              - pea protein`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "chicken"})
+
+	valid, errors := v.ValidateCookieParams(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_CookieParamEnumValidInteger(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: PattyCount
+          in: cookie
+          required: true
+          schema:
+            type: integer
+            enum:
+              - 1
+              - 2
+              - 3`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "PattyCount", Value: "2"})
+
+	valid, errors := v.ValidateCookieParams(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_CookieParamEnumInvalidInteger(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: PattyCount
+          in: cookie
+          required: true
+          schema:
+            type: integer
+            enum:
+              - 1
+              - 2
+              - 3`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "PattyCount", Value: "9"})
+
+	valid, errors := v.ValidateCookieParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Instead of '9', use one of the allowed values: '1, 2, 3'", errors[0].HowToFix)
+}
+
+func TestNewValidator_CookieParamConst(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: PattyCount
+          in: cookie
+          required: true
+          schema:
+            type: integer
+            const: 2`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "PattyCount", Value: "2"})
+
+	valid, errors := v.ValidateCookieParams(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "PattyCount", Value: "3"})
+
+	valid, errors = v.ValidateCookieParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Cookie parameter 'PattyCount' does not match the constant value", errors[0].Message)
+	assert.Equal(t, "Instead of '3', use the constant value: '2'", errors[0].HowToFix)
+}
+
+func TestNewValidator_CookieParamNumberConstraints(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: age
+          in: cookie
+          required: true
+          schema:
+            type: integer
+            minimum: 0
+            multipleOf: 5`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "age", Value: "25"})
+
+	valid, errors := v.ValidateCookieParams(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "age", Value: "-5"})
+
+	valid, errors = v.ValidateCookieParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Cookie parameter 'age' failed to validate", errors[0].Message)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "minimum: got -5, want 0", errors[0].SchemaValidationErrors[0].Reason)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "age", Value: "7"})
+
+	valid, errors = v.ValidateCookieParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "/multipleOf", errors[0].SchemaValidationErrors[0].Location)
+}
+
+func TestNewValidator_CookieParamStringConstraints(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: session
+          in: cookie
+          required: true
+          schema:
+            type: string
+            minLength: 4
+            pattern: "^[a-f0-9]+$"`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "session", Value: "beef01"})
+
+	valid, errors := v.ValidateCookieParams(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "session", Value: "ab"})
+
+	valid, errors = v.ValidateCookieParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Cookie parameter 'session' failed to validate", errors[0].Message)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "/minLength", errors[0].SchemaValidationErrors[0].Location)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "session", Value: "pickles"})
+
+	valid, errors = v.ValidateCookieParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "/pattern", errors[0].SchemaValidationErrors[0].Location)
+}
+
+func TestNewValidator_CookieParamArrayTypeCoercion(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: ages
+          in: cookie
+          required: true
+          explode: false
+          schema:
+            type: array
+            items:
+              type: integer
+              minimum: 0`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "ages", Value: "3,-1"})
+
+	v := NewParameterValidator(&m.Model)
+	valid, errors := v.ValidateCookieParams(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	v = NewParameterValidator(&m.Model, config.WithParameterTypeCoercion())
+	valid, errors = v.ValidateCookieParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Cookie parameter 'ages' failed to validate", errors[0].Message)
+	assert.Equal(t, "minimum: got -1, want 0", errors[0].SchemaValidationErrors[0].Reason)
+}
+
+func TestNewValidator_CookieParamValuesKeepCase(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: PattyPreference
+          in: cookie
+          required: true
+          schema:
+            type: string
+            enum: [rare, medium]
+        - name: ExtraCheese
+          in: cookie
+          required: true
+          schema:
+            type: boolean`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "WellDone"})
+	request.AddCookie(&http.Cookie{Name: "ExtraCheese", Value: "YesPlease"})
+
+	valid, errors := v.ValidateCookieParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 2)
+	assert.Contains(t, errors[0].Reason, "The value 'WellDone' is not one of those values")
+	assert.Contains(t, errors[0].HowToFix, "WellDone")
+	assert.Contains(t, errors[1].Reason, "however the value 'YesPlease' is not a valid boolean")
+	assert.Contains(t, errors[1].HowToFix, "YesPlease")
+}
+
+func TestNewValidator_CookieParamEnumInvalidString(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: PattyPreference
+          in: cookie
+          required: true
+          schema:
+            type: string
+            enum:
+              - beef
+              - chicken
+              - pea protein`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "milk"})
+
+	valid, errors := v.ValidateCookieParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t,
+		"Instead of 'milk', use one of the allowed values: 'beef, chicken, pea protein'", errors[0].HowToFix)
+	assert.Equal(t, request.Method, errors[0].RequestMethod)
+	assert.Equal(t, request.URL.Path, errors[0].RequestPath)
+	assert.Equal(t, "/burgers/beef", errors[0].SpecPath)
+}
+
+func TestNewValidator_CookieParamBooleanInvalid(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: PattyPreference
+          in: cookie
+          required: true
+          schema:
+            type: boolean`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "12345"})
+
+	valid, errors := v.ValidateCookieParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Convert the value '12345' into a true/false value", errors[0].HowToFix)
+}
+
+func TestNewValidator_CookieParamObjectValid(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: PattyPreference
+          in: cookie
+          required: true
+          explode: false
+          schema:
+            type: object
+            properties:
+              pink:
+                type: boolean
+              number:
+                type: number
+            required: [pink, number]`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "pink,true,number,2"})
+
+	valid, errors := v.ValidateCookieParams(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_CookieParamObjectInvalid(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: PattyPreference
+          in: cookie
+          required: true
+          explode: false
+          schema:
+            type: object
+            properties:
+              pink:
+                type: boolean
+              number:
+                type: number
+            required: [pink, number]`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "pink,2,number,2"})
+
+	valid, errors := v.ValidateCookieParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "got number, want boolean", errors[0].SchemaValidationErrors[0].Reason)
+}
+
+func TestNewValidator_CookieParamObjectExplodedValid(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: PattyPreference
+          in: cookie
+          required: true
+          style: form
+          explode: true
+          schema:
+            type: object
+            properties:
+              pink:
+                type: boolean
+              number:
+                type: number
+            required: [pink, number]`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "pink=true&number=2"})
+
+	valid, errors := v.ValidateCookieParams(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_CookieParamObjectExplodedInvalid(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: PattyPreference
+          in: cookie
+          required: true
+          style: form
+          explode: true
+          schema:
+            type: object
+            properties:
+              pink:
+                type: boolean
+              number:
+                type: number
+            required: [pink, number]`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "pink=true&number=two"})
+
+	valid, errors := v.ValidateCookieParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, helpers.ParameterValidationCookie, errors[0].ValidationSubType)
+	assert.Equal(t, "got string, want number", errors[0].SchemaValidationErrors[0].Reason)
+}
+
+func TestNewValidator_CookieParamObjectExplodedMalformed(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: PattyPreference
+          in: cookie
+          style: form
+          explode: true
           schema:
-            type: number`
+            type: object
+            properties:
+              pink:
+                type: boolean`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 	m, _ := doc.BuildV3Model()
 	v := NewParameterValidator(&m.Model)
 
 	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
-	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "1"})
+	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "pink"})
 
 	valid, errors := v.ValidateCookieParams(request)
 
-	assert.True(t, valid)
-	assert.Len(t, errors, 0)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Cookie parameter 'PattyPreference' cannot be decoded", errors[0].Message)
 }
 
-func TestNewValidator_CookieParamNumberValidFloat(t *testing.T) {
+func TestNewValidator_CookieParamObjectDeepObjectValid(t *testing.T) {
 
 	spec := `openapi: 3.1.0
 paths:
@@ -76,15 +808,24 @@ paths:
         - name: PattyPreference
           in: cookie
           required: true
+          style: deepObject
+          explode: true
           schema:
-            type: number`
+            type: object
+            properties:
+              pink:
+                type: boolean
+              number:
+                type: number
+            required: [pink, number]`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 	m, _ := doc.BuildV3Model()
 	v := NewParameterValidator(&m.Model)
 
 	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
-	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "123.455"})
+	request.AddCookie(&http.Cookie{Name: "PattyPreference",
+		Value: "PattyPreference[pink]=false&PattyPreference[number]=3.5"})
 
 	valid, errors := v.ValidateCookieParams(request)
 
@@ -92,7 +833,7 @@ paths:
 	assert.Len(t, errors, 0)
 }
 
-func TestNewValidator_CookieParamNumberInvalid(t *testing.T) {
+func TestNewValidator_CookieParamObjectDeepObjectInvalidProperty(t *testing.T) {
 
 	spec := `openapi: 3.1.0
 paths:
@@ -102,24 +843,34 @@ paths:
         - name: PattyPreference
           in: cookie
           required: true
+          style: deepObject
+          explode: true
           schema:
-            type: number`
+            type: object
+            properties:
+              pink:
+                type: boolean
+              number:
+                type: number
+            required: [pink, number]`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 	m, _ := doc.BuildV3Model()
 	v := NewParameterValidator(&m.Model)
 
 	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
-	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "false"})
+	request.AddCookie(&http.Cookie{Name: "PattyPreference",
+		Value: "PattyPreference[pink]=rare&PattyPreference[number]=3"})
 
 	valid, errors := v.ValidateCookieParams(request)
 
 	assert.False(t, valid)
 	assert.Len(t, errors, 1)
-	assert.Equal(t, "Convert the value 'false' into a number", errors[0].HowToFix)
+	assert.Equal(t, "Cookie parameter 'PattyPreference' failed to validate", errors[0].Message)
+	assert.Equal(t, "got string, want boolean", errors[0].SchemaValidationErrors[0].Reason)
 }
 
-func TestNewValidator_CookieParamBooleanValid(t *testing.T) {
+func TestNewValidator_CookieParamObjectUnsupportedStyle(t *testing.T) {
 
 	spec := `openapi: 3.1.0
 paths:
@@ -129,23 +880,30 @@ paths:
         - name: PattyPreference
           in: cookie
           required: true
+          style: matrix
           schema:
-            type: boolean`
+            type: object
+            properties:
+              pink:
+                type: boolean`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 	m, _ := doc.BuildV3Model()
 	v := NewParameterValidator(&m.Model)
 
 	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
-	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "true"})
+	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "pink=true"})
 
 	valid, errors := v.ValidateCookieParams(request)
 
-	assert.True(t, valid)
-	assert.Len(t, errors, 0)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Cookie parameter 'PattyPreference' has an unsupported style", errors[0].Message)
+	assert.Equal(t, errors[0].HowToFix, "Cookie parameters only support the 'form' style, "+
+		"use 'form' (or 'deepObject') as the style for cookie parameters")
 }
 
-func TestNewValidator_CookieParamEnumValidString(t *testing.T) {
+func TestNewValidator_CookieParamContentJSONValid(t *testing.T) {
 
 	spec := `openapi: 3.1.0
 paths:
@@ -155,19 +913,24 @@ paths:
         - name: PattyPreference
           in: cookie
           required: true
-          schema:
-            type: string
-            enum:
-              - beef
-              - chicken
-              - pea protein`
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  pink:
+                    type: boolean
+                  number:
+                    type: number
+                required: [pink, number]`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 	m, _ := doc.BuildV3Model()
 	v := NewParameterValidator(&m.Model)
 
 	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
-	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "chicken"})
+	request.AddCookie(&http.Cookie{Name: "PattyPreference",
+		Value: url.QueryEscape(`{"pink":true,"number":2}`)})
 
 	valid, errors := v.ValidateCookieParams(request)
 
@@ -175,7 +938,7 @@ paths:
 	assert.Len(t, errors, 0)
 }
 
-func TestNewValidator_CookieParamEnumInvalidString(t *testing.T) {
+func TestNewValidator_CookieParamContentJSONInvalidSchema(t *testing.T) {
 
 	spec := `openapi: 3.1.0
 paths:
@@ -185,32 +948,34 @@ paths:
         - name: PattyPreference
           in: cookie
           required: true
-          schema:
-            type: string
-            enum:
-              - beef
-              - chicken
-              - pea protein`
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  pink:
+                    type: boolean
+                  number:
+                    type: number
+                required: [pink, number]`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 	m, _ := doc.BuildV3Model()
 	v := NewParameterValidator(&m.Model)
 
 	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
-	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "milk"})
+	request.AddCookie(&http.Cookie{Name: "PattyPreference",
+		Value: url.QueryEscape(`{"pink":"yes","number":2}`)})
 
 	valid, errors := v.ValidateCookieParams(request)
 
 	assert.False(t, valid)
 	assert.Len(t, errors, 1)
-	assert.Equal(t,
-		"Instead of 'milk', use one of the allowed values: 'beef, chicken, pea protein'", errors[0].HowToFix)
-	assert.Equal(t, request.Method, errors[0].RequestMethod)
-	assert.Equal(t, request.URL.Path, errors[0].RequestPath)
-	assert.Equal(t, "/burgers/beef", errors[0].SpecPath)
+	assert.Equal(t, "Cookie parameter 'PattyPreference' failed to validate", errors[0].Message)
+	assert.Equal(t, "got string, want boolean", errors[0].SchemaValidationErrors[0].Reason)
 }
 
-func TestNewValidator_CookieParamBooleanInvalid(t *testing.T) {
+func TestNewValidator_CookieParamContentJSONMalformed(t *testing.T) {
 
 	spec := `openapi: 3.1.0
 paths:
@@ -220,24 +985,30 @@ paths:
         - name: PattyPreference
           in: cookie
           required: true
-          schema:
-            type: boolean`
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  pink:
+                    type: boolean`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 	m, _ := doc.BuildV3Model()
 	v := NewParameterValidator(&m.Model)
 
 	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
-	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "12345"})
+	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: url.QueryEscape(`{"pink":tru`)})
 
 	valid, errors := v.ValidateCookieParams(request)
 
 	assert.False(t, valid)
 	assert.Len(t, errors, 1)
-	assert.Equal(t, "Convert the value '12345' into a true/false value", errors[0].HowToFix)
+	assert.Equal(t, "Cookie parameter 'PattyPreference' is not valid JSON", errors[0].Message)
+	assert.Equal(t, "The JSON submitted is invalid, please check the syntax", errors[0].HowToFix)
 }
 
-func TestNewValidator_CookieParamObjectValid(t *testing.T) {
+func TestNewValidator_CookieParamArrayValidNumber(t *testing.T) {
 
 	spec := `openapi: 3.1.0
 paths:
@@ -247,22 +1018,17 @@ paths:
         - name: PattyPreference
           in: cookie
           required: true
-          explode: false
           schema:
-            type: object
-            properties:
-              pink:
-                type: boolean
-              number:
-                type: number
-            required: [pink, number]`
+            type: array
+            items:
+              type: number`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 	m, _ := doc.BuildV3Model()
 	v := NewParameterValidator(&m.Model)
 
 	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
-	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "pink,true,number,2"})
+	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "2,3,4"})
 
 	valid, errors := v.ValidateCookieParams(request)
 
@@ -270,7 +1036,7 @@ paths:
 	assert.Len(t, errors, 0)
 }
 
-func TestNewValidator_CookieParamObjectInvalid(t *testing.T) {
+func TestNewValidator_CookieParamArrayExplodedValidNumber(t *testing.T) {
 
 	spec := `openapi: 3.1.0
 paths:
@@ -280,31 +1046,56 @@ paths:
         - name: PattyPreference
           in: cookie
           required: true
-          explode: false
+          explode: true
           schema:
-            type: object
-            properties:
-              pink:
-                type: boolean
-              number:
-                type: number
-            required: [pink, number]`
+            type: array
+            items:
+              type: number`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 	m, _ := doc.BuildV3Model()
 	v := NewParameterValidator(&m.Model)
 
 	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
-	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "pink,2,number,2"})
+	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "2&PattyPreference=3&PattyPreference=4"})
+
+	valid, errors := v.ValidateCookieParams(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_CookieParamArrayExplodedInvalidNumber(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: PattyPreference
+          in: cookie
+          required: true
+          explode: true
+          schema:
+            type: array
+            items:
+              type: number`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "2&PattyPreference=three&PattyPreference=4"})
 
 	valid, errors := v.ValidateCookieParams(request)
 
 	assert.False(t, valid)
 	assert.Len(t, errors, 1)
-	assert.Equal(t, "got number, want boolean", errors[0].SchemaValidationErrors[0].Reason)
+	assert.Equal(t, "Convert the value 'three' into a number", errors[0].HowToFix)
 }
 
-func TestNewValidator_CookieParamArrayValidNumber(t *testing.T) {
+func TestNewValidator_CookieParamArrayExplodedRepeated(t *testing.T) {
 
 	spec := `openapi: 3.1.0
 paths:
@@ -314,6 +1105,7 @@ paths:
         - name: PattyPreference
           in: cookie
           required: true
+          explode: true
           schema:
             type: array
             items:
@@ -324,12 +1116,62 @@ paths:
 	v := NewParameterValidator(&m.Model)
 
 	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
-	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "2,3,4"})
+	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "2"})
+	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "3"})
 
 	valid, errors := v.ValidateCookieParams(request)
 
-	assert.True(t, valid)
-	assert.Len(t, errors, 0)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Cookie array parameter 'PattyPreference' is repeated", errors[0].Message)
+}
+
+func TestNewValidator_CookieParamArrayNotExplodedRepeated(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: PattyPreference
+          in: cookie
+          required: true
+          explode: false
+          schema:
+            type: array
+            items:
+              type: number`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "2,3"})
+	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "4,five"})
+
+	valid, errors := v.ValidateCookieParams(request)
+
+	// the repeat is reported, and every value that was sent is still validated.
+	assert.False(t, valid)
+	require.Len(t, errors, 2)
+	assert.Equal(t, "Cookie array parameter 'PattyPreference' is repeated", errors[0].Message)
+	assert.Contains(t, errors[0].Reason, "is defined as not being exploded")
+	assert.Equal(t, 9, errors[0].SpecLine)
+	assert.Equal(t, "Cookie array parameter 'PattyPreference' is not a valid number", errors[1].Message)
+
+	// 'explode' is not declared, so the error points at the schema.
+	spec = strings.Replace(spec, "          explode: false\n", "", 1)
+	doc, _ = libopenapi.NewDocument([]byte(spec))
+	m, _ = doc.BuildV3Model()
+	v = NewParameterValidator(&m.Model)
+
+	valid, errors = v.ValidateCookieParams(request)
+
+	assert.False(t, valid)
+	require.Len(t, errors, 2)
+	assert.Equal(t, "Cookie array parameter 'PattyPreference' is repeated", errors[0].Message)
+	assert.Equal(t, 9, errors[0].SpecLine)
 }
 
 func TestNewValidator_CookieParamArrayInvalidNumber(t *testing.T) {
@@ -527,6 +1369,44 @@ paths:
 	assert.Equal(t, "Instead of '2500', use one of the allowed values: '1, 2, 99'", errors[0].HowToFix)
 }
 
+func TestNewValidator_CookieParamContentJSONNoType(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: PattyPreference
+          in: cookie
+          content:
+            application/json:
+              schema:
+                maxLength: 1`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: url.QueryEscape(`"abc"`)})
+
+	valid, errors := v.ValidateCookieParams(request)
+
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Cookie parameter 'PattyPreference' failed to validate", errors[0].Message)
+	assert.Equal(t, -1, errors[0].SpecLine)
+	require.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "maxLength", errors[0].SchemaValidationErrors[0].Keyword)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: url.QueryEscape(`"a"`)})
+
+	valid, errors = v.ValidateCookieParams(request)
+
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+}
+
 func TestNewValidator_PresetPath(t *testing.T) {
 
 	spec := `openapi: 3.1.0
@@ -588,3 +1468,86 @@ paths:
 	assert.Len(t, errors, 1)
 	assert.Equal(t, "GET Path '/pizza/beef' not found", errors[0].Message)
 }
+
+func TestNewValidator_CookieParams_Concurrent(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: PattyPreference
+          in: cookie
+          required: true
+          schema:
+            type: number`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(valid bool) {
+			defer wg.Done()
+			request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+			if valid {
+				request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "2"})
+			} else {
+				request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "two"})
+			}
+
+			ok, errors := v.ValidateCookieParams(request)
+
+			// each call must only see its own errors.
+			assert.Equal(t, valid, ok)
+			if valid {
+				assert.Empty(t, errors)
+			} else {
+				assert.Len(t, errors, 1)
+			}
+		}(i%2 == 0)
+	}
+	wg.Wait()
+}
+
+func TestNewValidator_CookieParams_RepeatedCalls(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/beef:
+    get:
+      parameters:
+        - name: PattyPreference
+          in: cookie
+          required: true
+          schema:
+            type: number`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "two"})
+
+	valid, errors := v.ValidateCookieParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+
+	// the errors from the first request must not leak into the second.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "2"})
+
+	valid, errors = v.ValidateCookieParams(request)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers/beef", nil)
+	request.AddCookie(&http.Cookie{Name: "PattyPreference", Value: "three"})
+
+	valid, errors = v.ValidateCookieParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+}
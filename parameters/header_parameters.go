@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/pb33f/libopenapi-validator/config"
 	"github.com/pb33f/libopenapi-validator/errors"
 	"github.com/pb33f/libopenapi-validator/helpers"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
@@ -47,7 +48,8 @@ func (v *paramValidator) ValidateHeaderParamsWithPathItem(request *http.Request,
 		if p.In == helpers.Header {
 
 			seenHeaders[strings.ToLower(p.Name)] = true
-			if param := request.Header.Get(p.Name); param != "" {
+			// an empty header is still present, so it is validated rather than reported as missing.
+			if param, found := helpers.FindHeaderValue(request.Header, p.Name); found {
 
 				var sch *base.Schema
 				if p.Schema != nil {
@@ -58,37 +60,55 @@ func (v *paramValidator) ValidateHeaderParamsWithPathItem(request *http.Request,
 				for _, ty := range pType {
 					switch ty {
 					case helpers.Integer, helpers.Number:
-						if _, err := strconv.ParseFloat(param, 64); err != nil {
+						headerNumber, err := strconv.ParseFloat(param, 64)
+						if err != nil {
 							validationErrors = append(validationErrors,
-								errors.InvalidHeaderParamNumber(p, strings.ToLower(param), sch))
+								errors.InvalidHeaderParamNumber(p, param, sch))
 							break
 						}
 						// check if the param is within the enum
-						if sch.Enum != nil {
-							matchFound := false
-							for _, enumVal := range sch.Enum {
-								if strings.TrimSpace(param) == fmt.Sprint(enumVal.Value) {
-									matchFound = true
-									break
-								}
-							}
-							if !matchFound {
-								validationErrors = append(validationErrors,
-									errors.IncorrectCookieParamEnum(p, strings.ToLower(param), sch))
-							}
+						if sch.Enum != nil && !helpers.MatchesEnum(param, ty, sch.Enum) {
+							validationErrors = append(validationErrors,
+								errors.IncorrectHeaderParamEnum(p, param, sch))
+							break
 						}
+						if sch.Const != nil && !helpers.MatchesConst(param, ty, sch.Const) {
+							validationErrors = append(validationErrors,
+								errors.IncorrectHeaderParamConst(p, param, sch))
+							break
+						}
+						// validate the number against the schema, so numeric constraints such as
+						// minimum, maximum and multipleOf are checked.
+						validationErrors = append(validationErrors,
+							ValidateSingleParameterSchema(sch, headerNumber,
+								"Header parameter",
+								"The header parameter",
+								p.Name,
+								helpers.ParameterValidation,
+								helpers.ParameterValidationHeader,
+								config.WithExistingOpts(v.options))...)
 
 					case helpers.Boolean:
 						if _, err := strconv.ParseBool(param); err != nil {
 							validationErrors = append(validationErrors,
-								errors.IncorrectHeaderParamBool(p, strings.ToLower(param), sch))
+								errors.IncorrectHeaderParamBool(p, param, sch))
+							break
+						}
+						if sch.Enum != nil && !helpers.MatchesEnum(param, ty, sch.Enum) {
+							validationErrors = append(validationErrors,
+								errors.IncorrectHeaderParamEnum(p, param, sch))
+						}
+						if sch.Const != nil && !helpers.MatchesConst(param, ty, sch.Const) {
+							validationErrors = append(validationErrors,
+								errors.IncorrectHeaderParamConst(p, param, sch))
 						}
 
 					case helpers.Object:
 
 						// check if the header is default encoded or not
 						var encodedObj map[string]interface{}
-						// we have found our header, check the explode type.
+						// we have found our header, check the explode type. headers use the 'simple' style, so
+						// a non-exploded object is 'key1,val1,key2,val2' and an exploded object is 'key1=val1,key2=val2'.
 						if p.IsDefaultHeaderEncoding() {
 							encodedObj = helpers.ConstructMapFromCSV(param)
 						} else {
@@ -99,7 +119,7 @@ func (v *paramValidator) ValidateHeaderParamsWithPathItem(request *http.Request,
 
 						if len(encodedObj) == 0 {
 							validationErrors = append(validationErrors,
-								errors.HeaderParameterCannotBeDecoded(p, strings.ToLower(param)))
+								errors.HeaderParameterCannotBeDecoded(p, param))
 							break
 						}
 
@@ -113,34 +133,41 @@ func (v *paramValidator) ValidateHeaderParamsWithPathItem(request *http.Request,
 									"The header parameter",
 									p.Name,
 									helpers.ParameterValidation,
-									helpers.ParameterValidationQuery)...)
+									helpers.ParameterValidationHeader,
+									config.WithExistingOpts(v.options))...)
 						}
 
 					case helpers.Array:
-						if !p.IsExploded() { // only unexploded arrays are supported for cookie params
-							if sch.Items.IsA() {
-								validationErrors = append(validationErrors,
-									ValidateHeaderArray(sch, p, param)...)
-							}
+						// the 'simple' style encodes arrays as CSV, regardless of the explode value.
+						if sch.Items != nil && sch.Items.IsA() {
+							validationErrors = append(validationErrors,
+								ValidateHeaderArray(sch, p, param, config.WithExistingOpts(v.options))...)
 						}
 
 					case helpers.String:
 
 						// check if the schema has an enum, and if so, match the value against one of
 						// the defined enum values.
-						if sch.Enum != nil {
-							matchFound := false
-							for _, enumVal := range sch.Enum {
-								if strings.TrimSpace(param) == fmt.Sprint(enumVal.Value) {
-									matchFound = true
-									break
-								}
-							}
-							if !matchFound {
-								validationErrors = append(validationErrors,
-									errors.IncorrectHeaderParamEnum(p, strings.ToLower(param), sch))
-							}
+						if sch.Enum != nil && !helpers.MatchesEnum(param, ty, sch.Enum) {
+							validationErrors = append(validationErrors,
+								errors.IncorrectHeaderParamEnum(p, param, sch))
+							break
+						}
+						if sch.Const != nil && !helpers.MatchesConst(param, ty, sch.Const) {
+							validationErrors = append(validationErrors,
+								errors.IncorrectHeaderParamConst(p, param, sch))
+							break
 						}
+						// validate the string against the schema, so string constraints such as
+						// minLength, maxLength and pattern are checked.
+						validationErrors = append(validationErrors,
+							ValidateSingleParameterSchema(sch, param,
+								"Header parameter",
+								"The header parameter",
+								p.Name,
+								helpers.ParameterValidation,
+								helpers.ParameterValidationHeader,
+								config.WithExistingOpts(v.options))...)
 					}
 				}
 			} else {
@@ -5,6 +5,8 @@ package parameters
 
 import (
 	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi-validator/config"
+	"github.com/pb33f/libopenapi-validator/helpers"
 	"github.com/pb33f/libopenapi-validator/paths"
 	"github.com/stretchr/testify/assert"
 	"net/http"
@@ -601,6 +603,236 @@ paths:
 		"use one of the allowed values: '1, 2, 99'", errors[0].HowToFix)
 }
 
+func TestNewValidator_HeaderParamBooleanInvalidEnum(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /vending/drinks:
+    get:
+      parameters:
+        - name: decaf
+          in: header
+          required: true
+          schema:
+            type: boolean
+            enum: [false]`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/vending/drinks", nil)
+	request.Header.Set("decaf", "true")
+
+	valid, errors := v.ValidateHeaderParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Header parameter 'decaf' does not match allowed values", errors[0].Message)
+	assert.Equal(t, "Instead of 'true', "+
+		"use one of the allowed values: 'false'", errors[0].HowToFix)
+}
+
+func TestNewValidator_HeaderParamConst(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /vending/drinks:
+    get:
+      parameters:
+        - name: drinkType
+          in: header
+          required: true
+          schema:
+            type: string
+            const: coffee`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/vending/drinks", nil)
+	request.Header.Set("drinkType", "coffee")
+
+	valid, errors := v.ValidateHeaderParams(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request.Header.Set("drinkType", "Tea")
+
+	valid, errors = v.ValidateHeaderParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Header parameter 'drinkType' does not match the constant value", errors[0].Message)
+	assert.Equal(t, "Instead of 'Tea', use the constant value: 'coffee'", errors[0].HowToFix)
+}
+
+func TestNewValidator_HeaderParamNumberConstraints(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /vending/drinks:
+    get:
+      parameters:
+        - name: coffeeCups
+          in: header
+          required: true
+          schema:
+            type: number
+            exclusiveMaximum: 10`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/vending/drinks", nil)
+	request.Header.Set("coffeeCups", "9.5")
+
+	valid, errors := v.ValidateHeaderParams(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request.Header.Set("coffeeCups", "10")
+
+	valid, errors = v.ValidateHeaderParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Header parameter 'coffeeCups' failed to validate", errors[0].Message)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "/exclusiveMaximum", errors[0].SchemaValidationErrors[0].Location)
+}
+
+func TestNewValidator_HeaderParamStringConstraints(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /vending/drinks:
+    get:
+      parameters:
+        - name: coupon
+          in: header
+          required: true
+          schema:
+            type: string
+            pattern: "^[A-Z]+$"
+            maxLength: 4`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/vending/drinks", nil)
+	request.Header.Set("coupon", "FREE")
+
+	valid, errors := v.ValidateHeaderParams(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request.Header.Set("coupon", "free")
+
+	valid, errors = v.ValidateHeaderParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Header parameter 'coupon' failed to validate", errors[0].Message)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "/pattern", errors[0].SchemaValidationErrors[0].Location)
+
+	request.Header.Set("coupon", "FREEBIE")
+
+	valid, errors = v.ValidateHeaderParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "/maxLength", errors[0].SchemaValidationErrors[0].Location)
+}
+
+func TestNewValidator_HeaderParamArrayTypeCoercion(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /vending/drinks:
+    get:
+      parameters:
+        - name: coffeeCups
+          in: header
+          required: true
+          schema:
+            type: array
+            items:
+              type: number
+              maximum: 5`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/vending/drinks", nil)
+	request.Header.Set("coffeeCups", "1, 2.5, 8")
+
+	v := NewParameterValidator(&m.Model)
+	valid, errors := v.ValidateHeaderParams(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	v = NewParameterValidator(&m.Model, config.WithParameterTypeCoercion())
+	valid, errors = v.ValidateHeaderParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Header parameter 'coffeeCups' failed to validate", errors[0].Message)
+	assert.Equal(t, "maximum: got 8, want 5", errors[0].SchemaValidationErrors[0].Reason)
+}
+
+func TestNewValidator_HeaderParamRef(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /vending/drinks:
+    get:
+      parameters:
+        - $ref: '#/components/parameters/Milk'
+components:
+  parameters:
+    Milk:
+      name: X-Milk
+      in: header
+      required: true
+      schema:
+        $ref: '#/components/schemas/Milk'
+  schemas:
+    Milk:
+      type: string
+      enum: [oat, soy]`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/vending/drinks", nil)
+	request.Header.Set("X-Milk", "oat")
+
+	valid, errors := v.ValidateHeaderParams(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request.Header.Set("X-Milk", "cow")
+
+	valid, errors = v.ValidateHeaderParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Header parameter 'X-Milk' does not match allowed values", errors[0].Message)
+	assert.Equal(t, "Instead of 'cow', use one of the allowed values: 'oat, soy'", errors[0].HowToFix)
+}
+
 func TestNewValidator_HeaderParamSetPath(t *testing.T) {
 
 	spec := `openapi: 3.1.0
@@ -663,3 +895,284 @@ paths:
 	assert.Len(t, errors, 1)
 	assert.Equal(t, "GET Path '/buying/drinks' not found", errors[0].Message)
 }
+
+func TestNewValidator_HeaderParamExplodedObject(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /vending/drinks:
+    get:
+      parameters:
+        - name: X-Trace
+          in: header
+          style: simple
+          explode: true
+          schema:
+            type: object
+            properties:
+              span:
+                type: integer
+              token:
+                type: string
+            required: [span]`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/vending/drinks", nil)
+	request.Header.Set("X-Trace", "span=12, token=dHJhY2U=")
+
+	valid, errors := v.ValidateHeaderParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request.Header.Set("X-Trace", "span=twelve,token=dHJhY2U=")
+
+	valid, errors = v.ValidateHeaderParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Header parameter 'X-Trace' failed to validate", errors[0].Message)
+	assert.Equal(t, helpers.ParameterValidationHeader, errors[0].ValidationSubType)
+	assert.Equal(t, "got string, want integer", errors[0].SchemaValidationErrors[0].Reason)
+}
+
+func TestNewValidator_HeaderParamNotExplodedObject(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /vending/drinks:
+    get:
+      parameters:
+        - name: X-Trace
+          in: header
+          style: simple
+          explode: false
+          schema:
+            type: object
+            properties:
+              span:
+                type: integer
+              token:
+                type: string
+            required: [span]`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/vending/drinks", nil)
+	request.Header.Set("X-Trace", "span,12,token,abc")
+
+	valid, errors := v.ValidateHeaderParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request.Header.Set("X-Trace", "token,abc")
+
+	valid, errors = v.ValidateHeaderParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, helpers.ParameterValidationHeader, errors[0].ValidationSubType)
+	assert.Equal(t, "missing property 'span'", errors[0].SchemaValidationErrors[0].Reason)
+}
+
+func TestNewValidator_HeaderParamCaseInsensitive(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /vending/drinks:
+    get:
+      parameters:
+        - name: X-Request-Id
+          in: header
+          required: true
+          schema:
+            type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/vending/drinks", nil)
+	request.Header["x-request-id"] = []string{"1234"} // not canonicalized.
+
+	valid, errors := v.ValidateHeaderParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request.Header["x-request-id"] = []string{"abc"}
+
+	valid, errors = v.ValidateHeaderParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Header parameter 'X-Request-Id' is not a valid integer", errors[0].Message)
+}
+
+func TestNewValidator_HeaderParamRequiredMissingEmptyValid(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /vending/drinks:
+    get:
+      parameters:
+        - name: X-Cups
+          in: header
+          required: true
+          schema:
+            type: integer
+        - name: X-Note
+          in: header
+          required: true
+          schema:
+            type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	// missing headers are reported as missing.
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/vending/drinks", nil)
+
+	valid, errors := v.ValidateHeaderParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 2)
+	assert.Equal(t, "Header parameter 'X-Cups' is missing", errors[0].Message)
+	assert.Equal(t, "Header parameter 'X-Note' is missing", errors[1].Message)
+
+	// empty headers are present, so they are validated against the schema instead.
+	request.Header.Set("X-Cups", "")
+	request.Header.Set("X-Note", "")
+
+	valid, errors = v.ValidateHeaderParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Header parameter 'X-Cups' is not a valid integer", errors[0].Message)
+
+	// and valid headers pass.
+	request.Header.Set("X-Cups", "2")
+
+	valid, errors = v.ValidateHeaderParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_HeaderParamArray(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /vending/drinks:
+    get:
+      parameters:
+        - name: X-Ids
+          in: header
+          explode: true
+          schema:
+            type: array
+            items:
+              type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/vending/drinks", nil)
+	request.Header.Set("X-Ids", "1, 2,3")
+
+	valid, errors := v.ValidateHeaderParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request.Header.Set("X-Ids", "1, two, 3")
+
+	valid, errors = v.ValidateHeaderParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Header array parameter 'X-Ids' is not a valid integer", errors[0].Message)
+	assert.Equal(t, "The header parameter (which is an array) 'X-Ids' is defined as being an integer, "+
+		"however the value 'two' at index 1 is not a valid integer", errors[0].Reason)
+	assert.Equal(t, 1, *errors[0].ItemIndex)
+}
+
+func TestValidateHeaderParamsWithOptions(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /vending/drinks:
+    get:
+      parameters:
+        - name: X-Order-Id
+          in: header
+          required: true
+          schema:
+            type: string
+            format: uuid
+        - name: size
+          in: query
+          schema:
+            type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	options := config.NewValidationOptions(config.WithStrictQueryParams(), config.WithFormatAssertions())
+
+	// the undefined query parameter is not reported, as only the headers are validated.
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/vending/drinks?sugar=lots", nil)
+	request.Header.Set("X-Order-Id", "d2e0c1b4-6f4a-4a8e-9a7e-2f1e6c1d9b3a")
+
+	valid, errors := ValidateHeaderParamsWithOptions(&m.Model, request, options)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	// the format is asserted, as the options are respected.
+	request.Header.Set("X-Order-Id", "not-a-uuid")
+
+	valid, errors = ValidateHeaderParamsWithOptions(&m.Model, request, options)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Header parameter 'X-Order-Id' failed to validate", errors[0].Message)
+
+	// the format is only an annotation with the default options.
+	valid, errors = ValidateHeaderParamsWithOptions(&m.Model, request, nil)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	// the query is still checked in strict mode, when it's validated.
+	valid, errors = ValidateQueryParamsWithOptions(&m.Model, request, options)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Query parameter 'sugar' is not defined", errors[0].Message)
+}
+
+func TestNewValidator_HeaderParamEnumInvalidKeepsCase(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /bish/bosh:
+    get:
+      parameters:
+        - name: X-Sauce
+          in: header
+          schema:
+            type: string
+            enum: [ketchup, mayo]`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/bish/bosh", nil)
+	request.Header.Set("X-Sauce", "Ketchup")
+
+	valid, errors := v.ValidateHeaderParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Header parameter 'X-Sauce' does not match allowed values", errors[0].Message)
+	assert.Equal(t, "The header parameter 'X-Sauce' has pre-defined values set via an enum. "+
+		"The value 'Ketchup' is not one of those values.", errors[0].Reason)
+}
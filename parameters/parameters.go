@@ -4,6 +4,7 @@
 package parameters
 
 import (
+	"github.com/pb33f/libopenapi-validator/config"
 	"github.com/pb33f/libopenapi-validator/errors"
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
 	"net/http"
@@ -56,6 +57,7 @@ type ParameterValidator interface {
 
 	// ValidateSecurity validates the security requirements for the operation. It returns a boolean stating true
 	// if validation passed (false for failed), and a slice of errors if validation failed.
+	// Security is validated by security.SecurityValidator, this method is kept for existing callers.
 	ValidateSecurity(request *http.Request) (bool, []*errors.ValidationError)
 
 	// ValidateSecurityWithPathItem validates the security requirements for the operation. It returns a boolean stating true
@@ -63,11 +65,51 @@ type ParameterValidator interface {
 	ValidateSecurityWithPathItem(request *http.Request, pathItem *v3.PathItem, pathValue string) (bool, []*errors.ValidationError)
 }
 
-// NewParameterValidator will create a new ParameterValidator from an OpenAPI 3+ document
-func NewParameterValidator(document *v3.Document) ParameterValidator {
-	return &paramValidator{document: document}
+// NewParameterValidator will create a new ParameterValidator from an OpenAPI 3+ document. Options can be
+// supplied to change the behavior of the validator, by default no options are enabled. The validator does not hold
+// any state between calls, so it is safe for concurrent use.
+func NewParameterValidator(document *v3.Document, opts ...config.Option) ParameterValidator {
+	return &paramValidator{document: document, options: config.NewValidationOptions(opts...)}
 }
 
 type paramValidator struct {
 	document *v3.Document
+	options  *config.ValidationOptions
+}
+
+// ValidateQueryParamsWithOptions will validate only the query parameters of an *http.Request against a document,
+// using the supplied options, so targeted validation behaves the same as a configured validator. Default options
+// are used if the options are nil.
+func ValidateQueryParamsWithOptions(document *v3.Document, request *http.Request,
+	options *config.ValidationOptions) (bool, []*errors.ValidationError) {
+	return newParamValidatorWithOptions(document, options).ValidateQueryParams(request)
+}
+
+// ValidateHeaderParamsWithOptions will validate only the header parameters of an *http.Request against a document,
+// using the supplied options. Default options are used if the options are nil.
+func ValidateHeaderParamsWithOptions(document *v3.Document, request *http.Request,
+	options *config.ValidationOptions) (bool, []*errors.ValidationError) {
+	return newParamValidatorWithOptions(document, options).ValidateHeaderParams(request)
+}
+
+// ValidateCookieParamsWithOptions will validate only the cookie parameters of an *http.Request against a document,
+// using the supplied options. Default options are used if the options are nil.
+func ValidateCookieParamsWithOptions(document *v3.Document, request *http.Request,
+	options *config.ValidationOptions) (bool, []*errors.ValidationError) {
+	return newParamValidatorWithOptions(document, options).ValidateCookieParams(request)
+}
+
+// ValidatePathParamsWithOptions will validate only the path parameters of an *http.Request against a document,
+// using the supplied options. Default options are used if the options are nil.
+func ValidatePathParamsWithOptions(document *v3.Document, request *http.Request,
+	options *config.ValidationOptions) (bool, []*errors.ValidationError) {
+	return newParamValidatorWithOptions(document, options).ValidatePathParams(request)
+}
+
+// newParamValidatorWithOptions will create a paramValidator that uses an existing set of options.
+func newParamValidatorWithOptions(document *v3.Document, options *config.ValidationOptions) *paramValidator {
+	if options == nil {
+		options = config.NewValidationOptions()
+	}
+	return &paramValidator{document: document, options: options}
 }
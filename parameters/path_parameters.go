@@ -6,9 +6,11 @@ package parameters
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 
+	"github.com/pb33f/libopenapi-validator/config"
 	"github.com/pb33f/libopenapi-validator/errors"
 	"github.com/pb33f/libopenapi-validator/helpers"
 	"github.com/pb33f/libopenapi-validator/paths"
@@ -39,7 +41,8 @@ func (v *paramValidator) ValidatePathParamsWithPathItem(request *http.Request, p
 		}}
 	}
 	// split the path into segments
-	submittedSegments := strings.Split(paths.StripRequestPath(request, v.document), helpers.Slash)
+	// the escaped path is used, so percent-encoded slashes and delimiters stay inside their parameter values.
+	submittedSegments := strings.Split(paths.StripRequestEscapedPath(request, v.document), helpers.Slash)
 	pathSegments := strings.Split(pathValue, helpers.Slash)
 
 	// extract params for the operation
@@ -55,27 +58,24 @@ func (v *paramValidator) ValidatePathParamsWithPathItem(request *http.Request, p
 				}
 				i := strings.IndexRune(pathSegments[x], '{')
 				if i > -1 {
-					isMatrix := false
-					isLabel := false
-					// isExplode := false
-					isSimple := true
+					// the style can be declared on the parameter, or with a prefix in the path template.
+					isMatrix := p.Style == helpers.MatrixStyle
+					isLabel := p.Style == helpers.LabelStyle
 					paramTemplate := pathSegments[x][i+1 : len(pathSegments[x])-1]
 					paramName := paramTemplate
 					// check for an asterisk on the end of the parameter (explode)
 					if strings.HasSuffix(paramTemplate, helpers.Asterisk) {
-						// isExplode = true
 						paramName = paramTemplate[:len(paramTemplate)-1]
 					}
 					if strings.HasPrefix(paramTemplate, helpers.Period) {
 						isLabel = true
-						isSimple = false
 						paramName = paramName[1:]
 					}
 					if strings.HasPrefix(paramTemplate, helpers.SemiColon) {
 						isMatrix = true
-						isSimple = false
 						paramName = paramName[1:]
 					}
+					isSimple := !isLabel && !isMatrix
 
 					// does this param name match the current path segment param name
 					if paramName != p.Name {
@@ -83,37 +83,40 @@ func (v *paramValidator) ValidatePathParamsWithPathItem(request *http.Request, p
 					}
 
 					paramValue := ""
+					rawParamValue := ""
 
-					// extract the parameter value from the path.
+					// extract the parameter value from the path, and percent-decode it.
 					if x < len(submittedSegments) {
-						paramValue = submittedSegments[x]
+						rawParamValue = submittedSegments[x]
+						paramValue = unescapePathValue(rawParamValue)
 					}
 
 					if paramValue == "" {
-						//Mandatory path parameter cannot be empty
-						if p.Required != nil && *p.Required {
-							validationErrors = append(validationErrors, errors.PathParameterMissing(p))
-							break
-						}
-						continue
+						// the path template matched, but the segment holding the parameter is empty. Path parameters
+						// are always required, so this is reported as a missing parameter, not as an unknown path.
+						validationErrors = append(validationErrors, errors.PathParameterMissing(p))
+						break
 					}
 
 					// extract the schema from the parameter
 					sch := p.Schema.Schema()
 
 					// check enum (if present)
-					enumCheck := func(paramValue string) {
-						matchFound := false
-						for _, enumVal := range sch.Enum {
-							if strings.TrimSpace(paramValue) == fmt.Sprint(enumVal.Value) {
-								matchFound = true
-								break
-							}
+					enumCheck := func(paramValue, valueType string) {
+						if !helpers.MatchesEnum(paramValue, valueType, sch.Enum) {
+							validationErrors = append(validationErrors,
+								errors.IncorrectPathParamEnum(p, paramValue, sch))
 						}
-						if !matchFound {
+					}
+
+					// check const (if present), so the expected value is reported.
+					constCheck := func(paramValue, valueType string) bool {
+						if !helpers.MatchesConst(paramValue, valueType, sch.Const) {
 							validationErrors = append(validationErrors,
-								errors.IncorrectPathParamEnum(p, strings.ToLower(paramValue), sch))
+								errors.IncorrectPathParamConst(p, paramValue, sch))
+							return false
 						}
+						return true
 					}
 
 					// for each type, check the value.
@@ -123,22 +126,27 @@ func (v *paramValidator) ValidatePathParamsWithPathItem(request *http.Request, p
 							switch sch.Type[typ] {
 							case helpers.String:
 
-								// TODO: label and matrix style validation
+								// remove the prefix added by label and matrix styles, leaving the value.
+								stringValue := decodeStyledPathValue(p, isLabel, isMatrix, paramValue)
 
 								// check if the param is within the enum
 								if sch.Enum != nil {
-									enumCheck(paramValue)
+									enumCheck(stringValue, helpers.String)
+									break
+								}
+								if sch.Const != nil && !constCheck(stringValue, helpers.String) {
 									break
 								}
 								validationErrors = append(validationErrors,
 									ValidateSingleParameterSchema(
 										sch,
-										paramValue,
+										stringValue,
 										"Path parameter",
 										"The path parameter",
 										p.Name,
 										helpers.ParameterValidation,
 										helpers.ParameterValidationPath,
+										config.WithExistingOpts(v.options),
 									)...)
 
 							case helpers.Integer, helpers.Number:
@@ -150,7 +158,10 @@ func (v *paramValidator) ValidatePathParamsWithPathItem(request *http.Request, p
 								}
 								// check if the param is within the enum
 								if sch.Enum != nil {
-									enumCheck(rawParamValue)
+									enumCheck(rawParamValue, sch.Type[typ])
+									break
+								}
+								if sch.Const != nil && !constCheck(rawParamValue, sch.Type[typ]) {
 									break
 								}
 								validationErrors = append(validationErrors, ValidateSingleParameterSchema(
@@ -161,11 +172,25 @@ func (v *paramValidator) ValidatePathParamsWithPathItem(request *http.Request, p
 									p.Name,
 									helpers.ParameterValidation,
 									helpers.ParameterValidationPath,
+									config.WithExistingOpts(v.options),
 								)...)
 
 							case helpers.Boolean:
+								// check if the param is within the enum, once it's known to be a boolean.
+								if sch.Enum != nil {
+									boolValue := decodeStyledPathValue(p, isLabel, isMatrix, paramValue)
+									if _, err := strconv.ParseBool(boolValue); err == nil {
+										enumCheck(boolValue, helpers.Boolean)
+									}
+								}
+								if sch.Const != nil {
+									boolValue := decodeStyledPathValue(p, isLabel, isMatrix, paramValue)
+									if _, err := strconv.ParseBool(boolValue); err == nil {
+										constCheck(boolValue, helpers.Boolean)
+									}
+								}
 								if isLabel && p.Style == helpers.LabelStyle {
-									if _, err := strconv.ParseFloat(paramValue[1:], 64); err != nil {
+									if _, err := strconv.ParseBool(paramValue[1:]); err != nil {
 										validationErrors = append(validationErrors,
 											errors.IncorrectPathParamBool(p, paramValue[1:], sch))
 									}
@@ -221,7 +246,8 @@ func (v *paramValidator) ValidatePathParamsWithPathItem(request *http.Request, p
 											"The path parameter",
 											p.Name,
 											helpers.ParameterValidation,
-											helpers.ParameterValidationPath)...)
+											helpers.ParameterValidationPath,
+											config.WithExistingOpts(v.options))...)
 								}
 
 							case helpers.Array:
@@ -229,28 +255,14 @@ func (v *paramValidator) ValidatePathParamsWithPathItem(request *http.Request, p
 								// extract the items schema in order to validate the array items.
 								if sch.Items != nil && sch.Items.IsA() {
 									iSch := sch.Items.A.Schema()
+									// split the raw value before decoding the items, so an encoded delimiter
+									// (for example '%2C') is part of an item, rather than separating two items.
+									arrayValues := decodePathArrayValues(p, isLabel, isMatrix, rawParamValue)
+									for pv := range arrayValues {
+										arrayValues[pv] = unescapePathValue(arrayValues[pv])
+									}
+									typeErrors := len(validationErrors)
 									for n := range iSch.Type {
-										// determine how to explode the array
-										var arrayValues []string
-										if isSimple {
-											arrayValues = strings.Split(paramValue, helpers.Comma)
-										}
-										if isLabel {
-											if !p.IsExploded() {
-												arrayValues = strings.Split(paramValue[1:], helpers.Comma)
-											} else {
-												arrayValues = strings.Split(paramValue[1:], helpers.Period)
-											}
-										}
-										if isMatrix {
-											if !p.IsExploded() {
-												paramValue = strings.Replace(paramValue[1:], fmt.Sprintf("%s=", p.Name), "", 1)
-												arrayValues = strings.Split(paramValue, helpers.Comma)
-											} else {
-												paramValue = strings.ReplaceAll(paramValue[1:], fmt.Sprintf("%s=", p.Name), "")
-												arrayValues = strings.Split(paramValue, helpers.SemiColon)
-											}
-										}
 										switch iSch.Type[n] {
 										case helpers.Integer, helpers.Number:
 											for pv := range arrayValues {
@@ -279,6 +291,25 @@ func (v *paramValidator) ValidatePathParamsWithPathItem(request *http.Request, p
 											}
 										}
 									}
+
+									// once every item is the right type, validate the array against the schema, so the
+									// constraints of the items (enum, pattern, minimum etc.) and of the array are checked.
+									if len(validationErrors) == typeErrors {
+										items := make([]any, len(arrayValues))
+										for pv := range arrayValues {
+											items[pv] = helpers.CoerceValue(arrayValues[pv], iSch)
+										}
+										validationErrors = append(validationErrors,
+											ValidateParameterSchema(sch,
+												items,
+												"",
+												"Path parameter",
+												"The path parameter",
+												p.Name,
+												helpers.ParameterValidation,
+												helpers.ParameterValidationPath,
+												config.WithExistingOpts(v.options))...)
+									}
 								}
 							}
 						}
@@ -297,25 +328,53 @@ func (v *paramValidator) ValidatePathParamsWithPathItem(request *http.Request, p
 }
 
 func (v *paramValidator) resolveNumber(sch *base.Schema, p *v3.Parameter, isLabel bool, isMatrix bool, paramValue string) (string, float64, []*errors.ValidationError) {
-	if isLabel && p.Style == helpers.LabelStyle {
-		paramValueParsed, err := strconv.ParseFloat(paramValue[1:], 64)
-		if err != nil {
-			return "", 0, []*errors.ValidationError{errors.IncorrectPathParamNumber(p, paramValue[1:], sch)}
-		}
-		return paramValue[1:], paramValueParsed, nil
-	}
-	if isMatrix && p.Style == helpers.MatrixStyle {
-		// strip off the colon and the parameter name
-		paramValue = strings.Replace(paramValue[1:], fmt.Sprintf("%s=", p.Name), "", 1)
-		paramValueParsed, err := strconv.ParseFloat(paramValue, 64)
-		if err != nil {
-			return "", 0, []*errors.ValidationError{errors.IncorrectPathParamNumber(p, paramValue[1:], sch)}
-		}
-		return paramValue, paramValueParsed, nil
-	}
+	paramValue = decodeStyledPathValue(p, isLabel, isMatrix, paramValue)
 	paramValueParsed, err := strconv.ParseFloat(paramValue, 64)
 	if err != nil {
-		return "", 0, []*errors.ValidationError{errors.IncorrectPathParamNumber(p, paramValue[1:], sch)}
+		return "", 0, []*errors.ValidationError{errors.IncorrectPathParamNumber(p, paramValue, sch)}
 	}
 	return paramValue, paramValueParsed, nil
 }
+
+// unescapePathValue will percent-decode a path parameter value. A value that cannot be decoded is returned as it
+// was sent, so it is validated (and fails) as is.
+func unescapePathValue(value string) string {
+	if unescaped, err := url.PathUnescape(value); err == nil {
+		return unescaped
+	}
+	return value
+}
+
+// decodePathArrayValues will split the value of an array path parameter into its items, according to the style
+// and explode settings of the parameter.
+func decodePathArrayValues(p *v3.Parameter, isLabel, isMatrix bool, paramValue string) []string {
+	switch {
+	case isLabel:
+		if !p.IsExploded() {
+			return strings.Split(paramValue[1:], helpers.Comma)
+		}
+		return strings.Split(paramValue[1:], helpers.Period)
+	case isMatrix:
+		if !p.IsExploded() {
+			paramValue = strings.Replace(paramValue[1:], fmt.Sprintf("%s=", p.Name), "", 1)
+			return strings.Split(paramValue, helpers.Comma)
+		}
+		paramValue = strings.ReplaceAll(paramValue[1:], fmt.Sprintf("%s=", p.Name), "")
+		return strings.Split(paramValue, helpers.SemiColon)
+	default:
+		// simple style arrays are comma separated, whether they are exploded or not.
+		return strings.Split(paramValue, helpers.Comma)
+	}
+}
+
+// decodeStyledPathValue will remove the prefix that label ('.') and matrix (';name=') styles add to a path
+// parameter value, leaving the serialized value. Values of simple style parameters are returned unchanged.
+func decodeStyledPathValue(p *v3.Parameter, isLabel, isMatrix bool, paramValue string) string {
+	if isLabel && p.Style == helpers.LabelStyle {
+		return strings.TrimPrefix(paramValue, helpers.Period)
+	}
+	if isMatrix && p.Style == helpers.MatrixStyle {
+		return strings.TrimPrefix(strings.TrimPrefix(paramValue, helpers.SemiColon), fmt.Sprintf("%s=", p.Name))
+	}
+	return paramValue
+}
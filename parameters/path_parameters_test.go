@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi-validator/helpers"
 	"github.com/pb33f/libopenapi-validator/paths"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -67,7 +68,7 @@ paths:
 
 	assert.False(t, valid)
 	assert.Len(t, errors, 2)
-	assert.Equal(t, "Path array parameter 'burgerIds' is not a valid number", errors[0].Message)
+	assert.Equal(t, "Path array parameter 'burgerIds' is not a valid integer", errors[0].Message)
 	assert.Equal(t, request.Method, errors[0].RequestMethod)
 	assert.Equal(t, request.URL.Path, errors[0].RequestPath)
 	assert.Equal(t, "/burgers/{burgerIds*}/locate", errors[0].SpecPath)
@@ -285,7 +286,7 @@ paths:
 
 	assert.False(t, valid)
 	assert.Len(t, errors, 1)
-	assert.Equal(t, "Path parameter 'burgerId' is not a valid number", errors[0].Message)
+	assert.Equal(t, "Path parameter 'burgerId' is not a valid integer", errors[0].Message)
 }
 
 func TestNewValidator_SimpleEncodedPath_IntegerViolation(t *testing.T) {
@@ -315,7 +316,7 @@ paths:
 	assert.Len(t, errors, 1)
 	assert.Equal(t, "Path parameter 'burgerId' failed to validate", errors[0].Message)
 	assert.Len(t, errors[0].SchemaValidationErrors, 1)
-	assert.Equal(t, "Reason: minimum: got 1, want 10, Location: /minimum", errors[0].SchemaValidationErrors[0].Error())
+	assert.Equal(t, "Reason: minimum: got 1, want 10, Location: /minimum, Keyword: minimum, Expected: 10, Actual: 1", errors[0].SchemaValidationErrors[0].Error())
 }
 
 func TestNewValidator_SimpleEncodedPath_Integer(t *testing.T) {
@@ -397,7 +398,7 @@ paths:
 
 	assert.False(t, valid)
 	assert.Len(t, errors, 1)
-	assert.Equal(t, "Path parameter 'burgerId' is not a valid number", errors[0].Message)
+	assert.Equal(t, "Path parameter 'burgerId' is not a valid integer", errors[0].Message)
 }
 
 func TestNewValidator_LabelEncodedPath_IntegerViolation(t *testing.T) {
@@ -428,7 +429,7 @@ paths:
 	assert.Len(t, errors, 1)
 	assert.Equal(t, "Path parameter 'burgerId' failed to validate", errors[0].Message)
 	assert.Len(t, errors[0].SchemaValidationErrors, 1)
-	assert.Equal(t, "Reason: minimum: got 3, want 10, Location: /minimum", errors[0].SchemaValidationErrors[0].Error())
+	assert.Equal(t, "Reason: minimum: got 3, want 10, Location: /minimum, Keyword: minimum, Expected: 10, Actual: 3", errors[0].SchemaValidationErrors[0].Error())
 }
 
 func TestNewValidator_LabelEncodedPath_InvalidBoolean(t *testing.T) {
@@ -774,7 +775,7 @@ paths:
 
 	assert.False(t, valid)
 	assert.Len(t, errors, 1)
-	assert.Equal(t, "Path parameter 'burgerId' is not a valid number", errors[0].Message)
+	assert.Equal(t, "Path parameter 'burgerId' is not a valid integer", errors[0].Message)
 }
 
 func TestNewValidator_MatrixEncodedPath_PrimitiveNumberViolation(t *testing.T) {
@@ -805,7 +806,7 @@ paths:
 	assert.Len(t, errors, 1)
 	assert.Equal(t, "Path parameter 'burgerId' failed to validate", errors[0].Message)
 	assert.Len(t, errors[0].SchemaValidationErrors, 1)
-	assert.Equal(t, "Reason: minimum: got 3, want 5, Location: /minimum", errors[0].SchemaValidationErrors[0].Error())
+	assert.Equal(t, "Reason: minimum: got 3, want 5, Location: /minimum, Keyword: minimum, Expected: 5, Actual: 3", errors[0].SchemaValidationErrors[0].Error())
 }
 
 func TestNewValidator_MatrixEncodedPath_ValidPrimitiveBoolean(t *testing.T) {
@@ -1109,7 +1110,7 @@ paths:
 
 	assert.False(t, valid)
 	assert.Len(t, errors, 1)
-	assert.Equal(t, "Path array parameter 'burger' is not a valid number", errors[0].Message)
+	assert.Equal(t, "Path array parameter 'burger' is not a valid integer", errors[0].Message)
 }
 
 func TestNewValidator_PathParams_PathNotFound(t *testing.T) {
@@ -1199,6 +1200,81 @@ paths:
 
 }
 
+func TestNewValidator_PathParamStringConst(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}/locate:
+    parameters:
+      - name: burgerId
+        in: path
+        schema:
+          type: string
+          const: bigMac
+    get:
+      operationId: locateBurgers`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/bigMac/locate", nil)
+	valid, errors := v.ValidatePathParams(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers/whopper/locate", nil)
+	valid, errors = v.ValidatePathParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Path parameter 'burgerId' does not match the constant value", errors[0].Message)
+	assert.Equal(t, "Instead of 'whopper', use the constant value: 'bigMac'", errors[0].HowToFix)
+}
+
+func TestNewValidator_PathParamRef(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}/locate:
+    parameters:
+      - $ref: '#/components/parameters/BurgerId'
+    get:
+      operationId: locateBurgers
+components:
+  parameters:
+    BurgerId:
+      name: burgerId
+      in: path
+      required: true
+      schema:
+        type: integer
+        minimum: 1`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/12/locate", nil)
+	valid, errors := v.ValidatePathParams(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers/0/locate", nil)
+	valid, errors = v.ValidatePathParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Path parameter 'burgerId' failed to validate", errors[0].Message)
+	assert.Equal(t, "minimum: got 0, want 1", errors[0].SchemaValidationErrors[0].Reason)
+}
+
 func TestNewValidator_PathParamStringViolation(t *testing.T) {
 
 	spec := `openapi: 3.1.0
@@ -1226,7 +1302,7 @@ paths:
 	assert.Len(t, errors, 1)
 	assert.Equal(t, "Path parameter 'burgerId' failed to validate", errors[0].Message)
 	assert.Len(t, errors[0].SchemaValidationErrors, 1)
-	assert.Equal(t, "Reason: minLength: got 3, want 4, Location: /minLength", errors[0].SchemaValidationErrors[0].Error())
+	assert.Equal(t, "Reason: minLength: got 3, want 4, Location: /minLength, Keyword: minLength, Expected: 4, Actual: 3", errors[0].SchemaValidationErrors[0].Error())
 }
 
 func TestNewValidator_PathParamIntegerEnumValid(t *testing.T) {
@@ -1496,3 +1572,553 @@ paths:
 	assert.False(t, valid)
 	assert.Len(t, errors, 1)
 }
+
+func TestNewValidator_MatrixStylePath_ValidPrimitiveString(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}/locate:
+    parameters:
+      - name: burgerId
+        in: path
+        required: true
+        style: matrix
+        schema:
+          type: string
+          enum: [bigMac, whopper]
+    get:
+      operationId: locateBurgers`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/;burgerId=bigMac/locate", nil)
+	valid, errors := v.ValidatePathParams(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_MatrixStylePath_InvalidPrimitiveString(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}/locate:
+    parameters:
+      - name: burgerId
+        in: path
+        required: true
+        style: matrix
+        schema:
+          type: string
+          maxLength: 6
+    get:
+      operationId: locateBurgers`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/;burgerId=quarterPounder/locate", nil)
+	valid, errors := v.ValidatePathParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Path parameter 'burgerId' failed to validate", errors[0].Message)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "maxLength: got 14, want 6", errors[0].SchemaValidationErrors[0].Reason)
+}
+
+func TestNewValidator_MatrixStylePath_InvalidPrimitiveNumber(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}/locate:
+    parameters:
+      - name: burgerId
+        in: path
+        required: true
+        style: matrix
+        schema:
+          type: integer
+    get:
+      operationId: locateBurgers`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/;burgerId=five/locate", nil)
+	valid, errors := v.ValidatePathParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Path parameter 'burgerId' is not a valid integer", errors[0].Message)
+	assert.Contains(t, errors[0].Reason, "'five'")
+}
+
+func TestNewValidator_MatrixStylePath_ValidArray_Exploded(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burger}/locate:
+    parameters:
+      - name: burger
+        in: path
+        required: true
+        style: matrix
+        explode: true
+        schema:
+          type: array
+          items:
+            type: integer
+    get:
+      operationId: locateBurgers`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/;burger=1;burger=2;burger=3/locate", nil)
+	valid, errors := v.ValidatePathParams(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_MatrixStylePath_InvalidArray_Exploded(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burger}/locate:
+    parameters:
+      - name: burger
+        in: path
+        required: true
+        style: matrix
+        explode: true
+        schema:
+          type: array
+          items:
+            type: integer
+    get:
+      operationId: locateBurgers`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/;burger=1;burger=two;burger=3/locate", nil)
+	valid, errors := v.ValidatePathParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Contains(t, errors[0].Reason, "'two'")
+}
+
+func TestNewValidator_LabelStylePath_ValidObject_Exploded(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burger}/locate:
+    parameters:
+      - name: burger
+        in: path
+        required: true
+        style: label
+        explode: true
+        schema:
+          type: object
+          properties:
+            id:
+              type: integer
+            vegetarian:
+              type: boolean
+    get:
+      operationId: locateBurgers`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/.id=1234.vegetarian=true/locate", nil)
+	valid, errors := v.ValidatePathParams(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_LabelStylePath_InvalidObject_Exploded(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burger}/locate:
+    parameters:
+      - name: burger
+        in: path
+        required: true
+        style: label
+        explode: true
+        schema:
+          type: object
+          properties:
+            id:
+              type: integer
+            vegetarian:
+              type: boolean
+    get:
+      operationId: locateBurgers`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/.id=1234.vegetarian=sometimes/locate", nil)
+	valid, errors := v.ValidatePathParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Path parameter 'burger' failed to validate", errors[0].Message)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+}
+
+func TestNewValidator_LabelStylePath_PrimitiveBoolean(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{vegetarian}/locate:
+    parameters:
+      - name: vegetarian
+        in: path
+        required: true
+        style: label
+        schema:
+          type: boolean
+    get:
+      operationId: locateBurgers`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/.true/locate", nil)
+	valid, errors := v.ValidatePathParams(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers/.sometimes/locate", nil)
+	valid, errors = v.ValidatePathParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+}
+
+func TestNewValidator_SimpleArrayPath_Valid(t *testing.T) {
+
+	for _, explode := range []string{"true", "false"} {
+		spec := `openapi: 3.1.0
+paths:
+  /users/{ids}:
+    get:
+      parameters:
+        - name: ids
+          in: path
+          required: true
+          explode: ` + explode + `
+          schema:
+            type: array
+            items:
+              type: string
+              pattern: '^[a-z]+$'`
+
+		doc, _ := libopenapi.NewDocument([]byte(spec))
+
+		m, _ := doc.BuildV3Model()
+		v := NewParameterValidator(&m.Model)
+
+		request, _ := http.NewRequest(http.MethodGet, "https://things.com/users/alice,bob,carol", nil)
+		valid, errors := v.ValidatePathParams(request)
+
+		assert.True(t, valid, "explode: %s", explode)
+		assert.Len(t, errors, 0)
+	}
+}
+
+func TestNewValidator_SimpleArrayPath_InvalidElement(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /users/{ids}:
+    get:
+      parameters:
+        - name: ids
+          in: path
+          required: true
+          explode: true
+          schema:
+            type: array
+            items:
+              type: string
+              pattern: '^[a-z]+$'`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/users/alice,B0B,carol", nil)
+	valid, errors := v.ValidatePathParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Path parameter 'ids' failed to validate", errors[0].Message)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "/1", errors[0].SchemaValidationErrors[0].InstancePath)
+}
+
+func TestNewValidator_SimpleArrayPath_ItemConstraint(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /users/{ids}:
+    get:
+      parameters:
+        - name: ids
+          in: path
+          required: true
+          schema:
+            type: array
+            maxItems: 2
+            items:
+              type: integer
+              minimum: 1`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/users/1,0,3", nil)
+	valid, errors := v.ValidatePathParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 2)
+}
+
+func TestNewValidator_SimpleObjectPath(t *testing.T) {
+
+	for _, tc := range []struct {
+		explode string
+		path    string
+		valid   bool
+	}{
+		{"false", "id,1234,vegetarian,true", true},
+		{"false", "id,1234,vegetarian,sometimes", false},
+		{"true", "id=1234,vegetarian=true", true},
+		{"true", "id=1234,vegetarian=sometimes", false},
+	} {
+		spec := `openapi: 3.1.0
+paths:
+  /burgers/{burger}:
+    get:
+      parameters:
+        - name: burger
+          in: path
+          required: true
+          explode: ` + tc.explode + `
+          schema:
+            type: object
+            properties:
+              id:
+                type: integer
+              vegetarian:
+                type: boolean`
+
+		doc, _ := libopenapi.NewDocument([]byte(spec))
+
+		m, _ := doc.BuildV3Model()
+		v := NewParameterValidator(&m.Model)
+
+		request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/"+tc.path, nil)
+		valid, errors := v.ValidatePathParams(request)
+
+		assert.Equal(t, tc.valid, valid, tc.path)
+		if !tc.valid {
+			assert.Len(t, errors, 1)
+		}
+	}
+}
+
+func TestNewValidator_PathParamEmptySegment(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}/locate:
+    get:
+      parameters:
+        - name: burgerId
+          in: path
+          required: true
+          schema:
+            type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	// the path template matches, but the segment holding the parameter is empty.
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers//locate", nil)
+	valid, errors := v.ValidatePathParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Path parameter 'burgerId' is missing", errors[0].Message)
+	assert.Equal(t, helpers.ParameterValidation, errors[0].ValidationType)
+	assert.Equal(t, "/burgers/{burgerId}/locate", errors[0].SpecPath)
+	assert.False(t, errors[0].IsPathMissingError())
+}
+
+func TestNewValidator_PathParamEmptyTrailingSegment_RequiredNotDeclared(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}:
+    get:
+      parameters:
+        - name: burgerId
+          in: path
+          schema:
+            type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/", nil)
+	valid, errors := v.ValidatePathParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Path parameter 'burgerId' is missing", errors[0].Message)
+	assert.Equal(t, 6, errors[0].SpecLine)
+
+	// without the trailing slash, the path is not found at all.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers", nil)
+	valid, errors = v.ValidatePathParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.True(t, errors[0].IsPathMissingError())
+}
+
+func TestNewValidator_PathParamPercentEncodedEnum(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burger}/locate:
+    get:
+      parameters:
+        - name: burger
+          in: path
+          required: true
+          schema:
+            type: string
+            enum: [big mac, quarter pounder]`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/big%20mac/locate", nil)
+	valid, errors := v.ValidatePathParams(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers/whopper%20jr/locate", nil)
+	valid, errors = v.ValidatePathParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Contains(t, errors[0].Reason, "'whopper jr'")
+}
+
+func TestNewValidator_PathParamPercentEncodedSlash(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /files/{name}:
+    get:
+      parameters:
+        - name: name
+          in: path
+          required: true
+          schema:
+            type: string
+            pattern: '^[a-z]+/[a-z]+\.txt$'`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	// an encoded slash is part of the value, rather than separating two segments.
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/files/docs%2Freadme.txt", nil)
+	valid, errors := v.ValidatePathParams(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_PathParamPercentEncodedArrayDelimiter(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{toppings}:
+    get:
+      parameters:
+        - name: toppings
+          in: path
+          required: true
+          schema:
+            type: array
+            maxItems: 2
+            items:
+              type: string
+              enum: [cheese, salt, pepper, 'salt,pepper']`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	// the encoded comma is part of the second item, so there are two items, not three.
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/cheese,salt%2Cpepper", nil)
+	valid, errors := v.ValidatePathParams(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/burgers/cheese,salt,pepper", nil)
+	valid, errors = v.ValidatePathParams(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+}
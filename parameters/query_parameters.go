@@ -8,9 +8,11 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
+	"github.com/pb33f/libopenapi-validator/config"
 	"github.com/pb33f/libopenapi-validator/errors"
 	"github.com/pb33f/libopenapi-validator/helpers"
 	"github.com/pb33f/libopenapi-validator/paths"
@@ -19,6 +21,10 @@ import (
 	"github.com/pb33f/libopenapi/orderedmap"
 )
 
+// reservedValuesRegex matches the reserved characters that must be percent-encoded in query values, unless
+// 'allowReserved' is set. A '+' is not included, as it's the form encoding of a space in a raw query string.
+var reservedValuesRegex = regexp.MustCompile(`[:\/\?#\[\]\@!\$&'\(\)\*,;=]`)
+
 func (v *paramValidator) ValidateQueryParams(request *http.Request) (bool, []*errors.ValidationError) {
 	pathItem, errs, foundPath := paths.FindPath(request, v.document)
 	if len(errs) > 0 {
@@ -44,6 +50,7 @@ func (v *paramValidator) ValidateQueryParamsWithPathItem(request *http.Request,
 	// extract params for the operation
 	params := helpers.ExtractParamsForOperation(request, pathItem)
 	queryParams := make(map[string][]*helpers.QueryParam)
+	rawQueryValues := helpers.ExtractRawQueryValues(request.URL.RawQuery)
 	var validationErrors []*errors.ValidationError
 
 	for qKey, qVal := range request.URL.Query() {
@@ -51,21 +58,24 @@ func (v *paramValidator) ValidateQueryParamsWithPathItem(request *http.Request,
 		if strings.IndexRune(qKey, '[') > 0 && strings.IndexRune(qKey, ']') > 0 {
 			stripped := qKey[:strings.IndexRune(qKey, '[')]
 			value := qKey[strings.IndexRune(qKey, '[')+1 : strings.IndexRune(qKey, ']')]
+			_, path := helpers.ExtractDeepObjectPath(qKey)
 			queryParams[stripped] = append(queryParams[stripped], &helpers.QueryParam{
-				Key:      stripped,
-				Values:   qVal,
-				Property: value,
+				Key:       stripped,
+				Values:    qVal,
+				RawValues: rawQueryValues[qKey],
+				Property:  value,
+				Path:      path,
 			})
 		} else {
 			queryParams[qKey] = append(queryParams[qKey], &helpers.QueryParam{
-				Key:    qKey,
-				Values: qVal,
+				Key:       qKey,
+				Values:    qVal,
+				RawValues: rawQueryValues[qKey],
 			})
 		}
 	}
 
 	// look through the params for the query key
-doneLooking:
 	for p := range params {
 		if params[p].In == helpers.Query {
 
@@ -75,9 +85,6 @@ doneLooking:
 			if jk, ok := queryParams[params[p].Name]; ok {
 			skipValues:
 				for _, fp := range jk {
-					// let's check styles first.
-					validationErrors = append(validationErrors, ValidateQueryParamStyle(params[p], jk)...)
-
 					// there is a match, is the type correct
 					// this context is extracted from the 3.1 spec to explain what is going on here:
 					// For more complex scenarios, the content property can define the media type and schema of the
@@ -89,25 +96,47 @@ doneLooking:
 					} else {
 						// ok, no schema, check for a content type
 						for pair := orderedmap.First(params[p].Content); pair != nil; pair = pair.Next() {
-							sch = pair.Value().Schema.Schema()
+							if pair.Value().Schema != nil {
+								sch = pair.Value().Schema.Schema()
+							}
 							contentWrapped = true
 							contentType = pair.Key()
 							break
 						}
 					}
+					if sch == nil {
+						continue
+					}
+
+					// content encoded values are decoded using the media type, styles do not apply to them.
+					if contentWrapped && helpers.IsJSONContentType(contentType) {
+						validationErrors = append(validationErrors, validateQueryParamJSONContent(params[p], fp, sch, v.options)...)
+						continue
+					}
+
+					// let's check styles first.
+					validationErrors = append(validationErrors, ValidateQueryParamStyle(params[p], jk)...)
+
 					pType := sch.Type
 
+					// track the index of the first array item held by each value, as exploded arrays are
+					// spread across multiple values.
+					arrayOffset := 0
+
 					// for each param, check each type
-					for _, ef := range fp.Values {
+					for i, ef := range fp.Values {
 
 						// check allowReserved values. If this is set to true, then we can allow the
 						// following characters
 						//  :/?#[]@!$&'()*+,;=
-						// to be present as they are, without being URLEncoded.
-						if !params[p].AllowReserved {
-							rx := `[:\/\?#\[\]\@!\$&'\(\)\*\+,;=]`
-							regexp.MustCompile(rx)
-							if regexp.MustCompile(rx).MatchString(ef) && params[p].IsExploded() {
+						// to be present as they are, without being URLEncoded. The check is performed against
+						// the raw value, as it was sent, so correctly percent-encoded values are not flagged.
+						if !params[p].AllowReserved && params[p].IsExploded() {
+							raw := ef
+							if i < len(fp.RawValues) {
+								raw = fp.RawValues[i]
+							}
+							if reservedValuesRegex.MatchString(raw) {
 								validationErrors = append(validationErrors,
 									errors.IncorrectReservedValues(params[p], ef, sch))
 							}
@@ -116,7 +145,7 @@ doneLooking:
 							switch ty {
 
 							case helpers.String:
-								validationErrors = v.validateSimpleParam(sch, ef, ef, params[p])
+								validationErrors = append(validationErrors, v.validateSimpleParam(sch, ef, ef, params[p])...)
 							case helpers.Integer, helpers.Number:
 								efF, err := strconv.ParseFloat(ef, 64)
 								if err != nil {
@@ -124,11 +153,20 @@ doneLooking:
 										errors.InvalidQueryParamNumber(params[p], ef, sch))
 									break
 								}
-								validationErrors = v.validateSimpleParam(sch, ef, efF, params[p])
+								validationErrors = append(validationErrors, v.validateSimpleParam(sch, ef, efF, params[p])...)
 							case helpers.Boolean:
 								if _, err := strconv.ParseBool(ef); err != nil {
 									validationErrors = append(validationErrors,
 										errors.IncorrectQueryParamBool(params[p], ef, sch))
+									break
+								}
+								if sch.Enum != nil && !helpers.MatchesEnum(ef, ty, sch.Enum) {
+									validationErrors = append(validationErrors,
+										errors.IncorrectQueryParamEnum(params[p], ef, sch))
+								}
+								if sch.Const != nil && !helpers.MatchesConst(ef, ty, sch.Const) {
+									validationErrors = append(validationErrors,
+										errors.IncorrectQueryParamConst(params[p], ef, sch))
 								}
 							case helpers.Object:
 
@@ -138,6 +176,16 @@ doneLooking:
 
 								switch params[p].Style {
 								case helpers.DeepObject:
+									if helpers.IsNestedDeepObjectEncoding(jk) {
+										nested, err := helpers.ConstructNestedParamMapFromDeepObjectEncoding(jk)
+										if err != nil {
+											validationErrors = append(validationErrors,
+												errors.IncorrectDeepObjectNesting(params[p], err.Error(), sch))
+											break skipValues
+										}
+										encodedObj = nested
+										break
+									}
 									encodedObj = helpers.ConstructParamMapFromDeepObjectEncoding(jk, sch)
 								case helpers.PipeDelimited:
 									encodedObj = helpers.ConstructParamMapFromPipeEncoding(jk)
@@ -145,22 +193,7 @@ doneLooking:
 									encodedObj = helpers.ConstructParamMapFromSpaceEncoding(jk)
 								default:
 									// form encoding is default.
-									if contentWrapped {
-										switch contentType {
-										case helpers.JSONContentType:
-											// we need to unmarshal the JSON into a map[string]interface{}
-											encodedParams := make(map[string]interface{})
-											encodedObj = make(map[string]interface{})
-											if err := json.Unmarshal([]byte(ef), &encodedParams); err != nil {
-												validationErrors = append(validationErrors,
-													errors.IncorrectParamEncodingJSON(params[p], ef, sch))
-												break skipValues
-											}
-											encodedObj[params[p].Name] = encodedParams
-										}
-									} else {
-										encodedObj = helpers.ConstructParamMapFromFormEncodingArray(jk)
-									}
+									encodedObj = helpers.ConstructParamMapFromFormEncodingArray(jk)
 								}
 
 								numErrors := len(validationErrors)
@@ -171,7 +204,8 @@ doneLooking:
 										"The query parameter",
 										params[p].Name,
 										helpers.ParameterValidation,
-										helpers.ParameterValidationQuery)...)
+										helpers.ParameterValidationQuery,
+										config.WithExistingOpts(v.options))...)
 								if len(validationErrors) > numErrors {
 									// we've already added an error for this, so we can skip the rest of the values
 									break skipValues
@@ -183,11 +217,18 @@ doneLooking:
 								// only check if items is a schema, not a boolean
 								if sch.Items != nil && sch.Items.IsA() {
 									validationErrors = append(validationErrors,
-										ValidateQueryArray(sch, params[p], ef, contentWrapped)...)
+										validateQueryArray(sch, params[p], ef, contentWrapped, arrayOffset, v.options)...)
 								}
+								arrayOffset += len(decodeQueryArrayItems(params[p], ef, contentWrapped))
 							}
 						}
 					}
+
+					// validate the decoded array as a whole against the array level constraints.
+					if slices.Contains(pType, helpers.Array) {
+						validationErrors = append(validationErrors,
+							ValidateQueryArrayConstraints(sch, params[p], fp.Values, contentWrapped)...)
+					}
 				}
 			} else {
 				// if the param is not in the requests, so let's check if this param is an
@@ -196,19 +237,33 @@ doneLooking:
 					sch := params[p].Schema.Schema()
 
 					if len(sch.Type) > 0 && sch.Type[0] == helpers.Object && params[p].IsDefaultFormEncoding() {
-						// if the param is an object, and we're using default encoding, then we need to
-						// validate the schema.
-						decoded := helpers.ConstructParamMapFromQueryParamInput(queryParams)
-						validationErrors = append(validationErrors,
-							ValidateParameterSchema(sch,
-								decoded,
-								"",
-								"Query array parameter",
-								"The query parameter (which is an array)",
-								params[p].Name,
-								helpers.ParameterValidation,
-								helpers.ParameterValidationQuery)...)
-						break doneLooking
+						// each property of an exploded form object is sent as a separate query key, so the object
+						// is reconstructed from the keys that are not defined by any other parameter.
+						var objectParams []*helpers.QueryParam
+						for qKey, qps := range queryParams {
+							if isQueryKeyDefined(qKey, params, params[p]) {
+								continue
+							}
+							for _, qp := range qps {
+								if qp.Property == "" {
+									objectParams = append(objectParams, qp)
+								}
+							}
+						}
+						if len(objectParams) > 0 {
+							decoded := helpers.ConstructParamMapFromExplodedFormObject(objectParams, sch)
+							validationErrors = append(validationErrors,
+								ValidateParameterSchema(sch,
+									decoded,
+									"",
+									"Query parameter",
+									"The query parameter",
+									params[p].Name,
+									helpers.ParameterValidation,
+									helpers.ParameterValidationQuery,
+									config.WithExistingOpts(v.options))...)
+							continue
+						}
 					}
 				}
 				// if there is no match, check if the param is required or not.
@@ -219,6 +274,15 @@ doneLooking:
 		}
 	}
 
+	// in strict mode, report any query parameters that have not been defined.
+	if v.options != nil && v.options.StrictQueryParams {
+		for qKey := range queryParams {
+			if !isQueryKeyDefined(qKey, params, nil) {
+				validationErrors = append(validationErrors, errors.UndefinedQueryParam(qKey, pathItem))
+			}
+		}
+	}
+
 	errors.PopulateValidationErrors(validationErrors, request, pathValue)
 
 	if len(validationErrors) > 0 {
@@ -227,19 +291,87 @@ doneLooking:
 	return true, nil
 }
 
-func (v *paramValidator) validateSimpleParam(sch *base.Schema, rawParam string, parsedParam any, parameter *v3.Parameter) (validationErrors []*errors.ValidationError) {
-	// check if the param is within an enum
-	if sch.Enum != nil {
-		matchFound := false
-		for _, enumVal := range sch.Enum {
-			if strings.TrimSpace(rawParam) == fmt.Sprint(enumVal.Value) {
-				matchFound = true
-				break
+// isQueryKeyDefined will check if a query key is defined by one of the query parameters, other than the excluded
+// parameter (which may be nil). Bracketed keys, such as deepObject keys, are checked using the name before the
+// brackets. A key is defined by a parameter with the same name, or by an exploded 'form' object parameter that
+// defines it as a property, or that explicitly allows additional properties. The same check decides which keys
+// are undefined in strict mode, and which keys an exploded 'form' object is reconstructed from, so they agree.
+func isQueryKeyDefined(key string, params []*v3.Parameter, excluded *v3.Parameter) bool {
+	for _, p := range params {
+		if p == excluded || p.In != helpers.Query {
+			continue
+		}
+		if p.Name == key {
+			return true
+		}
+		if p.Schema == nil || !p.IsDefaultFormEncoding() {
+			continue
+		}
+		sch := p.Schema.Schema()
+		if sch == nil || !slices.Contains(sch.Type, helpers.Object) {
+			continue
+		}
+		if sch.Properties != nil {
+			if _, ok := sch.Properties.Get(key); ok {
+				return true
 			}
 		}
-		if !matchFound {
-			return []*errors.ValidationError{errors.IncorrectQueryParamEnum(parameter, rawParam, sch)}
+		if sch.AdditionalProperties != nil && (sch.AdditionalProperties.IsA() || sch.AdditionalProperties.B) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateQueryParamJSONContent will decode each value of a query parameter that is defined using a JSON media
+// type, and validate it against the schema of that media type. When the schema is an array, each value that is not
+// itself a JSON array is treated as an item of that array.
+func validateQueryParamJSONContent(param *v3.Parameter, qp *helpers.QueryParam, sch *base.Schema,
+	options *config.ValidationOptions) []*errors.ValidationError {
+	var validationErrors []*errors.ValidationError
+	for _, ef := range qp.Values {
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(ef), &decoded); err != nil {
+			validationErrors = append(validationErrors, errors.IncorrectParamEncodingJSON(param, ef, sch))
+			continue
+		}
+		if _, isArray := decoded.([]interface{}); !isArray && slices.Contains(sch.Type, helpers.Array) &&
+			sch.Items != nil && sch.Items.IsA() {
+			validationErrors = append(validationErrors,
+				validateDecodedParameter(sch.Items.A.Schema(),
+					decoded,
+					"Query array parameter",
+					"The query parameter (which is an array)",
+					param.Name,
+					helpers.ParameterValidation,
+					helpers.ParameterValidationQuery,
+					config.WithExistingOpts(options))...)
+			continue
 		}
+		validationErrors = append(validationErrors,
+			validateDecodedParameter(sch,
+				decoded,
+				"Query parameter",
+				"The query parameter",
+				param.Name,
+				helpers.ParameterValidation,
+				helpers.ParameterValidationQuery,
+				config.WithExistingOpts(options))...)
+	}
+	return validationErrors
+}
+
+func (v *paramValidator) validateSimpleParam(sch *base.Schema, rawParam string, parsedParam any, parameter *v3.Parameter) (validationErrors []*errors.ValidationError) {
+	// check if the param is within an enum, or matches a const, numbers are compared as numbers.
+	valueType := helpers.String
+	if _, ok := parsedParam.(float64); ok {
+		valueType = helpers.Number
+	}
+	if sch.Enum != nil && !helpers.MatchesEnum(rawParam, valueType, sch.Enum) {
+		return []*errors.ValidationError{errors.IncorrectQueryParamEnum(parameter, rawParam, sch)}
+	}
+	if sch.Const != nil && !helpers.MatchesConst(rawParam, valueType, sch.Const) {
+		return []*errors.ValidationError{errors.IncorrectQueryParamConst(parameter, rawParam, sch)}
 	}
 
 	return ValidateSingleParameterSchema(
@@ -250,5 +382,6 @@ func (v *paramValidator) validateSimpleParam(sch *base.Schema, rawParam string,
 		parameter.Name,
 		helpers.ParameterValidation,
 		helpers.ParameterValidationQuery,
+		config.WithExistingOpts(v.options),
 	)
 }
@@ -5,9 +5,11 @@ package parameters
 
 import (
 	"net/http"
+	"net/url"
 	"testing"
 
 	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi-validator/config"
 	"github.com/pb33f/libopenapi-validator/paths"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -668,7 +670,7 @@ paths:
 	assert.Len(t, errors, 0)
 }
 
-func TestNewValidator_QueryParamValidTypeArrayString(t *testing.T) {
+func TestNewValidator_QueryParamValidEnumNumberDecimal(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
   /a/fishy/on/a/dishy:
@@ -678,11 +680,8 @@ paths:
           in: query
           required: true
           schema:
-            type: array
-            items:
-              type: string
-      operationId: locateFishy
-`
+            type: integer
+            enum: [1, 99]`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -690,14 +689,14 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=cod&fishy=haddock", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=99.0", nil)
 
 	valid, errors := v.ValidateQueryParams(request)
 	assert.True(t, valid)
-	assert.Nil(t, errors)
+	assert.Len(t, errors, 0)
 }
 
-func TestNewValidator_QueryParamInvalidTypeArrayStringEnum(t *testing.T) {
+func TestNewValidator_QueryParamInvalidEnumBool(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
   /a/fishy/on/a/dishy:
@@ -707,12 +706,8 @@ paths:
           in: query
           required: true
           schema:
-            type: array
-            items:
-              type: string
-              enum: [cod, halibut]
-      operationId: locateFishy
-`
+            type: boolean
+            enum: [true]`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -720,30 +715,33 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=cod&fishy=haddock", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=false", nil)
 
 	valid, errors := v.ValidateQueryParams(request)
 	assert.False(t, valid)
 	assert.Len(t, errors, 1)
-	assert.Equal(t, "Instead of 'haddock', use one of the allowed values: 'cod, halibut'", errors[0].HowToFix)
+	assert.Equal(t, "Query parameter 'fishy' does not match allowed values", errors[0].Message)
+	assert.Equal(t, "Instead of 'false', use one of the allowed values: 'true'", errors[0].HowToFix)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=true", nil)
+
+	valid, errors = v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
 }
 
-func TestNewValidator_QueryParamInvalidTypeArrayNumberEnum(t *testing.T) {
+func TestNewValidator_QueryParamConst(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
   /a/fishy/on/a/dishy:
     get:
       parameters:
-        - name: fishy
+        - name: kind
           in: query
           required: true
           schema:
-            type: array
-            items:
-              type: number
-              enum: [1, 99]
-      operationId: locateFishy
-`
+            type: string
+            const: fish`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -751,29 +749,33 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=1&fishy=2", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?kind=fish", nil)
 
 	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?kind=chips", nil)
+
+	valid, errors = v.ValidateQueryParams(request)
 	assert.False(t, valid)
 	assert.Len(t, errors, 1)
-	assert.Equal(t, "Instead of '2', use one of the allowed values: '1, 99'", errors[0].HowToFix)
+	assert.Equal(t, "Query parameter 'kind' does not match the constant value", errors[0].Message)
+	assert.Equal(t, "Instead of 'chips', use the constant value: 'fish'", errors[0].HowToFix)
 }
 
-func TestNewValidator_QueryParamInvalidTypeArrayNumber(t *testing.T) {
+func TestNewValidator_QueryParamConstNumber(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
- /a/fishy/on/a/dishy:
-   get:
-     parameters:
-       - name: fishy
-         in: query
-         required: true
-         schema:
-           type: array
-           items:
-             type: number
-     operationId: locateFishy
-`
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: version
+          in: query
+          required: true
+          schema:
+            type: integer
+            const: 2`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -781,36 +783,33 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=cod&fishy=haddock", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?version=2", nil)
 
 	valid, errors := v.ValidateQueryParams(request)
-	assert.False(t, valid)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
 
-	assert.Len(t, errors, 2)
-	assert.Equal(t, "Query array parameter 'fishy' is not a valid number", errors[0].Message)
-	assert.Equal(t, "The query parameter (which is an array) 'fishy' is defined as being a number, "+
-		"however the value 'cod' is not a valid number", errors[0].Reason)
-	assert.Equal(t, "Query array parameter 'fishy' is not a valid number", errors[1].Message)
-	assert.Equal(t, "The query parameter (which is an array) 'fishy' is defined as being a number, "+
-		"however the value 'haddock' is not a valid number", errors[1].Reason)
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?version=3", nil)
+
+	valid, errors = v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Instead of '3', use the constant value: '2'", errors[0].HowToFix)
 }
 
-func TestNewValidator_QueryParamValidExplodedType(t *testing.T) {
+func TestNewValidator_QueryParamNumberConstraints(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
- /a/fishy/on/a/dishy:
-   get:
-     parameters:
-       - name: fishy
-         in: query
-         required: true
-         explode: true
-         schema:
-           type: array
-           items:
-             type: string
-     operationId: locateFishy
-`
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: age
+          in: query
+          required: true
+          schema:
+            type: integer
+            minimum: 0
+            multipleOf: 5`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -818,29 +817,42 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=cod,haddock", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?age=10", nil)
 
 	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?age=-1", nil)
+
+	valid, errors = v.ValidateQueryParams(request)
 	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Query parameter 'age' failed to validate", errors[0].Message)
+	assert.Len(t, errors[0].SchemaValidationErrors, 2)
 
-	assert.Len(t, errors, 2)
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?age=12", nil)
+
+	valid, errors = v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "/multipleOf", errors[0].SchemaValidationErrors[0].Location)
 }
 
-func TestNewValidator_QueryParamInvalidExplodedArray(t *testing.T) {
+func TestNewValidator_QueryParamStringConstraints(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
- /a/fishy/on/a/dishy:
-   get:
-     parameters:
-       - name: fishy
-         in: query
-         required: true
-         explode: true
-         schema:
-           type: array
-           items:
-             type: number
-     operationId: locateFishy`
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: code
+          in: query
+          required: true
+          schema:
+            type: string
+            pattern: "^[A-Z]{2}[0-9]+$"
+            maxLength: 6`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -848,27 +860,46 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=1&fishy=2", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?code=AB123", nil)
+
 	valid, errors := v.ValidateQueryParams(request)
 	assert.True(t, valid)
 	assert.Len(t, errors, 0)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?code=ab123", nil)
+
+	valid, errors = v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Query parameter 'code' failed to validate", errors[0].Message)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "/pattern", errors[0].SchemaValidationErrors[0].Location)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?code=AB12345", nil)
+
+	valid, errors = v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "/maxLength", errors[0].SchemaValidationErrors[0].Location)
 }
 
-func TestNewValidator_QueryParamInvalidExplodedArrayAndInvalidType(t *testing.T) {
+func TestNewValidator_QueryParamRef(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
- /a/fishy/on/a/dishy:
-   get:
-     parameters:
-       - name: fishy
-         in: query
-         required: true
-         explode: true
-         schema:
-           type: array
-           items:
-             type: number
-     operationId: locateFishy`
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - $ref: '#/components/parameters/PageSize'
+components:
+  parameters:
+    PageSize:
+      name: pageSize
+      in: query
+      required: true
+      schema:
+        type: integer
+        maximum: 50`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -876,29 +907,50 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=haddock&fishy=cod", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?pageSize=20", nil)
 
 	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?pageSize=100", nil)
+
+	valid, errors = v.ValidateQueryParams(request)
 	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Query parameter 'pageSize' failed to validate", errors[0].Message)
+	assert.Equal(t, "maximum: got 100, want 50", errors[0].SchemaValidationErrors[0].Reason)
 
-	assert.Len(t, errors, 2)
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy", nil)
+
+	valid, errors = v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Query parameter 'pageSize' is missing", errors[0].Message)
 }
 
-func TestNewValidator_QueryParamValidExploded(t *testing.T) {
+func TestNewValidator_QueryParamPathLevel(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
- /a/fishy/on/a/dishy:
-   get:
-     parameters:
-       - name: fishy
-         in: query
-         required: true
-         explode: false
-         schema:
-           type: array
-           items:
-             type: string
-     operationId: locateFishy`
+  /a/fishy/on/a/dishy:
+    parameters:
+      - name: fishy
+        in: query
+        required: true
+        schema:
+          type: integer
+          maximum: 10
+    get:
+      operationId: locateFishy
+    post:
+      operationId: locateFishyOverride
+      parameters:
+        - name: fishy
+          in: query
+          required: true
+          schema:
+            type: string
+            enum: [cod, haddock]`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -906,28 +958,49 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=cod,haddock,mackrel", nil)
+	// the path level parameter is inherited by the operation
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=12", nil)
 
 	valid, errors := v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Query parameter 'fishy' failed to validate", errors[0].Message)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=8", nil)
+
+	valid, errors = v.ValidateQueryParams(request)
 	assert.True(t, valid)
+	assert.Len(t, errors, 0)
 
+	// the operation level parameter overrides the path level parameter
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/a/fishy/on/a/dishy?fishy=cod", nil)
+
+	valid, errors = v.ValidateQueryParams(request)
+	assert.True(t, valid)
 	assert.Len(t, errors, 0)
+
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/a/fishy/on/a/dishy?fishy=8", nil)
+
+	valid, errors = v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Query parameter 'fishy' does not match allowed values", errors[0].Message)
 }
 
-func TestNewValidator_QueryParamInvalidTypeArrayBool(t *testing.T) {
+func TestNewValidator_QueryParamInvalidTypeArrayBoolEnum(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
- /a/fishy/on/a/dishy:
-   get:
-     parameters:
-       - name: fishy
-         in: query
-         required: true
-         schema:
-           type: array
-           items:
-             type: boolean 
-operationId: locateFishy`
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          required: true
+          schema:
+            type: array
+            items:
+              type: boolean
+              enum: [true]`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -935,34 +1008,29 @@ operationId: locateFishy`
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=cod&fishy=haddock", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=true&fishy=false", nil)
 
 	valid, errors := v.ValidateQueryParams(request)
 	assert.False(t, valid)
-
-	assert.Len(t, errors, 2)
-	assert.Equal(t, "Query array parameter 'fishy' is not a valid boolean", errors[0].Message)
-	assert.Equal(t, "The query parameter (which is an array) 'fishy' is defined as being a boolean, "+
-		"however the value 'cod' is not a valid true/false value", errors[0].Reason)
-	assert.Equal(t, "Query array parameter 'fishy' is not a valid boolean", errors[1].Message)
-	assert.Equal(t, "The query parameter (which is an array) 'fishy' is defined as being a boolean, "+
-		"however the value 'haddock' is not a valid true/false value", errors[1].Reason)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Query array parameter 'fishy' does not match allowed values", errors[0].Message)
 }
 
-func TestNewValidator_QueryParamInvalidTypeArrayFloat(t *testing.T) {
+func TestNewValidator_QueryParamValidTypeArrayString(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
- /a/fishy/on/a/dishy:
-   get:
-     parameters:
-       - name: fishy
-         in: query
-         required: true
-         schema:
-           type: array
-           items:
-             type: number
-operationId: locateFishy`
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          required: true
+          schema:
+            type: array
+            items:
+              type: string
+      operationId: locateFishy
+`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -970,73 +1038,14 @@ operationId: locateFishy`
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=12&fishy=12.12&fishy=1234567789.1233456657", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=cod&fishy=haddock", nil)
+
 	valid, errors := v.ValidateQueryParams(request)
 	assert.True(t, valid)
-	assert.Len(t, errors, 0)
-}
-
-func TestNewValidator_QueryParamInvalidTypeArrayFloatPipeDelimited(t *testing.T) {
-	spec := `openapi: 3.1.0
-paths:
- /a/fishy/on/a/dishy:
-   get:
-     parameters:
-       - name: fishy
-         in: query
-         style: pipeDelimited
-         required: true
-         schema:
-           type: array
-           items:
-             type: number
-operationId: locateFishy`
-
-	doc, _ := libopenapi.NewDocument([]byte(spec))
-
-	m, _ := doc.BuildV3Model()
-
-	v := NewParameterValidator(&m.Model)
-
-	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=12|12345.2344|22111233444.342452435", nil)
-	valid, errors := v.ValidateQueryParams(request)
-	assert.True(t, valid)
-	assert.Len(t, errors, 0)
-}
-
-func TestNewValidator_QueryParamInvalidTypeArrayObjectPipeDelimited(t *testing.T) {
-	spec := `openapi: 3.1.0
-paths:
- /a/fishy/on/a/dishy:
-   get:
-     parameters:
-       - name: fishy
-         in: query
-         style: pipeDelimited
-         required: true
-         schema:
-           type: object
-           properties:
-             ocean:
-               type: number
-             silver:
-               type: number
-           required: [ocean, silver]
-operationId: locateFishy`
-
-	doc, _ := libopenapi.NewDocument([]byte(spec))
-
-	m, _ := doc.BuildV3Model()
-
-	v := NewParameterValidator(&m.Model)
-
-	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=ocean|12|silver|12.2345", nil)
-	valid, errors := v.ValidateQueryParams(request)
-	assert.True(t, valid)
-	assert.Len(t, errors, 0)
+	assert.Nil(t, errors)
 }
 
-func TestNewValidator_QueryParamValidTypeObject(t *testing.T) {
+func TestNewValidator_QueryParamInvalidTypeArrayStringEnum(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
   /a/fishy/on/a/dishy:
@@ -1048,16 +1057,10 @@ paths:
           schema:
             type: array
             items:
-              type: object
-              properties:
-                vinegar:
-                  type: boolean
-                chips:
-                  type: number
-              required:
-                - vinegar
-                - chips
-      operationId: locateFishy`
+              type: string
+              enum: [cod, halibut]
+      operationId: locateFishy
+`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -1065,20 +1068,15 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy={\"cod\":\"cakes\"}&fishy={\"crab\":\"legs\"}", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=cod&fishy=haddock", nil)
 
 	valid, errors := v.ValidateQueryParams(request)
 	assert.False(t, valid)
-
-	assert.Len(t, errors, 2)
-	assert.Equal(t, "Query array parameter 'fishy' failed to validate", errors[0].Message)
-	assert.Equal(t, "The query parameter (which is an array) 'fishy' is defined as an object, "+
-		"however it failed to pass a schema validation", errors[0].Reason)
-	assert.Equal(t, "missing properties 'vinegar', 'chips'", errors[0].SchemaValidationErrors[0].Reason)
-	assert.Equal(t, "/required", errors[0].SchemaValidationErrors[0].Location)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Instead of 'haddock', use one of the allowed values: 'cod, halibut'", errors[0].HowToFix)
 }
 
-func TestNewValidator_QueryParamValidTypeObjectPropType_Invalid(t *testing.T) {
+func TestNewValidator_QueryParamInvalidTypeArrayNumberEnum(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
   /a/fishy/on/a/dishy:
@@ -1087,19 +1085,13 @@ paths:
         - name: fishy
           in: query
           required: true
-          content:
-            application/json:
-              schema:
-                type: object
-                properties:
-                  vinegar:
-                    type: boolean
-                  chips:
-                    type: number
-                  required:
-                    - vinegar
-                    - chips
-      operationId: locateFishy`
+          schema:
+            type: array
+            items:
+              type: number
+              enum: [1, 99]
+      operationId: locateFishy
+`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -1107,14 +1099,15 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy={\"vinegar\":\"cakes\",\"chips\":\"hello\"}&fishy={\"vinegar\":true,\"chips\":123.223}", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=1&fishy=2", nil)
+
 	valid, errors := v.ValidateQueryParams(request)
 	assert.False(t, valid)
 	assert.Len(t, errors, 1)
-	assert.Len(t, errors[0].SchemaValidationErrors, 2)
+	assert.Equal(t, "Instead of '2', use one of the allowed values: '1, 99'", errors[0].HowToFix)
 }
 
-func TestNewValidator_QueryParamValidTypeObjectPropTypeFloat(t *testing.T) {
+func TestNewValidator_QueryParamArrayTypeCoercion(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
   /a/fishy/on/a/dishy:
@@ -1123,63 +1116,67 @@ paths:
         - name: fishy
           in: query
           required: true
-          content:
-            application/json:
-              schema:
-                type: object
-                properties:
-                  vinegar:
-                    type: boolean
-                  chips:
-                    type: number
-              required:
-                - vinegar
-                - chips
-      operationId: locateFishy`
+          schema:
+            type: array
+            items:
+              type: integer
+              minimum: 1
+              maximum: 10
+      operationId: locateFishy
+`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
 	m, _ := doc.BuildV3Model()
 
+	// without coercion, only the type of each item is checked.
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy={\"vinegar\":true,\"chips\":12}&fishy={\"vinegar\":true,\"chips\":123.333}", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=1&fishy=50", nil)
+
 	valid, errors := v.ValidateQueryParams(request)
 	assert.True(t, valid)
 	assert.Len(t, errors, 0)
+
+	// with coercion, each item is validated against the items schema.
+	v = NewParameterValidator(&m.Model, config.WithParameterTypeCoercion())
+
+	valid, errors = v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Query array parameter 'fishy' failed to validate", errors[0].Message)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "maximum: got 50, want 10", errors[0].SchemaValidationErrors[0].Reason)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=0&fishy=2.5", nil)
+
+	valid, errors = v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 2)
+	assert.Equal(t, "minimum: got 0, want 1", errors[0].SchemaValidationErrors[0].Reason)
+	assert.Equal(t, "/type", errors[1].SchemaValidationErrors[0].Location)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=1&fishy=10", nil)
+
+	valid, errors = v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
 }
 
-func TestNewValidator_QueryParamInvalidTypeObjectArrayPropType_Ref(t *testing.T) {
+func TestNewValidator_QueryParamInvalidTypeArrayNumber(t *testing.T) {
 	spec := `openapi: 3.1.0
-components:
-  parameters:
-    something:
-      name: somethingElse
-      in: query
-      content:
-        application/json:
-          schema:
-            type: array
-            items:
-              type: object
-              properties:
-                vinegar:
-                  type: boolean
-                chips:
-                  type: number
-              required:
-                - vinegar
-                - chips
 paths:
-  /a/fishy/on/a/dishy:
-    get:
-      parameters:
-        - name: fishy
-          in: query
-          required: true
-          content:
-            $ref: "#/components/parameters/something/content"
-      operationId: locateFishy
+ /a/fishy/on/a/dishy:
+   get:
+     parameters:
+       - name: fishy
+         in: query
+         required: true
+         schema:
+           type: array
+           items:
+             type: number
+     operationId: locateFishy
 `
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
@@ -1188,44 +1185,35 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy={\"vinegar\":\"cakes\",\"chips\":\"hello\"}&fishy={\"vinegar\":true,\"chips\":123}", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=cod&fishy=haddock", nil)
+
 	valid, errors := v.ValidateQueryParams(request)
 	assert.False(t, valid)
-	assert.Len(t, errors, 1)
-	assert.Len(t, errors[0].SchemaValidationErrors, 2)
+
+	assert.Len(t, errors, 2)
+	assert.Equal(t, "Query array parameter 'fishy' is not a valid number", errors[0].Message)
+	assert.Equal(t, "The query parameter (which is an array) 'fishy' is defined as being a number, "+
+		"however the value 'cod' at index 0 is not a valid number", errors[0].Reason)
+	assert.Equal(t, "Query array parameter 'fishy' is not a valid number", errors[1].Message)
+	assert.Equal(t, "The query parameter (which is an array) 'fishy' is defined as being a number, "+
+		"however the value 'haddock' at index 1 is not a valid number", errors[1].Reason)
 }
 
-func TestNewValidator_QueryParamValidTypeObjectArrayPropType_Ref(t *testing.T) {
+func TestNewValidator_QueryParamValidExplodedType(t *testing.T) {
 	spec := `openapi: 3.1.0
-components:
-  parameters:
-    something:
-      name: somethingElse
-      in: query
-      content:
-        application/json:
-          schema:
-            type: array
-            items:
-              type: object
-              properties:
-                vinegar:
-                  type: boolean
-                chips:
-                  type: number
-              required:
-                - vinegar
-                - chips
 paths:
-  /a/fishy/on/a/dishy:
-    get:
-      parameters:
-        - name: fishy
-          in: query
-          required: true
-          content:
-            $ref: "#/components/parameters/something/content"
-      operationId: locateFishy
+ /a/fishy/on/a/dishy:
+   get:
+     parameters:
+       - name: fishy
+         in: query
+         required: true
+         explode: true
+         schema:
+           type: array
+           items:
+             type: string
+     operationId: locateFishy
 `
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
@@ -1234,36 +1222,29 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy={\"vinegar\":false,\"chips\":999}&fishy={\"vinegar\":true,\"chips\":123}", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=cod,haddock", nil)
+
 	valid, errors := v.ValidateQueryParams(request)
-	assert.True(t, valid)
-	assert.Len(t, errors, 0)
+	assert.False(t, valid)
+
+	assert.Len(t, errors, 2)
 }
 
-func TestNewValidator_QueryParamValidTypeObjectPropType_Ref(t *testing.T) {
+func TestNewValidator_QueryParamInvalidExplodedArray(t *testing.T) {
 	spec := `openapi: 3.1.0
-components:
-  parameters:
-    fishy:
-      name: fishy
-      in: query
-      schema:
-        type: object
-        properties:
-          vinegar:
-            type: boolean
-          chips:
-            type: number
-        required:
-          - vinegar
-          - chips
 paths:
-  /a/fishy/on/a/dishy:
-    get:
-      parameters:
-        - $ref: "#/components/parameters/fishy"
-      operationId: locateFishy
-`
+ /a/fishy/on/a/dishy:
+   get:
+     parameters:
+       - name: fishy
+         in: query
+         required: true
+         explode: true
+         schema:
+           type: array
+           items:
+             type: number
+     operationId: locateFishy`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -1271,36 +1252,27 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?vinegar=true&chips=12", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=1&fishy=2", nil)
 	valid, errors := v.ValidateQueryParams(request)
 	assert.True(t, valid)
 	assert.Len(t, errors, 0)
 }
 
-func TestNewValidator_QueryParamValidTypeObjectPropType_RefInvalid(t *testing.T) {
+func TestNewValidator_QueryParamInvalidExplodedArrayAndInvalidType(t *testing.T) {
 	spec := `openapi: 3.1.0
-components:
-  parameters:
-    fishy:
-      name: fishy
-      in: query
-      schema:
-        type: object
-        properties:
-          vinegar:
-            type: boolean
-          chips:
-            type: number
-        required:
-          - vinegar
-          - chips
 paths:
-  /a/fishy/on/a/dishy:
-    get:
-      parameters:
-        - $ref: "#/components/parameters/fishy"
-      operationId: locateFishy
-`
+ /a/fishy/on/a/dishy:
+   get:
+     parameters:
+       - name: fishy
+         in: query
+         required: true
+         explode: true
+         schema:
+           type: array
+           items:
+             type: number
+     operationId: locateFishy`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -1308,39 +1280,29 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?vinegar=true&chips=false", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=haddock&fishy=cod", nil)
+
 	valid, errors := v.ValidateQueryParams(request)
 	assert.False(t, valid)
-	assert.Len(t, errors, 1)
-	assert.Equal(t, "got boolean, want number", errors[0].SchemaValidationErrors[0].Reason)
+
+	assert.Len(t, errors, 2)
 }
 
-func TestNewValidator_QueryParamValidTypeObjectPropType_RefViaContentWrapped(t *testing.T) {
+func TestNewValidator_QueryParamValidExploded(t *testing.T) {
 	spec := `openapi: 3.1.0
-components:
-  parameters:
-    fishy:
-      name: fishy
-      in: query
-      content:
-        application/json:
-          schema:
-            type: object
-            properties:
-              vinegar:
-                type: boolean
-              chips:
-                type: number
-            required:
-              - vinegar
-              - chips
 paths:
-  /a/fishy/on/a/dishy:
-    get:
-      parameters:
-        - $ref: "#/components/parameters/fishy"
-      operationId: locateFishy
-`
+ /a/fishy/on/a/dishy:
+   get:
+     parameters:
+       - name: fishy
+         in: query
+         required: true
+         explode: false
+         schema:
+           type: array
+           items:
+             type: string
+     operationId: locateFishy`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -1348,7 +1310,7 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy={\"vinegar\":false,\"chips\":999}", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=cod,haddock,mackrel", nil)
 
 	valid, errors := v.ValidateQueryParams(request)
 	assert.True(t, valid)
@@ -1356,32 +1318,20 @@ paths:
 	assert.Len(t, errors, 0)
 }
 
-func TestNewValidator_QueryParamValidTypeObjectPropType_RefViaContentWrappedInvalid(t *testing.T) {
+func TestNewValidator_QueryParamInvalidTypeArrayBool(t *testing.T) {
 	spec := `openapi: 3.1.0
-components:
-  parameters:
-    fishy:
-      name: fishy
-      in: query
-      content:
-        application/json:
-          schema:
-            type: object
-            properties:
-              vinegar:
-                type: boolean
-              chips:
-                type: number
-            required:
-              - vinegar
-              - chips
 paths:
-  /a/fishy/on/a/dishy:
-    get:
-      parameters:
-        - $ref: "#/components/parameters/fishy"
-      operationId: locateFishy
-`
+ /a/fishy/on/a/dishy:
+   get:
+     parameters:
+       - name: fishy
+         in: query
+         required: true
+         schema:
+           type: array
+           items:
+             type: boolean 
+operationId: locateFishy`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -1389,39 +1339,34 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy={\"vinegar\":false,\"chips\":\"I am invalid\"}", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=cod&fishy=haddock", nil)
+
 	valid, errors := v.ValidateQueryParams(request)
 	assert.False(t, valid)
-	assert.Len(t, errors, 1)
-	assert.Equal(t, "got string, want number", errors[0].SchemaValidationErrors[0].Reason)
+
+	assert.Len(t, errors, 2)
+	assert.Equal(t, "Query array parameter 'fishy' is not a valid boolean", errors[0].Message)
+	assert.Equal(t, "The query parameter (which is an array) 'fishy' is defined as being a boolean, "+
+		"however the value 'cod' at index 0 is not a valid true/false value", errors[0].Reason)
+	assert.Equal(t, "Query array parameter 'fishy' is not a valid boolean", errors[1].Message)
+	assert.Equal(t, "The query parameter (which is an array) 'fishy' is defined as being a boolean, "+
+		"however the value 'haddock' at index 1 is not a valid true/false value", errors[1].Reason)
 }
 
-func TestNewValidator_QueryParamValidTypeObjectPropType_JSONInvalid(t *testing.T) {
+func TestNewValidator_QueryParamInvalidTypeArrayFloat(t *testing.T) {
 	spec := `openapi: 3.1.0
-components:
-  parameters:
-    fishy:
-      name: fishy
-      in: query
-      content:
-        application/json:
-          schema:
-            type: object
-            properties:
-              vinegar:
-                type: boolean
-              chips:
-                type: number
-            required:
-              - vinegar
-              - chips
 paths:
-  /a/fishy/on/a/dishy:
-    get:
-      parameters:
-        - $ref: "#/components/parameters/fishy"
-      operationId: locateFishy
-`
+ /a/fishy/on/a/dishy:
+   get:
+     parameters:
+       - name: fishy
+         in: query
+         required: true
+         schema:
+           type: array
+           items:
+             type: number
+operationId: locateFishy`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -1429,55 +1374,41 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=I am not json", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=12&fishy=12.12&fishy=1234567789.1233456657", nil)
 	valid, errors := v.ValidateQueryParams(request)
-	assert.False(t, valid)
-	assert.Len(t, errors, 1)
-	assert.Equal(t, "Query parameter 'fishy' is not valid JSON", errors[0].Message)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
 }
 
-func TestNewValidator_QueryParamInvalidTypeObjectPropType_Ref(t *testing.T) {
+func TestNewValidator_QueryParamInvalidTypeArrayFloatPipeDelimited(t *testing.T) {
 	spec := `openapi: 3.1.0
-components:
-  schema_validation:
-    chippy:
-      type: object
-      properties:
-        vinegar:
-          type: boolean
-        chips:
-          type: number
-      required:
-        - vinegar
-        - chips
-  parameters:
-    fishy:
-      name: fishy
-      in: query
-      content:
-        application/json:
-          schema:
-            $ref: "#/components/schema_validation/chippy"
 paths:
-  /a/fishy/on/a/dishy:
-    get:
-      parameters:
-        - $ref: "#/components/parameters/fishy"
-      operationId: locateFishy`
+ /a/fishy/on/a/dishy:
+   get:
+     parameters:
+       - name: fishy
+         in: query
+         style: pipeDelimited
+         required: true
+         schema:
+           type: array
+           items:
+             type: number
+operationId: locateFishy`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
 	m, _ := doc.BuildV3Model()
+
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy={\"vinegar\":1234,\"chips\":false}", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=12|12345.2344|22111233444.342452435", nil)
 	valid, errors := v.ValidateQueryParams(request)
-	assert.False(t, valid)
-	assert.Len(t, errors, 1)
-	assert.Len(t, errors[0].SchemaValidationErrors, 2)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
 }
 
-func TestNewValidator_QueryParamValidateStyle_AllowReserved(t *testing.T) {
+func TestNewValidator_QueryParamInvalidTypeArrayObjectPipeDelimited(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
  /a/fishy/on/a/dishy:
@@ -1485,10 +1416,735 @@ paths:
      parameters:
        - name: fishy
          in: query
+         style: pipeDelimited
          required: true
-         explode: true
          schema:
-           type: array
+           type: object
+           properties:
+             ocean:
+               type: number
+             silver:
+               type: number
+           required: [ocean, silver]
+operationId: locateFishy`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=ocean|12|silver|12.2345", nil)
+	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_QueryParamValidTypeObject(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          required: true
+          schema:
+            type: array
+            items:
+              type: object
+              properties:
+                vinegar:
+                  type: boolean
+                chips:
+                  type: number
+              required:
+                - vinegar
+                - chips
+      operationId: locateFishy`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy={\"cod\":\"cakes\"}&fishy={\"crab\":\"legs\"}", nil)
+
+	valid, errors := v.ValidateQueryParams(request)
+	assert.False(t, valid)
+
+	assert.Len(t, errors, 2)
+	assert.Equal(t, "Query array parameter 'fishy' failed to validate", errors[0].Message)
+	assert.Equal(t, "The query parameter (which is an array) 'fishy' is defined as an object, "+
+		"however it failed to pass a schema validation", errors[0].Reason)
+	assert.Equal(t, "missing properties 'vinegar', 'chips'", errors[0].SchemaValidationErrors[0].Reason)
+	assert.Equal(t, "/required", errors[0].SchemaValidationErrors[0].Location)
+}
+
+func TestNewValidator_QueryParamValidTypeObjectPropType_Invalid(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          required: true
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  vinegar:
+                    type: boolean
+                  chips:
+                    type: number
+                  required:
+                    - vinegar
+                    - chips
+      operationId: locateFishy`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy={\"vinegar\":\"cakes\",\"chips\":\"hello\"}&fishy={\"vinegar\":true,\"chips\":123.223}", nil)
+	valid, errors := v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 2)
+}
+
+func TestNewValidator_QueryParamValidTypeObjectPropTypeFloat(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          required: true
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  vinegar:
+                    type: boolean
+                  chips:
+                    type: number
+              required:
+                - vinegar
+                - chips
+      operationId: locateFishy`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy={\"vinegar\":true,\"chips\":12}&fishy={\"vinegar\":true,\"chips\":123.333}", nil)
+	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_QueryParamInvalidTypeObjectArrayPropType_Ref(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  parameters:
+    something:
+      name: somethingElse
+      in: query
+      content:
+        application/json:
+          schema:
+            type: array
+            items:
+              type: object
+              properties:
+                vinegar:
+                  type: boolean
+                chips:
+                  type: number
+              required:
+                - vinegar
+                - chips
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          required: true
+          content:
+            $ref: "#/components/parameters/something/content"
+      operationId: locateFishy
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy={\"vinegar\":\"cakes\",\"chips\":\"hello\"}&fishy={\"vinegar\":true,\"chips\":123}", nil)
+	valid, errors := v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 2)
+}
+
+func TestNewValidator_QueryParamValidTypeObjectArrayPropType_Ref(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  parameters:
+    something:
+      name: somethingElse
+      in: query
+      content:
+        application/json:
+          schema:
+            type: array
+            items:
+              type: object
+              properties:
+                vinegar:
+                  type: boolean
+                chips:
+                  type: number
+              required:
+                - vinegar
+                - chips
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          required: true
+          content:
+            $ref: "#/components/parameters/something/content"
+      operationId: locateFishy
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy={\"vinegar\":false,\"chips\":999}&fishy={\"vinegar\":true,\"chips\":123}", nil)
+	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_QueryParamValidTypeObjectPropType_Ref(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  parameters:
+    fishy:
+      name: fishy
+      in: query
+      schema:
+        type: object
+        properties:
+          vinegar:
+            type: boolean
+          chips:
+            type: number
+        required:
+          - vinegar
+          - chips
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - $ref: "#/components/parameters/fishy"
+      operationId: locateFishy
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?vinegar=true&chips=12", nil)
+	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_QueryParamValidTypeObjectPropType_RefInvalid(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  parameters:
+    fishy:
+      name: fishy
+      in: query
+      schema:
+        type: object
+        properties:
+          vinegar:
+            type: boolean
+          chips:
+            type: number
+        required:
+          - vinegar
+          - chips
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - $ref: "#/components/parameters/fishy"
+      operationId: locateFishy
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?vinegar=true&chips=false", nil)
+	valid, errors := v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "got boolean, want number", errors[0].SchemaValidationErrors[0].Reason)
+}
+
+func TestNewValidator_QueryParamValidTypeObjectPropType_RefViaContentWrapped(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  parameters:
+    fishy:
+      name: fishy
+      in: query
+      content:
+        application/json:
+          schema:
+            type: object
+            properties:
+              vinegar:
+                type: boolean
+              chips:
+                type: number
+            required:
+              - vinegar
+              - chips
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - $ref: "#/components/parameters/fishy"
+      operationId: locateFishy
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy={\"vinegar\":false,\"chips\":999}", nil)
+
+	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_QueryParamValidTypeObjectPropType_RefViaContentWrappedInvalid(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  parameters:
+    fishy:
+      name: fishy
+      in: query
+      content:
+        application/json:
+          schema:
+            type: object
+            properties:
+              vinegar:
+                type: boolean
+              chips:
+                type: number
+            required:
+              - vinegar
+              - chips
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - $ref: "#/components/parameters/fishy"
+      operationId: locateFishy
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy={\"vinegar\":false,\"chips\":\"I am invalid\"}", nil)
+	valid, errors := v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "got string, want number", errors[0].SchemaValidationErrors[0].Reason)
+}
+
+func TestNewValidator_QueryParamValidTypeObjectPropType_JSONInvalid(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  parameters:
+    fishy:
+      name: fishy
+      in: query
+      content:
+        application/json:
+          schema:
+            type: object
+            properties:
+              vinegar:
+                type: boolean
+              chips:
+                type: number
+            required:
+              - vinegar
+              - chips
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - $ref: "#/components/parameters/fishy"
+      operationId: locateFishy
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=I am not json", nil)
+	valid, errors := v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Query parameter 'fishy' is not valid JSON", errors[0].Message)
+}
+
+func TestNewValidator_QueryParamInvalidTypeObjectPropType_Ref(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schema_validation:
+    chippy:
+      type: object
+      properties:
+        vinegar:
+          type: boolean
+        chips:
+          type: number
+      required:
+        - vinegar
+        - chips
+  parameters:
+    fishy:
+      name: fishy
+      in: query
+      content:
+        application/json:
+          schema:
+            $ref: "#/components/schema_validation/chippy"
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - $ref: "#/components/parameters/fishy"
+      operationId: locateFishy`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy={\"vinegar\":1234,\"chips\":false}", nil)
+	valid, errors := v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 2)
+}
+
+func TestNewValidator_QueryParamValidateStyle_AllowReserved(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+ /a/fishy/on/a/dishy:
+   get:
+     parameters:
+       - name: fishy
+         in: query
+         required: true
+         explode: true
+         schema:
+           type: array
+           items:
+             type: string
+     operationId: locateFishy`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?fishy=$$oh", nil)
+
+	valid, errors := v.ValidateQueryParams(request)
+	assert.False(t, valid)
+
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "parameter values need to URL Encoded to ensure "+
+		"reserved values are correctly encoded, for example: '%24%24oh'", errors[0].HowToFix)
+}
+
+func TestNewValidator_QueryParamAllowReservedTrue_CommaAndSlash(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+ /a/fishy/on/a/dishy:
+   get:
+     parameters:
+       - name: fishy
+         in: query
+         required: true
+         explode: true
+         allowReserved: true
+         schema:
+           type: string
+     operationId: locateFishy`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?fishy=cod,haddock/mackrel", nil)
+
+	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_QueryParamAllowReservedFalse_CommaAndSlash(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+ /a/fishy/on/a/dishy:
+   get:
+     parameters:
+       - name: fishy
+         in: query
+         required: true
+         explode: true
+         allowReserved: false
+         schema:
+           type: string
+     operationId: locateFishy`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?fishy=cod,haddock/mackrel", nil)
+
+	valid, errors := v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 2)
+	assert.Equal(t, "Query parameter 'fishy' is not exploded correctly", errors[0].Message)
+	assert.Equal(t, "Query parameter 'fishy' value contains reserved values", errors[1].Message)
+	assert.Equal(t, "parameter values need to URL Encoded to ensure "+
+		"reserved values are correctly encoded, for example: 'cod%2Chaddock%2Fmackrel'", errors[1].HowToFix)
+}
+
+func TestNewValidator_QueryParamAllowReservedFalse_EncodedCommaAndSlash(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+ /a/fishy/on/a/dishy:
+   get:
+     parameters:
+       - name: fishy
+         in: query
+         required: true
+         explode: true
+         allowReserved: false
+         schema:
+           type: array
+           items:
+             type: string
+     operationId: locateFishy`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	// the reserved characters are percent-encoded, so they are not flagged.
+	request, _ := http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?fishy=haddock%2Fmackrel&fishy=cod+fillet", nil)
+
+	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_QueryParamValidateStyle_ValidObjectArrayNoExplode(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+ /a/fishy/on/a/dishy:
+   get:
+     parameters:
+       - name: fishy
+         in: query
+         required: true
+         schema:
+           type: array
+           items:
+             type: string
+     operationId: locateFishy`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?fishy=cod,haddock,mackrel", nil)
+
+	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_QueryParamValidateStyle_InValidObjectArrayNoExplode(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+ /a/fishy/on/a/dishy:
+   get:
+     parameters:
+       - name: fishy
+         in: query
+         required: true
+         explode: true
+         allowReserved: true
+         schema:
+           type: array
+           items:
+             type: string
+     operationId: locateFishy`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?fishy=cod,haddock,mackrel", nil)
+
+	valid, errors := v.ValidateQueryParams(request)
+	assert.False(t, valid)
+
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Query parameter 'fishy' is not exploded correctly", errors[0].Message)
+}
+
+func TestNewValidator_QueryParamValidateStyle_SpaceDelimitedIncorrectlyExploded(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+ /a/fishy/on/a/dishy:
+   get:
+     parameters:
+       - name: fishy
+         in: query
+         required: true
+         style: spaceDelimited
+         schema:
+           type: array
+           items:
+             type: string
+     operationId: locateFishy`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?fishy=cod&fishy=haddock&fishy=mackrel", nil)
+
+	valid, errors := v.ValidateQueryParams(request)
+	assert.False(t, valid)
+
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Query parameter 'fishy' delimited incorrectly", errors[0].Message)
+}
+
+func TestNewValidator_QueryParamValidateStyle_PipeDelimitedObjectValidExplode(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+ /a/fishy/on/a/dishy:
+   get:
+     parameters:
+       - name: fishy
+         in: query
+         required: true
+         style: pipeDelimited
+         schema:
+           type: array
+           items:
+             type: string
+     operationId: locateFishy`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?fishy=cod|haddock|mackrel", nil)
+
+	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_QueryParamValidateStyle_PipeDelimitedObjectInvalidExplode(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+ /a/fishy/on/a/dishy:
+   get:
+     parameters:
+       - name: fishy
+         in: query
+         required: true
+         style: pipeDelimited
+         schema:
+           type: array
            items:
              type: string
      operationId: locateFishy`
@@ -1500,30 +2156,712 @@ paths:
 	v := NewParameterValidator(&m.Model)
 
 	request, _ := http.NewRequest(http.MethodGet,
-		"https://things.com/a/fishy/on/a/dishy?fishy=$$oh", nil)
+		"https://things.com/a/fishy/on/a/dishy?fishy=cod|haddock|mackrel&fishy=breaded|cooked|fried", nil)
+
+	valid, errors := v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+}
+
+func TestNewValidator_QueryParamValidateStyle_PipeDelimitedObjectValid(t *testing.T) {
+	spec := `
+openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: plate
+          in: query
+          required: true
+          style: pipeDelimited
+          schema:
+            type: array
+            items:
+              type: string
+        - name: fishy
+          in: query
+          required: true
+          style: pipeDelimited
+          schema:
+            type: array
+            items:
+              type: string
+      operationId: locateFishy`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?fishy=cod|haddock|mackrel&plate=flat|round", nil)
+
+	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_QueryParamValidateStyle_PipeDelimitedObjectDecode(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          required: true
+          style: pipeDelimited
+          schema:
+            type: object
+            properties:
+              fish:
+                type: string
+                enum:
+                  - salmon
+                  - tuna
+                  - cod
+              dish:
+                type: string
+                enum:
+                 - salad
+                 - soup
+                 - stew
+            required:
+              - fish
+              - dish
+      operationId: locateFishy`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?fishy=fish|salmon|dish|stew", nil)
+
+	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_QueryParamValidateStyle_PipeDelimitedObjectDecodeInvalid(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          required: true
+          style: pipeDelimited
+          schema:
+            type: object
+            properties:
+              fish:
+                type: string
+                enum:
+                  - salmon
+                  - tuna
+                  - cod
+              dish:
+                type: string
+                enum:
+                 - salad
+                 - soup
+                 - stew
+            required:
+              - fish
+              - dish
+      operationId: locateFishy`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?fishy=fish|salmon|dish|cakes", nil)
+
+	valid, errors := v.ValidateQueryParams(request)
+	assert.False(t, valid)
+
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "value must be one of 'salad', 'soup', 'stew'", errors[0].SchemaValidationErrors[0].Reason)
+}
+
+func TestNewValidator_QueryParamValidateStyle_SpaceDelimitedObjectDecode(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          required: true
+          style: spaceDelimited
+          schema:
+            type: object
+            properties:
+              fish:
+                type: string
+                enum:
+                  - salmon
+                  - tuna
+                  - cod
+              dish:
+                type: string
+                enum:
+                 - salad
+                 - soup
+                 - stew
+            required:
+              - fish
+              - dish
+      operationId: locateFishy`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?fishy=fish%20salmon%20dish%20stew", nil) // dumb, don't do this.
+
+	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_QueryParamValidateStyle_SpaceDelimitedObjectDecodeInvalid(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          required: true
+          style: spaceDelimited
+          schema:
+            type: object
+            properties:
+              fish:
+                type: string
+                enum:
+                  - salmon
+                  - tuna
+                  - cod
+              dish:
+                type: string
+                enum:
+                 - salad
+                 - soup
+                 - stew
+            required:
+              - fish
+              - dish
+      operationId: locateFishy`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?fishy=fish%20salmon%20dish%20coffee", nil) // dumb, don't do this.
+	valid, errors := v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "value must be one of 'salad', 'soup', 'stew'", errors[0].SchemaValidationErrors[0].Reason)
+}
+
+func TestNewValidator_QueryParamValidateStyle_PipeDelimitedObjectInvalidMultiple(t *testing.T) {
+	spec := `
+openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: plate
+          in: query
+          required: true
+          style: pipeDelimited
+          schema:
+            type: array
+            items:
+              type: string
+        - name: fishy
+          in: query
+          required: true
+          style: pipeDelimited
+          schema:
+            type: array
+            items:
+              type: string
+      operationId: locateFishy`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?fishy=cod|haddock|mackrel&plate=flat,round", nil)
+	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_QueryParamValidateStyle_DeepObjectMultiValuesNoSchema(t *testing.T) {
+	spec := `---
+openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          required: true
+          style: deepObject
+          schema:
+            type: object
+      operationId: locateFishy`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?fishy[ocean]=atlantic&fishy[salt]=12", nil)
+
+	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_QueryParamValidateStyle_DeepObjectMultiValuesInvalid(t *testing.T) {
+	spec := `---
+openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          required: true
+          style: deepObject
+          schema:
+            type: object
+      operationId: locateFishy`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?fishy=atlantic&fishy=12", nil)
+
+	valid, errors := v.ValidateQueryParams(request)
+	assert.False(t, valid)
+
+	assert.Len(t, errors, 2)
+	assert.Equal(t, "The query parameter 'fishy' has the 'deepObject' style defined, "+
+		"There are multiple values (2) supplied, instead of a single value", errors[0].Reason)
+}
+
+func TestNewValidator_QueryParamValidateStyle_FormEncoding(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          required: true
+          schema:
+            type: object
+            properties:
+              ocean:
+                type: string
+              fins:
+                type: number
+            required: [ocean, fins]
+        - name: dishy
+          in: query
+          required: [hot, salty]
+          schema:
+            type: object
+            properties:
+              hot:
+                type: boolean
+              salty:
+                type: boolean
+      operationId: locateFishy`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?fishy=ocean,atlantic,fins,4&dishy=hot,true,salty,true", nil)
+
+	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_QueryParamValidateStyle_FormEncodingInvalid(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          required: true
+          schema:
+            type: object
+            properties:
+              ocean:
+                type: string
+              fins:
+                type: number
+            required: [ocean, fins]
+        - name: dishy
+          in: query
+          schema:
+            required: [hot, salty]
+            type: object
+            properties:
+              hot:
+                type: boolean
+              salty:
+                type: boolean
+      operationId: locateFishy`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?fishy=ocean,atlantic,fins,4&dishy=hot,no,salty,why", nil)
+
+	valid, errors := v.ValidateQueryParams(request)
+	assert.False(t, valid)
+
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "got string, want boolean", errors[0].SchemaValidationErrors[0].Reason)
+	assert.Equal(t, "got string, want boolean", errors[0].SchemaValidationErrors[1].Reason)
+}
+
+func TestNewValidator_QueryParamValidateStyle_FormEncodingArray(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          required: true
+          schema:
+            type: array
+            items:
+              type: number
+        - name: dishy
+          in: query
+          required: true
+          schema:
+            type: array
+            items:
+              type: string
+      operationId: locateFishy
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?fishy=1,2,3&dishy=a,little,plate", nil)
+
+	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_QueryParamValidateStyle_FormEncodingArrayExplode(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          explode: true
+          required: true
+          schema:
+            type: array
+            items:
+              type: number
+        - name: dishy
+          in: query
+          explode: true
+          required: true
+          schema:
+            type: array
+            items:
+              type: string
+      operationId: locateFishy
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?fishy=1&fishy=2&fishy=3&dishy=a&dishy=little&dishy=dish", nil)
+
+	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_QueryParamValidateStyle_FormEncodingArrayExplodeInvalid(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          explode: true
+          required: true
+          schema:
+            type: array
+            items:
+              type: number
+        - name: dishy
+          in: query
+          explode: true
+          required: true
+          schema:
+            type: array
+            items:
+              type: string
+      operationId: locateFishy
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?fishy=1,2,3&dishy=little,dishy", nil)
+	valid, errors := v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 4)
+	assert.Equal(t, "The query parameter 'fishy' has a default or 'form' encoding defined, however the "+
+		"value '1,2,3' is encoded as an object or an array using commas. "+
+		"The contract defines the explode value to set to 'true'", errors[0].Reason)
+}
+
+func TestNewValidator_QueryParamValidateStyle_PipeDelimitedValid(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          required: true
+          style: pipeDelimited  
+          schema:
+            type: array
+            items:
+              type: number
+        - name: dishy
+          in: query
+          style: pipeDelimited
+          required: true
+          schema:
+            type: array
+            items:
+              type: string
+      operationId: locateFishy
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?fishy=1|2|3&dishy=little|dishy", nil)
+	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_QueryParamValidateStyle_SpaceDelimitedValid(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          required: true
+          style: spaceDelimited  
+          schema:
+            type: array
+            items:
+              type: number
+        - name: dishy
+          in: query
+          style: spaceDelimited
+          required: true
+          schema:
+            type: array
+            items:
+              type: string
+      operationId: locateFishy
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?fishy=1%202%203&dishy=little%20dishy", nil)
+	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestNewValidator_QueryParamValidateStyle_SpaceDelimitedInvalidSchema(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          required: true
+          style: spaceDelimited  
+          schema:
+            type: array
+            items:
+              type: number
+        - name: dishy
+          in: query
+          style: spaceDelimited
+          required: true
+          schema:
+            type: array
+            items:
+              type: string
+      operationId: locateFishy
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?fishy=1|%202%203&dishy=little%20dishy", nil)
+	valid, errors := v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Convert the value '1|' into a number", errors[0].HowToFix)
+}
+
+func TestNewValidator_QueryParamValidateStyle_DeepObjectMultiValuesFailedSchema(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          required: true
+          style: deepObject
+          schema:
+            type: object
+            properties:
+              ocean:
+                type: string
+              salt:
+                type: boolean
+            required: [ocean, salt]
+      operationId: locateFishy`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?fishy[ocean]=atlantic&fishy[salt]=12", nil)
 
 	valid, errors := v.ValidateQueryParams(request)
 	assert.False(t, valid)
 
 	assert.Len(t, errors, 1)
-	assert.Equal(t, "parameter values need to URL Encoded to ensure "+
-		"reserved values are correctly encoded, for example: '%24%24oh'", errors[0].HowToFix)
+	assert.Equal(t, "got number, want boolean", errors[0].SchemaValidationErrors[0].Reason)
 }
 
-func TestNewValidator_QueryParamValidateStyle_ValidObjectArrayNoExplode(t *testing.T) {
+func TestNewValidator_QueryParamSetPath(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
- /a/fishy/on/a/dishy:
-   get:
-     parameters:
-       - name: fishy
-         in: query
-         required: true
-         schema:
-           type: array
-           items:
-             type: string
-     operationId: locateFishy`
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          required: true
+          style: deepObject
+          schema:
+            type: object
+            properties:
+              ocean:
+                type: string
+              salt:
+                type: boolean
+            required: [ocean, salt]
+      operationId: locateFishy`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -1532,30 +2870,37 @@ paths:
 	v := NewParameterValidator(&m.Model)
 
 	request, _ := http.NewRequest(http.MethodGet,
-		"https://things.com/a/fishy/on/a/dishy?fishy=cod,haddock,mackrel", nil)
+		"https://things.com/a/fishy/on/a/dishy?fishy[ocean]=atlantic&fishy[salt]=12", nil)
 
-	valid, errors := v.ValidateQueryParams(request)
-	assert.True(t, valid)
+	// preset the path
+	path, _, pv := paths.FindPath(request, &m.Model)
 
-	assert.Len(t, errors, 0)
+	valid, errors := v.ValidateQueryParamsWithPathItem(request, path, pv)
+	assert.False(t, valid)
+
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "got number, want boolean", errors[0].SchemaValidationErrors[0].Reason)
 }
 
-func TestNewValidator_QueryParamValidateStyle_InValidObjectArrayNoExplode(t *testing.T) {
+func TestNewValidator_QueryParamSetPath_notfound(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
- /a/fishy/on/a/dishy:
-   get:
-     parameters:
-       - name: fishy
-         in: query
-         required: true
-         explode: true
-         allowReserved: true
-         schema:
-           type: array
-           items:
-             type: string
-     operationId: locateFishy`
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          required: true
+          style: deepObject
+          schema:
+            type: object
+            properties:
+              ocean:
+                type: string
+              salt:
+                type: boolean
+            required: [ocean, salt]
+      operationId: locateFishy`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -1564,30 +2909,150 @@ paths:
 	v := NewParameterValidator(&m.Model)
 
 	request, _ := http.NewRequest(http.MethodGet,
-		"https://things.com/a/fishy/on/a/dishy?fishy=cod,haddock,mackrel", nil)
+		"https://things.com/a/beef/on/a/dishy?fishy[ocean]=atlantic&fishy[salt]=12", nil)
 
-	valid, errors := v.ValidateQueryParams(request)
+	// preset the path
+	path, _, pv := paths.FindPath(request, &m.Model)
+
+	valid, errors := v.ValidateQueryParamsWithPathItem(request, path, pv)
 	assert.False(t, valid)
 
 	assert.Len(t, errors, 1)
-	assert.Equal(t, "Query parameter 'fishy' is not exploded correctly", errors[0].Message)
+	assert.Equal(t, "GET Path '/a/beef/on/a/dishy' not found", errors[0].Message)
 }
 
-func TestNewValidator_QueryParamValidateStyle_SpaceDelimitedIncorrectlyExploded(t *testing.T) {
+func TestNewValidator_QueryParamValidateStyle_DeepObjectMultiValuesFailedMultipleSchemas(t *testing.T) {
+	spec := `---
+openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          required: true
+          style: deepObject
+          schema:
+            type: object
+            properties:
+              ocean:
+                type: string
+              salt:
+                type: boolean
+            required:
+              - ocean
+              - salt
+        - name: dishy
+          in: query
+          required: true
+          style: deepObject
+          schema:
+            type: object
+            properties:
+              size:
+                type: string
+              numCracks:
+                type: number
+            required:
+              - size
+              - numCracks
+        - name: cake
+          in: query
+          required: true
+          style: deepObject
+          schema:
+            type: object
+            properties:
+              message:
+                type: string
+              numCandles:
+                type: number
+            required:
+              - message
+              - numCandles
+      operationId: locateFishy`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?fishy[ocean]=atlantic&fishy[salt]=12"+
+			"&dishy[size]=big&dishy[numCracks]=false"+
+			"&cake[message]=happy%20birthday&cake[numCandles]=false", nil)
+
+	valid, errors := v.ValidateQueryParams(request)
+	assert.False(t, valid)
+
+	assert.Len(t, errors, 3)
+	assert.Equal(t, "got number, want boolean", errors[0].SchemaValidationErrors[0].Reason)
+	assert.Equal(t, "got boolean, want number", errors[1].SchemaValidationErrors[0].Reason)
+	assert.Equal(t, "got boolean, want number", errors[2].SchemaValidationErrors[0].Reason)
+}
+
+func TestNewValidator_ValidateEncodedObjectIsCorrect(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
- /a/fishy/on/a/dishy:
-   get:
-     parameters:
-       - name: fishy
-         in: query
-         required: true
-         style: spaceDelimited
-         schema:
-           type: array
-           items:
-             type: string
-     operationId: locateFishy`
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: fishy
+          in: query
+          required: true
+          style: deepObject
+          schema:
+            type: object
+            properties:
+              ocean:
+                type: string
+              salt:
+                type: boolean
+            required: [ocean, salt]
+      operationId: locateFishy`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	sch := m.Model.Paths.PathItems.GetOrZero("/a/fishy/on/a/dishy").Get.Parameters[0].Schema
+
+	s := sch.Schema()
+
+	// this is not compatible.
+	rawObject := map[int]int{
+		1: 2,
+	}
+
+	errs := ValidateParameterSchema(s, rawObject, "cake", "burger", "lemons",
+		"pizza", "rice", "herbs")
+
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "lemons 'pizza' is defined as an object, "+
+		"however it failed to be decoded as an object", errs[0].Reason)
+}
+
+// https://github.com/pb33f/wiretap/issues/82
+func TestNewValidator_QueryParamValidateStyle_DeepObjectAdditionalPropertiesArray(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /anything/queryParams/deepObject/map:
+    get:
+      operationId: deepObjectQueryParamsMap
+      parameters:
+        - name: mapArrParam
+          in: query
+          style: deepObject
+          schema:
+            type: object
+            additionalProperties:
+              type: array
+              items:
+                type: string
+            example: { "test": ["test", "test2"], "test2": ["test3", "test4"] }
+      responses:
+        "200":
+          description: OK`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -1596,30 +3061,33 @@ paths:
 	v := NewParameterValidator(&m.Model)
 
 	request, _ := http.NewRequest(http.MethodGet,
-		"https://things.com/a/fishy/on/a/dishy?fishy=cod&fishy=haddock&fishy=mackrel", nil)
+		"https://things.com/anything/queryParams/deepObject/map?mapArrParam[test2]=test3&mapArrParam[test2]=test4&mapArrParam[test]=test&mapArrParam[test]=test2", nil)
 
 	valid, errors := v.ValidateQueryParams(request)
-	assert.False(t, valid)
+	assert.True(t, valid)
 
-	assert.Len(t, errors, 1)
-	assert.Equal(t, "Query parameter 'fishy' delimited incorrectly", errors[0].Message)
+	assert.Len(t, errors, 0)
 }
 
-func TestNewValidator_QueryParamValidateStyle_PipeDelimitedObjectValidExplode(t *testing.T) {
+// https://github.com/pb33f/wiretap/issues/82
+func TestNewValidator_QueryParamValidateStyle_DeepObjectAdditionalPropertiesArrayTop(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
- /a/fishy/on/a/dishy:
-   get:
-     parameters:
-       - name: fishy
-         in: query
-         required: true
-         style: pipeDelimited
-         schema:
-           type: array
-           items:
-             type: string
-     operationId: locateFishy`
+  /anything/queryParams/deepObject/map:
+    get:
+      operationId: deepObjectQueryParamsMap
+      parameters:
+        - name: mapArrParam
+          in: query
+          style: deepObject
+          schema:
+            type: array
+            items:
+              type: string
+            example: { "test": ["test", "test2"], "test2": ["test3", "test4"] }
+      responses:
+        "200":
+          description: OK`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -1628,28 +3096,35 @@ paths:
 	v := NewParameterValidator(&m.Model)
 
 	request, _ := http.NewRequest(http.MethodGet,
-		"https://things.com/a/fishy/on/a/dishy?fishy=cod|haddock|mackrel", nil)
+		"https://things.com/anything/queryParams/deepObject/map?mapArrParam[test2]=test3&mapArrParam[test2]=test4&mapArrParam[test]=test&mapArrParam[test]=test2", nil)
 
 	valid, errors := v.ValidateQueryParams(request)
 	assert.True(t, valid)
+
 	assert.Len(t, errors, 0)
 }
 
-func TestNewValidator_QueryParamValidateStyle_PipeDelimitedObjectInvalidExplode(t *testing.T) {
+// https://github.com/pb33f/wiretap/issues/82
+func TestNewValidator_QueryParamValidateStyle_DeepObjectAdditionalPropertiesArray_Fail(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
- /a/fishy/on/a/dishy:
-   get:
-     parameters:
-       - name: fishy
-         in: query
-         required: true
-         style: pipeDelimited
-         schema:
-           type: array
-           items:
-             type: string
-     operationId: locateFishy`
+  /anything/queryParams/deepObject/map:
+    get:
+      operationId: deepObjectQueryParamsMap
+      parameters:
+        - name: mapArrParam
+          in: query
+          style: deepObject
+          schema:
+            type: object
+            additionalProperties:
+              type: array
+              items:
+                type: string
+            example: { "test": ["test", "test2"], "test2": ["test3", "test4"] }
+      responses:
+        "200":
+          description: OK`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -1658,99 +3133,110 @@ paths:
 	v := NewParameterValidator(&m.Model)
 
 	request, _ := http.NewRequest(http.MethodGet,
-		"https://things.com/a/fishy/on/a/dishy?fishy=cod|haddock|mackrel&fishy=breaded|cooked|fried", nil)
+		"https://things.com/anything/queryParams/deepObject/map?mapArrParam[test2]=23&mapArrParam[test2]=test4&mapArrParam[test]=test&mapArrParam[test]=test2", nil)
 
 	valid, errors := v.ValidateQueryParams(request)
 	assert.False(t, valid)
 	assert.Len(t, errors, 1)
+	assert.Equal(t, "got number, want string", errors[0].SchemaValidationErrors[0].Reason)
 }
 
-func TestNewValidator_QueryParamValidateStyle_PipeDelimitedObjectValid(t *testing.T) {
-	spec := `
-openapi: 3.1.0
+// https://github.com/pb33f/wiretap/issues/83
+func TestNewValidator_QueryParamValidateStyle_BadSchemaDeepObject(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: Test
+  version: 0.1.0
+security:
+  - apiKeyAuth: []
 paths:
-  /a/fishy/on/a/dishy:
+  /anything/queryParams/deepObject/obj:
     get:
+      operationId: deepObjectQueryParamsObject
       parameters:
-        - name: plate
+        - name: objParam
           in: query
-          required: true
-          style: pipeDelimited
+          style: deepObject
           schema:
-            type: array
-            items:
-              type: string
-        - name: fishy
-          in: query
+            $ref: "components.yaml#/components/schemas/simpleObject"
           required: true
-          style: pipeDelimited
-          schema:
-            type: array
-            items:
-              type: string
-      operationId: locateFishy`
+      responses:
+        "200":
+          description: OK
+components:
+  securitySchemes:
+    apiKeyAuth:
+      type: apiKey
+      in: header
+      name: Authorization
+      description: Authenticate using an API Key generated via our platform.`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
-	m, _ := doc.BuildV3Model()
+	m, err := doc.BuildV3Model()
+	assert.Len(t, err, 1) // path build will fail because of missing schema.
 
 	v := NewParameterValidator(&m.Model)
 
 	request, _ := http.NewRequest(http.MethodGet,
-		"https://things.com/a/fishy/on/a/dishy?fishy=cod|haddock|mackrel&plate=flat|round", nil)
+		"http://localhost:9090/anything/queryParams/deepObject/obj?objParam=blahdedahdedah", nil)
 
 	valid, errors := v.ValidateQueryParams(request)
 	assert.True(t, valid)
-
 	assert.Len(t, errors, 0)
 }
 
-func TestNewValidator_QueryParamValidateStyle_PipeDelimitedObjectDecode(t *testing.T) {
+// https://github.com/pb33f/wiretap/issues/83
+func TestNewValidator_QueryParamValidateStyle_BadSchemaDeepObject_Inline(t *testing.T) {
 	spec := `openapi: 3.1.0
+info:
+  title: Test
+  version: 0.1.0
+security:
+  - apiKeyAuth: []
 paths:
-  /a/fishy/on/a/dishy:
+  /anything/queryParams/deepObject/obj:
     get:
+      operationId: deepObjectQueryParamsObject
       parameters:
-        - name: fishy
+        - name: objParam
           in: query
-          required: true
-          style: pipeDelimited
+          style: deepObject
           schema:
             type: object
             properties:
-              fish:
-                type: string
-                enum:
-                  - salmon
-                  - tuna
-                  - cod
-              dish:
+              cake:
                 type: string
-                enum:
-                 - salad
-                 - soup
-                 - stew
-            required:
-              - fish
-              - dish
-      operationId: locateFishy`
+          required: true
+      responses:
+        "200":
+          description: OK
+components:
+  securitySchemes:
+    apiKeyAuth:
+      type: apiKey
+      in: header
+      name: Authorization
+      description: Authenticate using an API Key generated via our platform.`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
-	m, _ := doc.BuildV3Model()
+	m, err := doc.BuildV3Model()
+	assert.Len(t, err, 0) // no patch build here
 
 	v := NewParameterValidator(&m.Model)
 
 	request, _ := http.NewRequest(http.MethodGet,
-		"https://things.com/a/fishy/on/a/dishy?fishy=fish|salmon|dish|stew", nil)
+		"http://localhost:9090/anything/queryParams/deepObject/obj?objParam=blahdedahdedah", nil)
 
 	valid, errors := v.ValidateQueryParams(request)
-	assert.True(t, valid)
-
-	assert.Len(t, errors, 0)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "The query parameter 'objParam' is defined as an object,"+
+		" however it failed to pass a schema validation", errors[0].Reason)
 }
 
-func TestNewValidator_QueryParamValidateStyle_PipeDelimitedObjectDecodeInvalid(t *testing.T) {
+func TestNewValidator_ValidateRawMap(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
   /a/fishy/on/a/dishy:
@@ -1759,89 +3245,87 @@ paths:
         - name: fishy
           in: query
           required: true
-          style: pipeDelimited
+          style: deepObject
           schema:
             type: object
             properties:
-              fish:
-                type: string
-                enum:
-                  - salmon
-                  - tuna
-                  - cod
-              dish:
+              ocean:
                 type: string
-                enum:
-                 - salad
-                 - soup
-                 - stew
-            required:
-              - fish
-              - dish
+              salt:
+                type: boolean
+            required: [ocean, salt]
       operationId: locateFishy`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
-
 	m, _ := doc.BuildV3Model()
 
-	v := NewParameterValidator(&m.Model)
+	sch := m.Model.Paths.PathItems.GetOrZero("/a/fishy/on/a/dishy").Get.Parameters[0].Schema
 
-	request, _ := http.NewRequest(http.MethodGet,
-		"https://things.com/a/fishy/on/a/dishy?fishy=fish|salmon|dish|cakes", nil)
+	s := sch.Schema()
 
-	valid, errors := v.ValidateQueryParams(request)
-	assert.False(t, valid)
+	// this is not compatible.
+	rawObject := map[int]int{
+		1: 2,
+	}
 
-	assert.Len(t, errors, 1)
-	assert.Equal(t, "value must be one of 'salad', 'soup', 'stew'", errors[0].SchemaValidationErrors[0].Reason)
+	errs := ValidateParameterSchema(s, rawObject, "cake", "burger", "lemons",
+		"pizza", "rice", "herbs")
+
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "lemons 'pizza' is defined as an object, "+
+		"however it failed to be decoded as an object", errs[0].Reason)
 }
 
-func TestNewValidator_QueryParamValidateStyle_SpaceDelimitedObjectDecode(t *testing.T) {
+// https://github.com/pb33f/wiretap/issues/83
+func TestNewValidator_QueryParamValidateStyle_EmptyDeepObject(t *testing.T) {
 	spec := `openapi: 3.1.0
+info:
+  title: Test
+  version: 0.1.0
+security:
+  - apiKeyAuth: []
 paths:
-  /a/fishy/on/a/dishy:
+  /anything/queryParams/deepObject/obj:
     get:
+      operationId: deepObjectQueryParamsObject
       parameters:
-        - name: fishy
+        - name: objParam
           in: query
-          required: true
-          style: spaceDelimited
+          style: deepObject
           schema:
             type: object
             properties:
-              fish:
-                type: string
-                enum:
-                  - salmon
-                  - tuna
-                  - cod
-              dish:
+              cake:
                 type: string
-                enum:
-                 - salad
-                 - soup
-                 - stew
-            required:
-              - fish
-              - dish
-      operationId: locateFishy`
+          required: true
+      responses:
+        "200":
+          description: OK
+components:
+  securitySchemes:
+    apiKeyAuth:
+      type: apiKey
+      in: header
+      name: Authorization
+      description: Authenticate using an API Key generated via our platform.`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
-	m, _ := doc.BuildV3Model()
+	m, err := doc.BuildV3Model()
+	assert.Len(t, err, 0) // no patch build here
 
 	v := NewParameterValidator(&m.Model)
 
 	request, _ := http.NewRequest(http.MethodGet,
-		"https://things.com/a/fishy/on/a/dishy?fishy=fish%20salmon%20dish%20stew", nil) // dumb, don't do this.
+		"http://localhost:9090/anything/queryParams/deepObject/obj?objParam", nil)
 
 	valid, errors := v.ValidateQueryParams(request)
 	assert.True(t, valid)
-
 	assert.Len(t, errors, 0)
+
 }
 
-func TestNewValidator_QueryParamValidateStyle_SpaceDelimitedObjectDecodeInvalid(t *testing.T) {
+func TestNewValidator_QueryParamArrayFormInvalidItem(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
   /a/fishy/on/a/dishy:
@@ -1849,27 +3333,13 @@ paths:
       parameters:
         - name: fishy
           in: query
-          required: true
-          style: spaceDelimited
+          explode: false
           schema:
-            type: object
-            properties:
-              fish:
-                type: string
-                enum:
-                  - salmon
-                  - tuna
-                  - cod
-              dish:
-                type: string
-                enum:
-                 - salad
-                 - soup
-                 - stew
-            required:
-              - fish
-              - dish
-      operationId: locateFishy`
+            type: array
+            items:
+              type: integer
+      operationId: locateFishy
+`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -1877,38 +3347,30 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet,
-		"https://things.com/a/fishy/on/a/dishy?fishy=fish%20salmon%20dish%20coffee", nil) // dumb, don't do this.
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=1,x,3", nil)
+
 	valid, errors := v.ValidateQueryParams(request)
 	assert.False(t, valid)
 	assert.Len(t, errors, 1)
-	assert.Equal(t, "value must be one of 'salad', 'soup', 'stew'", errors[0].SchemaValidationErrors[0].Reason)
+	assert.Equal(t, "Convert the value 'x' into a number", errors[0].HowToFix)
 }
 
-func TestNewValidator_QueryParamValidateStyle_PipeDelimitedObjectInvalidMultiple(t *testing.T) {
-	spec := `
-openapi: 3.1.0
+func TestNewValidator_QueryParamArraySpaceDelimitedInvalidItem(t *testing.T) {
+	spec := `openapi: 3.1.0
 paths:
   /a/fishy/on/a/dishy:
     get:
       parameters:
-        - name: plate
-          in: query
-          required: true
-          style: pipeDelimited
-          schema:
-            type: array
-            items:
-              type: string
         - name: fishy
           in: query
-          required: true
-          style: pipeDelimited
+          style: spaceDelimited
+          explode: false
           schema:
             type: array
             items:
-              type: string
-      operationId: locateFishy`
+              type: integer
+      operationId: locateFishy
+`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -1916,27 +3378,30 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet,
-		"https://things.com/a/fishy/on/a/dishy?fishy=cod|haddock|mackrel&plate=flat,round", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=1%20x%203", nil)
+
 	valid, errors := v.ValidateQueryParams(request)
-	assert.True(t, valid)
-	assert.Len(t, errors, 0)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Convert the value 'x' into a number", errors[0].HowToFix)
 }
 
-func TestNewValidator_QueryParamValidateStyle_DeepObjectMultiValuesNoSchema(t *testing.T) {
-	spec := `---
-openapi: 3.1.0
+func TestNewValidator_QueryParamArrayPipeDelimitedInvalidItem(t *testing.T) {
+	spec := `openapi: 3.1.0
 paths:
   /a/fishy/on/a/dishy:
     get:
       parameters:
         - name: fishy
           in: query
-          required: true
-          style: deepObject
+          style: pipeDelimited
+          explode: false
           schema:
-            type: object
-      operationId: locateFishy`
+            type: array
+            items:
+              type: integer
+      operationId: locateFishy
+`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -1944,29 +3409,31 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet,
-		"https://things.com/a/fishy/on/a/dishy?fishy[ocean]=atlantic&fishy[salt]=12", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=1|x|3", nil)
 
 	valid, errors := v.ValidateQueryParams(request)
-	assert.True(t, valid)
-
-	assert.Len(t, errors, 0)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Convert the value 'x' into a number", errors[0].HowToFix)
 }
 
-func TestNewValidator_QueryParamValidateStyle_DeepObjectMultiValuesInvalid(t *testing.T) {
-	spec := `---
-openapi: 3.1.0
+func TestNewValidator_QueryParamArrayPipeDelimitedEnum(t *testing.T) {
+	spec := `openapi: 3.1.0
 paths:
   /a/fishy/on/a/dishy:
     get:
       parameters:
         - name: fishy
           in: query
-          required: true
-          style: deepObject
+          style: pipeDelimited
+          explode: false
           schema:
-            type: object
-      operationId: locateFishy`
+            type: array
+            items:
+              type: string
+              enum: [cod, halibut]
+      operationId: locateFishy
+`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -1974,45 +3441,36 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet,
-		"https://things.com/a/fishy/on/a/dishy?fishy=atlantic&fishy=12", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=cod|halibut", nil)
 
 	valid, errors := v.ValidateQueryParams(request)
-	assert.False(t, valid)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
 
-	assert.Len(t, errors, 2)
-	assert.Equal(t, "The query parameter 'fishy' has the 'deepObject' style defined, "+
-		"There are multiple values (2) supplied, instead of a single value", errors[0].Reason)
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=cod|haddock", nil)
+
+	valid, errors = v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Instead of 'haddock', use one of the allowed values: 'cod, halibut'", errors[0].HowToFix)
 }
 
-func TestNewValidator_QueryParamValidateStyle_FormEncoding(t *testing.T) {
+func TestNewValidator_QueryParamArrayMinItemsEmpty(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
   /a/fishy/on/a/dishy:
     get:
       parameters:
-        - name: fishy
-          in: query
-          required: true
-          schema:
-            type: object
-            properties:
-              ocean:
-                type: string
-              fins:
-                type: number
-            required: [ocean, fins]
-        - name: dishy
+        - name: tags
           in: query
-          required: [hot, salty]
+          explode: false
           schema:
-            type: object
-            properties:
-              hot:
-                type: boolean
-              salty:
-                type: boolean
-      operationId: locateFishy`
+            type: array
+            minItems: 1
+            items:
+              type: string
+      operationId: locateFishy
+`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -2020,43 +3478,36 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet,
-		"https://things.com/a/fishy/on/a/dishy?fishy=ocean,atlantic,fins,4&dishy=hot,true,salty,true", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?tags=", nil)
 
 	valid, errors := v.ValidateQueryParams(request)
-	assert.True(t, valid)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Query array parameter 'tags' does not contain enough items", errors[0].Message)
+	assert.Equal(t, "The array must contain at least 1 item(s), however 0 were supplied", errors[0].HowToFix)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?tags=cod", nil)
 
+	valid, errors = v.ValidateQueryParams(request)
+	assert.True(t, valid)
 	assert.Len(t, errors, 0)
 }
 
-func TestNewValidator_QueryParamValidateStyle_FormEncodingInvalid(t *testing.T) {
+func TestNewValidator_QueryParamArrayMaxItems(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
   /a/fishy/on/a/dishy:
     get:
       parameters:
-        - name: fishy
-          in: query
-          required: true
-          schema:
-            type: object
-            properties:
-              ocean:
-                type: string
-              fins:
-                type: number
-            required: [ocean, fins]
-        - name: dishy
+        - name: tags
           in: query
           schema:
-            required: [hot, salty]
-            type: object
-            properties:
-              hot:
-                type: boolean
-              salty:
-                type: boolean
-      operationId: locateFishy`
+            type: array
+            maxItems: 2
+            items:
+              type: string
+      operationId: locateFishy
+`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -2064,37 +3515,28 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet,
-		"https://things.com/a/fishy/on/a/dishy?fishy=ocean,atlantic,fins,4&dishy=hot,no,salty,why", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?tags=cod&tags=haddock&tags=halibut", nil)
 
 	valid, errors := v.ValidateQueryParams(request)
 	assert.False(t, valid)
-
 	assert.Len(t, errors, 1)
-	assert.Equal(t, "got string, want boolean", errors[0].SchemaValidationErrors[0].Reason)
-	assert.Equal(t, "got string, want boolean", errors[0].SchemaValidationErrors[1].Reason)
+	assert.Equal(t, "Query array parameter 'tags' contains too many items", errors[0].Message)
 }
 
-func TestNewValidator_QueryParamValidateStyle_FormEncodingArray(t *testing.T) {
+func TestNewValidator_QueryParamArrayUniqueItems(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
   /a/fishy/on/a/dishy:
     get:
       parameters:
-        - name: fishy
-          in: query
-          required: true
-          schema:
-            type: array
-            items:
-              type: number
-        - name: dishy
+        - name: tags
           in: query
-          required: true
+          explode: false
           schema:
             type: array
+            uniqueItems: true
             items:
-              type: string
+              type: integer
       operationId: locateFishy
 `
 
@@ -2104,16 +3546,22 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet,
-		"https://things.com/a/fishy/on/a/dishy?fishy=1,2,3&dishy=a,little,plate", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?tags=1,2,1", nil)
 
 	valid, errors := v.ValidateQueryParams(request)
-	assert.True(t, valid)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Query array parameter 'tags' contains duplicate items", errors[0].Message)
+	assert.Equal(t, "Remove the duplicate value '1', all items in the array must be unique", errors[0].HowToFix)
 
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?tags=1,2,3", nil)
+
+	valid, errors = v.ValidateQueryParams(request)
+	assert.True(t, valid)
 	assert.Len(t, errors, 0)
 }
 
-func TestNewValidator_QueryParamValidateStyle_FormEncodingArrayExplode(t *testing.T) {
+func TestNewValidator_QueryParamArrayUniqueItemsObjects(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
   /a/fishy/on/a/dishy:
@@ -2122,19 +3570,16 @@ paths:
         - name: fishy
           in: query
           explode: true
-          required: true
-          schema:
-            type: array
-            items:
-              type: number
-        - name: dishy
-          in: query
-          explode: true
-          required: true
           schema:
             type: array
+            uniqueItems: true
             items:
-              type: string
+              type: object
+              properties:
+                vinegar:
+                  type: boolean
+                chips:
+                  type: number
       operationId: locateFishy
 `
 
@@ -2144,37 +3589,96 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet,
-		"https://things.com/a/fishy/on/a/dishy?fishy=1&fishy=2&fishy=3&dishy=a&dishy=little&dishy=dish", nil)
+	// the objects only differ by the order of their properties, so they are duplicates.
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy="+
+		url.QueryEscape(`{"vinegar":true,"chips":2}`)+"&fishy="+url.QueryEscape(`{"chips":2, "vinegar":true}`), nil)
 
 	valid, errors := v.ValidateQueryParams(request)
-	assert.True(t, valid)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Query array parameter 'fishy' contains duplicate items", errors[0].Message)
+	assert.Equal(t, "Remove the duplicate value '{\"chips\":2, \"vinegar\":true}', all items in the array must be unique",
+		errors[0].HowToFix)
 
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy="+
+		url.QueryEscape(`{"vinegar":true,"chips":2}`)+"&fishy="+url.QueryEscape(`{"vinegar":false,"chips":2}`), nil)
+
+	valid, errors = v.ValidateQueryParams(request)
+	assert.True(t, valid)
 	assert.Len(t, errors, 0)
 }
 
-func TestNewValidator_QueryParamValidateStyle_FormEncodingArrayExplodeInvalid(t *testing.T) {
+func TestNewValidator_QueryParamDeepObjectNested(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
   /a/fishy/on/a/dishy:
     get:
       parameters:
-        - name: fishy
+        - name: filter
           in: query
+          style: deepObject
           explode: true
-          required: true
           schema:
-            type: array
-            items:
-              type: number
-        - name: dishy
+            type: object
+            properties:
+              status:
+                type: string
+              owner:
+                type: object
+                properties:
+                  id:
+                    type: integer
+                  name:
+                    type: string
+                required: [id]
+              tags:
+                type: array
+                items:
+                  type: string
+      operationId: locateFishy
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+
+	v := NewParameterValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?filter[status]=open&filter[owner][id]=5&filter[owner][name]=dave"+
+			"&filter[tags][0]=a&filter[tags][1]=b", nil)
+
+	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request, _ = http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?filter[status]=open&filter[owner][name]=dave&filter[tags][0]=a", nil)
+
+	valid, errors = v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Query parameter 'filter' failed to validate", errors[0].Message)
+	assert.Contains(t, errors[0].SchemaValidationErrors[0].Reason, "missing property 'id'")
+}
+
+func TestNewValidator_QueryParamDeepObjectNestedMissingIndex(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /a/fishy/on/a/dishy:
+    get:
+      parameters:
+        - name: filter
           in: query
+          style: deepObject
           explode: true
-          required: true
           schema:
-            type: array
-            items:
-              type: string
+            type: object
+            properties:
+              tags:
+                type: array
+                items:
+                  type: string
       operationId: locateFishy
 `
 
@@ -2185,37 +3689,29 @@ paths:
 	v := NewParameterValidator(&m.Model)
 
 	request, _ := http.NewRequest(http.MethodGet,
-		"https://things.com/a/fishy/on/a/dishy?fishy=1,2,3&dishy=little,dishy", nil)
+		"https://things.com/a/fishy/on/a/dishy?filter[tags][0]=a&filter[tags][2]=c", nil)
+
 	valid, errors := v.ValidateQueryParams(request)
 	assert.False(t, valid)
-	assert.Len(t, errors, 4)
-	assert.Equal(t, "The query parameter 'fishy' has a default or 'form' encoding defined, however the "+
-		"value '1,2,3' is encoded as an object or an array using commas. "+
-		"The contract defines the explode value to set to 'true'", errors[0].Reason)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Query parameter 'filter' is not a valid deepObject", errors[0].Message)
+	assert.Equal(t, "The query parameter 'filter' has the 'deepObject' style defined, however it cannot be decoded "+
+		"into an object: the array 'filter[tags]' is missing one or more indices, indices must start at 0 "+
+		"and be sequential", errors[0].Reason)
 }
 
-func TestNewValidator_QueryParamValidateStyle_PipeDelimitedValid(t *testing.T) {
+func TestNewValidator_QueryParamDeepObjectNestedConflict(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
   /a/fishy/on/a/dishy:
     get:
       parameters:
-        - name: fishy
-          in: query
-          required: true
-          style: pipeDelimited  
-          schema:
-            type: array
-            items:
-              type: number
-        - name: dishy
+        - name: filter
           in: query
-          style: pipeDelimited
-          required: true
+          style: deepObject
+          explode: true
           schema:
-            type: array
-            items:
-              type: string
+            type: object
       operationId: locateFishy
 `
 
@@ -2226,34 +3722,28 @@ paths:
 	v := NewParameterValidator(&m.Model)
 
 	request, _ := http.NewRequest(http.MethodGet,
-		"https://things.com/a/fishy/on/a/dishy?fishy=1|2|3&dishy=little|dishy", nil)
+		"https://things.com/a/fishy/on/a/dishy?filter[status]=open&filter[status][name]=closed", nil)
+
 	valid, errors := v.ValidateQueryParams(request)
-	assert.True(t, valid)
-	assert.Len(t, errors, 0)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Query parameter 'filter' is not a valid deepObject", errors[0].Message)
+	assert.Contains(t, errors[0].Reason, "is used as both a value and an object")
 }
 
-func TestNewValidator_QueryParamValidateStyle_SpaceDelimitedValid(t *testing.T) {
+func TestNewValidator_QueryParamArrayInvalidItemIndex(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
   /a/fishy/on/a/dishy:
     get:
       parameters:
-        - name: fishy
+        - name: ids
           in: query
-          required: true
-          style: spaceDelimited  
-          schema:
-            type: array
-            items:
-              type: number
-        - name: dishy
-          in: query
-          style: spaceDelimited
-          required: true
+          explode: false
           schema:
             type: array
             items:
-              type: string
+              type: integer
       operationId: locateFishy
 `
 
@@ -2263,35 +3753,35 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet,
-		"https://things.com/a/fishy/on/a/dishy?fishy=1%202%203&dishy=little%20dishy", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?ids=1,foo,3", nil)
+
 	valid, errors := v.ValidateQueryParams(request)
-	assert.True(t, valid)
-	assert.Len(t, errors, 0)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "The query parameter (which is an array) 'ids' is defined as being an integer, "+
+		"however the value 'foo' at index 1 is not a valid integer", errors[0].Reason)
+	assert.NotNil(t, errors[0].ItemIndex)
+	assert.Equal(t, 1, *errors[0].ItemIndex)
 }
 
-func TestNewValidator_QueryParamValidateStyle_SpaceDelimitedInvalidSchema(t *testing.T) {
+func TestNewValidator_QueryParamContentJSON(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
   /a/fishy/on/a/dishy:
     get:
       parameters:
-        - name: fishy
-          in: query
-          required: true
-          style: spaceDelimited  
-          schema:
-            type: array
-            items:
-              type: number
-        - name: dishy
+        - name: filter
           in: query
-          style: spaceDelimited
-          required: true
-          schema:
-            type: array
-            items:
-              type: string
+          content:
+            application/vnd.fishy+json:
+              schema:
+                type: object
+                properties:
+                  status:
+                    type: string
+                  limit:
+                    type: integer
+                required: [status]
       operationId: locateFishy
 `
 
@@ -2301,33 +3791,37 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet,
-		"https://things.com/a/fishy/on/a/dishy?fishy=1|%202%203&dishy=little%20dishy", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?filter="+
+		url.QueryEscape(`{"status":"open","limit":10}`), nil)
+
 	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?filter="+
+		url.QueryEscape(`{"limit":"ten"}`), nil)
+
+	valid, errors = v.ValidateQueryParams(request)
 	assert.False(t, valid)
 	assert.Len(t, errors, 1)
-	assert.Equal(t, "Convert the value '1|' into a number", errors[0].HowToFix)
+	assert.Equal(t, "Query parameter 'filter' failed to validate", errors[0].Message)
+	assert.Len(t, errors[0].SchemaValidationErrors, 2)
 }
 
-func TestNewValidator_QueryParamValidateStyle_DeepObjectMultiValuesFailedSchema(t *testing.T) {
+func TestNewValidator_QueryParamContentJSONMalformed(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
   /a/fishy/on/a/dishy:
     get:
       parameters:
-        - name: fishy
+        - name: filter
           in: query
-          required: true
-          style: deepObject
-          schema:
-            type: object
-            properties:
-              ocean:
-                type: string
-              salt:
-                type: boolean
-            required: [ocean, salt]
-      operationId: locateFishy`
+          content:
+            application/json:
+              schema:
+                type: object
+      operationId: locateFishy
+`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -2335,35 +3829,32 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet,
-		"https://things.com/a/fishy/on/a/dishy?fishy[ocean]=atlantic&fishy[salt]=12", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?filter="+
+		url.QueryEscape(`{"status":"open"`), nil)
 
 	valid, errors := v.ValidateQueryParams(request)
 	assert.False(t, valid)
-
 	assert.Len(t, errors, 1)
-	assert.Equal(t, "got number, want boolean", errors[0].SchemaValidationErrors[0].Reason)
+	assert.Equal(t, "Query parameter 'filter' is not valid JSON", errors[0].Message)
+	assert.Equal(t, 8, errors[0].SpecLine)
 }
 
-func TestNewValidator_QueryParamSetPath(t *testing.T) {
+func TestNewValidator_QueryParamContentJSONArray(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
   /a/fishy/on/a/dishy:
     get:
       parameters:
-        - name: fishy
+        - name: ids
           in: query
-          required: true
-          style: deepObject
-          schema:
-            type: object
-            properties:
-              ocean:
-                type: string
-              salt:
-                type: boolean
-            required: [ocean, salt]
-      operationId: locateFishy`
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  type: integer
+      operationId: locateFishy
+`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -2371,38 +3862,36 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet,
-		"https://things.com/a/fishy/on/a/dishy?fishy[ocean]=atlantic&fishy[salt]=12", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?ids="+
+		url.QueryEscape(`[1,2,3]`), nil)
 
-	// preset the path
-	path, _, pv := paths.FindPath(request, &m.Model)
+	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
 
-	valid, errors := v.ValidateQueryParamsWithPathItem(request, path, pv)
-	assert.False(t, valid)
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?ids="+
+		url.QueryEscape(`[1,"two",3]`), nil)
 
+	valid, errors = v.ValidateQueryParams(request)
+	assert.False(t, valid)
 	assert.Len(t, errors, 1)
-	assert.Equal(t, "got number, want boolean", errors[0].SchemaValidationErrors[0].Reason)
+	assert.Equal(t, "Query parameter 'ids' failed to validate", errors[0].Message)
 }
 
-func TestNewValidator_QueryParamSetPath_notfound(t *testing.T) {
+func TestNewValidator_QueryParamContentJSONNoType(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
   /a/fishy/on/a/dishy:
     get:
       parameters:
-        - name: fishy
+        - name: code
           in: query
-          required: true
-          style: deepObject
-          schema:
-            type: object
-            properties:
-              ocean:
-                type: string
-              salt:
-                type: boolean
-            required: [ocean, salt]
-      operationId: locateFishy`
+          content:
+            application/json:
+              schema:
+                maxLength: 1
+      operationId: locateFishy
+`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -2410,91 +3899,74 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet,
-		"https://things.com/a/beef/on/a/dishy?fishy[ocean]=atlantic&fishy[salt]=12", nil)
-
-	// preset the path
-	path, _, pv := paths.FindPath(request, &m.Model)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?code="+
+		url.QueryEscape(`"%"`), nil)
 
-	valid, errors := v.ValidateQueryParamsWithPathItem(request, path, pv)
-	assert.False(t, valid)
+	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
 
-	assert.Len(t, errors, 1)
-	assert.Equal(t, "GET Path '/a/beef/on/a/dishy' not found", errors[0].Message)
+	// the value is only decoded once, so '%25' and '%zz' are three characters long.
+	for _, code := range []string{`"abc"`, `"%25"`, `"%zz"`} {
+		request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?code="+
+			url.QueryEscape(code), nil)
+
+		valid, errors = v.ValidateQueryParams(request)
+		assert.False(t, valid, code)
+		require.Len(t, errors, 1, code)
+		assert.Equal(t, "Query parameter 'code' failed to validate", errors[0].Message)
+		assert.Equal(t, -1, errors[0].SpecLine)
+		require.Len(t, errors[0].SchemaValidationErrors, 1)
+		assert.Equal(t, "maxLength", errors[0].SchemaValidationErrors[0].Keyword)
+	}
 }
 
-func TestNewValidator_QueryParamValidateStyle_DeepObjectMultiValuesFailedMultipleSchemas(t *testing.T) {
-	spec := `---
-openapi: 3.1.0
+func TestNewValidator_QueryParamStrictMode(t *testing.T) {
+	spec := `openapi: 3.1.0
 paths:
   /a/fishy/on/a/dishy:
     get:
       parameters:
         - name: fishy
           in: query
-          required: true
-          style: deepObject
-          schema:
-            type: object
-            properties:
-              ocean:
-                type: string
-              salt:
-                type: boolean
-            required:
-              - ocean
-              - salt
-        - name: dishy
-          in: query
-          required: true
-          style: deepObject
-          schema:
-            type: object
-            properties:
-              size:
-                type: string
-              numCracks:
-                type: number
-            required:
-              - size
-              - numCracks
-        - name: cake
-          in: query
-          required: true
-          style: deepObject
           schema:
-            type: object
-            properties:
-              message:
-                type: string
-              numCandles:
-                type: number
-            required:
-              - message
-              - numCandles
-      operationId: locateFishy`
+            type: string
+      operationId: locateFishy
+`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
 	m, _ := doc.BuildV3Model()
 
-	v := NewParameterValidator(&m.Model)
-
-	request, _ := http.NewRequest(http.MethodGet,
-		"https://things.com/a/fishy/on/a/dishy?fishy[ocean]=atlantic&fishy[salt]=12"+
-			"&dishy[size]=big&dishy[numCracks]=false"+
-			"&cake[message]=happy%20birthday&cake[numCandles]=false", nil)
+	// a declared param is always valid.
+	v := NewParameterValidator(&m.Model, config.WithStrictQueryParams())
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=cod", nil)
 
 	valid, errors := v.ValidateQueryParams(request)
-	assert.False(t, valid)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
 
-	assert.Len(t, errors, 3)
-	assert.Equal(t, "got number, want boolean", errors[0].SchemaValidationErrors[0].Reason)
-	assert.Equal(t, "got boolean, want number", errors[1].SchemaValidationErrors[0].Reason)
-	assert.Equal(t, "got boolean, want number", errors[2].SchemaValidationErrors[0].Reason)
+	// an undeclared param is ignored when strict mode is off.
+	v = NewParameterValidator(&m.Model)
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=cod&fihsy=cod", nil)
+
+	valid, errors = v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	// and reported when strict mode is on.
+	v = NewParameterValidator(&m.Model, config.WithStrictQueryParams())
+
+	valid, errors = v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Query parameter 'fihsy' is not defined", errors[0].Message)
+	assert.Equal(t, "Remove the query parameter 'fihsy' from the request, or check the name "+
+		"of the parameter is spelled correctly", errors[0].HowToFix)
+	assert.Equal(t, 3, errors[0].SpecLine)
 }
 
-func TestNewValidator_ValidateEncodedObjectIsCorrect(t *testing.T) {
+func TestNewValidator_QueryParamStrictModeFormObject(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
   /a/fishy/on/a/dishy:
@@ -2502,8 +3974,6 @@ paths:
       parameters:
         - name: fishy
           in: query
-          required: true
-          style: deepObject
           schema:
             type: object
             properties:
@@ -2511,122 +3981,131 @@ paths:
                 type: string
               salt:
                 type: boolean
-            required: [ocean, salt]
-      operationId: locateFishy`
+        - name: chips
+          in: query
+          schema:
+            type: object
+            additionalProperties: false
+      operationId: locateFishy
+`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
+
 	m, _ := doc.BuildV3Model()
 
-	sch := m.Model.Paths.PathItems.GetOrZero("/a/fishy/on/a/dishy").Get.Parameters[0].Schema
+	v := NewParameterValidator(&m.Model, config.WithStrictQueryParams())
 
-	s := sch.Schema()
+	// properties of an exploded form object are defined.
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?ocean=atlantic&salt=true", nil)
 
-	// this is not compatible.
-	rawObject := map[int]int{
-		1: 2,
-	}
+	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
 
-	errs := ValidateParameterSchema(s, rawObject, "cake", "burger", "lemons",
-		"pizza", "rice", "herbs")
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?ocean=atlantic&vinegar=true", nil)
 
-	assert.Len(t, errs, 1)
-	assert.Equal(t, "lemons 'pizza' is defined as an object, "+
-		"however it failed to be decoded as an object", errs[0].Reason)
+	// 'vinegar' is not defined by any parameter, so it's reported, and it's left for 'chips' which rejects it.
+	valid, errors = v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 2)
+	assert.Equal(t, "Query parameter 'chips' failed to validate", errors[0].Message)
+	assert.Equal(t, "Query parameter 'vinegar' is not defined", errors[1].Message)
 }
 
-// https://github.com/pb33f/wiretap/issues/82
-func TestNewValidator_QueryParamValidateStyle_DeepObjectAdditionalPropertiesArray(t *testing.T) {
+func TestNewValidator_QueryParamStrictModeFormObjectAllowsAdditional(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
-  /anything/queryParams/deepObject/map:
+  /a/fishy/on/a/dishy:
     get:
-      operationId: deepObjectQueryParamsMap
       parameters:
-        - name: mapArrParam
+        - name: fishy
           in: query
-          style: deepObject
           schema:
             type: object
-            additionalProperties:
-              type: array
-              items:
+            properties:
+              ocean:
                 type: string
-            example: { "test": ["test", "test2"], "test2": ["test3", "test4"] }
-      responses:
-        "200":
-          description: OK`
+            additionalProperties: true
+        - name: chips
+          in: query
+          schema:
+            type: object
+            additionalProperties: false
+      operationId: locateFishy
+`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
 	m, _ := doc.BuildV3Model()
 
-	v := NewParameterValidator(&m.Model)
+	v := NewParameterValidator(&m.Model, config.WithStrictQueryParams())
 
-	request, _ := http.NewRequest(http.MethodGet,
-		"https://things.com/anything/queryParams/deepObject/map?mapArrParam[test2]=test3&mapArrParam[test2]=test4&mapArrParam[test]=test&mapArrParam[test]=test2", nil)
+	// 'fishy' allows additional properties, so 'vinegar' is defined by it, and is not left for 'chips'.
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?ocean=atlantic&vinegar=true", nil)
 
 	valid, errors := v.ValidateQueryParams(request)
 	assert.True(t, valid)
-
 	assert.Len(t, errors, 0)
 }
 
-// https://github.com/pb33f/wiretap/issues/82
-func TestNewValidator_QueryParamValidateStyle_DeepObjectAdditionalPropertiesArrayTop(t *testing.T) {
+func TestNewValidator_QueryParamStrictModeAdditionalProperties(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
-  /anything/queryParams/deepObject/map:
+  /a/fishy/on/a/dishy:
     get:
-      operationId: deepObjectQueryParamsMap
       parameters:
-        - name: mapArrParam
+        - name: fishy
           in: query
-          style: deepObject
           schema:
-            type: array
-            items:
+            type: object
+            additionalProperties:
               type: string
-            example: { "test": ["test", "test2"], "test2": ["test3", "test4"] }
-      responses:
-        "200":
-          description: OK`
+      operationId: locateFishy
+`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
 	m, _ := doc.BuildV3Model()
 
-	v := NewParameterValidator(&m.Model)
+	v := NewParameterValidator(&m.Model, config.WithStrictQueryParams())
 
-	request, _ := http.NewRequest(http.MethodGet,
-		"https://things.com/anything/queryParams/deepObject/map?mapArrParam[test2]=test3&mapArrParam[test2]=test4&mapArrParam[test]=test&mapArrParam[test]=test2", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?ocean=atlantic&vinegar=malt", nil)
 
 	valid, errors := v.ValidateQueryParams(request)
 	assert.True(t, valid)
-
 	assert.Len(t, errors, 0)
 }
 
-// https://github.com/pb33f/wiretap/issues/82
-func TestNewValidator_QueryParamValidateStyle_DeepObjectAdditionalPropertiesArray_Fail(t *testing.T) {
+func TestNewValidator_QueryParamExplodedFormObject(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
-  /anything/queryParams/deepObject/map:
+  /a/fishy/on/a/dishy:
     get:
-      operationId: deepObjectQueryParamsMap
       parameters:
-        - name: mapArrParam
+        - name: filter
           in: query
-          style: deepObject
+          style: form
+          explode: true
+          required: true
           schema:
             type: object
-            additionalProperties:
-              type: array
-              items:
+            properties:
+              status:
                 type: string
-            example: { "test": ["test", "test2"], "test2": ["test3", "test4"] }
-      responses:
-        "200":
-          description: OK`
+              limit:
+                type: integer
+                maximum: 50
+              tags:
+                type: array
+                items:
+                  type: string
+            additionalProperties: false
+        - name: page
+          in: query
+          schema:
+            type: integer
+      operationId: locateFishy
+`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
@@ -2634,111 +4113,120 @@ paths:
 
 	v := NewParameterValidator(&m.Model)
 
+	// the keys of the other parameters are not part of the object.
 	request, _ := http.NewRequest(http.MethodGet,
-		"https://things.com/anything/queryParams/deepObject/map?mapArrParam[test2]=23&mapArrParam[test2]=test4&mapArrParam[test]=test&mapArrParam[test]=test2", nil)
+		"https://things.com/a/fishy/on/a/dishy?status=123&limit=20&tags=cod&tags=haddock&page=2", nil)
 
 	valid, errors := v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	// the other parameters are still validated.
+	request, _ = http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?status=open&page=two", nil)
+
+	valid, errors = v.ValidateQueryParams(request)
 	assert.False(t, valid)
 	assert.Len(t, errors, 1)
-	assert.Equal(t, "got number, want string", errors[0].SchemaValidationErrors[0].Reason)
+	assert.Equal(t, "Query parameter 'page' is not a valid integer", errors[0].Message)
+
+	// a required object with none of its keys is missing.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?page=2", nil)
+
+	valid, errors = v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Query parameter 'filter' is missing", errors[0].Message)
 }
 
-// https://github.com/pb33f/wiretap/issues/83
-func TestNewValidator_QueryParamValidateStyle_BadSchemaDeepObject(t *testing.T) {
+func TestNewValidator_QueryParamExplodedFormObjectInvalid(t *testing.T) {
 	spec := `openapi: 3.1.0
-info:
-  title: Test
-  version: 0.1.0
-security:
-  - apiKeyAuth: []
 paths:
-  /anything/queryParams/deepObject/obj:
+  /a/fishy/on/a/dishy:
     get:
-      operationId: deepObjectQueryParamsObject
       parameters:
-        - name: objParam
+        - name: filter
           in: query
-          style: deepObject
+          style: form
+          explode: true
           schema:
-            $ref: "components.yaml#/components/schemas/simpleObject"
-          required: true
-      responses:
-        "200":
-          description: OK
-components:
-  securitySchemes:
-    apiKeyAuth:
-      type: apiKey
-      in: header
-      name: Authorization
-      description: Authenticate using an API Key generated via our platform.`
+            type: object
+            properties:
+              status:
+                type: string
+              limit:
+                type: integer
+            additionalProperties: false
+      operationId: locateFishy
+`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
-	m, err := doc.BuildV3Model()
-	assert.Len(t, err, 1) // path build will fail because of missing schema.
+	m, _ := doc.BuildV3Model()
 
 	v := NewParameterValidator(&m.Model)
 
 	request, _ := http.NewRequest(http.MethodGet,
-		"http://localhost:9090/anything/queryParams/deepObject/obj?objParam=blahdedahdedah", nil)
+		"https://things.com/a/fishy/on/a/dishy?status=open&limit=ten", nil)
 
 	valid, errors := v.ValidateQueryParams(request)
-	assert.True(t, valid)
-	assert.Len(t, errors, 0)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Query parameter 'filter' failed to validate", errors[0].Message)
+	assert.Equal(t, "got string, want integer", errors[0].SchemaValidationErrors[0].Reason)
+	assert.Equal(t, "/limit", errors[0].SchemaValidationErrors[0].InstancePath)
+
+	// unknown keys are not allowed by the object.
+	request, _ = http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?status=open&vinegar=malt", nil)
+
+	valid, errors = v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Query parameter 'filter' failed to validate", errors[0].Message)
+	assert.Contains(t, errors[0].SchemaValidationErrors[0].Reason, "additional property 'vinegar' is not allowed")
 }
 
-// https://github.com/pb33f/wiretap/issues/83
-func TestNewValidator_QueryParamValidateStyle_BadSchemaDeepObject_Inline(t *testing.T) {
+func TestNewValidator_QueryParamArrayNotExplodedRepeated(t *testing.T) {
 	spec := `openapi: 3.1.0
-info:
-  title: Test
-  version: 0.1.0
-security:
-  - apiKeyAuth: []
 paths:
-  /anything/queryParams/deepObject/obj:
+  /a/fishy/on/a/dishy:
     get:
-      operationId: deepObjectQueryParamsObject
       parameters:
-        - name: objParam
+        - name: ids
           in: query
-          style: deepObject
+          explode: false
           schema:
-            type: object
-            properties:
-              cake:
-                type: string
-          required: true
-      responses:
-        "200":
-          description: OK
-components:
-  securitySchemes:
-    apiKeyAuth:
-      type: apiKey
-      in: header
-      name: Authorization
-      description: Authenticate using an API Key generated via our platform.`
+            type: array
+            items:
+              type: integer
+      operationId: locateFishy
+`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
-	m, err := doc.BuildV3Model()
-	assert.Len(t, err, 0) // no patch build here
+	m, _ := doc.BuildV3Model()
 
 	v := NewParameterValidator(&m.Model)
 
-	request, _ := http.NewRequest(http.MethodGet,
-		"http://localhost:9090/anything/queryParams/deepObject/obj?objParam=blahdedahdedah", nil)
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?ids=1,2&ids=3", nil)
 
 	valid, errors := v.ValidateQueryParams(request)
 	assert.False(t, valid)
 	assert.Len(t, errors, 1)
-	assert.Equal(t, "The query parameter 'objParam' is defined as an object,"+
-		" however it failed to pass a schema validation", errors[0].Reason)
+	assert.Equal(t, "Query array parameter 'ids' is repeated", errors[0].Message)
+	assert.Equal(t, "Non-exploded arrays cannot be repeated, send the array as a single comma separated value, "+
+		"for example: 'ids=1,2,3'", errors[0].HowToFix)
+	assert.Equal(t, 8, errors[0].SpecLine)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?ids=1,2,3", nil)
+
+	valid, errors = v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
 }
 
-func TestNewValidator_ValidateRawMap(t *testing.T) {
+func TestNewValidator_QueryParamUnsupportedPattern(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
   /a/fishy/on/a/dishy:
@@ -2746,83 +4234,89 @@ paths:
       parameters:
         - name: fishy
           in: query
-          required: true
-          style: deepObject
           schema:
-            type: object
-            properties:
-              ocean:
-                type: string
-              salt:
-                type: boolean
-            required: [ocean, salt]
-      operationId: locateFishy`
+            type: string
+            pattern: '^(?=.*[A-Z]).+$'
+            maxLength: 5
+      operationId: locateFishy
+`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
+
 	m, _ := doc.BuildV3Model()
 
-	sch := m.Model.Paths.PathItems.GetOrZero("/a/fishy/on/a/dishy").Get.Parameters[0].Schema
+	// the pattern is reported, rather than failing the whole validation.
+	v := NewParameterValidator(&m.Model)
 
-	s := sch.Schema()
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=cod", nil)
 
-	// this is not compatible.
-	rawObject := map[int]int{
-		1: 2,
-	}
+	valid, errors := v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "Query parameter 'fishy' schema pattern '^(?=.*[A-Z]).+$' cannot be compiled", errors[0].Message)
+	assert.Equal(t, "query", errors[0].ValidationSubType)
 
-	errs := ValidateParameterSchema(s, rawObject, "cake", "burger", "lemons",
-		"pizza", "rice", "herbs")
+	// the pattern is skipped, so the rest of the schema is still validated.
+	v = NewParameterValidator(&m.Model, config.WithSkipInvalidPatterns())
 
-	assert.Len(t, errs, 1)
-	assert.Equal(t, "lemons 'pizza' is defined as an object, "+
-		"however it failed to be decoded as an object", errs[0].Reason)
+	valid, errors = v.ValidateQueryParams(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/a/fishy/on/a/dishy?fishy=halibut", nil)
+
+	valid, errors = v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
 }
 
-// https://github.com/pb33f/wiretap/issues/83
-func TestNewValidator_QueryParamValidateStyle_EmptyDeepObject(t *testing.T) {
+func TestNewValidator_QueryParamDeepObjectMinMaxProperties(t *testing.T) {
 	spec := `openapi: 3.1.0
-info:
-  title: Test
-  version: 0.1.0
-security:
-  - apiKeyAuth: []
 paths:
-  /anything/queryParams/deepObject/obj:
+  /a/fishy/on/a/dishy:
     get:
-      operationId: deepObjectQueryParamsObject
       parameters:
-        - name: objParam
+        - name: metadata
           in: query
+          required: true
           style: deepObject
           schema:
             type: object
-            properties:
-              cake:
-                type: string
-          required: true
-      responses:
-        "200":
-          description: OK
-components:
-  securitySchemes:
-    apiKeyAuth:
-      type: apiKey
-      in: header
-      name: Authorization
-      description: Authenticate using an API Key generated via our platform.`
+            minProperties: 2
+            maxProperties: 3
+            additionalProperties:
+              type: string
+      operationId: locateFishy`
 
 	doc, _ := libopenapi.NewDocument([]byte(spec))
 
-	m, err := doc.BuildV3Model()
-	assert.Len(t, err, 0) // no patch build here
+	m, _ := doc.BuildV3Model()
 
 	v := NewParameterValidator(&m.Model)
 
 	request, _ := http.NewRequest(http.MethodGet,
-		"http://localhost:9090/anything/queryParams/deepObject/obj?objParam", nil)
+		"https://things.com/a/fishy/on/a/dishy?metadata[ocean]=atlantic&metadata[salt]=high", nil)
 
 	valid, errors := v.ValidateQueryParams(request)
 	assert.True(t, valid)
 	assert.Len(t, errors, 0)
 
+	request, _ = http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?metadata[ocean]=atlantic&metadata[salt]=high"+
+			"&metadata[depth]=deep&metadata[temperature]=cold", nil)
+
+	valid, errors = v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "maxProperties: got 4, want 3", errors[0].SchemaValidationErrors[0].Reason)
+
+	request, _ = http.NewRequest(http.MethodGet,
+		"https://things.com/a/fishy/on/a/dishy?metadata[ocean]=atlantic", nil)
+
+	valid, errors = v.ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "minProperties: got 1, want 2", errors[0].SchemaValidationErrors[0].Reason)
 }
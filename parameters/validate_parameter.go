@@ -8,12 +8,11 @@ import (
 	stdError "errors"
 	"fmt"
 	"github.com/pb33f/libopenapi-validator/helpers"
-	"golang.org/x/text/language"
-	"golang.org/x/text/message"
 	"net/url"
 	"reflect"
 	"strings"
 
+	"github.com/pb33f/libopenapi-validator/config"
 	"github.com/pb33f/libopenapi-validator/errors"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	"github.com/pb33f/libopenapi/utils"
@@ -28,25 +27,45 @@ func ValidateSingleParameterSchema(
 	name string,
 	validationType string,
 	subValType string,
+	opts ...config.Option,
 ) (validationErrors []*errors.ValidationError) {
-	jsch := compileSchema(name, buildJsonRender(schema))
+	jsch, decodedSchema, err := compileSchema(name, schema, config.NewValidationOptions(opts...))
+	if err != nil {
+		return []*errors.ValidationError{schemaCompilationError(err, schema, entity, name, validationType, subValType)}
+	}
 
 	scErrs := jsch.Validate(rawObject)
 	var werras *jsonschema.ValidationError
 	if stdError.As(scErrs, &werras) {
-		validationErrors = formatJsonSchemaValidationError(schema, werras, entity, reasonEntity, name, validationType, subValType)
+		validationErrors = formatJsonSchemaValidationError(schema, decodedSchema, werras, entity, reasonEntity, name, validationType, subValType)
 	}
 	return validationErrors
 }
 
-// compileSchema create a new json schema compiler and add the schema to it.
-func compileSchema(name string, jsonSchema []byte) *jsonschema.Schema {
-	compiler := jsonschema.NewCompiler()
-	compiler.UseLoader(helpers.NewCompilerLoader())
-	decodedSchema, _ := jsonschema.UnmarshalJSON(strings.NewReader(string(jsonSchema))) // decode the schema into a json blob
-	_ = compiler.AddResource(fmt.Sprintf("%s.json", name), decodedSchema)
-	jsch, _ := compiler.Compile(fmt.Sprintf("%s.json", name))
-	return jsch
+// compileSchema create a new json schema compiler and add the schema to it, the decoded schema is also returned.
+func compileSchema(name string, schema *base.Schema, options *config.ValidationOptions) (*jsonschema.Schema, any, error) {
+	return helpers.CompileSchema(name, schema, buildJsonRender(schema), helpers.ParameterValidation, options)
+}
+
+// schemaCompilationError will create a ValidationError for a parameter schema that cannot be compiled, a pattern
+// the regex engine does not support is reported as an invalid schema pattern, and an unknown format as an unknown
+// schema format.
+func schemaCompilationError(err error, schema *base.Schema, entity, name, validationType, subValType string) *errors.ValidationError {
+	var validationError *errors.ValidationError
+	if patternErr, ok := err.(*helpers.InvalidPatternError); ok {
+		validationError = errors.InvalidSchemaPattern(patternErr, schema, fmt.Sprintf("%s '%s'", entity, name), validationType)
+	} else if formatErr, ok := err.(*helpers.UnknownFormatError); ok {
+		validationError = errors.UnknownSchemaFormat(formatErr, schema, fmt.Sprintf("%s '%s'", entity, name), validationType)
+	} else {
+		validationError = &errors.ValidationError{
+			ValidationType: validationType,
+			Message:        err.Error(),
+			Reason:         fmt.Sprintf("Failed to compile the schema of %s '%s'.", strings.ToLower(entity), name),
+			Context:        schema,
+		}
+	}
+	validationError.ValidationSubType = subValType
+	return validationError
 }
 
 // buildJsonRender build a JSON render of the schema.
@@ -67,6 +86,7 @@ func buildJsonRender(schema *base.Schema) []byte {
 //	name: the name of the parameter
 //	validationType: the type of validation being performed
 //	subValType: the type of sub-validation being performed
+//	opts: options used to configure the validation, such as custom formats
 func ValidateParameterSchema(
 	schema *base.Schema,
 	rawObject any,
@@ -75,15 +95,12 @@ func ValidateParameterSchema(
 	reasonEntity,
 	name,
 	validationType,
-	subValType string) []*errors.ValidationError {
+	subValType string,
+	opts ...config.Option) []*errors.ValidationError {
 
 	var validationErrors []*errors.ValidationError
 
-	// 1. build a JSON render of the schema.
-	renderedSchema, _ := schema.RenderInline()
-	jsonSchema, _ := utils.ConvertYAMLtoJSON(renderedSchema)
-
-	// 2. decode the object into a json blob.
+	// 1. decode the object into a json blob.
 	var decodedObj interface{}
 	rawIsMap := false
 	validEncoding := false
@@ -99,21 +116,18 @@ func ValidateParameterSchema(
 			} else {
 				rawIsMap = true
 			}
+		default:
+			// the value has already been decoded into the type defined by the schema.
+			decodedObj = rawObject
+			validEncoding = true
 		}
 	} else {
 		decodedString, _ := url.QueryUnescape(rawBlob)
 		_ = json.Unmarshal([]byte(decodedString), &decodedObj)
 		validEncoding = true
 	}
-	// 3. create a new json schema compiler and add the schema to it
-	compiler := jsonschema.NewCompiler()
-
-	decodedSchema, _ := jsonschema.UnmarshalJSON(strings.NewReader(string(jsonSchema)))
-	_ = compiler.AddResource(fmt.Sprintf("%s.json", name), decodedSchema)
-	jsch, _ := compiler.Compile(fmt.Sprintf("%s.json", name))
 
-	// 4. validate the object against the schema
-	var scErrs error
+	// 2. validate the object against the schema, an empty object means the parameter has no value to validate.
 	if validEncoding {
 		p := decodedObj
 		if rawIsMap {
@@ -130,14 +144,15 @@ func ValidateParameterSchema(
 			if rawIsMap {
 				for k := range p.(map[string]interface{}) {
 					if k == "" {
+						line, col := schemaTypeLocation(schema)
 						validationErrors = append(validationErrors, &errors.ValidationError{
 							ValidationType:    validationType,
 							ValidationSubType: subValType,
 							Message:           fmt.Sprintf("%s '%s' failed to validate", entity, name),
 							Reason: fmt.Sprintf("%s '%s' is defined as an object, "+
 								"however it failed to pass a schema validation", reasonEntity, name),
-							SpecLine:               schema.GoLow().Type.KeyNode.Line,
-							SpecCol:                schema.GoLow().Type.KeyNode.Column,
+							SpecLine:               line,
+							SpecCol:                col,
 							SchemaValidationErrors: nil,
 							HowToFix:               errors.HowToFixInvalidSchema,
 						})
@@ -147,28 +162,26 @@ func ValidateParameterSchema(
 				}
 			}
 			if !skip {
-				scErrs = jsch.Validate(p)
+				validationErrors = append(validationErrors, validateDecodedParameter(schema, p, entity, reasonEntity,
+					name, validationType, subValType, opts...)...)
 			}
 		}
 	}
-	var werras *jsonschema.ValidationError
-	if stdError.As(scErrs, &werras) {
-		validationErrors = formatJsonSchemaValidationError(schema, werras, entity, reasonEntity, name, validationType, subValType)
-	}
 
 	// if there are no validationErrors, check that the supplied value is even JSON
 	if len(validationErrors) == 0 {
 		if rawIsMap {
 			if !validEncoding {
 				// add the error to the list
+				line, col := schemaTypeLocation(schema)
 				validationErrors = append(validationErrors, &errors.ValidationError{
 					ValidationType:    validationType,
 					ValidationSubType: subValType,
 					Message:           fmt.Sprintf("%s '%s' cannot be decoded", entity, name),
 					Reason: fmt.Sprintf("%s '%s' is defined as an object, "+
 						"however it failed to be decoded as an object", reasonEntity, name),
-					SpecLine: schema.GoLow().Type.KeyNode.Line,
-					SpecCol:  schema.GoLow().Type.KeyNode.Column,
+					SpecLine: line,
+					SpecCol:  col,
 					HowToFix: errors.HowToFixDecodingError,
 				})
 			}
@@ -177,43 +190,78 @@ func ValidateParameterSchema(
 	return validationErrors
 }
 
-func formatJsonSchemaValidationError(schema *base.Schema, scErrs *jsonschema.ValidationError, entity string, reasonEntity string, name string, validationType string, subValType string) (validationErrors []*errors.ValidationError) {
-	// flatten the validationErrors
-	schFlatErrs := scErrs.BasicOutput().Errors
-	var schemaValidationErrors []*errors.SchemaValidationFailure
-	for q := range schFlatErrs {
-		er := schFlatErrs[q]
+// validateDecodedParameter will validate a value that has already been decoded, such as a parameter defined
+// with a JSON media type, against the schema of the parameter. Unlike ValidateParameterSchema, an empty object
+// or a null value is validated as it is.
+func validateDecodedParameter(
+	schema *base.Schema,
+	decoded any,
+	entity,
+	reasonEntity,
+	name,
+	validationType,
+	subValType string,
+	opts ...config.Option) []*errors.ValidationError {
 
-		errMsg := er.Error.Kind.LocalizedString(message.NewPrinter(language.Tag{}))
-		if er.KeywordLocation == "" || helpers.IgnoreRegex.MatchString(errMsg) {
-			continue // ignore this error, it's not useful
+	// if the schema uses a discriminator, validate against the schema it selects, so any violations are
+	// reported against that schema, rather than as a generic oneOf / anyOf failure.
+	if selected, value, ok := helpers.ResolveDiscriminator(schema, decoded); ok {
+		if selected == nil {
+			unknown := errors.UnknownDiscriminatorValue(schema, value, fmt.Sprintf("%s '%s'", entity, name), validationType)
+			unknown.ValidationSubType = subValType
+			return []*errors.ValidationError{unknown}
 		}
+		schema = selected
+	}
 
-		fail := &errors.SchemaValidationFailure{
-			Reason:        errMsg,
-			Location:      er.KeywordLocation,
-			OriginalError: scErrs,
-		}
-		if schema != nil {
-			rendered, err := schema.RenderInline()
-			if err == nil && rendered != nil {
-				fail.ReferenceSchema = fmt.Sprintf("%s", rendered)
-			}
+	// compile the schema, or use the compiled schema from the cache if it has been compiled before.
+	renderedSchema, _ := helpers.RenderSchemaInline(schema)
+	jsonSchema, _ := utils.ConvertYAMLtoJSON(renderedSchema)
+	jsch, decodedSchema, err := helpers.CompileSchema(name, schema, jsonSchema, validationType, config.NewValidationOptions(opts...))
+	if err != nil {
+		return []*errors.ValidationError{schemaCompilationError(err, schema, entity, name, validationType, subValType)}
+	}
+
+	var werras *jsonschema.ValidationError
+	if stdError.As(jsch.Validate(decoded), &werras) {
+		return formatJsonSchemaValidationError(schema, decodedSchema, werras, entity, reasonEntity, name, validationType, subValType)
+	}
+	return nil
+}
+
+// schemaTypeLocation returns the line and column of the 'type' of a schema, or -1 if the schema has no type.
+func schemaTypeLocation(schema *base.Schema) (int, int) {
+	if low := schema.GoLow(); low != nil && low.Type.KeyNode != nil {
+		return low.Type.KeyNode.Line, low.Type.KeyNode.Column
+	}
+	return -1, -1
+}
+
+func formatJsonSchemaValidationError(schema *base.Schema, decodedSchema any, scErrs *jsonschema.ValidationError, entity string, reasonEntity string, name string, validationType string, subValType string) (validationErrors []*errors.ValidationError) {
+	// every failure references the schema of the parameter.
+	var referenceSchema string
+	if schema != nil {
+		if rendered, err := helpers.RenderSchemaInline(schema); err == nil && rendered != nil {
+			referenceSchema = string(rendered)
 		}
-		schemaValidationErrors = append(schemaValidationErrors, fail)
 	}
+	schemaValidationErrors := errors.SchemaFailures(scErrs, decodedSchema,
+		func(failure *errors.SchemaValidationFailure, _ jsonschema.OutputUnit) {
+			failure.ReferenceSchema = referenceSchema
+		})
 	schemaType := "undefined"
 	if len(schema.Type) > 0 {
 		schemaType = schema.Type[0]
 	}
+	line, col := schemaTypeLocation(schema)
 	validationErrors = append(validationErrors, &errors.ValidationError{
 		ValidationType:    validationType,
 		ValidationSubType: subValType,
 		Message:           fmt.Sprintf("%s '%s' failed to validate", entity, name),
 		Reason: fmt.Sprintf("%s '%s' is defined as an %s, "+
 			"however it failed to pass a schema validation", reasonEntity, name, schemaType),
-		SpecLine:               schema.GoLow().Type.KeyNode.Line,
-		SpecCol:                schema.GoLow().Type.KeyNode.Column,
+		SpecLine:               line,
+		SpecCol:                col,
 		SchemaValidationErrors: schemaValidationErrors,
 		HowToFix:               errors.HowToFixInvalidSchema,
 	})
@@ -4,7 +4,8 @@
 package parameters
 
 import (
-	"fmt"
+	"encoding/json"
+	"github.com/pb33f/libopenapi-validator/config"
 	"github.com/pb33f/libopenapi-validator/errors"
 	"github.com/pb33f/libopenapi-validator/helpers"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
@@ -16,16 +17,24 @@ import (
 
 // ValidateCookieArray will validate a cookie parameter that is an array
 func ValidateCookieArray(
-	sch *base.Schema, param *v3.Parameter, value string) []*errors.ValidationError {
+	sch *base.Schema, param *v3.Parameter, value string, opts ...config.Option) []*errors.ValidationError {
 
 	var validationErrors []*errors.ValidationError
 	itemsSchema := sch.Items.A.Schema()
+	options := config.NewValidationOptions(opts...)
 
-	// header arrays can only be encoded as CSV
-	items := helpers.ExplodeQueryValue(value, helpers.DefaultDelimited)
+	// cookie arrays are encoded as CSV, unless they are exploded, in which case the
+	// values are separated using ampersands, for example: '3&id=4&id=5'
+	var items []string
+	if param.IsExploded() {
+		items = helpers.ExplodeCookieValue(param.Name, value)
+	} else {
+		items = helpers.ExplodeQueryValue(value, helpers.DefaultDelimited)
+	}
 
 	// now check each item in the array
 	for _, item := range items {
+		numErrors := len(validationErrors)
 		// for each type defined in the item's schema, check the item
 		for _, itemType := range itemsSchema.Type {
 			switch itemType {
@@ -50,119 +59,113 @@ func ValidateCookieArray(
 				continue
 			}
 		}
+		if len(validationErrors) == numErrors {
+			validationErrors = append(validationErrors, validateCoercedArrayItem(itemsSchema, param, item,
+				"Cookie parameter", "The cookie parameter", helpers.ParameterValidationCookie, options)...)
+		}
 	}
 	return validationErrors
 }
 
 // ValidateHeaderArray will validate a header parameter that is an array
 func ValidateHeaderArray(
-	sch *base.Schema, param *v3.Parameter, value string) []*errors.ValidationError {
+	sch *base.Schema, param *v3.Parameter, value string, opts ...config.Option) []*errors.ValidationError {
 
 	var validationErrors []*errors.ValidationError
 	itemsSchema := sch.Items.A.Schema()
+	options := config.NewValidationOptions(opts...)
 
-	// header arrays can only be encoded as CSV
+	// header arrays can only be encoded as CSV, the 'simple' style is the same when exploded or not.
 	items := helpers.ExplodeQueryValue(value, helpers.DefaultDelimited)
 
 	// now check each item in the array
-	for _, item := range items {
+	for i, item := range items {
+		// optional whitespace is allowed around the commas of a header value.
+		item = strings.TrimSpace(item)
+		numErrors := len(validationErrors)
 		// for each type defined in the item's schema, check the item
 		for _, itemType := range itemsSchema.Type {
 			switch itemType {
 			case helpers.Integer, helpers.Number:
 				if _, err := strconv.ParseFloat(item, 64); err != nil {
 					validationErrors = append(validationErrors,
-						errors.IncorrectHeaderParamArrayNumber(param, item, sch, itemsSchema))
+						errors.IncorrectHeaderParamArrayNumber(param, item, i, sch, itemsSchema))
 				}
 			case helpers.Boolean:
 				if _, err := strconv.ParseBool(item); err != nil {
 					validationErrors = append(validationErrors,
-						errors.IncorrectHeaderParamArrayBoolean(param, item, sch, itemsSchema))
+						errors.IncorrectHeaderParamArrayBoolean(param, item, i, sch, itemsSchema))
 					break
 				}
 				// check for edge-cases "0" and "1" which can also be parsed into valid booleans
 				if item == "0" || item == "1" {
 					validationErrors = append(validationErrors,
-						errors.IncorrectHeaderParamArrayBoolean(param, item, sch, itemsSchema))
+						errors.IncorrectHeaderParamArrayBoolean(param, item, i, sch, itemsSchema))
 				}
 			case helpers.String:
 				// do nothing for now.
 				continue
 			}
 		}
+		if len(validationErrors) == numErrors {
+			validationErrors = append(validationErrors, validateCoercedArrayItem(itemsSchema, param, item,
+				"Header parameter", "The header parameter", helpers.ParameterValidationHeader, options)...)
+		}
 	}
 	return validationErrors
 }
 
 // ValidateQueryArray will validate a query parameter that is an array
 func ValidateQueryArray(
-	sch *base.Schema, param *v3.Parameter, ef string, contentWrapped bool) []*errors.ValidationError {
+	sch *base.Schema, param *v3.Parameter, ef string, contentWrapped bool, opts ...config.Option) []*errors.ValidationError {
+	return validateQueryArray(sch, param, ef, contentWrapped, 0, config.NewValidationOptions(opts...))
+}
+
+// validateQueryArray will validate the items of a query array value. The offset is the index of the first item
+// in the value, as an exploded array is split across multiple values, and is used to report the index of any
+// invalid items.
+func validateQueryArray(sch *base.Schema, param *v3.Parameter, ef string, contentWrapped bool, offset int,
+	options *config.ValidationOptions) []*errors.ValidationError {
 
 	var validationErrors []*errors.ValidationError
 	itemsSchema := sch.Items.A.Schema()
-
-	// check for an exploded bit on the schema.
-	// if it's exploded, then we need to check each item in the array
-	// if it's not exploded, then we need to check the whole array as a string
-	var items []string
-	if param.IsExploded() {
-		items = helpers.ExplodeQueryValue(ef, param.Style)
-	} else {
-		// check for a style of form (or no style) and if so, explode the value
-		if param.Style == "" || param.Style == helpers.Form {
-			if !contentWrapped {
-				items = helpers.ExplodeQueryValue(ef, param.Style)
-			} else {
-				items = []string{ef}
-			}
-		} else {
-			switch param.Style {
-			case helpers.PipeDelimited, helpers.SpaceDelimited:
-				items = helpers.ExplodeQueryValue(ef, param.Style)
-			}
-		}
-	}
+	items := decodeQueryArrayItems(param, ef, contentWrapped)
 
 	// check if the param is within an enum
-	checkEnum := func(enumCheck, item string) {
+	checkEnum := func(item string, index int, itemType string) {
 		// check if the array param is within an enum
 		if sch.Items.IsA() {
 			itemsSch := sch.Items.A.Schema()
-			if itemsSch.Enum != nil {
-				matchFound := false
-				for _, enumVal := range itemsSch.Enum {
-					if strings.TrimSpace(enumCheck) == fmt.Sprint(enumVal.Value) {
-						matchFound = true
-						break
-					}
-				}
-				if !matchFound {
-					validationErrors = append(validationErrors,
-						errors.IncorrectQueryParamEnumArray(param, item, sch))
-				}
+			if itemsSch.Enum != nil && !helpers.MatchesEnum(item, itemType, itemsSch.Enum) {
+				validationErrors = append(validationErrors,
+					errors.IncorrectQueryParamEnumArray(param, item, index, sch))
 			}
 		}
 	}
 
 	// now check each item in the array
-	for _, item := range items {
+	for i, item := range items {
+		index := offset + i
+		numErrors := len(validationErrors)
 		// for each type defined in the item's schema, check the item
 		for _, itemType := range itemsSchema.Type {
 			switch itemType {
 			case helpers.Integer, helpers.Number:
 				if _, err := strconv.ParseFloat(item, 64); err != nil {
 					validationErrors = append(validationErrors,
-						errors.IncorrectQueryParamArrayNumber(param, item, sch, itemsSchema))
+						errors.IncorrectQueryParamArrayNumber(param, item, index, sch, itemsSchema))
 					break
 				}
 				// will it blend?
-				checkEnum(ef, item)
+				checkEnum(item, index, itemType)
 
 			case helpers.Boolean:
 				if _, err := strconv.ParseBool(item); err != nil {
 					validationErrors = append(validationErrors,
-						errors.IncorrectQueryParamArrayBoolean(param, item, sch, itemsSchema))
+						errors.IncorrectQueryParamArrayBoolean(param, item, index, sch, itemsSchema))
+					break
 				}
+				checkEnum(item, index, itemType)
 			case helpers.Object:
 				validationErrors = append(validationErrors,
 					ValidateParameterSchema(itemsSchema,
@@ -172,18 +175,123 @@ func ValidateQueryArray(
 						"The query parameter (which is an array)",
 						param.Name,
 						helpers.ParameterValidation,
-						helpers.ParameterValidationQuery)...)
+						helpers.ParameterValidationQuery,
+						config.WithExistingOpts(options))...)
 
 			case helpers.String:
 
 				// will it float?
-				checkEnum(ef, item)
+				checkEnum(item, index, itemType)
+			}
+		}
+		if len(validationErrors) == numErrors && !slices.Contains(itemsSchema.Type, helpers.Object) {
+			validationErrors = append(validationErrors, validateCoercedArrayItem(itemsSchema, param, item,
+				"Query array parameter", "The query parameter (which is an array)", helpers.ParameterValidationQuery,
+				options)...)
+		}
+	}
+	return validationErrors
+}
+
+// validateCoercedArrayItem will convert an item of an array parameter into the type declared by the items schema,
+// and validate it against that schema. Items are only validated this way when the options enable the coercion of
+// parameter types.
+func validateCoercedArrayItem(itemsSchema *base.Schema, param *v3.Parameter, item, entity, reasonEntity,
+	subValType string, options *config.ValidationOptions) []*errors.ValidationError {
+	if options == nil || !options.CoerceParameterTypes {
+		return nil
+	}
+	return ValidateSingleParameterSchema(itemsSchema,
+		helpers.CoerceValue(item, itemsSchema),
+		entity,
+		reasonEntity,
+		param.Name,
+		helpers.ParameterValidation,
+		subValType,
+		config.WithExistingOpts(options))
+}
+
+// ValidateQueryArrayConstraints will validate the decoded items of a query array as a whole, against the
+// array level constraints of the schema (minItems, maxItems and uniqueItems). Empty values are not counted as items.
+func ValidateQueryArrayConstraints(
+	sch *base.Schema, param *v3.Parameter, values []string, contentWrapped bool) []*errors.ValidationError {
+
+	var validationErrors []*errors.ValidationError
+	var items []string
+	for _, ef := range values {
+		for _, item := range decodeQueryArrayItems(param, ef, contentWrapped) {
+			if item != "" {
+				items = append(items, item)
 			}
 		}
 	}
+
+	if sch.MinItems != nil && int64(len(items)) < *sch.MinItems {
+		validationErrors = append(validationErrors,
+			errors.IncorrectQueryParamArrayMinItems(param, len(items), sch))
+	}
+	if sch.MaxItems != nil && int64(len(items)) > *sch.MaxItems {
+		validationErrors = append(validationErrors,
+			errors.IncorrectQueryParamArrayMaxItems(param, len(items), sch))
+	}
+	if sch.UniqueItems != nil && *sch.UniqueItems {
+		seen := make(map[string]bool)
+		for _, item := range items {
+			key := uniqueItemKey(item)
+			if seen[key] {
+				validationErrors = append(validationErrors,
+					errors.IncorrectQueryParamArrayUniqueItems(param, item, sch))
+				break
+			}
+			seen[key] = true
+		}
+	}
 	return validationErrors
 }
 
+// uniqueItemKey will return the key used to compare an item of an array for uniqueness. Items that are JSON objects
+// or arrays are compared by their structure, so objects with the same properties in a different order are equal.
+// Any other item is compared as it was sent.
+func uniqueItemKey(item string) string {
+	trimmed := strings.TrimSpace(item)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return item
+	}
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(trimmed), &decoded); err != nil {
+		return item
+	}
+	// maps are encoded with sorted keys, so the encoding is the same for equal structures.
+	canonical, _ := json.Marshal(decoded)
+	return string(canonical)
+}
+
+// decodeQueryArrayItems will split a single query value into the items of an array, based on the style of the param.
+func decodeQueryArrayItems(param *v3.Parameter, ef string, contentWrapped bool) []string {
+	// check for an exploded bit on the schema.
+	// if it's exploded, then we need to check each item in the array
+	// if it's not exploded, then we need to check the whole array as a string
+	// an object or array item sent as JSON is a single item.
+	if isJSONArrayItem(param, ef) {
+		return []string{ef}
+	}
+	if param.IsExploded() {
+		return helpers.ExplodeQueryValue(ef, param.Style)
+	}
+	// check for a style of form (or no style) and if so, explode the value
+	if param.Style == "" || param.Style == helpers.Form {
+		if !contentWrapped {
+			return helpers.ExplodeQueryValue(ef, param.Style)
+		}
+		return []string{ef}
+	}
+	switch param.Style {
+	case helpers.PipeDelimited, helpers.SpaceDelimited:
+		return helpers.ExplodeQueryValue(ef, param.Style)
+	}
+	return nil
+}
+
 // ValidateQueryParamStyle will validate a query parameter by style
 func ValidateQueryParamStyle(param *v3.Parameter, as []*helpers.QueryParam) []*errors.ValidationError {
 
@@ -231,6 +339,24 @@ stopValidation:
 					}
 				}
 			default:
+				// a non-exploded array is sent as a single comma separated value, so the key cannot be repeated.
+				if param.Explode != nil && !*param.Explode && len(qp.Values) > 1 && param.Schema != nil &&
+					slices.Contains(param.Schema.Schema().Type, helpers.Array) {
+					validationErrors = append(validationErrors,
+						errors.IncorrectQueryParamArrayRepeat(param, qp, param.Schema.Schema()))
+					break stopValidation
+				}
+				// when reserved characters are allowed, a scalar value can carry commas as data,
+				// so they cannot be treated as delimiters.
+				if param.AllowReserved && param.Schema != nil &&
+					!slices.Contains(param.Schema.Schema().Type, helpers.Array) &&
+					!slices.Contains(param.Schema.Schema().Type, helpers.Object) {
+					continue
+				}
+				// the items of an array of objects or arrays are sent as JSON, so commas are part of the item.
+				if isJSONArrayItem(param, qp.Values[i]) {
+					continue
+				}
 				// check for a delimited list.
 				if helpers.DoesFormParamContainDelimiter(qp.Values[i], param.Style) {
 					if param.Explode != nil && *param.Explode {
@@ -243,3 +369,21 @@ stopValidation:
 	}
 	return validationErrors // defaults to true if no style is set.
 }
+
+// isJSONArrayItem will check if a query value is a JSON object or array, sent as an item of an array parameter whose
+// items are defined as objects or arrays.
+func isJSONArrayItem(param *v3.Parameter, value string) bool {
+	if param.Schema == nil {
+		return false
+	}
+	sch := param.Schema.Schema()
+	if sch == nil || !slices.Contains(sch.Type, helpers.Array) || sch.Items == nil || !sch.Items.IsA() {
+		return false
+	}
+	items := sch.Items.A.Schema()
+	if items == nil || (!slices.Contains(items.Type, helpers.Object) && !slices.Contains(items.Type, helpers.Array)) {
+		return false
+	}
+	trimmed := strings.TrimSpace(value)
+	return strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")
+}
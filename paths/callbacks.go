@@ -0,0 +1,48 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package paths
+
+import (
+	"net/http"
+
+	"github.com/pb33f/libopenapi-validator/errors"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// FindCallback will find the callback with the supplied name, declared in the 'callbacks' of an operation in the
+// document, and return the PathItem of the callback expression that has an operation for the method of the request.
+// The runtime expressions of a callback are not evaluated, the callback is matched by name only. If more than one
+// operation declares a callback with the name, the first one in the document that defines the method is used.
+//
+// The third return value is the callback expression of the PathItem, for example '{$request.body#/callbackUrl}'.
+// If the callback does not exist, or does not define the method of the request, the validation errors are returned
+// instead of the PathItem.
+func FindCallback(name string, request *http.Request, document *v3.Document) (*v3.PathItem, []*errors.ValidationError, string) {
+	var found *v3.Callback
+	if document != nil && document.Paths != nil {
+		for _, pathItem := range document.Paths.PathItems.FromOldest() {
+			for _, operation := range pathItem.GetOperations().FromOldest() {
+				if operation.Callbacks == nil {
+					continue
+				}
+				callback := operation.Callbacks.GetOrZero(name)
+				if callback == nil {
+					continue
+				}
+				if found == nil {
+					found = callback
+				}
+				for expression, callbackPathItem := range callback.Expression.FromOldest() {
+					if callbackPathItem != nil && hasOperation(callbackPathItem, request.Method) {
+						return callbackPathItem, nil, expression
+					}
+				}
+			}
+		}
+	}
+	if found == nil {
+		return nil, []*errors.ValidationError{errors.CallbackNotFound(name, request)}, ""
+	}
+	return nil, []*errors.ValidationError{errors.CallbackOperationNotFound(name, found, request)}, ""
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package paths
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi-validator/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+var callbackSpec = `openapi: 3.1.0
+paths:
+  /burgers:
+    post:
+      callbacks:
+        burgerReady:
+          '{$request.body#/readyUrl}':
+            put:
+              operationId: burgerReadyPut
+          '{$request.body#/callbackUrl}':
+            post:
+              operationId: burgerReady
+  /fries:
+    post:
+      callbacks:
+        burgerReady:
+          '{$request.body#/friesUrl}':
+            delete:
+              operationId: friesReady`
+
+func TestFindCallback(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(callbackSpec))
+	m, _ := doc.BuildV3Model()
+
+	// the runtime expression is not evaluated, so any URL matches.
+	request, _ := http.NewRequest(http.MethodPost, "https://consumer.com/anything", nil)
+	pathItem, errs, expression := FindCallback("burgerReady", request, &m.Model)
+	assert.Nil(t, errs)
+	assert.Equal(t, "burgerReady", pathItem.Post.OperationId)
+	assert.Equal(t, "{$request.body#/callbackUrl}", expression)
+
+	// the method is searched for across every operation that declares the callback.
+	request, _ = http.NewRequest(http.MethodDelete, "https://consumer.com/anything", nil)
+	pathItem, errs, expression = FindCallback("burgerReady", request, &m.Model)
+	assert.Nil(t, errs)
+	assert.Equal(t, "friesReady", pathItem.Delete.OperationId)
+	assert.Equal(t, "{$request.body#/friesUrl}", expression)
+}
+
+func TestFindCallback_NotFound(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(callbackSpec))
+	m, _ := doc.BuildV3Model()
+
+	request, _ := http.NewRequest(http.MethodPost, "https://consumer.com/anything", nil)
+	pathItem, errs, expression := FindCallback("burgerEaten", request, &m.Model)
+	assert.Nil(t, pathItem)
+	assert.Empty(t, expression)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, helpers.RequestMissingCallback, errs[0].ValidationSubType)
+	assert.Equal(t, "Callback 'burgerEaten' not found", errs[0].Message)
+}
+
+func TestFindCallback_MethodNotFound(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(callbackSpec))
+	m, _ := doc.BuildV3Model()
+
+	request, _ := http.NewRequest(http.MethodPatch, "https://consumer.com/anything", nil)
+	pathItem, errs, _ := FindCallback("burgerReady", request, &m.Model)
+	assert.Nil(t, pathItem)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, helpers.RequestMissingOperation, errs[0].ValidationSubType)
+	assert.Equal(t, "PATCH operation for callback 'burgerReady' does not exist", errs[0].Message)
+	assert.Equal(t, 7, errs[0].SpecLine)
+}
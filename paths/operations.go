@@ -0,0 +1,111 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package paths
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/pb33f/libopenapi-validator/errors"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// FindOperation will find where an operation is defined in a document, returning the HTTP method of the operation,
+// and the path (or the name of the webhook) that defines it. This is used to describe an operation that is validated
+// without a request. If the operation is not part of the document, false is returned.
+func FindOperation(operation *v3.Operation, document *v3.Document) (string, string, bool) {
+	if operation == nil || document == nil {
+		return "", "", false
+	}
+	if document.Paths != nil && document.Paths.PathItems != nil {
+		for path, pathItem := range document.Paths.PathItems.FromOldest() {
+			if method, ok := findOperationMethod(operation, pathItem); ok {
+				return method, path, true
+			}
+		}
+	}
+	if document.Webhooks != nil {
+		for name, pathItem := range document.Webhooks.FromOldest() {
+			if method, ok := findOperationMethod(operation, pathItem); ok {
+				return method, name, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// LookupOperationByID will find the operation in a document with the supplied operationId, returning the operation,
+// its HTTP method and the path (or the name of the webhook) that defines it. If no operation declares the operationId,
+// false is returned.
+func LookupOperationByID(operationID string, document *v3.Document) (*v3.Operation, string, string, bool) {
+	operation, _, method, path := findOperationByID(operationID, document)
+	return operation, method, path, operation != nil
+}
+
+// FindOperationByID will find the PathItem that defines the operation with the supplied operationId, so a request can
+// be validated against the operation without matching the path of the request to the paths of the document.
+//
+// The third return value is the path (or the name of the webhook) that defines the operation. If no operation
+// declares the operationId, or the method of the request is not the method of the operation, the validation errors
+// are returned instead of the PathItem.
+func FindOperationByID(operationID string, request *http.Request,
+	document *v3.Document) (*v3.PathItem, []*errors.ValidationError, string) {
+	operation, pathItem, method, path := findOperationByID(operationID, document)
+	if operation == nil {
+		return nil, []*errors.ValidationError{errors.OperationIDNotFound(operationID, request)}, ""
+	}
+	if !strings.EqualFold(method, request.Method) {
+		return nil, []*errors.ValidationError{
+			errors.OperationIDMethodMismatch(operationID, operation, method, request, path)}, path
+	}
+	return pathItem, nil, path
+}
+
+// findOperationByID will find the operation with an operationId, along with the PathItem, method and path of it.
+func findOperationByID(operationID string, document *v3.Document) (*v3.Operation, *v3.PathItem, string, string) {
+	if operationID == "" || document == nil {
+		return nil, nil, "", ""
+	}
+	if document.Paths != nil && document.Paths.PathItems != nil {
+		for path, pathItem := range document.Paths.PathItems.FromOldest() {
+			if operation, method, ok := findOperationWithID(operationID, pathItem); ok {
+				return operation, pathItem, method, path
+			}
+		}
+	}
+	if document.Webhooks != nil {
+		for name, pathItem := range document.Webhooks.FromOldest() {
+			if operation, method, ok := findOperationWithID(operationID, pathItem); ok {
+				return operation, pathItem, method, name
+			}
+		}
+	}
+	return nil, nil, "", ""
+}
+
+// findOperationWithID will return the operation of a path item with an operationId, and its HTTP method.
+func findOperationWithID(operationID string, pathItem *v3.PathItem) (*v3.Operation, string, bool) {
+	if pathItem == nil {
+		return nil, "", false
+	}
+	for method, op := range pathItem.GetOperations().FromOldest() {
+		if op != nil && op.OperationId == operationID {
+			return op, strings.ToUpper(method), true
+		}
+	}
+	return nil, "", false
+}
+
+// findOperationMethod will return the HTTP method of an operation, if it belongs to the path item.
+func findOperationMethod(operation *v3.Operation, pathItem *v3.PathItem) (string, bool) {
+	if pathItem == nil {
+		return "", false
+	}
+	for method, op := range pathItem.GetOperations().FromOldest() {
+		if op == operation {
+			return strings.ToUpper(method), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,115 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package paths
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi-validator/helpers"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindOperation(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    get:
+      operationId: listBurgers
+    post:
+      operationId: createBurger
+webhooks:
+  newBurger:
+    put:
+      operationId: burgerCreated`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	method, path, found := FindOperation(m.Model.Paths.PathItems.GetOrZero("/burgers").Post, &m.Model)
+	assert.True(t, found)
+	assert.Equal(t, http.MethodPost, method)
+	assert.Equal(t, "/burgers", path)
+
+	method, path, found = FindOperation(m.Model.Webhooks.GetOrZero("newBurger").Put, &m.Model)
+	assert.True(t, found)
+	assert.Equal(t, http.MethodPut, method)
+	assert.Equal(t, "newBurger", path)
+
+	_, _, found = FindOperation(&v3.Operation{OperationId: "createBurger"}, &m.Model)
+	assert.False(t, found)
+
+	_, _, found = FindOperation(nil, &m.Model)
+	assert.False(t, found)
+
+	_, _, found = FindOperation(m.Model.Paths.PathItems.GetOrZero("/burgers").Get, nil)
+	assert.False(t, found)
+}
+
+func TestLookupOperationByID(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}:
+    get:
+      operationId: getBurger
+webhooks:
+  newBurger:
+    put:
+      operationId: burgerCreated`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	operation, method, path, found := LookupOperationByID("getBurger", &m.Model)
+	assert.True(t, found)
+	assert.Same(t, m.Model.Paths.PathItems.GetOrZero("/burgers/{burgerId}").Get, operation)
+	assert.Equal(t, http.MethodGet, method)
+	assert.Equal(t, "/burgers/{burgerId}", path)
+
+	operation, method, path, found = LookupOperationByID("burgerCreated", &m.Model)
+	assert.True(t, found)
+	assert.Same(t, m.Model.Webhooks.GetOrZero("newBurger").Put, operation)
+	assert.Equal(t, http.MethodPut, method)
+	assert.Equal(t, "newBurger", path)
+
+	operation, _, _, found = LookupOperationByID("deleteBurger", &m.Model)
+	assert.False(t, found)
+	assert.Nil(t, operation)
+
+	_, _, _, found = LookupOperationByID("getBurger", nil)
+	assert.False(t, found)
+}
+
+func TestFindOperationByID(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}:
+    get:
+      operationId: getBurger`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	// the path of the request does not need to match the path of the operation.
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/anything", nil)
+	pathItem, errs, path := FindOperationByID("getBurger", request, &m.Model)
+	assert.Empty(t, errs)
+	assert.Same(t, m.Model.Paths.PathItems.GetOrZero("/burgers/{burgerId}"), pathItem)
+	assert.Equal(t, "/burgers/{burgerId}", path)
+
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/anything", nil)
+	pathItem, errs, path = FindOperationByID("getBurger", request, &m.Model)
+	assert.Nil(t, pathItem)
+	require.Len(t, errs, 1)
+	assert.Equal(t, helpers.RequestMissingOperation, errs[0].ValidationSubType)
+	assert.Equal(t, "/burgers/{burgerId}", path)
+
+	pathItem, errs, _ = FindOperationByID("deleteBurger", request, &m.Model)
+	assert.Nil(t, pathItem)
+	require.Len(t, errs, 1)
+	assert.Equal(t, helpers.RequestMissingOperationID, errs[0].ValidationSubType)
+}
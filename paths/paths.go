@@ -23,15 +23,16 @@ import (
 // parameters will not have been replaced with their values from the request - allowing model lookups.
 func FindPath(request *http.Request, document *v3.Document) (*v3.PathItem, []*errors.ValidationError, string) {
 	basePaths := getBasePaths(document)
-	stripped := StripRequestPath(request, document)
+	stripped := StripRequestEscapedPath(request, document)
 
 	reqPathSegments := strings.Split(stripped, "/")
 	if reqPathSegments[0] == "" {
 		reqPathSegments = reqPathSegments[1:]
 	}
 
-	var pItem *v3.PathItem
-	var foundPath string
+	var pItem, bestItem *v3.PathItem
+	var foundPath, bestPath string
+	var bestSegs []string
 	for pair := orderedmap.First(document.Paths.PathItems); pair != nil; pair = pair.Next() {
 		path := pair.Key()
 		pathItem := pair.Value()
@@ -55,41 +56,19 @@ func FindPath(request *http.Request, document *v3.Document) (*v3.PathItem, []*er
 		}
 		pItem = pathItem
 		foundPath = path
-		switch request.Method {
-		case http.MethodGet:
-			if pathItem.Get != nil {
-				return pathItem, nil, path
-			}
-		case http.MethodPost:
-			if pathItem.Post != nil {
-				return pathItem, nil, path
-			}
-		case http.MethodPut:
-			if pathItem.Put != nil {
-				return pathItem, nil, path
-			}
-		case http.MethodDelete:
-			if pathItem.Delete != nil {
-				return pathItem, nil, path
-			}
-		case http.MethodOptions:
-			if pathItem.Options != nil {
-				return pathItem, nil, path
-			}
-		case http.MethodHead:
-			if pathItem.Head != nil {
-				return pathItem, nil, path
-			}
-		case http.MethodPatch:
-			if pathItem.Patch != nil {
-				return pathItem, nil, path
-			}
-		case http.MethodTrace:
-			if pathItem.Trace != nil {
-				return pathItem, nil, path
-			}
+		if !hasOperation(pathItem, request.Method) {
+			continue
+		}
+
+		// when more than one path matches, a static segment wins over a templated one, so '/users/me' is
+		// chosen over '/users/{id}', regardless of the order the paths are declared in.
+		if bestItem == nil || isMoreSpecific(segs, bestSegs) {
+			bestItem, bestPath, bestSegs = pathItem, path, segs
 		}
 	}
+	if bestItem != nil {
+		return bestItem, nil, bestPath
+	}
 	if pItem != nil {
 		validationErrors := []*errors.ValidationError{{
 			ValidationType:    helpers.ParameterValidationPath,
@@ -121,17 +100,68 @@ func FindPath(request *http.Request, document *v3.Document) (*v3.PathItem, []*er
 	return nil, validationErrors, ""
 }
 
+// hasOperation will return true if the path item has an operation for the HTTP method.
+func hasOperation(pathItem *v3.PathItem, method string) bool {
+	switch method {
+	case http.MethodGet:
+		return pathItem.Get != nil
+	case http.MethodPost:
+		return pathItem.Post != nil
+	case http.MethodPut:
+		return pathItem.Put != nil
+	case http.MethodDelete:
+		return pathItem.Delete != nil
+	case http.MethodOptions:
+		return pathItem.Options != nil
+	case http.MethodHead:
+		return pathItem.Head != nil
+	case http.MethodPatch:
+		return pathItem.Patch != nil
+	case http.MethodTrace:
+		return pathItem.Trace != nil
+	}
+	return false
+}
+
+// isMoreSpecific will return true if the segments of a path are more specific than the segments of another path
+// that matches the same request. Segments are compared from left to right, and the first static segment that is
+// templated in the other path decides which is more specific.
+func isMoreSpecific(segs, otherSegs []string) bool {
+	for i := range segs {
+		if i >= len(otherSegs) {
+			break
+		}
+		templated := strings.Contains(segs[i], "{")
+		otherTemplated := strings.Contains(otherSegs[i], "{")
+		if templated != otherTemplated {
+			return !templated
+		}
+	}
+	return false
+}
+
 func getBasePaths(document *v3.Document) []string {
 	// extract base path from document to check against paths.
 	var basePaths []string
 	for _, s := range document.Servers {
+		serverURL := s.URL
+
+		// replace any server variables with their default values, so templated base paths can be matched.
+		if s.Variables != nil {
+			for name, variable := range s.Variables.FromOldest() {
+				if variable != nil && variable.Default != "" {
+					serverURL = strings.ReplaceAll(serverURL, fmt.Sprintf("{%s}", name), variable.Default)
+				}
+			}
+		}
+
 		var u *url.URL = nil
-		u, err := url.Parse(s.URL)
+		u, err := url.Parse(serverURL)
 
 		// if the host contains special characters, we should attempt to split and parse only the relative path
 		if err != nil {
 			// split at first occurrence
-			_, serverPath, _ := strings.Cut(strings.Replace(s.URL, "//", "", 1), "/")
+			_, serverPath, _ := strings.Cut(strings.Replace(serverURL, "//", "", 1), "/")
 
 			if !strings.HasPrefix(serverPath, "/") {
 				serverPath = "/" + serverPath
@@ -150,11 +180,22 @@ func getBasePaths(document *v3.Document) []string {
 
 // StripRequestPath strips the base path from the request path, based on the server paths provided in the specification
 func StripRequestPath(request *http.Request, document *v3.Document) string {
+	return stripRequestPath(request.URL.Path, request, document)
+}
+
+// StripRequestEscapedPath strips the base path from the escaped request path, based on the server paths provided in
+// the specification. Path parameter values are left percent-encoded, so encoded slashes and delimiters can be told
+// apart from the ones separating segments and array items.
+func StripRequestEscapedPath(request *http.Request, document *v3.Document) string {
+	return stripRequestPath(request.URL.EscapedPath(), request, document)
+}
+
+func stripRequestPath(path string, request *http.Request, document *v3.Document) string {
 
 	basePaths := getBasePaths(document)
 
 	// strip any base path
-	stripped := stripBaseFromPath(request.URL.Path, basePaths)
+	stripped := stripBaseFromPath(path, basePaths)
 	if request.URL.Fragment != "" {
 		stripped = fmt.Sprintf("%s#%s", stripped, request.URL.Fragment)
 	}
@@ -180,28 +221,43 @@ func checkPathAgainstBase(docPath, urlPath string, basePaths []string) bool {
 	return false
 }
 
+// stripBaseFromPath will strip the longest base path that the path starts with. A base path only matches whole
+// segments, so '/api/v2' is stripped from '/api/v2/users', but not from '/api/v20/users'.
 func stripBaseFromPath(path string, basePaths []string) string {
+	stripped := path
+	longest := -1
 	for i := range basePaths {
-		if strings.HasPrefix(path, basePaths[i]) {
-			return path[len(basePaths[i]):]
+		basePath := strings.TrimSuffix(basePaths[i], "/")
+		if len(basePath) <= longest || !strings.HasPrefix(path, basePath) {
+			continue
+		}
+		if len(path) > len(basePath) && path[len(basePath)] != '/' {
+			continue
 		}
+		stripped = path[len(basePath):]
+		longest = len(basePath)
 	}
-	return path
+	return stripped
 }
 
 func comparePaths(mapped, requested, basePaths []string) bool {
 	if len(mapped) != len(requested) {
 		return false // short circuit out
 	}
-	var imploded []string
+	var imploded, decoded []string
 	for i, seg := range mapped {
 		s := seg
+		r := requested[i]
 		if strings.Contains(seg, "{") {
-			s = requested[i]
+			s = r
+		} else if unescaped, err := url.PathUnescape(r); err == nil {
+			// the request path is escaped, so decode the segments that are compared with the specification.
+			r = unescaped
 		}
 		imploded = append(imploded, s)
+		decoded = append(decoded, r)
 	}
 	l := filepath.Join(imploded...)
-	r := filepath.Join(requested...)
+	r := filepath.Join(decoded...)
 	return checkPathAgainstBase(l, r, basePaths)
 }
@@ -295,6 +295,139 @@ paths:
 
 }
 
+func TestNewValidator_FindPathWithAndWithoutBasePath(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+servers:
+  - url: https://things.com/api
+  - url: https://things.com/api/v2
+paths:
+  /users/{userId}:
+    get:
+      operationId: getUser
+  /v2/users/{userId}:
+    get:
+      operationId: getLegacyUser
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	// the longest base path is stripped, even though '/api' is declared first.
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/api/v2/users/1234", nil)
+	pathItem, errs, foundPath := FindPath(request, &m.Model)
+	assert.Len(t, errs, 0)
+	assert.Equal(t, "getUser", pathItem.Get.OperationId)
+	assert.Equal(t, "/users/{userId}", foundPath)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/api/users/1234", nil)
+	pathItem, errs, _ = FindPath(request, &m.Model)
+	assert.Len(t, errs, 0)
+	assert.Equal(t, "getUser", pathItem.Get.OperationId)
+
+	// a request without a base path still matches.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/users/1234", nil)
+	pathItem, errs, _ = FindPath(request, &m.Model)
+	assert.Len(t, errs, 0)
+	assert.Equal(t, "getUser", pathItem.Get.OperationId)
+}
+
+func TestNewValidator_FindPathBasePathMatchesWholeSegments(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+servers:
+  - url: https://things.com/api/v2
+paths:
+  /api/v20/users:
+    get:
+      operationId: getUsers
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	// '/api/v2' is not stripped from '/api/v20'.
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/api/v20/users", nil)
+	pathItem, errs, _ := FindPath(request, &m.Model)
+	assert.Len(t, errs, 0)
+	assert.Equal(t, "getUsers", pathItem.Get.OperationId)
+}
+
+func TestNewValidator_FindPathWithServerVariables(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+servers:
+  - url: https://{region}.things.com/{basePath}
+    variables:
+      region:
+        default: eu
+      basePath:
+        default: api/v2
+paths:
+  /users:
+    get:
+      operationId: getUsers
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	assert.Equal(t, []string{"/api/v2"}, getBasePaths(&m.Model))
+
+	request, _ := http.NewRequest(http.MethodGet, "https://eu.things.com/api/v2/users", nil)
+	pathItem, errs, _ := FindPath(request, &m.Model)
+	assert.Len(t, errs, 0)
+	assert.Equal(t, "getUsers", pathItem.Get.OperationId)
+}
+
+func TestNewValidator_FindPathStaticBeatsTemplated(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /users/{id}:
+    get:
+      operationId: getUser
+    delete:
+      operationId: deleteUser
+  /users/me:
+    get:
+      operationId: getMe
+  /users/{id}/posts/latest:
+    get:
+      operationId: getLatestPost
+  /users/me/posts/{postId}:
+    get:
+      operationId: getMyPost
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	// the static path wins, even though the templated path is declared first.
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/users/me", nil)
+	pathItem, errs, foundPath := FindPath(request, &m.Model)
+	assert.Len(t, errs, 0)
+	assert.Equal(t, "getMe", pathItem.Get.OperationId)
+	assert.Equal(t, "/users/me", foundPath)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/users/1234", nil)
+	pathItem, errs, _ = FindPath(request, &m.Model)
+	assert.Len(t, errs, 0)
+	assert.Equal(t, "getUser", pathItem.Get.OperationId)
+
+	// the static path has no delete operation, so the templated path is used.
+	request, _ = http.NewRequest(http.MethodDelete, "https://things.com/users/me", nil)
+	pathItem, errs, _ = FindPath(request, &m.Model)
+	assert.Len(t, errs, 0)
+	assert.Equal(t, "deleteUser", pathItem.Delete.OperationId)
+
+	// the first segment that differs decides.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/users/me/posts/latest", nil)
+	pathItem, errs, _ = FindPath(request, &m.Model)
+	assert.Len(t, errs, 0)
+	assert.Equal(t, "getMyPost", pathItem.Get.OperationId)
+}
+
 func TestNewValidator_FindPathMissing(t *testing.T) {
 
 	spec := `openapi: 3.1.0
@@ -657,6 +790,42 @@ paths:
 
 }
 
+func TestNewValidator_FindPathWithEncodedSlash(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /files/{name}:
+    get:
+      operationId: getFile
+  /files/{folder}/{name}:
+    get:
+      operationId: getFolderFile
+  /hello world:
+    get:
+      operationId: hello
+`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	// an encoded slash is part of the path parameter value, so it does not add a segment.
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/files/docs%2Freadme.txt", nil)
+	pathItem, errs, _ := FindPath(request, &m.Model)
+	assert.Len(t, errs, 0)
+	assert.Equal(t, "getFile", pathItem.Get.OperationId)
+
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/files/docs/readme.txt", nil)
+	pathItem, errs, _ = FindPath(request, &m.Model)
+	assert.Len(t, errs, 0)
+	assert.Equal(t, "getFolderFile", pathItem.Get.OperationId)
+
+	// literal segments are compared after they have been decoded.
+	request, _ = http.NewRequest(http.MethodGet, "https://things.com/hello%20world", nil)
+	pathItem, errs, _ = FindPath(request, &m.Model)
+	assert.Len(t, errs, 0)
+	assert.Equal(t, "hello", pathItem.Get.OperationId)
+}
+
 func TestNewValidator_FindPathMissingWithBaseURLInServer(t *testing.T) {
 
 	spec := `openapi: 3.1.0
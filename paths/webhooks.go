@@ -0,0 +1,29 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package paths
+
+import (
+	"net/http"
+
+	"github.com/pb33f/libopenapi-validator/errors"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// FindWebhook will find the webhook in the document with the supplied name, that has an operation for the method of
+// the request. Webhooks are an OpenAPI 3.1 feature, they describe requests that are sent to the API consumer,
+// so they are found by name rather than by the path of the request. If the webhook does not exist, or does not
+// define the method of the request, the validation errors are returned instead of the PathItem.
+func FindWebhook(name string, request *http.Request, document *v3.Document) (*v3.PathItem, []*errors.ValidationError) {
+	var pathItem *v3.PathItem
+	if document != nil && document.Webhooks != nil {
+		pathItem = document.Webhooks.GetOrZero(name)
+	}
+	if pathItem == nil {
+		return nil, []*errors.ValidationError{errors.WebhookNotFound(name, request)}
+	}
+	if !hasOperation(pathItem, request.Method) {
+		return nil, []*errors.ValidationError{errors.WebhookOperationNotFound(name, pathItem, request)}
+	}
+	return pathItem, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package paths
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi-validator/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindWebhook(t *testing.T) {
+	spec := `openapi: 3.1.0
+webhooks:
+  newBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	request, _ := http.NewRequest(http.MethodPost, "https://consumer.com/hooks", nil)
+	pathItem, errs := FindWebhook("newBurger", request, &m.Model)
+	assert.NotNil(t, pathItem)
+	assert.Nil(t, errs)
+	assert.Same(t, m.Model.Webhooks.GetOrZero("newBurger"), pathItem)
+}
+
+func TestFindWebhook_NotFound(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    post:
+      operationId: createBurger`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	request, _ := http.NewRequest(http.MethodPost, "https://consumer.com/hooks", nil)
+	pathItem, errs := FindWebhook("newBurger", request, &m.Model)
+	assert.Nil(t, pathItem)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, helpers.RequestMissingWebhook, errs[0].ValidationSubType)
+	assert.Equal(t, "Webhook 'newBurger' not found", errs[0].Message)
+	assert.Equal(t, "newBurger", errs[0].SpecPath)
+}
+
+func TestFindWebhook_MethodNotFound(t *testing.T) {
+	spec := `openapi: 3.1.0
+webhooks:
+  newBurger:
+    post:
+      operationId: newBurger`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	request, _ := http.NewRequest(http.MethodPut, "https://consumer.com/hooks", nil)
+	pathItem, errs := FindWebhook("newBurger", request, &m.Model)
+	assert.Nil(t, pathItem)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, helpers.RequestMissingOperation, errs[0].ValidationSubType)
+	assert.Equal(t, "PUT operation for webhook 'newBurger' does not exist", errs[0].Message)
+	assert.Equal(t, 4, errs[0].SpecLine)
+}
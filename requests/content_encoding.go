@@ -0,0 +1,119 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package requests
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	stdError "errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/pb33f/libopenapi-validator/config"
+	"github.com/pb33f/libopenapi-validator/helpers"
+)
+
+// errBodyTooLarge is returned when a compressed request body, or the decompressed body, is larger than the maximum
+// decoded body size of the options.
+var errBodyTooLarge = stdError.New("the request body is larger than the maximum decoded body size")
+
+// decodeContentEncoding will replace the body of a request that has been compressed with gzip or deflate, as declared
+// by its 'Content-Encoding' header, with the decompressed body so it can be validated. Bodies with any other encoding
+// are left as they are. The returned function is called once the body has been validated, it puts the compressed
+// body back, unless the options keep the decompressed body. Neither the compressed nor the decompressed body is read
+// beyond the maximum decoded body size, errBodyTooLarge is returned if either is larger.
+func decodeContentEncoding(request *http.Request, options *config.ValidationOptions) (func(), error) {
+	restore := func() {}
+	header := request.Header.Get(helpers.ContentEncodingHeader)
+	if header == "" || request.Body == nil || (options != nil && options.DisableContentDecoding) {
+		return restore, nil
+	}
+
+	// encodings are listed in the order they were applied, 'identity' means no encoding at all.
+	var encodings []string
+	for _, encoding := range strings.Split(header, helpers.Comma) {
+		encoding = strings.ToLower(strings.TrimSpace(encoding))
+		switch encoding {
+		case "", "identity":
+		case "gzip", "x-gzip", "deflate":
+			encodings = append(encodings, encoding)
+		default:
+			return restore, nil
+		}
+	}
+	if len(encodings) == 0 {
+		return restore, nil
+	}
+
+	limit := maxDecodedBodySize(options)
+	raw, err := io.ReadAll(io.LimitReader(request.Body, limit+1))
+	if int64(len(raw)) > limit {
+		// the rest of the body is left unread, so it's put back behind what has been read.
+		request.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(raw), request.Body), request.Body}
+		return restore, errBodyTooLarge
+	}
+	request.Body = io.NopCloser(bytes.NewReader(raw))
+	if err != nil || len(raw) == 0 {
+		return restore, nil
+	}
+	decoded := raw
+	for i := len(encodings) - 1; i >= 0; i-- {
+		if decoded, err = decompress(encodings[i], decoded, limit); err != nil {
+			return restore, err
+		}
+	}
+
+	request.Body = io.NopCloser(bytes.NewReader(decoded))
+	if options != nil && options.KeepDecodedBody {
+		request.Header.Del(helpers.ContentEncodingHeader)
+		request.ContentLength = int64(len(decoded))
+		return restore, nil
+	}
+	return func() {
+		request.Body = io.NopCloser(bytes.NewReader(raw))
+	}, nil
+}
+
+// maxDecodedBodySize returns the largest size a compressed request body is decompressed to.
+func maxDecodedBodySize(options *config.ValidationOptions) int64 {
+	if options != nil && options.MaxDecodedBodySize > 0 {
+		return options.MaxDecodedBodySize
+	}
+	return config.DefaultMaxDecodedBodySize
+}
+
+// decompress will decompress a body that has been compressed with gzip or deflate, errBodyTooLarge is returned if
+// the decompressed body is larger than the limit.
+func decompress(encoding string, body []byte, limit int64) ([]byte, error) {
+	var reader io.ReadCloser
+	var err error
+	switch encoding {
+	case "deflate":
+		// deflate bodies should be wrapped in the zlib format, however some clients send raw deflate data.
+		reader, err = zlib.NewReader(bytes.NewReader(body))
+		if err == zlib.ErrHeader {
+			reader, err = flate.NewReader(bytes.NewReader(body)), nil
+		}
+	default:
+		reader, err = gzip.NewReader(bytes.NewReader(body))
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	decoded, err := io.ReadAll(io.LimitReader(reader, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(decoded)) > limit {
+		return nil, errBodyTooLarge
+	}
+	return decoded, nil
+}
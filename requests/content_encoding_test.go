@@ -0,0 +1,201 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package requests
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi-validator/config"
+	"github.com/pb33f/libopenapi-validator/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var contentEncodingSpec = `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+                patties:
+                  type: integer`
+
+func gzipBody(t *testing.T, body string) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(body))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func newEncodedRequest(body []byte, encoding string) *http.Request {
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewReader(body))
+	request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+	request.Header.Set(helpers.ContentEncodingHeader, encoding)
+	return request
+}
+
+func TestValidateBody_GzipContentEncoding(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(contentEncodingSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	compressed := gzipBody(t, `{"name": "Big Mac", "patties": 2}`)
+	request := newEncodedRequest(compressed, "gzip")
+	valid, errs := v.ValidateRequestBody(request)
+	assert.True(t, valid)
+	assert.Empty(t, errs)
+
+	// the compressed body is put back, as it was sent.
+	read, _ := io.ReadAll(request.Body)
+	assert.Equal(t, compressed, read)
+	assert.Equal(t, "gzip", request.Header.Get(helpers.ContentEncodingHeader))
+
+	// the decompressed body is validated against the schema.
+	valid, errs = v.ValidateRequestBody(newEncodedRequest(gzipBody(t, `{"patties": "two"}`), "gzip"))
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Len(t, errs[0].SchemaValidationErrors, 2)
+}
+
+func TestValidateBody_DeflateContentEncoding(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(contentEncodingSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	_, _ = w.Write([]byte(`{"name": "Big Mac"}`))
+	_ = w.Close()
+
+	valid, errs := v.ValidateRequestBody(newEncodedRequest(buf.Bytes(), "deflate"))
+	assert.True(t, valid)
+	assert.Empty(t, errs)
+}
+
+func TestValidateBody_ContentEncoding_KeepDecodedBody(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(contentEncodingSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model, config.WithDecodedRequestBody())
+
+	body := `{"name": "Big Mac", "patties": 2}`
+	request := newEncodedRequest(gzipBody(t, body), "gzip")
+	valid, errs := v.ValidateRequestBody(request)
+	assert.True(t, valid)
+	assert.Empty(t, errs)
+
+	// the handler receives the decompressed body, which is no longer encoded.
+	read, _ := io.ReadAll(request.Body)
+	assert.Equal(t, body, string(read))
+	assert.Empty(t, request.Header.Get(helpers.ContentEncodingHeader))
+	assert.Equal(t, int64(len(body)), request.ContentLength)
+}
+
+func TestValidateBody_ContentEncoding_Invalid(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(contentEncodingSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request := newEncodedRequest([]byte(`{"name": "Big Mac"}`), "gzip")
+	valid, errs := v.ValidateRequestBody(request)
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Equal(t, helpers.RequestBodyValidation, errs[0].ValidationType)
+	assert.Equal(t, helpers.RequestBodyContentEncoding, errs[0].ValidationSubType)
+	assert.Equal(t, "POST request body for '/burgers/createBurger' cannot be decoded with content encoding 'gzip'",
+		errs[0].Message)
+	assert.Equal(t, "/burgers/createBurger", errs[0].SpecPath)
+
+	// the body is put back as it was sent.
+	read, _ := io.ReadAll(request.Body)
+	assert.Equal(t, `{"name": "Big Mac"}`, string(read))
+}
+
+func TestValidateBody_ContentEncoding_Disabled(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(contentEncodingSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model, config.WithoutContentDecoding())
+
+	// the compressed body is validated as it was sent, so it's not valid JSON.
+	valid, errs := v.ValidateRequestBody(newEncodedRequest(gzipBody(t, `{"name": "Big Mac"}`), "gzip"))
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+
+	// unknown encodings are not decoded.
+	v = NewRequestBodyValidator(&m.Model)
+	valid, _ = v.ValidateRequestBody(newEncodedRequest([]byte(`{"name": "Big Mac"}`), "br"))
+	assert.True(t, valid)
+}
+
+func TestValidateBody_ContentEncoding_TooLarge(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(contentEncodingSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model, config.WithMaxDecodedBodySize(64))
+
+	// the body is small once compressed, but it decompresses to more than the limit.
+	body := `{"name": "` + strings.Repeat("a", 100) + `"}`
+	compressed := gzipBody(t, body)
+	require.Less(t, len(compressed), 64)
+
+	request := newEncodedRequest(compressed, "gzip")
+	valid, errs := v.ValidateRequestBody(request)
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Equal(t, helpers.RequestBodyContentEncoding, errs[0].ValidationSubType)
+	assert.Equal(t, "POST request body for '/burgers/createBurger' is too large to decode with content encoding 'gzip'",
+		errs[0].Message)
+
+	read, _ := io.ReadAll(request.Body)
+	assert.Equal(t, compressed, read)
+
+	// a compressed body that is larger than the limit is not read any further, but is still put back.
+	compressed = gzipBody(t, strings.Repeat(`{"name": "Big Mac"}`, 10))
+	compressed = append(compressed, bytes.Repeat([]byte{0}, 64)...)
+	request = newEncodedRequest(compressed, "gzip")
+	valid, errs = v.ValidateRequestBody(request)
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Reason, "larger than 64 bytes once decompressed")
+
+	read, _ = io.ReadAll(request.Body)
+	assert.Equal(t, compressed, read)
+}
+
+func TestValidateBody_ContentEncoding_DefaultMaxDecodedBodySize(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(contentEncodingSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	// a body of zeros that decompresses to one byte more than the default limit.
+	var buf bytes.Buffer
+	w, _ := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	chunk := make([]byte, 1<<20)
+	for written := int64(0); written <= config.DefaultMaxDecodedBodySize; written += int64(len(chunk)) {
+		_, _ = w.Write(chunk)
+	}
+	_ = w.Close()
+
+	valid, errs := v.ValidateRequestBody(newEncodedRequest(buf.Bytes(), "gzip"))
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Equal(t, fmt.Sprintf("Send a request body that is no larger than %d bytes once it has been decompressed",
+		config.DefaultMaxDecodedBodySize), errs[0].HowToFix)
+}
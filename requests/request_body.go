@@ -4,9 +4,11 @@
 package requests
 
 import (
+	"github.com/pb33f/libopenapi-validator/config"
 	"github.com/pb33f/libopenapi-validator/errors"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	"github.com/pb33f/libopenapi/datamodel/high/v3"
+	"io"
 	"net/http"
 	"sync"
 )
@@ -25,11 +27,22 @@ type RequestBodyValidator interface {
 	// request body is valid, false if it is not. The second return value will be a slice of ValidationError pointers if
 	// the body is not valid.
 	ValidateRequestBodyWithPathItem(request *http.Request, pathItem *v3.PathItem, pathValue string) (bool, []*errors.ValidationError)
+
+	// ValidateRequestBodyReader will validate a body read from any io.Reader against the request body of an operation,
+	// using the supplied content type, so a body can be validated without an *http.Request (for example, a message
+	// read from a queue). The first return value will be true if the body is valid, false if it is not. The second
+	// return value will be a slice of ValidationError pointers if the body is not valid.
+	ValidateRequestBodyReader(operation *v3.Operation, contentType string, reader io.Reader) (bool, []*errors.ValidationError)
 }
 
-// NewRequestBodyValidator will create a new RequestBodyValidator from an OpenAPI 3+ document
-func NewRequestBodyValidator(document *v3.Document) RequestBodyValidator {
-	return &requestBodyValidator{document: document, schemaCache: &sync.Map{}}
+// NewRequestBodyValidator will create a new RequestBodyValidator from an OpenAPI 3+ document. Options can be
+// supplied to change the behavior of the validator, by default no options are enabled.
+func NewRequestBodyValidator(document *v3.Document, opts ...config.Option) RequestBodyValidator {
+	return &requestBodyValidator{
+		document:    document,
+		options:     config.NewValidationOptions(opts...),
+		schemaCache: &sync.Map{},
+	}
 }
 
 
@@ -41,6 +54,6 @@ type schemaCache struct {
 
 type requestBodyValidator struct {
 	document    *v3.Document
-	errors      []*errors.ValidationError
+	options     *config.ValidationOptions
 	schemaCache *sync.Map
 }
@@ -4,10 +4,15 @@
 package requests
 
 import (
+	"bytes"
+	stdError "errors"
+	"io"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"fmt"
+	"github.com/pb33f/libopenapi-validator/config"
 	"github.com/pb33f/libopenapi-validator/errors"
 	"github.com/pb33f/libopenapi-validator/helpers"
 	"github.com/pb33f/libopenapi-validator/paths"
@@ -42,7 +47,34 @@ func (v *requestBodyValidator) ValidateRequestBodyWithPathItem(request *http.Req
 	if operation == nil {
 		return false, []*errors.ValidationError{errors.OperationNotFound(pathItem, request, request.Method, pathValue)}
 	}
-	if operation.RequestBody == nil {
+	return v.validateOperationRequestBody(request, operation, pathValue)
+}
+
+func (v *requestBodyValidator) ValidateRequestBodyReader(operation *v3.Operation, contentType string,
+	reader io.Reader) (bool, []*errors.ValidationError) {
+
+	// the body is validated as a request, using the method and path that define the operation in the document, so
+	// any errors describe the operation. There is no method or path if the operation is not part of the document.
+	method, pathValue, _ := paths.FindOperation(operation, v.document)
+	request := &http.Request{
+		Method: method,
+		URL:    &url.URL{Path: pathValue},
+		Header: http.Header{},
+		Body:   http.NoBody,
+	}
+	if reader != nil {
+		request.Body = io.NopCloser(reader)
+	}
+	if contentType != "" {
+		request.Header.Set(helpers.ContentTypeHeader, contentType)
+	}
+	return v.validateOperationRequestBody(request, operation, pathValue)
+}
+
+// validateOperationRequestBody will validate the body of a request against the request body of an operation.
+func (v *requestBodyValidator) validateOperationRequestBody(request *http.Request, operation *v3.Operation,
+	pathValue string) (bool, []*errors.ValidationError) {
+	if operation == nil || operation.RequestBody == nil {
 		return true, nil
 	}
 
@@ -52,6 +84,10 @@ func (v *requestBodyValidator) ValidateRequestBodyWithPathItem(request *http.Req
 	if operation.RequestBody.Required != nil {
 		required = *operation.RequestBody.Required
 	}
+	// a required body must be sent, an empty JSON value such as '{}' is still a body, so it's left to the schema.
+	if required && isRequestBodyAbsent(request) {
+		return false, []*errors.ValidationError{errors.RequestBodyMissing(operation, request, pathValue)}
+	}
 	if contentType == "" {
 		if !required {
 			// request body is not required, the validation stop there.
@@ -60,16 +96,24 @@ func (v *requestBodyValidator) ValidateRequestBodyWithPathItem(request *http.Req
 		return false, []*errors.ValidationError{errors.RequestContentTypeNotFound(operation, request, pathValue)}
 	}
 
-	// extract the media type from the content type header.
+	// extract the media type from the content type header, and find the media type that matches it, the
+	// parameters of the content type are used to prefer media types that declare the same parameters.
 	ct, _, _ := helpers.ExtractContentType(contentType)
-	mediaType, ok := operation.RequestBody.Content.Get(ct)
+	mediaRange, mediaType, ok := helpers.FindMediaTypeKey(operation.RequestBody.Content, contentType)
 	if !ok {
 		return false, []*errors.ValidationError{errors.RequestContentTypeNotFound(operation, request, pathValue)}
 	}
 
-	// we currently only support JSON validation for request bodies
-	// this will capture *everything* that contains some form of 'json' in the content type
-	if !strings.Contains(strings.ToLower(contentType), helpers.JSONType) {
+	// we currently only support JSON, XML, YAML, text, form and multipart validation for request bodies
+	// structured suffixes are routed to the decoder for their syntax, so 'application/vnd.api+json' is JSON,
+	// and any other 'text/' content type is validated as a string.
+	isForm := strings.EqualFold(ct, helpers.FormURLEncodedContentType)
+	isMultipart := strings.EqualFold(ct, helpers.MultipartFormDataContentType)
+	isJSON := helpers.IsJSONContentType(ct)
+	isXML := helpers.IsXMLContentType(ct)
+	isYAML := helpers.IsYAMLContentType(ct)
+	isText := strings.HasPrefix(strings.ToLower(ct), helpers.TextType)
+	if !isForm && !isMultipart && !isJSON && !isXML && !isYAML && !isText {
 		return true, nil
 	}
 
@@ -97,7 +141,7 @@ func (v *requestBodyValidator) ValidateRequestBodyWithPathItem(request *http.Req
 		// render the schema inline and perform the intensive work of rendering and converting
 		// this is only performed once per schema and cached in the validator.
 		schema = mediaType.Schema.Schema()
-		renderedInline, _ = schema.RenderInline()
+		renderedInline, _ = helpers.RenderSchemaInline(schema)
 		renderedJSON, _ = utils.ConvertYAMLtoJSON(renderedInline)
 		v.schemaCache.Store(hash, &schemaCache{
 			schema:         schema,
@@ -106,10 +150,65 @@ func (v *requestBodyValidator) ValidateRequestBodyWithPathItem(request *http.Req
 		})
 	}
 
+	// a body compressed with gzip or deflate is decompressed before it's validated.
+	restore, err := decodeContentEncoding(request, v.options)
+	if stdError.Is(err, errBodyTooLarge) {
+		return false, []*errors.ValidationError{errors.RequestBodyDecodedTooLarge(request,
+			maxDecodedBodySize(v.options), pathValue)}
+	}
+	if err != nil {
+		return false, []*errors.ValidationError{errors.RequestBodyEncodingInvalid(request, err, pathValue)}
+	}
+	defer restore()
+
 	// render the schema, to be used for validation
-	validationSucceeded, validationErrors := ValidateRequestSchema(request, schema, renderedInline, renderedJSON)
+	var validationSucceeded bool
+	var validationErrors []*errors.ValidationError
+	switch {
+	case isForm:
+		validationSucceeded, validationErrors = ValidateRequestFormSchema(request, schema, mediaType.Encoding,
+			renderedInline, renderedJSON, config.WithExistingOpts(v.options))
+	case isMultipart:
+		validationSucceeded, validationErrors = ValidateRequestMultipartSchema(request, schema, mediaType.Encoding,
+			renderedInline, renderedJSON, config.WithExistingOpts(v.options))
+	case isXML:
+		validationSucceeded, validationErrors = ValidateRequestXMLSchema(request, schema, renderedInline, renderedJSON,
+			config.WithExistingOpts(v.options))
+	case isYAML:
+		validationSucceeded, validationErrors = ValidateRequestYAMLSchema(request, schema, renderedInline, renderedJSON,
+			config.WithExistingOpts(v.options))
+	case isText && !isJSON:
+		// a text body can only be validated against a schema for a single value, such as a string.
+		if !helpers.IsScalarSchema(schema) {
+			return true, nil
+		}
+		validationSucceeded, validationErrors = ValidateRequestTextSchema(request, schema, renderedInline, renderedJSON,
+			config.WithExistingOpts(v.options))
+	case v.options.StreamRequestBody:
+		validationSucceeded, validationErrors = ValidateRequestSchemaStream(request, schema, renderedInline,
+			renderedJSON, config.WithExistingOpts(v.options))
+	default:
+		validationSucceeded, validationErrors = ValidateRequestSchema(request, schema, renderedInline, renderedJSON,
+			config.WithExistingOpts(v.options))
+	}
 
 	errors.PopulateValidationErrors(validationErrors, request, pathValue)
+	errors.PopulateMediaType(validationErrors, mediaRange)
 
 	return validationSucceeded, validationErrors
 }
+
+// isRequestBodyAbsent returns true if the request has no body, or the body has no content. Only the first byte of the
+// body is read to check, it's then put back in front of the rest of the body, so the body can still be streamed.
+func isRequestBodyAbsent(request *http.Request) bool {
+	if request.Body == nil || request.Body == http.NoBody {
+		return true
+	}
+	var first [1]byte
+	n, _ := io.ReadFull(request.Body, first[:])
+	request.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(first[:n]), request.Body), request.Body}
+	return n == 0
+}
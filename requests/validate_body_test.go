@@ -7,12 +7,18 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi-validator/config"
+	"github.com/pb33f/libopenapi-validator/errors"
+	"github.com/pb33f/libopenapi-validator/helpers"
 	"github.com/pb33f/libopenapi-validator/paths"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestValidateBody_NotRequiredBody(t *testing.T) {
@@ -103,7 +109,7 @@ paths:
     post:
       requestBody:
         content:
-          application/yaml:
+          application/octet-stream:
             schema:
               type: object
               properties:
@@ -129,7 +135,7 @@ paths:
 
 	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
 		bytes.NewBuffer(bodyBytes))
-	request.Header.Set("Content-Type", "application/yaml")
+	request.Header.Set("Content-Type", "application/octet-stream")
 
 	valid, errors := v.ValidateRequestBody(request)
 
@@ -854,9 +860,14 @@ components:
 
 	assert.False(t, valid)
 	assert.Len(t, errors, 1)
-	assert.Len(t, errors[0].SchemaValidationErrors, 2)
-	assert.Equal(t, "missing properties 'uncookedWeight', 'uncookedHeight'", errors[0].SchemaValidationErrors[0].Reason)
-	assert.Equal(t, "missing properties 'usedOil', 'usedAnimalFat'", errors[0].SchemaValidationErrors[1].Reason)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+
+	oneOf := errors[0].SchemaValidationErrors[0]
+	assert.Equal(t, "/oneOf", oneOf.Location)
+	assert.Equal(t, 0, *oneOf.MatchedBranches)
+	assert.Len(t, oneOf.Branches, 2)
+	assert.Equal(t, "missing properties 'uncookedWeight', 'uncookedHeight'", oneOf.Branches[0].Reason)
+	assert.Equal(t, "missing properties 'usedOil', 'usedAnimalFat'", oneOf.Branches[1].Reason)
 
 }
 
@@ -1322,6 +1333,2023 @@ func TestValidateBody_SchemaNoType_Issue75(t *testing.T) {
 
 	assert.False(t, isSuccess)
 	assert.Len(t, valErrs, 1)
-	assert.Equal(t, "PUT request body is empty for '/path1'", valErrs[0].Message)
+	assert.Equal(t, "PUT request body is missing for '/path1'", valErrs[0].Message)
+
+}
+
+func TestValidateBody_RequiredBodyMissing(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger", nil)
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, helpers.RequestMissingBody, errors[0].ValidationSubType)
+	assert.Equal(t, "POST request body is missing for '/burgers/createBurger'", errors[0].Message)
+	assert.Equal(t, "The operation requires a request body, send a body with one of the 1 "+
+		"supported types: application/json", errors[0].HowToFix)
+	assert.Equal(t, 6, errors[0].SpecLine)
+	assert.Equal(t, "/burgers/createBurger", errors[0].SpecPath)
+
+	// a body that is read as empty is missing too, even without a content type.
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		io.NopCloser(strings.NewReader("")))
+
+	valid, errors = v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, helpers.RequestMissingBody, errors[0].ValidationSubType)
+}
+
+func TestValidateBody_RequiredBodyEmptyObject(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	// the body is read from an unsized reader, so it has to be peeked to tell if it's there.
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		io.NopCloser(strings.NewReader("{}")))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	// the body can still be read after validation.
+	body, _ := io.ReadAll(request.Body)
+	assert.Equal(t, "{}", string(body))
+}
+
+func TestValidateBody_ContentTypeMatching(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                patties:
+                  type: integer
+          text/*:
+            schema:
+              type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	// exact match, validated against the schema.
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewBufferString(`{"patties": "two"}`))
+	request.Header.Set("content-type", "application/json; charset=utf-8")
+
+	valid, errors := v.ValidateRequestBody(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "POST request body for '/burgers/createBurger' failed to validate schema", errors[0].Message)
+
+	// wildcard match.
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewBufferString("two patties please"))
+	request.Header.Set("content-type", "text/plain")
+
+	valid, errors = v.ValidateRequestBody(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	// unsupported type.
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewBufferString("<burger/>"))
+	request.Header.Set("content-type", "application/xml")
+
+	valid, errors = v.ValidateRequestBody(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "POST operation request content type 'application/xml' does not exist", errors[0].Message)
+	assert.Equal(t, "The content type is invalid, Use one of the 2 supported types for this operation: "+
+		"application/json, text/*", errors[0].HowToFix)
+}
+
+var burgerListSpec = `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: array
+              items:
+                type: object
+                required: [name]
+                properties:
+                  name:
+                    type: string
+                  patties:
+                    type: integer`
+
+func benchmarkValidateBody(b *testing.B, opts ...config.Option) {
+	doc, _ := libopenapi.NewDocument([]byte(burgerListSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model, opts...)
+
+	burgers := make([]map[string]interface{}, 10000)
+	for i := range burgers {
+		burgers[i] = map[string]interface{}{"name": fmt.Sprintf("burger %d", i), "patties": i % 4}
+	}
+	body, _ := json.Marshal(burgers)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+			bytes.NewReader(body))
+		request.Header.Set("Content-Type", "application/json")
+		v.ValidateRequestBody(request)
+	}
+}
+
+func BenchmarkValidateBody(b *testing.B) {
+	benchmarkValidateBody(b)
+}
+
+func BenchmarkValidateBody_Streaming(b *testing.B) {
+	benchmarkValidateBody(b, config.WithStreamingRequestBody(0))
+}
+
+// the body is larger than the limit, so no more than the limit is held in memory, and the body is not validated.
+func BenchmarkValidateBody_Streaming_TooLarge(b *testing.B) {
+	benchmarkValidateBody(b, config.WithStreamingRequestBody(1<<10))
+}
 
+func benchmarkValidateSmallBody(b *testing.B, opts ...config.Option) {
+	doc, _ := libopenapi.NewDocument([]byte(burgerListSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model, opts...)
+	body := []byte(`[{"name": "big mac", "patties": 2}]`)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+			bytes.NewReader(body))
+		request.Header.Set("Content-Type", "application/json")
+		v.ValidateRequestBody(request)
+	}
+}
+
+func BenchmarkValidateBody_SmallBody(b *testing.B) {
+	benchmarkValidateSmallBody(b)
+}
+
+func BenchmarkValidateBody_SmallBody_NoSchemaCache(b *testing.B) {
+	benchmarkValidateSmallBody(b, config.WithSchemaCache(nil))
+}
+
+func TestValidateBody_Streaming(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(burgerListSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model, config.WithStreamingRequestBody(0))
+
+	body := `[{"name": "big mac", "patties": 2}, {"name": "whopper", "patties": 1}]`
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	// the body is intact, so it can be read by the handler of the request.
+	read, err := io.ReadAll(request.Body)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(read))
+}
+
+func TestValidateBody_Streaming_Invalid(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(burgerListSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model, config.WithStreamingRequestBody(0))
+
+	body := `[{"patties": 2}]`
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	require.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "missing property 'name'", errors[0].SchemaValidationErrors[0].Reason)
+
+	read, err := io.ReadAll(request.Body)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(read))
+}
+
+func TestValidateBody_Streaming_CannotDecode(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(burgerListSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model, config.WithStreamingRequestBody(0))
+
+	// there can only be a single JSON value in the body.
+	for _, body := range []string{`[{"name": "big mac"}] []`, `[{"name": "big mac"}] x`, `[{"name": `} {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+			strings.NewReader(body))
+		request.Header.Set("Content-Type", "application/json")
+
+		valid, errors := v.ValidateRequestBody(request)
+		assert.False(t, valid, body)
+		require.Len(t, errors, 1, body)
+		assert.True(t, strings.HasPrefix(errors[0].Reason, "The request body cannot be decoded"), body)
+
+		read, err := io.ReadAll(request.Body)
+		require.NoError(t, err)
+		assert.Equal(t, body, string(read))
+	}
+}
+
+func TestValidateBody_Streaming_Empty(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(burgerListSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model, config.WithStreamingRequestBody(0))
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		strings.NewReader(""))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "POST request body is empty for '/burgers/createBurger'", errors[0].Message)
+}
+
+func TestValidateBody_Streaming_TooLarge(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(burgerListSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model, config.WithStreamingRequestBody(64))
+
+	burgers := make([]map[string]interface{}, 100)
+	for i := range burgers {
+		burgers[i] = map[string]interface{}{"name": fmt.Sprintf("burger %d", i), "patties": i % 4}
+	}
+	body, _ := json.Marshal(burgers)
+	reader := bytes.NewReader(body)
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger", reader)
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, helpers.RequestBodyTooLarge, errors[0].ValidationSubType)
+	assert.Equal(t, "POST request body for '/burgers/createBurger' is too large to validate", errors[0].Message)
+	assert.Equal(t, "/burgers/createBurger", errors[0].SpecPath)
+
+	// no more than the limit (and the byte that shows the body is larger) has been read from the body.
+	assert.Equal(t, int64(len(body)-65), int64(reader.Len()))
+
+	// the body is intact, what was read is put back in front of the rest of the body.
+	read, err := io.ReadAll(request.Body)
+	require.NoError(t, err)
+	assert.Equal(t, body, read)
+}
+
+var discriminatorSpec = `openapi: 3.1.0
+paths:
+  /pets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Cat'
+        - $ref: '#/components/schemas/Dog'
+      discriminator:
+        propertyName: petType
+        mapping:
+          kitty: '#/components/schemas/Cat'
+    Cat:
+      type: object
+      required: [petType, lives]
+      properties:
+        petType:
+          type: string
+        lives:
+          type: integer
+          maximum: 9
+    Dog:
+      type: object
+      required: [petType, barks]
+      properties:
+        petType:
+          type: string
+        barks:
+          type: boolean`
+
+func TestValidateBody_Discriminator(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(discriminatorSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	for name, body := range map[string]string{
+		"Mapped":   `{"petType":"kitty","lives":9}`,
+		"Implicit": `{"petType":"Dog","barks":true}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			request, _ := http.NewRequest(http.MethodPost, "https://things.com/pets", bytes.NewBufferString(body))
+			request.Header.Set("Content-Type", "application/json")
+
+			valid, errors := v.ValidateRequestBody(request)
+
+			assert.True(t, valid)
+			assert.Len(t, errors, 0)
+		})
+	}
+}
+
+func TestValidateBody_Discriminator_UnknownValue(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(discriminatorSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/pets",
+		bytes.NewBufferString(`{"petType":"Fish","fins":2}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "POST request body discriminator value 'Fish' does not map to a schema", errors[0].Message)
+	assert.Equal(t, "Use one of the values that map to a schema for the discriminator: kitty, Cat, Dog",
+		errors[0].HowToFix)
+	assert.Equal(t, 16, errors[0].SpecLine)
+}
+
+func TestValidateBody_Discriminator_VariantInvalid(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(discriminatorSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/pets",
+		bytes.NewBufferString(`{"petType":"kitty","lives":10}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "maximum: got 10, want 9", errors[0].SchemaValidationErrors[0].Reason)
+	assert.Equal(t, "/properties/lives/maximum", errors[0].SchemaValidationErrors[0].Location)
+}
+
+func TestValidateBody_OneOfMatchingTwoBranches(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              oneOf:
+                - type: object
+                  required: [name]
+                - type: object
+                  required: [patties]`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewBufferString(`{"name":"Big Mac","patties":2}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "oneOf failed, 2 branches matched (exactly one is expected)",
+		errors[0].SchemaValidationErrors[0].Reason)
+	assert.Equal(t, 2, *errors[0].SchemaValidationErrors[0].MatchedBranches)
+}
+
+func TestValidateBody_SchemaFailureDetails(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                burger:
+                  type: object
+                  properties:
+                    toppings:
+                      type: array
+                      items:
+                        type: object
+                        properties:
+                          grams:
+                            type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewBufferString(`{"burger":{"toppings":[{"grams":10},{"grams":"lots"}]}}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+
+	failure := errors[0].SchemaValidationErrors[0]
+	assert.Equal(t, "/burger/toppings/1/grams", failure.InstancePath)
+	assert.Equal(t, "/properties/burger/properties/toppings/items/properties/grams/type", failure.DeepLocation)
+	assert.Equal(t, "type", failure.Keyword)
+	assert.Equal(t, []string{"integer"}, failure.Expected)
+	assert.Equal(t, "string", failure.Actual)
+}
+
+func TestValidateBody_SchemaFailureInstanceLines(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                burger:
+                  type: object
+                  properties:
+                    toppings:
+                      type: array
+                      items:
+                        type: object
+                        properties:
+                          grams:
+                            type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	body := `{
+  "burger": {
+    "toppings": [
+      {"grams": 10},
+      {
+        "grams": "lots"
+      }
+    ]
+  }
+}`
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewBufferString(body))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "/burger/toppings/1/grams", errors[0].SchemaValidationErrors[0].InstancePath)
+	assert.Equal(t, 6, errors[0].SchemaValidationErrors[0].InstanceLine)
+	assert.Equal(t, 18, errors[0].SchemaValidationErrors[0].InstanceColumn)
+}
+
+func TestValidateBody_CustomFormat(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/pay:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                account:
+                  type: string
+                  format: iban
+                email:
+                  type: string
+                  format: email`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	// a very relaxed iban check, just enough to tell good and bad values apart.
+	iban := func(v any) error {
+		s, ok := v.(string)
+		if !ok {
+			return nil
+		}
+		if len(s) < 15 || s[0] < 'A' || s[0] > 'Z' || s[1] < 'A' || s[1] > 'Z' {
+			return fmt.Errorf("'%s' is not a valid iban", s)
+		}
+		return nil
+	}
+	v := NewRequestBodyValidator(&m.Model, config.WithFormatRegistry(config.NewFormatRegistry().Register("iban", iban)))
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/pay",
+		bytes.NewBufferString(`{"account": "GB82WEST12345698765432", "email": "not-checked"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers/pay",
+		bytes.NewBufferString(`{"account": "12345"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors = v.ValidateRequestBody(request)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "format", errors[0].SchemaValidationErrors[0].Keyword)
+	assert.Contains(t, errors[0].SchemaValidationErrors[0].Reason, "is not a valid iban")
+
+	// without the format registered, the format is ignored.
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers/pay",
+		bytes.NewBufferString(`{"account": "12345"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors = NewRequestBodyValidator(&m.Model).ValidateRequestBody(request)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestValidateBody_FormatAssertion(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                id:
+                  type: string
+                  format: uuid
+                cooked:
+                  type: string
+                  format: date-time
+                chef:
+                  type: string
+                  format: email`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	goodBody := `{"id": "3e4666bf-d5e5-4aa7-b8ce-cefe41c7568a", "cooked": "2024-01-31T12:30:00Z", "chef": "chef@pb33f.io"}`
+	badBody := `{"id": "burger-1", "cooked": "yesterday", "chef": "the chef"}`
+
+	validate := func(v RequestBodyValidator, body string) (bool, int) {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+			bytes.NewBufferString(body))
+		request.Header.Set("Content-Type", "application/json")
+		valid, errors := v.ValidateRequestBody(request)
+		if len(errors) == 0 {
+			return valid, 0
+		}
+		return valid, len(errors[0].SchemaValidationErrors)
+	}
+
+	// formats are annotations by default.
+	v := NewRequestBodyValidator(&m.Model)
+	valid, failures := validate(v, goodBody)
+	assert.True(t, valid)
+	assert.Equal(t, 0, failures)
+	valid, failures = validate(v, badBody)
+	assert.True(t, valid)
+	assert.Equal(t, 0, failures)
+
+	v = NewRequestBodyValidator(&m.Model, config.WithFormatAssertions())
+	valid, failures = validate(v, goodBody)
+	assert.True(t, valid)
+	assert.Equal(t, 0, failures)
+	valid, failures = validate(v, badBody)
+	assert.False(t, valid)
+	assert.Equal(t, 3, failures)
+}
+
+func TestValidateBody_ReadOnlyProperties(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [id, name]
+              properties:
+                id:
+                  type: integer
+                  readOnly: true
+                name:
+                  type: string
+                password:
+                  type: string
+                  writeOnly: true`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	validate := func(v RequestBodyValidator, body string) (bool, []*errors.ValidationError) {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+			bytes.NewBufferString(body))
+		request.Header.Set("Content-Type", "application/json")
+		return v.ValidateRequestBody(request)
+	}
+
+	// a readOnly property is not required in a request.
+	v := NewRequestBodyValidator(&m.Model)
+	valid, errs := validate(v, `{"name": "Big Mac", "password": "secret"}`)
+	assert.True(t, valid)
+	assert.Len(t, errs, 0)
+
+	// but it can be sent, unless strict.
+	valid, errs = validate(v, `{"id": 1, "name": "Big Mac"}`)
+	assert.True(t, valid)
+	assert.Len(t, errs, 0)
+
+	v = NewRequestBodyValidator(&m.Model, config.WithStrictReadWriteOnly())
+	valid, errs = validate(v, `{"name": "Big Mac", "password": "secret"}`)
+	assert.True(t, valid)
+	assert.Len(t, errs, 0)
+
+	valid, errs = validate(v, `{
+  "id": 1,
+  "name": "Big Mac"
+}`)
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "POST request body for '/burgers/createBurger' contains the read only property '/id'",
+		errs[0].Message)
+	assert.Equal(t, 2, errs[0].SchemaValidationErrors[0].InstanceLine)
+
+	// a readOnly property is required when the options require it.
+	v = NewRequestBodyValidator(&m.Model, config.WithRequireReadOnly())
+	valid, errs = validate(v, `{"name": "Big Mac", "password": "secret"}`)
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "required", errs[0].SchemaValidationErrors[0].Keyword)
+}
+
+func TestValidateBody_OpenAPI30Nullable(t *testing.T) {
+	spec := `openapi: %s
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+                  nullable: true
+                patty:
+                  type: object
+                  nullable: true
+                  properties:
+                    grams:
+                      type: integer
+                      nullable: true
+                toppings:
+                  type: array
+                  nullable: true
+                  items:
+                    type: string
+                    nullable: true`
+
+	validate := func(version, body string) (bool, []*errors.ValidationError) {
+		doc, _ := libopenapi.NewDocument([]byte(fmt.Sprintf(spec, version)))
+		m, _ := doc.BuildV3Model()
+		v := NewRequestBodyValidator(&m.Model)
+
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+			bytes.NewBufferString(body))
+		request.Header.Set("Content-Type", "application/json")
+		return v.ValidateRequestBody(request)
+	}
+
+	nulls := `{"name": null, "patty": null, "toppings": null}`
+	nestedNulls := `{"patty": {"grams": null}, "toppings": ["cheese", null]}`
+	values := `{"name": "Big Mac", "patty": {"grams": 100}, "toppings": ["cheese"]}`
+
+	for _, body := range []string{nulls, nestedNulls, values} {
+		valid, errs := validate("3.0.3", body)
+		assert.True(t, valid, body)
+		assert.Len(t, errs, 0, body)
+	}
+
+	// a non-null value must still match the schema.
+	valid, errs := validate("3.0.3", `{"name": 1, "patty": {"grams": "lots"}}`)
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Len(t, errs[0].SchemaValidationErrors, 2)
+
+	// nullable is not a keyword in OpenAPI 3.1, so null values are rejected.
+	valid, errs = validate("3.1.0", nulls)
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Len(t, errs[0].SchemaValidationErrors, 3)
+}
+
+func TestValidateBody_ApplyDefaults(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+                size:
+                  type: string
+                  default: large
+                fries:
+                  type: boolean
+              if:
+                required: [size]
+                properties:
+                  size:
+                    const: large
+              then:
+                required: [fries]`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	newRequest := func(body string) *http.Request {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+			bytes.NewBufferString(body))
+		request.Header.Set("Content-Type", "application/json")
+		return request
+	}
+
+	// without defaults applied, the dependent constraint does not apply.
+	valid, errs := NewRequestBodyValidator(&m.Model).ValidateRequestBody(newRequest(`{"name": "Big Mac"}`))
+	assert.True(t, valid)
+	assert.Len(t, errs, 0)
+
+	v := NewRequestBodyValidator(&m.Model, config.WithApplyDefaults())
+
+	// the default size is filled in, which means fries are now required.
+	request := newRequest(`{"name": "Big Mac"}`)
+	valid, errs = v.ValidateRequestBody(request)
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Len(t, errs[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "required", errs[0].SchemaValidationErrors[0].Keyword)
+	assert.Equal(t, "/then/required", errs[0].SchemaValidationErrors[0].DeepLocation)
+
+	// the augmented body is returned to the caller.
+	request = newRequest(`{"name": "Big Mac", "fries": true}`)
+	valid, errs = v.ValidateRequestBody(request)
+	assert.True(t, valid)
+	assert.Len(t, errs, 0)
+
+	body, _ := io.ReadAll(request.Body)
+	assert.JSONEq(t, `{"name": "Big Mac", "fries": true, "size": "large"}`, string(body))
+	assert.Equal(t, int64(len(body)), request.ContentLength)
+}
+
+func TestValidateBody_AdditionalPropertiesFalse(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              additionalProperties: false
+              properties:
+                name:
+                  type: string
+                patties:
+                  type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	validate := func(body string) (bool, []*errors.ValidationError) {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+			bytes.NewBufferString(body))
+		request.Header.Set("Content-Type", "application/json")
+		return v.ValidateRequestBody(request)
+	}
+
+	valid, errs := validate(`{"name": "Big Mac", "fries": true}`)
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Len(t, errs[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "additional property 'fries' is not allowed", errs[0].SchemaValidationErrors[0].Reason)
+	assert.Equal(t, "/fries", errs[0].SchemaValidationErrors[0].InstancePath)
+	assert.Equal(t, "fries", errs[0].SchemaValidationErrors[0].Actual)
+
+	valid, errs = validate(`{
+  "name": "Big Mac",
+  "fries": true,
+  "drink": "cola"
+}`)
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Len(t, errs[0].SchemaValidationErrors, 2)
+
+	reasons := map[string]int{}
+	for _, failure := range errs[0].SchemaValidationErrors {
+		assert.Equal(t, "additionalProperties", failure.Keyword)
+		reasons[failure.Reason] = failure.InstanceLine
+	}
+	assert.Equal(t, map[string]int{
+		"additional property 'fries' is not allowed": 3,
+		"additional property 'drink' is not allowed": 4,
+	}, reasons)
+}
+
+func TestValidateBody_PatternProperties(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              additionalProperties: false
+              properties:
+                name:
+                  type: string
+              patternProperties:
+                '^x-[a-z]+$':
+                  type: integer
+                  minimum: 1`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	validate := func(body string) (bool, []*errors.ValidationError) {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+			bytes.NewBufferString(body))
+		request.Header.Set("Content-Type", "application/json")
+		return v.ValidateRequestBody(request)
+	}
+
+	// a key matching the pattern, with a valid value.
+	valid, errs := validate(`{"name": "Big Mac", "x-pickles": 2}`)
+	assert.True(t, valid)
+	assert.Len(t, errs, 0)
+
+	// a key matching the pattern, with an invalid value.
+	valid, errs = validate(`{"name": "Big Mac", "x-pickles": 0}`)
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Len(t, errs[0].SchemaValidationErrors, 1)
+	failure := errs[0].SchemaValidationErrors[0]
+	assert.Equal(t, "minimum", failure.Keyword)
+	assert.Equal(t, "/x-pickles", failure.InstancePath)
+	assert.Equal(t, 9, failure.Line) // the line within the rendered schema.
+
+	// a key matching no pattern falls under additionalProperties.
+	valid, errs = validate(`{"name": "Big Mac", "x-Pickles": 2}`)
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Len(t, errs[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "additional property 'x-Pickles' is not allowed", errs[0].SchemaValidationErrors[0].Reason)
+}
+
+func TestValidateBody_AllOfMergedRequired(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              allOf:
+                - $ref: '#/components/schemas/Burger'
+                - type: object
+                  required: [id, patties]
+                  properties:
+                    patties:
+                      type: integer
+              unevaluatedProperties: false
+components:
+  schemas:
+    Burger:
+      type: object
+      required: [name]
+      properties:
+        id:
+          type: integer
+          readOnly: true
+        name:
+          type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	validate := func(body string) (bool, []*errors.ValidationError) {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+			bytes.NewBufferString(body))
+		request.Header.Set("Content-Type", "application/json")
+		return v.ValidateRequestBody(request)
+	}
+
+	// properties from both branches are merged, and the readOnly id is not required in a request.
+	valid, errs := validate(`{"name": "Big Mac", "patties": 2}`)
+	assert.True(t, valid)
+	assert.Len(t, errs, 0)
+
+	// a required property contributed by the second branch is missing.
+	valid, errs = validate(`{"name": "Big Mac"}`)
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Len(t, errs[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "missing property 'patties'", errs[0].SchemaValidationErrors[0].Reason)
+	assert.Equal(t, "/allOf/1/required", errs[0].SchemaValidationErrors[0].DeepLocation)
+
+	// required properties missing from every branch are all reported.
+	valid, errs = validate(`{}`)
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Len(t, errs[0].SchemaValidationErrors, 2)
+
+	// properties that are not defined by any branch are rejected.
+	valid, errs = validate(`{"name": "Big Mac", "patties": 2, "fries": true}`)
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Len(t, errs[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "unevaluatedProperties", errs[0].SchemaValidationErrors[0].Keyword)
+}
+
+func TestValidateBody_RepeatedCalls(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewBufferString(`{"name": 12}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errs := v.ValidateRequestBody(request)
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+
+	// the errors from the first request must not leak into the second.
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewBufferString(`{"name": "big mac"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errs = v.ValidateRequestBody(request)
+	assert.True(t, valid)
+	assert.Empty(t, errs)
+}
+
+func TestValidateBody_ContentTypeParametersAndSuffix(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	for _, contentType := range []string{
+		"application/json; charset=utf-8",
+		"application/json;charset=UTF-8",
+		"application/vnd.api+json",
+		"application/vnd.api+json; charset=utf-8",
+	} {
+		t.Run(contentType, func(t *testing.T) {
+			request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+				bytes.NewBufferString(`{"name":"Big Mac"}`))
+			request.Header.Set("Content-Type", contentType)
+
+			valid, errors := v.ValidateRequestBody(request)
+			assert.True(t, valid)
+			assert.Len(t, errors, 0)
+
+			// the body is still validated against the schema.
+			request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+				bytes.NewBufferString(`{"name":123}`))
+			request.Header.Set("Content-Type", contentType)
+
+			valid, errors = v.ValidateRequestBody(request)
+			assert.False(t, valid)
+			assert.Len(t, errors, 1)
+			assert.Equal(t, "POST request body for '/burgers/createBurger' failed to validate schema", errors[0].Message)
+		})
+	}
+}
+
+func TestValidateBody_StructuredSuffix(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/vnd.burger.v1+json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+                patties:
+                  type: integer
+          application/vnd.burger.v1+xml:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+                patties:
+                  type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	for name, tc := range map[string]struct {
+		contentType string
+		body        string
+		valid       bool
+	}{
+		"JSON":        {contentType: "application/vnd.burger.v1+json", body: `{"name":"Big Mac","patties":2}`, valid: true},
+		"JSONInvalid": {contentType: "application/vnd.burger.v1+json", body: `{"name":"Big Mac","patties":"two"}`},
+		"XML":         {contentType: "application/vnd.burger.v1+xml", body: `<burger><name>Big Mac</name></burger>`, valid: true},
+		"XMLInvalid":  {contentType: "application/vnd.burger.v1+xml", body: `<burger><patties>2</patties></burger>`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+				bytes.NewBufferString(tc.body))
+			request.Header.Set("Content-Type", tc.contentType)
+
+			valid, errors := v.ValidateRequestBody(request)
+			assert.Equal(t, tc.valid, valid)
+			if tc.valid {
+				assert.Len(t, errors, 0)
+				return
+			}
+			assert.Len(t, errors, 1)
+			assert.Equal(t, "POST request body for '/burgers/createBurger' failed to validate schema", errors[0].Message)
+		})
+	}
+}
+
+// lookaheadRegexp stands in for an ECMA regex engine, for the '^(?=.*[0-9]).+$' pattern used by the test.
+type lookaheadRegexp struct{}
+
+func (lookaheadRegexp) MatchString(s string) bool {
+	return strings.ContainsAny(s, "0123456789")
+}
+
+func TestValidateBody_CustomRegexEngine(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                code:
+                  type: string
+                  pattern: '^(?=.*[0-9]).+$'`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model, config.WithRegexEngine(func(pattern string) (config.Regexp, error) {
+		return lookaheadRegexp{}, nil
+	}))
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewBufferString(`{"code": "burger-1"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errs := v.ValidateRequestBody(request)
+	assert.True(t, valid)
+	assert.Empty(t, errs)
+
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewBufferString(`{"code": "burger"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errs = v.ValidateRequestBody(request)
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+}
+
+func TestValidateBody_UnsupportedPattern(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+                  pattern: '^(?=.*[A-Z]).+$'
+                patties:
+                  type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	newRequest := func() *http.Request {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+			bytes.NewBufferString(`{"name": "big mac", "patties": "two"}`))
+		request.Header.Set("Content-Type", "application/json")
+		return request
+	}
+
+	// the pattern is reported, rather than failing the whole validation.
+	v := NewRequestBodyValidator(&m.Model)
+	valid, errs := v.ValidateRequestBody(newRequest())
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, helpers.InvalidSchemaPattern, errs[0].ValidationSubType)
+	assert.Equal(t, "POST request body schema pattern '^(?=.*[A-Z]).+$' cannot be compiled", errs[0].Message)
+	assert.Contains(t, errs[0].Reason, "The pattern at '/properties/name/pattern'")
+
+	// the pattern is skipped, so the rest of the schema is still validated.
+	v = NewRequestBodyValidator(&m.Model, config.WithSkipInvalidPatterns())
+	valid, errs = v.ValidateRequestBody(newRequest())
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Len(t, errs[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "got string, want integer", errs[0].SchemaValidationErrors[0].Reason)
+}
+
+func TestValidateBody_UnknownFormat(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                bakedAt:
+                  type: string
+                  format: date-tim`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	newRequest := func() *http.Request {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+			bytes.NewBufferString(`{"bakedAt": "yesterday"}`))
+		request.Header.Set("Content-Type", "application/json")
+		return request
+	}
+
+	// unknown formats are ignored by default.
+	v := NewRequestBodyValidator(&m.Model)
+	valid, errs := v.ValidateRequestBody(newRequest())
+	assert.True(t, valid)
+	assert.Empty(t, errs)
+
+	v = NewRequestBodyValidator(&m.Model, config.WithUnknownFormatError())
+	valid, errs = v.ValidateRequestBody(newRequest())
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, helpers.UnknownSchemaFormat, errs[0].ValidationSubType)
+	assert.Equal(t, "POST request body schema format 'date-tim' is not known", errs[0].Message)
+	assert.Contains(t, errs[0].Reason, "The format at '/properties/bakedAt/format'")
+}
+
+func TestValidateBody_Const(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                kind:
+                  type: string
+                  const: burger
+                name:
+                  type: string
+              required: [kind, name]`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewBufferString(`{"kind": "burger", "name": "Big Mac"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewBufferString(`{"kind": "hotdog", "name": "Big Mac"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors = v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "value must be 'burger'", errors[0].SchemaValidationErrors[0].Reason)
+	assert.Equal(t, "/properties/kind/const", errors[0].SchemaValidationErrors[0].Location)
+}
+
+var readerBodySpec = `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+                patties:
+                  type: integer`
+
+func TestValidateBody_Reader(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(readerBodySpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+	operation := m.Model.Paths.PathItems.GetOrZero("/burgers/createBurger").Post
+
+	valid, errors := v.ValidateRequestBodyReader(operation, "application/json",
+		strings.NewReader(`{"name": "Big Mac", "patties": 2}`))
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestValidateBody_ReaderInvalid(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(readerBodySpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+	operation := m.Model.Paths.PathItems.GetOrZero("/burgers/createBurger").Post
+
+	valid, errors := v.ValidateRequestBodyReader(operation, "application/json",
+		strings.NewReader(`{"patties": "two"}`))
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 2)
+	assert.Equal(t, http.MethodPost, errors[0].RequestMethod)
+	assert.Equal(t, "/burgers/createBurger", errors[0].SpecPath)
+}
+
+func TestValidateBody_ReaderMissingContentType(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(readerBodySpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+	operation := m.Model.Paths.PathItems.GetOrZero("/burgers/createBurger").Post
+
+	valid, errors := v.ValidateRequestBodyReader(operation, "", strings.NewReader(`{"name": "Big Mac"}`))
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, helpers.RequestBodyContentType, errors[0].ValidationSubType)
+
+	valid, errors = v.ValidateRequestBodyReader(operation, "application/xml", strings.NewReader(`<burger/>`))
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, helpers.RequestBodyContentType, errors[0].ValidationSubType)
+}
+
+func TestValidateBody_ReaderNoRequestBody(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    get:
+      operationId: listBurgers`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	valid, errors := v.ValidateRequestBodyReader(m.Model.Paths.PathItems.GetOrZero("/burgers").Get,
+		"application/json", strings.NewReader(`{}`))
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	valid, errors = v.ValidateRequestBodyReader(nil, "application/json", nil)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestValidateBody_RequestBodyRef(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        $ref: '#/components/requestBodies/Burger'
+components:
+  requestBodies:
+    Burger:
+      required: true
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Burger'
+  schemas:
+    Burger:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        patties:
+          type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewBufferString(`{"name": "Big Mac", "patties": 2}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		bytes.NewBufferString(`{"patties": "two"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	valid, errors = v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 2)
+
+	// the referenced request body is required, so a missing body is reported.
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger", http.NoBody)
+
+	valid, errors = v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, helpers.RequestMissingBody, errors[0].ValidationSubType)
+}
+
+func TestValidateBody_DependentRequired(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/pay:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                card:
+                  type: string
+                cvv:
+                  type: string
+                tip:
+                  type: number
+              dependentRequired:
+                card: [cvv]
+              dependentSchemas:
+                tip:
+                  required: [card]`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	validate := func(body string) (bool, []*errors.ValidationError) {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/pay",
+			bytes.NewBufferString(body))
+		request.Header.Set("Content-Type", "application/json")
+		return v.ValidateRequestBody(request)
+	}
+
+	valid, errs := validate(`{"card": "4111111111111111", "cvv": "123", "tip": 2}`)
+	assert.True(t, valid)
+	assert.Len(t, errs, 0)
+
+	// the card is present, so the cvv is required.
+	valid, errs = validate(`{"card": "4111111111111111"}`)
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Len(t, errs[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "properties 'cvv' required, if 'card' exists", errs[0].SchemaValidationErrors[0].Reason)
+
+	// the tip is present, so the dependent schema requires the card.
+	valid, errs = validate(`{"tip": 2}`)
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs[0].SchemaValidationErrors[0].Reason, "missing property 'card'")
+}
+
+func TestValidateBody_IfThenElse(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/pay:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                method:
+                  type: string
+                card:
+                  type: string
+                iban:
+                  type: string
+              if:
+                properties:
+                  method:
+                    const: card
+              then:
+                required: [card]
+                if:
+                  required: [card]
+                then:
+                  properties:
+                    card:
+                      pattern: '^[0-9]+$'
+              else:
+                required: [iban]`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	validate := func(body string) (bool, []*errors.ValidationError) {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/pay",
+			bytes.NewBufferString(body))
+		request.Header.Set("Content-Type", "application/json")
+		return v.ValidateRequestBody(request)
+	}
+
+	valid, errs := validate(`{"method": "card", "card": "4111111111111111"}`)
+	assert.True(t, valid)
+	assert.Len(t, errs, 0)
+
+	valid, errs = validate(`{"method": "bank", "iban": "GB33BUKB20201555555555"}`)
+	assert.True(t, valid)
+	assert.Len(t, errs, 0)
+
+	// the 'if' matches, so the 'then' branch applies.
+	valid, errs = validate(`{"method": "card"}`)
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Len(t, errs[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "missing property 'card'", errs[0].SchemaValidationErrors[0].Reason)
+	assert.Equal(t, "/then/required", errs[0].SchemaValidationErrors[0].Location)
+
+	// the nested conditional of the 'then' branch applies too.
+	valid, errs = validate(`{"method": "card", "card": "four"}`)
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Len(t, errs[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "/then/then/properties/card/pattern", errs[0].SchemaValidationErrors[0].Location)
+	assert.Equal(t, "/card", errs[0].SchemaValidationErrors[0].InstancePath)
+
+	// the 'if' does not match, so the 'else' branch applies.
+	valid, errs = validate(`{"method": "bank"}`)
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Len(t, errs[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "missing property 'iban'", errs[0].SchemaValidationErrors[0].Reason)
+	assert.Equal(t, "/else/required", errs[0].SchemaValidationErrors[0].Location)
+}
+
+func TestValidateBody_UniqueItemsObjects(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurgers:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: array
+              uniqueItems: true
+              items:
+                type: object
+                properties:
+                  name:
+                    type: string
+                  toppings:
+                    type: array
+                    items:
+                      type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	validate := func(body string) (bool, []*errors.ValidationError) {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurgers",
+			bytes.NewBufferString(body))
+		request.Header.Set("Content-Type", "application/json")
+		return v.ValidateRequestBody(request)
+	}
+
+	// the objects only differ by the order of their properties, so they are duplicates.
+	valid, errs := validate(`[{"name": "Big Mac", "toppings": ["pickle"]}, {"toppings": ["pickle"], "name": "Big Mac"}]`)
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Len(t, errs[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "uniqueItems", errs[0].SchemaValidationErrors[0].Keyword)
+
+	valid, errs = validate(`[{"name": "Big Mac", "toppings": ["pickle"]}, {"name": "Big Mac", "toppings": ["onion"]}]`)
+	assert.True(t, valid)
+	assert.Len(t, errs, 0)
+}
+
+func TestValidateBody_MinMaxProperties(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                metadata:
+                  type: object
+                  minProperties: 1
+                  maxProperties: 2
+                  additionalProperties:
+                    type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	newRequest := func(body string) *http.Request {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+			bytes.NewBufferString(body))
+		request.Header.Set("Content-Type", "application/json")
+		return request
+	}
+
+	valid, errs := v.ValidateRequestBody(newRequest(`{"metadata": {"bun": "sesame", "sauce": "ketchup"}}`))
+	assert.True(t, valid)
+	assert.Empty(t, errs)
+
+	valid, errs = v.ValidateRequestBody(newRequest(`{"metadata": {"bun": "sesame", "sauce": "ketchup", "cheese": "yes"}}`))
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Len(t, errs[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "/metadata", errs[0].SchemaValidationErrors[0].InstancePath)
+	assert.Equal(t, "maxProperties: got 3, want 2", errs[0].SchemaValidationErrors[0].Reason)
+
+	valid, errs = v.ValidateRequestBody(newRequest(`{"metadata": {}}`))
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Len(t, errs[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "minProperties: got 0, want 1", errs[0].SchemaValidationErrors[0].Reason)
+}
+
+func TestValidateBody_PropertyNames(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                toppings:
+                  type: object
+                  propertyNames:
+                    pattern: '^[a-z]+$'
+                  additionalProperties:
+                    type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	newRequest := func(body string) *http.Request {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+			bytes.NewBufferString(body))
+		request.Header.Set("Content-Type", "application/json")
+		return request
+	}
+
+	valid, errs := v.ValidateRequestBody(newRequest(`{"toppings": {"pickles": 2, "onions": 1}}`))
+	assert.True(t, valid)
+	assert.Empty(t, errs)
+
+	valid, errs = v.ValidateRequestBody(newRequest(`{"toppings": {"pickles": 2, "Extra_Cheese": 1}}`))
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Len(t, errs[0].SchemaValidationErrors, 1)
+	failure := errs[0].SchemaValidationErrors[0]
+	assert.Equal(t, "property name 'Extra_Cheese' is not valid: 'Extra_Cheese' does not match pattern '^[a-z]+$'",
+		failure.Reason)
+	assert.Equal(t, "propertyNames", failure.Keyword)
+	assert.Equal(t, "/properties/toppings/propertyNames", failure.DeepLocation)
+	assert.Equal(t, "/toppings/Extra_Cheese", failure.InstancePath)
+	assert.Equal(t, "Extra_Cheese", failure.Actual)
+}
+
+func TestValidateBody_ContentEncoding(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                recipe:
+                  type: string
+                  contentEncoding: base64
+                  contentMediaType: application/json
+                  contentSchema:
+                    type: object
+                    properties:
+                      patties:
+                        type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	newRequest := func(recipe string) *http.Request {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+			bytes.NewBufferString(fmt.Sprintf(`{"recipe": "%s"}`, recipe)))
+		request.Header.Set("Content-Type", "application/json")
+		return request
+	}
+
+	// content is only an annotation by default.
+	v := NewRequestBodyValidator(&m.Model)
+	valid, errs := v.ValidateRequestBody(newRequest("not base64!"))
+	assert.True(t, valid)
+	assert.Empty(t, errs)
+
+	v = NewRequestBodyValidator(&m.Model, config.WithContentAssertions())
+
+	// {"patties": 2}
+	valid, errs = v.ValidateRequestBody(newRequest("eyJwYXR0aWVzIjogMn0="))
+	assert.True(t, valid)
+	assert.Empty(t, errs)
+
+	valid, errs = v.ValidateRequestBody(newRequest("not base64!"))
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Len(t, errs[0].SchemaValidationErrors, 1)
+	assert.Equal(t, helpers.ContentEncoding, errs[0].SchemaValidationErrors[0].Keyword)
+	assert.Equal(t, "/recipe", errs[0].SchemaValidationErrors[0].InstancePath)
+	assert.Contains(t, errs[0].SchemaValidationErrors[0].Reason, "value is not 'base64' encoded")
+
+	// {patties
+	valid, errs = v.ValidateRequestBody(newRequest("e3BhdHRpZXM="))
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Len(t, errs[0].SchemaValidationErrors, 1)
+	assert.Equal(t, helpers.ContentMediaType, errs[0].SchemaValidationErrors[0].Keyword)
+
+	// {"patties": "two"}
+	valid, errs = v.ValidateRequestBody(newRequest("eyJwYXR0aWVzIjogInR3byJ9"))
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, helpers.ContentSchema, errs[0].SchemaValidationErrors[0].Keyword)
+}
+
+func TestValidateBody_RootArray(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurgers:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: array
+              minItems: 1
+              maxItems: 3
+              items:
+                type: object
+                required: [name]
+                properties:
+                  name:
+                    type: string
+                  patties:
+                    type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	newRequest := func(body string) *http.Request {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurgers",
+			bytes.NewBufferString(body))
+		request.Header.Set("Content-Type", "application/json")
+		return request
+	}
+
+	valid, errs := v.ValidateRequestBody(newRequest(`[{"name": "Big Mac", "patties": 2}, {"name": "Whopper"}]`))
+	assert.True(t, valid)
+	assert.Empty(t, errs)
+
+	// each element is validated against the items schema.
+	valid, errs = v.ValidateRequestBody(newRequest(`[{"name": "Big Mac", "patties": 2}, {"name": "Whopper", "patties": "one"}]`))
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Len(t, errs[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "/1/patties", errs[0].SchemaValidationErrors[0].InstancePath)
+	assert.Equal(t, "type", errs[0].SchemaValidationErrors[0].Keyword)
+
+	valid, errs = v.ValidateRequestBody(newRequest(`[]`))
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "minItems", errs[0].SchemaValidationErrors[0].Keyword)
+
+	valid, errs = v.ValidateRequestBody(newRequest(`[{"name": "a"}, {"name": "b"}, {"name": "c"}, {"name": "d"}]`))
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "maxItems", errs[0].SchemaValidationErrors[0].Keyword)
+}
+
+func TestValidateBody_PrefixItems(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/locate:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                coordinates:
+                  type: array
+                  prefixItems:
+                    - type: number
+                    - type: number
+                  items: false`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	newRequest := func(body string) *http.Request {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/locate",
+			bytes.NewBufferString(body))
+		request.Header.Set("Content-Type", "application/json")
+		return request
+	}
+
+	valid, errs := v.ValidateRequestBody(newRequest(`{"coordinates": [51.5, -0.12]}`))
+	assert.True(t, valid)
+	assert.Empty(t, errs)
+
+	// each element is validated against the schema at the same position.
+	valid, errs = v.ValidateRequestBody(newRequest(`{"coordinates": [51.5, "west"]}`))
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Len(t, errs[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "/coordinates/1", errs[0].SchemaValidationErrors[0].InstancePath)
+	assert.Equal(t, "type", errs[0].SchemaValidationErrors[0].Keyword)
+
+	// 'items: false' means no elements are allowed after the tuple.
+	valid, errs = v.ValidateRequestBody(newRequest(`{"coordinates": [51.5, -0.12, 11]}`))
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Len(t, errs[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "/coordinates/2", errs[0].SchemaValidationErrors[0].InstancePath)
+	assert.Equal(t, "items", errs[0].SchemaValidationErrors[0].Keyword)
+}
+
+func TestValidateBody_SchemaDraft(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/locate:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: array
+              prefixItems:
+                - type: number
+                - type: number`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	newRequest := func(body string) *http.Request {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/locate",
+			bytes.NewBufferString(body))
+		request.Header.Set("Content-Type", "application/json")
+		return request
+	}
+
+	// 'prefixItems' is only understood by 2020-12, so draft-07 does not check the elements.
+	v := NewRequestBodyValidator(&m.Model, config.WithSchemaDraft(config.SchemaDraft7))
+	valid, errs := v.ValidateRequestBody(newRequest(`[51.5, "west"]`))
+	assert.True(t, valid)
+	assert.Empty(t, errs)
+
+	v = NewRequestBodyValidator(&m.Model, config.WithSchemaDraft(config.SchemaDraft2020))
+	valid, errs = v.ValidateRequestBody(newRequest(`[51.5, "west"]`))
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "/1", errs[0].SchemaValidationErrors[0].InstancePath)
+}
+
+func TestValidateBody_MultipleMediaTypes_ReportsMediaType(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+          application/*:
+            schema:
+              type: object
+              required: [patties]
+              properties:
+                patties:
+                  type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	newRequest := func(contentType, body string) *http.Request {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+			bytes.NewBufferString(body))
+		request.Header.Set("Content-Type", contentType)
+		return request
+	}
+
+	valid, errs := v.ValidateRequestBody(newRequest("application/json", `{"patties": 2}`))
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "application/json", errs[0].MediaType)
+	assert.Contains(t, errs[0].Error(), "Media Type: application/json")
+
+	// a structured suffix is matched by 'application/json' before the wildcard subtype, so that schema is used.
+	valid, errs = v.ValidateRequestBody(newRequest("application/vnd.burger+json", `{"patties": 2}`))
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "application/json", errs[0].MediaType)
+	assert.Equal(t, []string{"name"}, errs[0].SchemaValidationErrors[0].Expected)
 }
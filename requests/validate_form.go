@@ -0,0 +1,137 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package requests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/pb33f/libopenapi-validator/config"
+	"github.com/pb33f/libopenapi-validator/errors"
+	"github.com/pb33f/libopenapi-validator/helpers"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// ValidateRequestFormSchema will validate a http.Request pointer with an 'application/x-www-form-urlencoded' body
+// against a schema. The form is decoded into an object before it's validated, the encoding object of the media type
+// (if defined) is used to determine how arrays and objects have been serialized, and values are coerced into the
+// types defined by the schema.
+// If validation fails, it will return a list of validation errors as the second return value.
+func ValidateRequestFormSchema(
+	request *http.Request,
+	schema *base.Schema,
+	encoding *orderedmap.Map[string, *v3.Encoding],
+	renderedSchema,
+	jsonSchema []byte,
+	opts ...config.Option) (bool, []*errors.ValidationError) {
+
+	requestBody := readRequestBody(request)
+
+	var decodedObj interface{}
+	if len(requestBody) > 0 {
+		values, err := url.ParseQuery(string(requestBody))
+		if err != nil {
+			// cannot decode the request body, so it's not valid
+			return false, []*errors.ValidationError{requestBodyDecodingError(request, err, renderedSchema, requestBody)}
+		}
+		decodedObj = decodeFormBody(values, schema, encoding)
+	}
+	return validateDecodedRequestBody(request, schema, renderedSchema, jsonSchema, requestBody, decodedObj,
+		config.NewValidationOptions(opts...))
+}
+
+// decodeFormBody will decode the values of a form into an object. Keys that use square brackets are decoded as
+// deepObject properties, repeated keys are decoded as arrays.
+func decodeFormBody(
+	values url.Values, schema *base.Schema, encoding *orderedmap.Map[string, *v3.Encoding]) map[string]interface{} {
+
+	decoded := make(map[string]interface{})
+	var deepObjects []*helpers.QueryParam
+	for key, vals := range values {
+		name, path := helpers.ExtractDeepObjectPath(key)
+		if len(path) > 0 {
+			deepObjects = append(deepObjects, &helpers.QueryParam{Key: name, Values: vals, Path: path})
+			continue
+		}
+		var enc *v3.Encoding
+		if encoding != nil {
+			enc = encoding.GetOrZero(key)
+		}
+		decoded[key] = decodeFormValue(vals, formPropertySchema(schema, key), enc)
+	}
+	if len(deepObjects) > 0 {
+		if nested, err := helpers.ConstructNestedParamMapFromDeepObjectEncoding(deepObjects); err == nil {
+			for k, v := range nested {
+				decoded[k] = v
+			}
+		}
+	}
+	return decoded
+}
+
+// formPropertySchema will return the schema for a property of a form, falling back to the schema for
+// additional properties if the property is not defined.
+func formPropertySchema(schema *base.Schema, name string) *base.Schema {
+	if schema == nil {
+		return nil
+	}
+	if schema.Properties != nil {
+		if prop, ok := schema.Properties.Get(name); ok && prop != nil {
+			return prop.Schema()
+		}
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.IsA() {
+		return schema.AdditionalProperties.A.Schema()
+	}
+	return nil
+}
+
+// decodeFormValue will decode the values of a single form property, based on the schema and encoding of
+// the property.
+func decodeFormValue(vals []string, sch *base.Schema, enc *v3.Encoding) interface{} {
+	// a property can be encoded using a different content type, JSON is the only one that can be decoded.
+	if enc != nil && helpers.IsJSONContentType(enc.ContentType) {
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(vals[0]), &decoded); err == nil {
+			return decoded
+		}
+		return vals[0]
+	}
+
+	if sch != nil && slices.Contains(sch.Type, helpers.Array) {
+		var itemsSchema *base.Schema
+		if sch.Items != nil && sch.Items.IsA() {
+			itemsSchema = sch.Items.A.Schema()
+		}
+		// arrays are exploded by default, so each item is a repeated key, unless the encoding says otherwise.
+		var items []string
+		if enc != nil && ((enc.Explode != nil && !*enc.Explode) ||
+			enc.Style == helpers.SpaceDelimited || enc.Style == helpers.PipeDelimited) {
+			for _, v := range vals {
+				items = append(items, helpers.ExplodeQueryValue(v, enc.Style)...)
+			}
+		} else {
+			items = vals
+		}
+		decoded := make([]interface{}, len(items))
+		for i := range items {
+			decoded[i] = helpers.CoerceValue(items[i], itemsSchema)
+		}
+		return decoded
+	}
+
+	if len(vals) > 1 {
+		// a repeated key for a property that is not an array, this will fail validation.
+		decoded := make([]interface{}, len(vals))
+		for i := range vals {
+			decoded[i] = helpers.CoerceValue(vals[i], sch)
+		}
+		return decoded
+	}
+	return helpers.CoerceValue(vals[0], sch)
+}
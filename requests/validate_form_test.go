@@ -0,0 +1,102 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package requests
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/stretchr/testify/assert"
+)
+
+var formSpec = `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/x-www-form-urlencoded:
+            schema:
+              type: object
+              required: [name, patties]
+              properties:
+                name:
+                  type: string
+                patties:
+                  type: integer
+                price:
+                  type: number
+                vegetarian:
+                  type: boolean
+                toppings:
+                  type: array
+                  items:
+                    type: string
+                sauces:
+                  type: array
+                  items:
+                    type: integer
+                extras:
+                  type: object
+                  properties:
+                    cheese:
+                      type: boolean
+            encoding:
+              sauces:
+                explode: false
+              extras:
+                contentType: application/json`
+
+func TestValidateBody_FormURLEncoded(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(formSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	body := "name=Big+Mac&patties=2&price=4.99&vegetarian=false&toppings=pickles&toppings=onions" +
+		"&sauces=1,2,3&extras=%7B%22cheese%22%3Atrue%7D"
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestValidateBody_FormURLEncoded_MissingRequired(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(formSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		strings.NewReader("name=Big+Mac&vegetarian=true"))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "missing property 'patties'", errors[0].SchemaValidationErrors[0].Reason)
+}
+
+func TestValidateBody_FormURLEncoded_InvalidCoercion(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(formSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		strings.NewReader("name=Big+Mac&patties=two&vegetarian=nope"))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 2)
+}
@@ -0,0 +1,146 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package requests
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/pb33f/libopenapi-validator/config"
+	"github.com/pb33f/libopenapi-validator/errors"
+	"github.com/pb33f/libopenapi-validator/helpers"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// ValidateRequestMultipartSchema will validate a http.Request pointer with a 'multipart/form-data' body against a
+// schema. Each part of the body is decoded into a property of an object before it's validated, field parts are
+// coerced into the types defined by the schema and file parts are treated as strings, so they can be validated
+// against a 'type: string, format: binary' schema. If the encoding object of the media type defines a content type
+// for a part, the content type of the part is checked against it.
+// If validation fails, it will return a list of validation errors as the second return value.
+func ValidateRequestMultipartSchema(
+	request *http.Request,
+	schema *base.Schema,
+	encoding *orderedmap.Map[string, *v3.Encoding],
+	renderedSchema,
+	jsonSchema []byte,
+	opts ...config.Option) (bool, []*errors.ValidationError) {
+
+	requestBody := readRequestBody(request)
+
+	var validationErrors []*errors.ValidationError
+	var decodedObj interface{}
+	if len(requestBody) > 0 {
+		_, _, boundary := helpers.ExtractContentType(request.Header.Get(helpers.ContentTypeHeader))
+		parts, err := readMultipartParts(requestBody, strings.Trim(boundary, `"`))
+		if err != nil {
+			// cannot decode the request body, so it's not valid
+			return false, []*errors.ValidationError{requestBodyDecodingError(request, err, renderedSchema, requestBody)}
+		}
+		var decoded map[string]interface{}
+		decoded, validationErrors = decodeMultipartBody(request, parts, schema, encoding)
+		decodedObj = decoded
+	}
+
+	valid, schemaErrors := validateDecodedRequestBody(request, schema, renderedSchema, jsonSchema, requestBody, decodedObj,
+		config.NewValidationOptions(opts...))
+	validationErrors = append(validationErrors, schemaErrors...)
+	return valid && len(validationErrors) == 0, validationErrors
+}
+
+// multipartPart is a single part read from a multipart body.
+type multipartPart struct {
+	name        string
+	contentType string
+	value       string
+}
+
+// readMultipartParts will read all the named parts of a multipart body, parts without a name are ignored.
+func readMultipartParts(body []byte, boundary string) ([]*multipartPart, error) {
+	if boundary == "" {
+		return nil, fmt.Errorf("no multipart boundary defined in the content type")
+	}
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+	var parts []*multipartPart
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return parts, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() == "" {
+			continue
+		}
+		parts = append(parts, &multipartPart{
+			name:        part.FormName(),
+			contentType: part.Header.Get(helpers.ContentTypeHeader),
+			value:       string(content),
+		})
+	}
+}
+
+// decodeMultipartBody will decode the parts of a multipart body into an object, any part that has a content
+// type that does not match the content type of its encoding is returned as a validation error.
+func decodeMultipartBody(request *http.Request, parts []*multipartPart, schema *base.Schema,
+	encoding *orderedmap.Map[string, *v3.Encoding]) (map[string]interface{}, []*errors.ValidationError) {
+
+	var validationErrors []*errors.ValidationError
+	var names []string
+	values := make(map[string][]string)
+	for _, part := range parts {
+		if _, ok := values[part.name]; !ok {
+			names = append(names, part.name)
+		}
+		values[part.name] = append(values[part.name], part.value)
+
+		if encoding == nil {
+			continue
+		}
+		enc := encoding.GetOrZero(part.name)
+		if enc == nil || enc.ContentType == "" || part.contentType == "" {
+			continue
+		}
+		partType, _, _ := helpers.ExtractContentType(part.contentType)
+		if !matchesAnyMediaRange(enc.ContentType, partType) {
+			validationErrors = append(validationErrors,
+				errors.RequestPartContentTypeMismatch(request, part.name, partType, enc))
+		}
+	}
+
+	decoded := make(map[string]interface{})
+	for _, name := range names {
+		var enc *v3.Encoding
+		if encoding != nil {
+			if e := encoding.GetOrZero(name); e != nil {
+				// style and explode only apply to form urlencoded bodies, so only the content type is used.
+				enc = &v3.Encoding{ContentType: e.ContentType}
+			}
+		}
+		decoded[name] = decodeFormValue(values[name], formPropertySchema(schema, name), enc)
+	}
+	return decoded, validationErrors
+}
+
+// matchesAnyMediaRange will check if a content type matches any of the comma separated media ranges
+// of an encoding content type.
+func matchesAnyMediaRange(mediaRanges, contentType string) bool {
+	for _, mediaRange := range strings.Split(mediaRanges, helpers.Comma) {
+		if helpers.MatchesMediaRange(mediaRange, contentType) {
+			return true
+		}
+	}
+	return false
+}
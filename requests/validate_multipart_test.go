@@ -0,0 +1,153 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package requests
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/stretchr/testify/assert"
+)
+
+var multipartSpec = `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        required: true
+        content:
+          multipart/form-data:
+            schema:
+              type: object
+              additionalProperties: false
+              required: [name, photo]
+              properties:
+                name:
+                  type: string
+                patties:
+                  type: integer
+                photo:
+                  type: string
+                  format: binary
+            encoding:
+              photo:
+                contentType: image/png, image/jpeg`
+
+type multipartField struct {
+	name, fileName, contentType, value string
+}
+
+func createMultipartRequest(fields ...multipartField) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for _, f := range fields {
+		header := textproto.MIMEHeader{}
+		if f.fileName != "" {
+			header.Set("Content-Disposition", `form-data; name="`+f.name+`"; filename="`+f.fileName+`"`)
+		} else {
+			header.Set("Content-Disposition", `form-data; name="`+f.name+`"`)
+		}
+		if f.contentType != "" {
+			header.Set("Content-Type", f.contentType)
+		}
+		part, _ := writer.CreatePart(header)
+		_, _ = part.Write([]byte(f.value))
+	}
+	_ = writer.Close()
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger", body)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	return request
+}
+
+func TestValidateBody_Multipart(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(multipartSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request := createMultipartRequest(
+		multipartField{name: "name", value: "Big Mac"},
+		multipartField{name: "patties", value: "2"},
+		multipartField{name: "photo", fileName: "burger.png", contentType: "image/png", value: "\x89PNG"},
+	)
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestValidateBody_Multipart_MissingFilePart(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(multipartSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request := createMultipartRequest(
+		multipartField{name: "name", value: "Big Mac"},
+		multipartField{name: "patties", value: "2"},
+	)
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "missing property 'photo'", errors[0].SchemaValidationErrors[0].Reason)
+}
+
+func TestValidateBody_Multipart_UnexpectedPart(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(multipartSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request := createMultipartRequest(
+		multipartField{name: "name", value: "Big Mac"},
+		multipartField{name: "photo", fileName: "burger.png", contentType: "image/png", value: "\x89PNG"},
+		multipartField{name: "fries", value: "large"},
+	)
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "additional property 'fries' is not allowed", errors[0].SchemaValidationErrors[0].Reason)
+}
+
+func TestValidateBody_Multipart_PartContentTypeMismatch(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(multipartSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request := createMultipartRequest(
+		multipartField{name: "name", value: "Big Mac"},
+		multipartField{name: "photo", fileName: "burger.gif", contentType: "image/gif", value: "GIF89a"},
+	)
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "POST request part 'photo' content type 'image/gif' is not allowed", errors[0].Message)
+	assert.Equal(t, 23, errors[0].SpecLine)
+}
+
+func TestValidateBody_Multipart_MissingBoundary(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(multipartSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request := createMultipartRequest(multipartField{name: "name", value: "Big Mac"})
+	request.Header.Set("Content-Type", "multipart/form-data")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Contains(t, errors[0].Reason, "no multipart boundary defined")
+}
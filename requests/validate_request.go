@@ -7,43 +7,30 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"github.com/pb33f/libopenapi-validator/config"
 	"github.com/pb33f/libopenapi-validator/errors"
 	"github.com/pb33f/libopenapi-validator/helpers"
 	"github.com/pb33f/libopenapi-validator/schema_validation"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/utils"
 	"github.com/santhosh-tekuri/jsonschema/v6"
-	"golang.org/x/text/language"
-	"golang.org/x/text/message"
-	"gopkg.in/yaml.v3"
 	"io"
 	"net/http"
-	"reflect"
-	"regexp"
-	"strconv"
-	"strings"
 )
 
-var instanceLocationRegex = regexp.MustCompile(`^/(\d+)`)
-
-// ValidateRequestSchema will validate a http.Request pointer against a schema.
+// ValidateRequestSchema will validate a http.Request pointer against a schema. Options can be supplied to
+// configure the validation, such as custom formats.
 // If validation fails, it will return a list of validation errors as the second return value.
 func ValidateRequestSchema(
 	request *http.Request,
 	schema *base.Schema,
 	renderedSchema,
-	jsonSchema []byte) (bool, []*errors.ValidationError) {
+	jsonSchema []byte,
+	opts ...config.Option) (bool, []*errors.ValidationError) {
 
 	var validationErrors []*errors.ValidationError
 
-	var requestBody []byte
-	if request != nil && request.Body != nil {
-		requestBody, _ = io.ReadAll(request.Body)
-
-		// close the request body, so it can be re-read later by another player in the chain
-		_ = request.Body.Close()
-		request.Body = io.NopCloser(bytes.NewBuffer(requestBody))
-
-	}
+	requestBody := readRequestBody(request)
 
 	var decodedObj interface{}
 
@@ -52,27 +39,118 @@ func ValidateRequestSchema(
 
 		if err != nil {
 			// cannot decode the request body, so it's not valid
-			violation := &errors.SchemaValidationFailure{
-				Reason:          err.Error(),
-				Location:        "unavailable",
-				ReferenceSchema: string(renderedSchema),
-				ReferenceObject: string(requestBody),
-			}
-			validationErrors = append(validationErrors, &errors.ValidationError{
-				ValidationType:    helpers.RequestBodyValidation,
-				ValidationSubType: helpers.Schema,
-				Message: fmt.Sprintf("%s request body for '%s' failed to validate schema",
-					request.Method, request.URL.Path),
-				Reason:                 fmt.Sprintf("The request body cannot be decoded: %s", err.Error()),
-				SpecLine:               1,
-				SpecCol:                0,
-				SchemaValidationErrors: []*errors.SchemaValidationFailure{violation},
-				HowToFix:               errors.HowToFixInvalidSchema,
-				Context:                string(renderedSchema), // attach the rendered schema to the error
-			})
+			validationErrors = append(validationErrors,
+				requestBodyDecodingError(request, err, renderedSchema, requestBody))
 			return false, validationErrors
 		}
 	}
+	return validateDecodedRequestBody(request, schema, renderedSchema, jsonSchema, requestBody, decodedObj,
+		config.NewValidationOptions(opts...))
+}
+
+// ValidateRequestSchemaStream will validate a http.Request pointer against a schema, decoding the JSON body with a
+// streaming decoder as it's read from the request, rather than reading the whole body before decoding it. What has
+// been read is kept, so the body can be re-read later by another player in the chain. No more than the maximum
+// streamed body size of the options is read, a body that is larger fails validation, and the rest of it is left unread
+// behind what has been kept.
+// If validation fails, it will return a list of validation errors as the second return value.
+func ValidateRequestSchemaStream(
+	request *http.Request,
+	schema *base.Schema,
+	renderedSchema,
+	jsonSchema []byte,
+	opts ...config.Option) (bool, []*errors.ValidationError) {
+
+	options := config.NewValidationOptions(opts...)
+	if request == nil || request.Body == nil {
+		return validateDecodedRequestBody(request, schema, renderedSchema, jsonSchema, nil, nil, options)
+	}
+
+	limit := options.MaxStreamedBodySize
+	if limit <= 0 {
+		limit = config.DefaultMaxStreamedBodySize
+	}
+	var buffered bytes.Buffer
+	decoder := json.NewDecoder(io.TeeReader(io.LimitReader(request.Body, limit+1), &buffered))
+
+	var decodedObj interface{}
+	decodeErr := decoder.Decode(&decodedObj)
+	if decodeErr == io.EOF {
+		decodeErr = nil // the body is empty
+	} else if decodeErr == nil {
+		// there can only be a single JSON value in the body.
+		if _, err := decoder.Token(); err != io.EOF {
+			decodeErr = err
+			if decodeErr == nil {
+				decodeErr = fmt.Errorf("invalid character after top-level value")
+			}
+		}
+	}
+
+	// put what has been read back in front of anything that has not been read, so the body is intact.
+	requestBody := buffered.Bytes()
+	request.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(requestBody), request.Body), request.Body}
+
+	if int64(len(requestBody)) > limit {
+		return false, []*errors.ValidationError{errors.RequestBodyTooLarge(request, limit)}
+	}
+	if decodeErr != nil {
+		// cannot decode the request body, so it's not valid
+		return false, []*errors.ValidationError{requestBodyDecodingError(request, decodeErr, renderedSchema, requestBody)}
+	}
+	return validateDecodedRequestBody(request, schema, renderedSchema, jsonSchema, requestBody, decodedObj, options)
+}
+
+// readRequestBody will read the body of a request, then close it and replace it with a copy of what was read, so
+// the body can be re-read later by another player in the chain.
+func readRequestBody(request *http.Request) []byte {
+	if request == nil || request.Body == nil {
+		return nil
+	}
+	requestBody, _ := io.ReadAll(request.Body)
+	_ = request.Body.Close()
+	request.Body = io.NopCloser(bytes.NewReader(requestBody))
+	return requestBody
+}
+
+// requestBodyDecodingError will create a validation error for a request body that cannot be decoded.
+func requestBodyDecodingError(request *http.Request, err error, renderedSchema, requestBody []byte) *errors.ValidationError {
+	violation := &errors.SchemaValidationFailure{
+		Reason:          err.Error(),
+		Location:        "unavailable",
+		ReferenceSchema: string(renderedSchema),
+		ReferenceObject: string(requestBody),
+	}
+	return &errors.ValidationError{
+		ValidationType:    helpers.RequestBodyValidation,
+		ValidationSubType: helpers.Schema,
+		Message: fmt.Sprintf("%s request body for '%s' failed to validate schema",
+			request.Method, request.URL.Path),
+		Reason:                 fmt.Sprintf("The request body cannot be decoded: %s", err.Error()),
+		SpecLine:               1,
+		SpecCol:                0,
+		SchemaValidationErrors: []*errors.SchemaValidationFailure{violation},
+		HowToFix:               errors.HowToFixInvalidSchema,
+		Context:                string(renderedSchema), // attach the rendered schema to the error
+	}
+}
+
+// validateDecodedRequestBody will validate a request body that has already been decoded, against a schema.
+// The raw request body is used to check if the body is empty, and as the reference object for any violations.
+// The options are used to configure the schema compiler, such as any custom formats.
+func validateDecodedRequestBody(
+	request *http.Request,
+	schema *base.Schema,
+	renderedSchema,
+	jsonSchema,
+	requestBody []byte,
+	decodedObj interface{},
+	options *config.ValidationOptions) (bool, []*errors.ValidationError) {
+
+	var validationErrors []*errors.ValidationError
 
 	// no request body? but we do have a schema?
 	if len(requestBody) <= 0 && len(jsonSchema) > 0 {
@@ -108,11 +186,32 @@ func ValidateRequestSchema(
 		return false, validationErrors
 	}
 
-	compiler := jsonschema.NewCompiler()
-	compiler.UseLoader(helpers.NewCompilerLoader())
-	decodedSchema, _ := jsonschema.UnmarshalJSON(strings.NewReader(string(jsonSchema)))
-	_ = compiler.AddResource("requestBody.json", decodedSchema)
-	jsch, err := compiler.Compile("requestBody.json")
+	// if the schema uses a discriminator, validate against the schema it selects, so any violations are
+	// reported against that schema, rather than as a generic oneOf / anyOf failure.
+	if selected, value, ok := helpers.ResolveDiscriminator(schema, decodedObj); ok {
+		if selected == nil {
+			validationErrors = append(validationErrors, errors.UnknownDiscriminatorValue(schema, value,
+				fmt.Sprintf("%s request body", request.Method), helpers.RequestBodyValidation))
+			return false, validationErrors
+		}
+		schema = selected
+		renderedSchema, _ = helpers.RenderSchemaInline(selected)
+		jsonSchema, _ = utils.ConvertYAMLtoJSON(renderedSchema)
+	}
+
+	// compile the schema, or use the compiled schema from the cache if it has been compiled before.
+	jsch, decodedSchema, err := helpers.CompileSchema("requestBody", schema, jsonSchema,
+		helpers.RequestBodyValidation, options)
+	if patternErr, ok := err.(*helpers.InvalidPatternError); ok {
+		validationErrors = append(validationErrors, errors.InvalidSchemaPattern(patternErr, schema,
+			fmt.Sprintf("%s request body", request.Method), helpers.RequestBodyValidation))
+		return false, validationErrors
+	}
+	if formatErr, ok := err.(*helpers.UnknownFormatError); ok {
+		validationErrors = append(validationErrors, errors.UnknownSchemaFormat(formatErr, schema,
+			fmt.Sprintf("%s request body", request.Method), helpers.RequestBodyValidation))
+		return false, validationErrors
+	}
 	if err != nil {
 		validationErrors = append(validationErrors, &errors.ValidationError{
 			ValidationType:    helpers.RequestBodyValidation,
@@ -124,76 +223,33 @@ func ValidateRequestSchema(
 		return false, validationErrors
 	}
 
+	// fill in any missing properties that have a default, so the defaults are validated too. A JSON body is replaced
+	// with the body that includes the defaults, so it's the augmented body that is read by the next player in the chain.
+	if options.ApplyDefaults {
+		if withDefaults, applied := helpers.ApplyDefaults(decodedSchema, decodedObj); applied {
+			decodedObj = withDefaults
+			contentType, _, _ := helpers.ExtractContentType(request.Header.Get(helpers.ContentTypeHeader))
+			if helpers.IsJSONContentType(contentType) {
+				if encoded, encodeErr := json.Marshal(decodedObj); encodeErr == nil {
+					requestBody = encoded
+					request.Body = io.NopCloser(bytes.NewReader(encoded))
+					request.ContentLength = int64(len(encoded))
+				}
+			}
+		}
+	}
+
 	// validate the object against the schema
 	scErrs := jsch.Validate(decodedObj)
 	if scErrs != nil {
 
 		jk := scErrs.(*jsonschema.ValidationError)
 
-		// flatten the validationErrors
-		schFlatErrs := jk.BasicOutput().Errors
-		var schemaValidationErrors []*errors.SchemaValidationFailure
-		for q := range schFlatErrs {
-			er := schFlatErrs[q]
-
-			errMsg := er.Error.Kind.LocalizedString(message.NewPrinter(language.Tag{}))
-
-			if er.KeywordLocation == "" || helpers.IgnoreRegex.MatchString(errMsg) {
-				continue // ignore this error, it's useless tbh, utter noise.
-			}
-			if er.Error != nil {
-
-				// re-encode the schema.
-				var renderedNode yaml.Node
-				_ = yaml.Unmarshal(renderedSchema, &renderedNode)
-
-				// locate the violated property in the schema
-				located := schema_validation.LocateSchemaPropertyNodeByJSONPath(renderedNode.Content[0], er.KeywordLocation)
-
-				// extract the element specified by the instance
-				val := instanceLocationRegex.FindStringSubmatch(er.InstanceLocation)
-				var referenceObject string
-
-				if len(val) > 0 {
-					referenceIndex, _ := strconv.Atoi(val[1])
-					if reflect.ValueOf(decodedObj).Type().Kind() == reflect.Slice {
-						found := decodedObj.([]any)[referenceIndex]
-						recoded, _ := json.MarshalIndent(found, "", "  ")
-						referenceObject = string(recoded)
-					}
-				}
-				if referenceObject == "" {
-					referenceObject = string(requestBody)
-				}
-
-				errMsg := er.Error.Kind.LocalizedString(message.NewPrinter(language.Tag{}))
+		schemaValidationErrors := errors.SchemaFailures(jk, decodedSchema,
+			schema_validation.DescribeSchemaFailure(renderedSchema, decodedObj, requestBody))
 
-				violation := &errors.SchemaValidationFailure{
-					Reason:          errMsg,
-					Location:        er.KeywordLocation,
-					ReferenceSchema: string(renderedSchema),
-					ReferenceObject: referenceObject,
-					OriginalError:   jk,
-				}
-				// if we have a location within the schema, add it to the error
-				if located != nil {
-
-					line := located.Line
-					// if the located node is a map or an array, then the actual human interpretable
-					// line on which the violation occurred is the line of the key, not the value.
-					if located.Kind == yaml.MappingNode || located.Kind == yaml.SequenceNode {
-						if line > 0 {
-							line--
-						}
-					}
-
-					// location of the violation within the rendered schema.
-					violation.Line = line
-					violation.Column = located.Column
-				}
-				schemaValidationErrors = append(schemaValidationErrors, violation)
-			}
-		}
+		// locate each of the values that failed within the body, so they can be highlighted.
+		errors.PopulateInstanceLines(schemaValidationErrors, requestBody)
 
 		line := 1
 		col := 0
@@ -217,6 +273,15 @@ func ValidateRequestSchema(
 			Context:                string(renderedSchema), // attach the rendered schema to the error
 		})
 	}
+
+	// readOnly properties should only be returned by the service, so report any that have been sent.
+	if options.StrictReadWriteOnly {
+		for _, pointer := range helpers.FindMarkedProperties(decodedSchema, decodedObj, helpers.ReadOnly) {
+			readOnlyErr := errors.RequestReadOnlyProperty(request, pointer, renderedSchema)
+			errors.PopulateInstanceLines(readOnlyErr.SchemaValidationErrors, requestBody)
+			validationErrors = append(validationErrors, readOnlyErr)
+		}
+	}
 	if len(validationErrors) > 0 {
 		return false, validationErrors
 	}
@@ -0,0 +1,35 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package requests
+
+import (
+	"net/http"
+
+	"github.com/pb33f/libopenapi-validator/config"
+	"github.com/pb33f/libopenapi-validator/errors"
+	"github.com/pb33f/libopenapi-validator/helpers"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// ValidateRequestTextSchema will validate a http.Request pointer with a text body (for example 'text/plain')
+// against a schema. The body is not decoded, the raw body is validated as a single value, so constraints such as
+// 'pattern', 'maxLength' and 'enum' are applied to the whole body. If the schema defines a type other than a
+// string, the body is coerced into that type first.
+// If validation fails, it will return a list of validation errors as the second return value.
+func ValidateRequestTextSchema(
+	request *http.Request,
+	schema *base.Schema,
+	renderedSchema,
+	jsonSchema []byte,
+	opts ...config.Option) (bool, []*errors.ValidationError) {
+
+	requestBody := readRequestBody(request)
+
+	var decodedObj interface{}
+	if len(requestBody) > 0 {
+		decodedObj = helpers.CoerceValue(string(requestBody), schema)
+	}
+	return validateDecodedRequestBody(request, schema, renderedSchema, jsonSchema, requestBody, decodedObj,
+		config.NewValidationOptions(opts...))
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package requests
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/stretchr/testify/assert"
+)
+
+var textSpec = `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}/name:
+    put:
+      requestBody:
+        required: true
+        content:
+          text/plain:
+            schema:
+              type: string
+              pattern: '^[A-Z][a-z]+( [A-Z][a-z]+)*$'
+              maxLength: 20
+  /burgers/{burgerId}/patties:
+    put:
+      requestBody:
+        content:
+          text/plain:
+            schema:
+              type: integer
+              maximum: 4
+  /burgers/{burgerId}/notes:
+    put:
+      requestBody:
+        content:
+          text/plain:
+            schema:
+              type: object`
+
+func TestValidateBody_TextPlain(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(textSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPut, "https://things.com/burgers/1/name",
+		strings.NewReader("Big Mac"))
+	request.Header.Set("Content-Type", "text/plain; charset=utf-8")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
+
+func TestValidateBody_TextPlain_Invalid(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(textSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	// the body is validated as a string, it's not decoded as JSON.
+	request, _ := http.NewRequest(http.MethodPut, "https://things.com/burgers/1/name",
+		strings.NewReader(`"big mac"`))
+	request.Header.Set("Content-Type", "text/plain")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "/pattern", errors[0].SchemaValidationErrors[0].Location)
+
+	request, _ = http.NewRequest(http.MethodPut, "https://things.com/burgers/1/name",
+		strings.NewReader("The Biggest Mac In The World"))
+	request.Header.Set("Content-Type", "text/plain")
+
+	valid, errors = v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "maxLength: got 28, want 20", errors[0].SchemaValidationErrors[0].Reason)
+}
+
+func TestValidateBody_TextPlain_Integer(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(textSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPut, "https://things.com/burgers/1/patties",
+		strings.NewReader("2"))
+	request.Header.Set("Content-Type", "text/plain")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	request, _ = http.NewRequest(http.MethodPut, "https://things.com/burgers/1/patties",
+		strings.NewReader("5"))
+	request.Header.Set("Content-Type", "text/plain")
+
+	valid, errors = v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "maximum: got 5, want 4", errors[0].SchemaValidationErrors[0].Reason)
+}
+
+func TestValidateBody_TextPlain_ObjectSchemaSkipped(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(textSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPut, "https://things.com/burgers/1/notes",
+		strings.NewReader("extra pickles"))
+	request.Header.Set("Content-Type", "text/plain")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+}
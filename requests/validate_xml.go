@@ -0,0 +1,39 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package requests
+
+import (
+	"net/http"
+
+	"github.com/pb33f/libopenapi-validator/config"
+	"github.com/pb33f/libopenapi-validator/errors"
+	"github.com/pb33f/libopenapi-validator/helpers"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// ValidateRequestXMLSchema will validate a http.Request pointer with an XML body against a schema. The body is
+// decoded into an object using the 'xml' objects of the schema, to map elements and attributes to properties,
+// before it's validated.
+// If validation fails, it will return a list of validation errors as the second return value.
+func ValidateRequestXMLSchema(
+	request *http.Request,
+	schema *base.Schema,
+	renderedSchema,
+	jsonSchema []byte,
+	opts ...config.Option) (bool, []*errors.ValidationError) {
+
+	requestBody := readRequestBody(request)
+
+	var decodedObj interface{}
+	if len(requestBody) > 0 {
+		var err error
+		decodedObj, err = helpers.DecodeXML(requestBody, schema)
+		if err != nil {
+			// cannot decode the request body, so it's not valid
+			return false, []*errors.ValidationError{requestBodyDecodingError(request, err, renderedSchema, requestBody)}
+		}
+	}
+	return validateDecodedRequestBody(request, schema, renderedSchema, jsonSchema, requestBody, decodedObj,
+		config.NewValidationOptions(opts...))
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package requests
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/stretchr/testify/assert"
+)
+
+var xmlSpec = `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/xml:
+            schema:
+              type: object
+              xml:
+                name: burger
+              required: [name, patties]
+              properties:
+                id:
+                  type: integer
+                  xml:
+                    attribute: true
+                name:
+                  type: string
+                patties:
+                  type: integer
+                toppings:
+                  type: array
+                  xml:
+                    wrapped: true
+                  items:
+                    type: string
+                    xml:
+                      name: topping`
+
+func TestValidateBody_XML(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(xmlSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	body := `<burger id="12"><name>Big Mac</name><patties>2</patties>` +
+		`<toppings><topping>pickles</topping><topping>onions</topping></toppings></burger>`
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/xml")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	// the body can be re-read after validation.
+	reread, _ := io.ReadAll(request.Body)
+	assert.Equal(t, body, string(reread))
+}
+
+func TestValidateBody_XML_MissingRequiredElement(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(xmlSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	body := `<burger id="12"><name>Big Mac</name></burger>`
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/xml; charset=utf-8")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "missing property 'patties'", errors[0].SchemaValidationErrors[0].Reason)
+}
+
+func TestValidateBody_XML_InvalidType(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(xmlSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	body := `<burger id="twelve"><name>Big Mac</name><patties>2</patties></burger>`
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/xml")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "got string, want integer", errors[0].SchemaValidationErrors[0].Reason)
+}
+
+func TestValidateBody_XML_Malformed(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(xmlSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+		strings.NewReader(`<burger><name>Big Mac</burger>`))
+	request.Header.Set("Content-Type", "application/xml")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Contains(t, errors[0].Reason, "The request body cannot be decoded")
+}
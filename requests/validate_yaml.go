@@ -0,0 +1,34 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package requests
+
+import (
+	"net/http"
+
+	"github.com/pb33f/libopenapi-validator/config"
+	"github.com/pb33f/libopenapi-validator/errors"
+	"github.com/pb33f/libopenapi-validator/helpers"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// ValidateRequestYAMLSchema will validate a http.Request pointer with a YAML body against a schema. The body is
+// decoded into the same structure a JSON body would be, and then validated in the same way.
+// If validation fails, it will return a list of validation errors as the second return value.
+func ValidateRequestYAMLSchema(
+	request *http.Request,
+	schema *base.Schema,
+	renderedSchema,
+	jsonSchema []byte,
+	opts ...config.Option) (bool, []*errors.ValidationError) {
+
+	requestBody := readRequestBody(request)
+
+	decodedObj, err := helpers.DecodeYAML(requestBody)
+	if err != nil {
+		// cannot decode the request body, so it's not valid
+		return false, []*errors.ValidationError{requestBodyDecodingError(request, err, renderedSchema, requestBody)}
+	}
+	return validateDecodedRequestBody(request, schema, renderedSchema, jsonSchema, requestBody, decodedObj,
+		config.NewValidationOptions(opts...))
+}
@@ -0,0 +1,129 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package requests
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/stretchr/testify/assert"
+)
+
+var yamlSpec = `openapi: 3.1.0
+paths:
+  /config:
+    put:
+      requestBody:
+        required: true
+        content:
+          application/yaml:
+            schema:
+              type: object
+              required: [name, replicas]
+              properties:
+                name:
+                  type: string
+                replicas:
+                  type: integer
+                  minimum: 1
+                created:
+                  type: string
+                  format: date
+                labels:
+                  type: object
+                  additionalProperties:
+                    type: string`
+
+func TestValidateBody_YAML(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(yamlSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	body := `name: burger-service
+replicas: 2
+created: 2024-01-31
+labels: &labels
+  team: kitchen
+`
+
+	request, _ := http.NewRequest(http.MethodPut, "https://things.com/config", strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/yaml")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	// the body can be re-read after validation.
+	reread, _ := io.ReadAll(request.Body)
+	assert.Equal(t, body, string(reread))
+}
+
+func TestValidateBody_YAML_Invalid(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(yamlSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPut, "https://things.com/config",
+		strings.NewReader("name: burger-service\nreplicas: 0\n"))
+	request.Header.Set("Content-Type", "application/yaml")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "minimum: got 0, want 1", errors[0].SchemaValidationErrors[0].Reason)
+}
+
+func TestValidateBody_YAML_DuplicateKey(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(yamlSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPut, "https://things.com/config",
+		strings.NewReader("name: burger-service\nreplicas: 2\nreplicas: 3\n"))
+	request.Header.Set("Content-Type", "application/yaml; charset=utf-8")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Contains(t, errors[0].Reason, `mapping key "replicas" already defined at line 2`)
+}
+
+func TestValidateBody_YAML_Empty(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(yamlSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodPut, "https://things.com/config", strings.NewReader(""))
+	request.Header.Set("Content-Type", "application/yaml")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "PUT request body is missing for '/config'", errors[0].Message)
+}
+
+func TestValidateBody_YAML_JSONBody(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(yamlSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewRequestBodyValidator(&m.Model)
+
+	// JSON is also valid YAML, so it's decoded and validated in the same way.
+	request, _ := http.NewRequest(http.MethodPut, "https://things.com/config",
+		strings.NewReader(`{"name": "burger-service", "replicas": "two"}`))
+	request.Header.Set("Content-Type", "application/yaml")
+
+	valid, errors := v.ValidateRequestBody(request)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "got string, want integer", errors[0].SchemaValidationErrors[0].Reason)
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"sync"
 
+	"github.com/pb33f/libopenapi-validator/config"
 	"github.com/pb33f/libopenapi-validator/errors"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
@@ -19,19 +20,20 @@ import (
 type ResponseBodyValidator interface {
 
 	// ValidateResponseBody will validate the response body for a http.Response pointer. The request is used to
-	// locate the operation in the specification, the response is used to ensure the response code, media type and the
-	// schema of the response body are valid.
+	// locate the operation in the specification, the response is used to ensure the response code, media type, the
+	// declared headers and the schema of the response body are valid.
 	ValidateResponseBody(request *http.Request, response *http.Response) (bool, []*errors.ValidationError)
 
 	// ValidateResponseBodyWithPathItem will validate the response body for a http.Response pointer. The request is used to
-	// locate the operation in the specification, the response is used to ensure the response code, media type and the
-	// schema of the response body are valid.
+	// locate the operation in the specification, the response is used to ensure the response code, media type, the
+	// declared headers and the schema of the response body are valid.
 	ValidateResponseBodyWithPathItem(request *http.Request, response *http.Response, pathItem *v3.PathItem, pathFound string) (bool, []*errors.ValidationError)
 }
 
-// NewResponseBodyValidator will create a new ResponseBodyValidator from an OpenAPI 3+ document
-func NewResponseBodyValidator(document *v3.Document) ResponseBodyValidator {
-	return &responseBodyValidator{document: document, schemaCache: &sync.Map{}}
+// NewResponseBodyValidator will create a new ResponseBodyValidator from an OpenAPI 3+ document. Options can be
+// supplied to change the behavior of the validator, by default no options are enabled.
+func NewResponseBodyValidator(document *v3.Document, opts ...config.Option) ResponseBodyValidator {
+	return &responseBodyValidator{document: document, schemaCache: &sync.Map{}, options: config.NewValidationOptions(opts...)}
 }
 
 type schemaCache struct {
@@ -43,4 +45,5 @@ type schemaCache struct {
 type responseBodyValidator struct {
 	document    *v3.Document
 	schemaCache *sync.Map
+	options     *config.ValidationOptions
 }
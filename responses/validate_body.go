@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/pb33f/libopenapi-validator/config"
 	"github.com/pb33f/libopenapi-validator/errors"
 	"github.com/pb33f/libopenapi-validator/helpers"
 	"github.com/pb33f/libopenapi-validator/paths"
@@ -51,62 +52,47 @@ func (v *responseBodyValidator) ValidateResponseBodyWithPathItem(request *http.R
 	// extract the response code from the response
 	httpCode := response.StatusCode
 	contentType := response.Header.Get(helpers.ContentTypeHeader)
-	codeStr := strconv.Itoa(httpCode)
 
 	// extract the media type from the content type header.
 	mediaTypeSting, _, _ := helpers.ExtractContentType(contentType)
 
-	// check if the response code is in the contract
-	foundResponse := operation.Responses.Codes.GetOrZero(codeStr)
+	// check if the response code is in the contract, an exact match is preferred over a range, and then the default.
+	foundResponse, codeStr, isDefault := findResponse(operation.Responses, httpCode)
 	if foundResponse == nil {
-		// check range definition for response codes
-		foundResponse = operation.Responses.Codes.GetOrZero(fmt.Sprintf("%dXX", httpCode/100))
-		if foundResponse != nil {
-			codeStr = fmt.Sprintf("%dXX", httpCode/100)
+		// no default, no code match, nothing!
+		validationErrors = append(validationErrors,
+			errors.ResponseCodeNotFound(operation, request, httpCode))
+	} else {
+		// validate any headers declared for the response.
+		if foundResponse.Headers != nil {
+			_, headerErrors := ValidateResponseHeaders(request, response, foundResponse.Headers, codeStr,
+				config.WithExistingOpts(v.options))
+			validationErrors = append(validationErrors, headerErrors...)
 		}
-	}
 
-	if foundResponse != nil {
-		if foundResponse.Content != nil { // only validate if we have content types.
-			// check content type has been defined in the contract
-			if mediaType, ok := foundResponse.Content.Get(mediaTypeSting); ok {
-				validationErrors = append(validationErrors,
-					v.checkResponseSchema(request, response, mediaTypeSting, mediaType)...)
-			} else {
-				// check that the operation *actually* returns a body. (i.e. a 204 response)
-				if foundResponse.Content != nil && orderedmap.Len(foundResponse.Content) > 0 {
-
-					// content type not found in the contract
-					validationErrors = append(validationErrors,
-						errors.ResponseContentTypeNotFound(operation, request, response, codeStr, false))
-
-				}
-			}
-		}
-	} else {
-		// no code match, check for default response
-		if operation.Responses.Default != nil && operation.Responses.Default.Content != nil {
-			// check content type has been defined in the contract
-			if mediaType, ok := operation.Responses.Default.Content.Get(mediaTypeSting); ok {
-				validationErrors = append(validationErrors,
-					v.checkResponseSchema(request, response, contentType, mediaType)...)
+		// the body is not validated once the headers have failed, when failing fast.
+		failed := v.options.FailFast && len(validationErrors) > 0
+		if foundResponse.Content != nil && !failed { // only validate if we have content types.
+			// check content type has been defined in the contract, wildcard media ranges are also matched.
+			if mediaRange, mediaType, ok := helpers.FindMediaTypeKey(foundResponse.Content, contentType); ok {
+				schemaErrors := v.checkResponseSchema(request, response, mediaTypeSting, mediaType)
+				errors.PopulateMediaType(schemaErrors, mediaRange)
+				validationErrors = append(validationErrors, schemaErrors...)
 			} else {
 				// check that the operation *actually* returns a body. (i.e. a 204 response)
-				if operation.Responses.Default.Content != nil && orderedmap.Len(operation.Responses.Default.Content) > 0 {
+				if orderedmap.Len(foundResponse.Content) > 0 {
 
 					// content type not found in the contract
 					validationErrors = append(validationErrors,
-						errors.ResponseContentTypeNotFound(operation, request, response, codeStr, true))
+						errors.ResponseContentTypeNotFound(operation, request, response, codeStr, isDefault))
 				}
 			}
-		} else {
-			// TODO: add support for '2XX' and '3XX' responses in the contract
-			// no default, no code match, nothing!
-			validationErrors = append(validationErrors,
-				errors.ResponseCodeNotFound(operation, request, httpCode))
 		}
 	}
 
+	if v.options.FailFast && len(validationErrors) > 1 {
+		validationErrors = validationErrors[:1]
+	}
 	errors.PopulateValidationErrors(validationErrors, request, pathFound)
 
 	if len(validationErrors) > 0 {
@@ -115,6 +101,31 @@ func (v *responseBodyValidator) ValidateResponseBodyWithPathItem(request *http.R
 	return true, nil
 }
 
+// findResponse will find the response declared for a status code. An exact match of the code is preferred, followed
+// by a range (for example '2XX'), and then the default response. The code the response was found for is returned,
+// along with a flag that is true if the default response was used.
+func findResponse(responses *v3.Responses, httpCode int) (*v3.Response, string, bool) {
+	codeStr := strconv.Itoa(httpCode)
+	if responses == nil {
+		return nil, codeStr, false
+	}
+	if found := responses.Codes.GetOrZero(codeStr); found != nil {
+		return found, codeStr, false
+	}
+
+	// check range definition for response codes, the 'X' is case-insensitive.
+	rangeStr := fmt.Sprintf("%dXX", httpCode/100)
+	for pair := orderedmap.First(responses.Codes); pair != nil; pair = pair.Next() {
+		if strings.EqualFold(pair.Key(), rangeStr) {
+			return pair.Value(), pair.Key(), false
+		}
+	}
+	if responses.Default != nil {
+		return responses.Default, codeStr, true
+	}
+	return nil, codeStr, false
+}
+
 func (v *responseBodyValidator) checkResponseSchema(
 	request *http.Request,
 	response *http.Response,
@@ -123,10 +134,13 @@ func (v *responseBodyValidator) checkResponseSchema(
 ) []*errors.ValidationError {
 	var validationErrors []*errors.ValidationError
 
-	// currently, we can only validate JSON based responses, so check for the presence
-	// of 'json' in the content type (what ever it may be) so we can perform a schema check on it.
-	// anything other than JSON, will be ignored.
-	if strings.Contains(strings.ToLower(contentType), helpers.JSONType) {
+	// currently, we can only validate JSON, XML and text based responses, so check the content type is JSON or
+	// XML (including structured suffixes such as '+json') so we can perform a schema check on it, any other
+	// 'text/' content type is validated as a string. anything else will be ignored.
+	isJSON := helpers.IsJSONContentType(contentType)
+	isXML := helpers.IsXMLContentType(contentType)
+	isText := !isJSON && !isXML && strings.HasPrefix(strings.ToLower(contentType), helpers.TextType)
+	if isJSON || isXML || isText {
 		// extract schema from media type
 		if mediaType.Schema != nil {
 
@@ -147,7 +161,7 @@ func (v *responseBodyValidator) checkResponseSchema(
 				// render the schema inline and perform the intensive work of rendering and converting
 				// this is only performed once per schema and cached in the validator.
 				schema = mediaType.Schema.Schema()
-				renderedInline, _ = schema.RenderInline()
+				renderedInline, _ = helpers.RenderSchemaInline(schema)
 				renderedJSON, _ = utils.ConvertYAMLtoJSON(renderedInline)
 				v.schemaCache.Store(hash, &schemaCache{
 					schema:         schema,
@@ -157,7 +171,19 @@ func (v *responseBodyValidator) checkResponseSchema(
 			}
 
 			// render the schema, to be used for validation
-			valid, vErrs := ValidateResponseSchema(request, response, schema, renderedInline, renderedJSON)
+			validate := ValidateResponseSchema
+			switch {
+			case isXML:
+				validate = ValidateResponseXMLSchema
+			case isText:
+				// a text body can only be validated against a schema for a single value, such as a string.
+				if !helpers.IsScalarSchema(schema) {
+					return validationErrors
+				}
+				validate = ValidateResponseTextSchema
+			}
+			valid, vErrs := validate(request, response, schema, renderedInline, renderedJSON,
+				config.WithExistingOpts(v.options))
 			if !valid {
 				validationErrors = append(validationErrors, vErrs...)
 			}
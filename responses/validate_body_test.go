@@ -8,11 +8,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi-validator/config"
+	liberrors "github.com/pb33f/libopenapi-validator/errors"
 	"github.com/pb33f/libopenapi-validator/helpers"
 	"github.com/pb33f/libopenapi-validator/paths"
 	"github.com/stretchr/testify/assert"
@@ -855,8 +858,9 @@ components:
 
 	assert.False(t, valid)
 	assert.Len(t, errors, 1)
-	assert.Len(t, errors[0].SchemaValidationErrors, 2)
-	assert.Equal(t, "got number, want boolean", errors[0].SchemaValidationErrors[1].Reason)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors[0].Branches, 2)
+	assert.Equal(t, "got number, want boolean", errors[0].SchemaValidationErrors[0].Branches[1].Reason)
 }
 
 func TestValidateBody_ValidBasicSchema(t *testing.T) {
@@ -1312,3 +1316,480 @@ func (er *errorReader) Read(p []byte) (n int, err error) {
 func (er *errorReader) Close() error {
 	return nil
 }
+
+func TestValidateBody_ResponseContentType(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  name:
+                    type: string
+        '201':
+          content:
+            application/*:
+              schema:
+                type: object
+                properties:
+                  name:
+                    type: string
+        default:
+          content:
+            '*/*':
+              schema:
+                type: object
+                properties:
+                  name:
+                    type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewResponseBodyValidator(&m.Model)
+
+	for name, tc := range map[string]struct {
+		code        int
+		contentType string
+		body        string
+		valid       bool
+		message     string
+	}{
+		"Matching": {
+			code: http.StatusOK, contentType: "application/json; charset=utf-8", body: `{"name":"Big Mac"}`, valid: true,
+		},
+		"Mismatched": {
+			code: http.StatusOK, contentType: "text/plain", body: `Big Mac`,
+			message: "POST / 200 operation response content type 'text/plain' does not exist",
+		},
+		"WildcardSubType": {
+			code: http.StatusCreated, contentType: "application/vnd.burger+json", body: `{"name":"Big Mac"}`, valid: true,
+		},
+		"WildcardSubTypeInvalidBody": {
+			code: http.StatusCreated, contentType: "application/vnd.burger+json", body: `{"name":123}`,
+			message: "201 response body for '/burgers/createBurger' failed to validate schema",
+		},
+		"WildcardSubTypeMismatched": {
+			code: http.StatusCreated, contentType: "text/plain", body: `Big Mac`,
+			message: "POST / 201 operation response content type 'text/plain' does not exist",
+		},
+		"DefaultWildcard": {
+			code: http.StatusTeapot, contentType: "text/plain", body: `Big Mac`, valid: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger", nil)
+
+			res := httptest.NewRecorder()
+			res.Header().Set(helpers.ContentTypeHeader, tc.contentType)
+			res.WriteHeader(tc.code)
+			_, _ = res.Write([]byte(tc.body))
+
+			valid, errors := v.ValidateResponseBody(request, res.Result())
+
+			assert.Equal(t, tc.valid, valid)
+			if tc.valid {
+				assert.Len(t, errors, 0)
+				return
+			}
+			assert.Len(t, errors, 1)
+			assert.Equal(t, tc.message, errors[0].Message)
+		})
+	}
+}
+
+func TestValidateBody_ResponseStatusCode(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [name]
+        2XX:
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [id]
+        4xx:
+          description: client error
+  /burgers/deleteBurger:
+    post:
+      responses:
+        '204':
+          description: deleted
+        default:
+          description: unexpected error
+  /burgers/updateBurger:
+    post:
+      responses:
+        '200':
+          description: updated`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewResponseBodyValidator(&m.Model)
+
+	for name, tc := range map[string]struct {
+		path    string
+		code    int
+		body    string
+		valid   bool
+		message string
+	}{
+		"Exact": {
+			path: "/burgers/createBurger", code: http.StatusOK, body: `{"name":"Big Mac"}`, valid: true,
+		},
+		"ExactPreferredOverRange": {
+			path: "/burgers/createBurger", code: http.StatusOK, body: `{"id":1}`,
+			message: "200 response body for '/burgers/createBurger' failed to validate schema",
+		},
+		"Range": {
+			path: "/burgers/createBurger", code: http.StatusCreated, body: `{"id":1}`, valid: true,
+		},
+		"LowerCaseRange": {
+			path: "/burgers/createBurger", code: http.StatusNotFound, valid: true,
+		},
+		"Unmatched": {
+			path: "/burgers/createBurger", code: http.StatusInternalServerError,
+			message: "POST operation request response code '500' does not exist",
+		},
+		"Default": {
+			path: "/burgers/deleteBurger", code: http.StatusInternalServerError, valid: true,
+		},
+		"UnmatchedWithoutDefault": {
+			path: "/burgers/updateBurger", code: http.StatusNotFound,
+			message: "POST operation request response code '404' does not exist",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			request, _ := http.NewRequest(http.MethodPost, "https://things.com"+tc.path, nil)
+
+			res := httptest.NewRecorder()
+			res.Header().Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+			res.WriteHeader(tc.code)
+			_, _ = res.Write([]byte(tc.body))
+
+			valid, errors := v.ValidateResponseBody(request, res.Result())
+
+			assert.Equal(t, tc.valid, valid)
+			if tc.valid {
+				assert.Len(t, errors, 0)
+				return
+			}
+			assert.Len(t, errors, 1)
+			assert.Equal(t, tc.message, errors[0].Message)
+		})
+	}
+}
+
+func TestValidateBody_Discriminator(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /pets/{petId}:
+    get:
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                oneOf:
+                  - $ref: '#/components/schemas/Cat'
+                  - $ref: '#/components/schemas/Dog'
+                discriminator:
+                  propertyName: petType
+components:
+  schemas:
+    Cat:
+      type: object
+      required: [petType, lives]
+      properties:
+        petType:
+          type: string
+        lives:
+          type: integer
+    Dog:
+      type: object
+      required: [petType, barks]
+      properties:
+        petType:
+          type: string
+        barks:
+          type: boolean`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	m, _ := doc.BuildV3Model()
+	v := NewResponseBodyValidator(&m.Model)
+
+	for name, tc := range map[string]struct {
+		body    string
+		valid   bool
+		message string
+		reason  string
+	}{
+		"Valid": {
+			body: `{"petType":"Cat","lives":9}`, valid: true,
+		},
+		"UnknownValue": {
+			body:    `{"petType":"Fish"}`,
+			message: "200 response body discriminator value 'Fish' does not map to a schema",
+		},
+		"VariantInvalid": {
+			body:    `{"petType":"Dog","barks":"woof"}`,
+			message: "200 response body for '/pets/1' failed to validate schema",
+			reason:  "got string, want boolean",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			request, _ := http.NewRequest(http.MethodGet, "https://things.com/pets/1", nil)
+
+			res := httptest.NewRecorder()
+			res.Header().Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+			res.WriteHeader(http.StatusOK)
+			_, _ = res.Write([]byte(tc.body))
+
+			valid, errors := v.ValidateResponseBody(request, res.Result())
+
+			assert.Equal(t, tc.valid, valid)
+			if tc.valid {
+				assert.Len(t, errors, 0)
+				return
+			}
+			assert.Len(t, errors, 1)
+			assert.Equal(t, tc.message, errors[0].Message)
+			if tc.reason != "" {
+				assert.Len(t, errors[0].SchemaValidationErrors, 1)
+				assert.Equal(t, tc.reason, errors[0].SchemaValidationErrors[0].Reason)
+			}
+		})
+	}
+}
+
+func TestValidateBody_WriteOnlyProperties(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [id, password]
+                properties:
+                  id:
+                    type: integer
+                    readOnly: true
+                  password:
+                    type: string
+                    writeOnly: true`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	validate := func(v ResponseBodyValidator, body string) (bool, int, string) {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger", nil)
+		res := httptest.NewRecorder()
+		res.Header().Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+		res.WriteHeader(http.StatusOK)
+		_, _ = res.Write([]byte(body))
+		valid, errs := v.ValidateResponseBody(request, res.Result())
+		if len(errs) == 0 {
+			return valid, 0, ""
+		}
+		return valid, len(errs), errs[0].Message
+	}
+
+	// a writeOnly property is not required in a response, but a readOnly property is.
+	v := NewResponseBodyValidator(&m.Model)
+	valid, count, _ := validate(v, `{"id": 1}`)
+	assert.True(t, valid)
+	assert.Equal(t, 0, count)
+
+	valid, count, msg := validate(v, `{"password": "secret"}`)
+	assert.False(t, valid)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "200 response body for '/burgers/createBurger' failed to validate schema", msg)
+
+	// a writeOnly property can be returned, unless strict.
+	valid, count, _ = validate(v, `{"id": 1, "password": "secret"}`)
+	assert.True(t, valid)
+	assert.Equal(t, 0, count)
+
+	v = NewResponseBodyValidator(&m.Model, config.WithStrictReadWriteOnly())
+	valid, count, msg = validate(v, `{"id": 1, "password": "secret"}`)
+	assert.False(t, valid)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "200 response body for '/burgers/createBurger' contains the write only property '/password'", msg)
+}
+
+func TestValidateBody_DependentRequired(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/pay:
+    get:
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  card:
+                    type: string
+                  cvv:
+                    type: string
+                dependentRequired:
+                  card: [cvv]`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewResponseBodyValidator(&m.Model)
+
+	validate := func(body string) (bool, []*liberrors.ValidationError) {
+		request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/pay", nil)
+		res := httptest.NewRecorder()
+		res.Header().Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+		res.WriteHeader(http.StatusOK)
+		_, _ = res.Write([]byte(body))
+		return v.ValidateResponseBody(request, res.Result())
+	}
+
+	valid, errs := validate(`{"card": "4111111111111111", "cvv": "123"}`)
+	assert.True(t, valid)
+	assert.Len(t, errs, 0)
+
+	valid, errs = validate(`{"card": "4111111111111111"}`)
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "200 response body for '/burgers/pay' failed to validate schema", errs[0].Message)
+	assert.Equal(t, "properties 'cvv' required, if 'card' exists", errs[0].SchemaValidationErrors[0].Reason)
+}
+
+func TestValidateBody_UnsupportedPattern(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  name:
+                    type: string
+                    pattern: '^(?=.*[A-Z]).+$'
+                  patties:
+                    type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	validate := func(v ResponseBodyValidator) (bool, []*liberrors.ValidationError) {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger", nil)
+		res := httptest.NewRecorder()
+		res.Header().Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+		res.WriteHeader(http.StatusOK)
+		_, _ = res.Write([]byte(`{"name": "big mac", "patties": "two"}`))
+		return v.ValidateResponseBody(request, res.Result())
+	}
+
+	// the pattern is reported, rather than failing the whole validation.
+	valid, errs := validate(NewResponseBodyValidator(&m.Model))
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, helpers.InvalidSchemaPattern, errs[0].ValidationSubType)
+	assert.Equal(t, "200 response body schema pattern '^(?=.*[A-Z]).+$' cannot be compiled", errs[0].Message)
+
+	// the pattern is skipped, so the rest of the schema is still validated.
+	valid, errs = validate(NewResponseBodyValidator(&m.Model, config.WithSkipInvalidPatterns()))
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Len(t, errs[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "got string, want integer", errs[0].SchemaValidationErrors[0].Reason)
+}
+
+// trackingBody records whether a response body has been read.
+type trackingBody struct {
+	io.Reader
+	read bool
+}
+
+func (b *trackingBody) Read(p []byte) (int, error) {
+	b.read = true
+	return b.Reader.Read(p)
+}
+
+func (b *trackingBody) Close() error {
+	return nil
+}
+
+func TestValidateBody_FailFast(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      responses:
+        '200':
+          headers:
+            X-Rate-Limit:
+              required: true
+              schema:
+                type: integer
+            X-Burger-Style:
+              schema:
+                type: string
+                enum: [grilled, fried]
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  name:
+                    type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	validate := func(v ResponseBodyValidator) (bool, []*liberrors.ValidationError, *trackingBody) {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger", nil)
+		body := &trackingBody{Reader: bytes.NewBufferString(`{"name": 123}`)}
+		response := &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				helpers.ContentTypeHeader: []string{helpers.JSONContentType},
+				"X-Burger-Style":          []string{"steamed"},
+			},
+			Body: body,
+		}
+		valid, errs := v.ValidateResponseBody(request, response)
+		return valid, errs, body
+	}
+
+	valid, errs, body := validate(NewResponseBodyValidator(&m.Model))
+	assert.False(t, valid)
+	assert.Len(t, errs, 3)
+	assert.True(t, body.read)
+
+	// only the first header error is returned, and the body is not validated.
+	valid, errs, body = validate(NewResponseBodyValidator(&m.Model, config.WithFailFast()))
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "POST / 200 operation response header 'X-Rate-Limit' is missing", errs[0].Message)
+	assert.False(t, body.read)
+}
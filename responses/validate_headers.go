@@ -0,0 +1,93 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package responses
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi-validator/config"
+	"github.com/pb33f/libopenapi-validator/errors"
+	"github.com/pb33f/libopenapi-validator/helpers"
+	"github.com/pb33f/libopenapi-validator/parameters"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// ValidateResponseHeaders will validate the headers of a http.Response pointer against the headers declared for
+// the response in the specification. Required headers must be present, and the value of each header that is
+// present must match the schema of the header.
+// If validation fails, it will return a list of validation errors as the second return value.
+func ValidateResponseHeaders(
+	request *http.Request,
+	response *http.Response,
+	headers *orderedmap.Map[string, *v3.Header],
+	code string,
+	opts ...config.Option) (bool, []*errors.ValidationError) {
+
+	var validationErrors []*errors.ValidationError
+	for pair := orderedmap.First(headers); pair != nil; pair = pair.Next() {
+		name, header := pair.Key(), pair.Value()
+
+		// the content type of a response is described by its content, so a header definition for it is ignored.
+		if header == nil || strings.EqualFold(name, helpers.ContentTypeHeader) {
+			continue
+		}
+		value, found := helpers.FindHeaderValue(response.Header, name)
+		if !found {
+			if header.Required {
+				validationErrors = append(validationErrors,
+					errors.ResponseHeaderMissing(request, name, header, code))
+			}
+			continue
+		}
+		if header.Schema == nil {
+			continue
+		}
+		sch := header.Schema.Schema()
+		if sch == nil {
+			continue
+		}
+		validationErrors = append(validationErrors,
+			parameters.ValidateParameterSchema(sch,
+				decodeResponseHeader(value, sch, header.Explode),
+				"",
+				"Response header",
+				"The response header",
+				name,
+				helpers.ResponseBodyValidation,
+				helpers.ParameterValidationHeader,
+				opts...)...)
+	}
+	if len(validationErrors) > 0 {
+		return false, validationErrors
+	}
+	return true, nil
+}
+
+// decodeResponseHeader will decode the value of a response header into the type defined by its schema. Headers
+// use the 'simple' style, so arrays and objects are encoded as comma separated values.
+func decodeResponseHeader(value string, sch *base.Schema, explode bool) interface{} {
+	switch {
+	case slices.Contains(sch.Type, helpers.Array):
+		var itemsSchema *base.Schema
+		if sch.Items != nil && sch.Items.IsA() {
+			itemsSchema = sch.Items.A.Schema()
+		}
+		items := strings.Split(value, helpers.Comma)
+		decoded := make([]interface{}, len(items))
+		for i := range items {
+			decoded[i] = helpers.CoerceValue(strings.TrimSpace(items[i]), itemsSchema)
+		}
+		return decoded
+	case slices.Contains(sch.Type, helpers.Object):
+		if explode {
+			return helpers.ConstructKVFromCSV(value)
+		}
+		return helpers.ConstructMapFromCSV(value)
+	}
+	return helpers.CoerceValue(strings.TrimSpace(value), sch)
+}
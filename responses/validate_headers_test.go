@@ -0,0 +1,137 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package responses
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi-validator/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+var responseHeadersSpec = `openapi: 3.1.0
+paths:
+  /burgers:
+    get:
+      responses:
+        '200':
+          headers:
+            X-Rate-Limit:
+              required: true
+              schema:
+                type: integer
+            X-Burger-Style:
+              schema:
+                type: string
+                enum: [grilled, fried]
+            X-Request-Id:
+              schema:
+                type: string
+                pattern: '^[a-f0-9]{8}$'
+            X-Burger-Ids:
+              schema:
+                type: array
+                items:
+                  type: integer
+            X-Burger-Code:
+              schema:
+                maxLength: 4
+            Content-Type:
+              required: true
+              schema:
+                type: string
+          content:
+            application/json:
+              schema:
+                type: object`
+
+func validateResponseHeaders(headers map[string]string) (bool, []string) {
+	doc, _ := libopenapi.NewDocument([]byte(responseHeadersSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewResponseBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers", nil)
+
+	res := httptest.NewRecorder()
+	res.Header().Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+	for k, val := range headers {
+		res.Header().Set(k, val)
+	}
+	res.WriteHeader(http.StatusOK)
+	_, _ = res.Write([]byte(`{}`))
+
+	valid, errors := v.ValidateResponseBody(request, res.Result())
+	var messages []string
+	for _, e := range errors {
+		messages = append(messages, e.Message)
+	}
+	return valid, messages
+}
+
+func TestValidateResponseHeaders_Valid(t *testing.T) {
+	valid, messages := validateResponseHeaders(map[string]string{
+		"X-Rate-Limit":   "100",
+		"X-Burger-Style": "grilled",
+		"X-Request-Id":   "0a1b2c3d",
+		"X-Burger-Ids":   "1, 2, 3",
+	})
+
+	assert.True(t, valid)
+	assert.Len(t, messages, 0)
+}
+
+func TestValidateResponseHeaders_MissingRequired(t *testing.T) {
+	valid, messages := validateResponseHeaders(map[string]string{
+		"X-Burger-Style": "fried",
+	})
+
+	assert.False(t, valid)
+	assert.Equal(t, []string{"GET / 200 operation response header 'X-Rate-Limit' is missing"}, messages)
+}
+
+func TestValidateResponseHeaders_WrongType(t *testing.T) {
+	valid, messages := validateResponseHeaders(map[string]string{
+		"X-Rate-Limit": "lots",
+	})
+
+	assert.False(t, valid)
+	assert.Equal(t, []string{"Response header 'X-Rate-Limit' failed to validate"}, messages)
+}
+
+func TestValidateResponseHeaders_EnumPatternAndArray(t *testing.T) {
+	valid, messages := validateResponseHeaders(map[string]string{
+		"X-Rate-Limit":   "100",
+		"X-Burger-Style": "steamed",
+		"X-Request-Id":   "not-an-id",
+		"X-Burger-Ids":   "1,two",
+	})
+
+	assert.False(t, valid)
+	assert.ElementsMatch(t, []string{
+		"Response header 'X-Burger-Style' failed to validate",
+		"Response header 'X-Request-Id' failed to validate",
+		"Response header 'X-Burger-Ids' failed to validate",
+	}, messages)
+}
+
+func TestValidateResponseHeaders_NoType(t *testing.T) {
+	valid, messages := validateResponseHeaders(map[string]string{
+		"X-Rate-Limit":  "100",
+		"X-Burger-Code": "B1",
+	})
+
+	assert.True(t, valid)
+	assert.Len(t, messages, 0)
+
+	valid, messages = validateResponseHeaders(map[string]string{
+		"X-Rate-Limit":  "100",
+		"X-Burger-Code": "BIG-MAC",
+	})
+
+	assert.False(t, valid)
+	assert.Equal(t, []string{"Response header 'X-Burger-Code' failed to validate"}, messages)
+}
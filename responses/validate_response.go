@@ -7,38 +7,78 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"github.com/pb33f/libopenapi-validator/config"
 	"github.com/pb33f/libopenapi-validator/errors"
 	"github.com/pb33f/libopenapi-validator/helpers"
 	"github.com/pb33f/libopenapi-validator/schema_validation"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/utils"
 	"github.com/santhosh-tekuri/jsonschema/v6"
-	"golang.org/x/text/language"
-	"golang.org/x/text/message"
-	"gopkg.in/yaml.v3"
 	"io"
 	"net/http"
-	"reflect"
-	"regexp"
-	"strconv"
-	"strings"
 )
 
-var instanceLocationRegex = regexp.MustCompile(`^/(\d+)`)
-
 // ValidateResponseSchema will validate the response body for a http.Response pointer. The request is used to
 // locate the operation in the specification, the response is used to ensure the response code, media type and the
 // schema of the response body are valid.
 //
-// This function is used by the ValidateResponseBody function, but can be used independently.
+// This function is used by the ValidateResponseBody function, but can be used independently. Options can be supplied
+// to configure the validation, such as custom formats.
 func ValidateResponseSchema(
 	request *http.Request,
 	response *http.Response,
 	schema *base.Schema,
 	renderedSchema,
-	jsonSchema []byte) (bool, []*errors.ValidationError) {
+	jsonSchema []byte,
+	opts ...config.Option) (bool, []*errors.ValidationError) {
 
-	var validationErrors []*errors.ValidationError
+	responseBody, readErr := readResponseBody(request, response, renderedSchema)
+	if readErr != nil {
+		return false, []*errors.ValidationError{readErr}
+	}
+
+	var decodedObj interface{}
+
+	if len(responseBody) > 0 {
+		err := json.Unmarshal(responseBody, &decodedObj)
 
+		if err != nil {
+			// cannot decode the response body, so it's not valid
+			return false, []*errors.ValidationError{responseBodyDecodingError(request, err, renderedSchema, responseBody)}
+		}
+	}
+
+	return validateDecodedResponseBody(request, response, schema, renderedSchema, jsonSchema, responseBody, decodedObj,
+		config.NewValidationOptions(opts...))
+}
+
+// responseBodyDecodingError will create a validation error for a response body that cannot be decoded.
+func responseBodyDecodingError(request *http.Request, err error, renderedSchema, responseBody []byte) *errors.ValidationError {
+	violation := &errors.SchemaValidationFailure{
+		Reason:          err.Error(),
+		Location:        "unavailable",
+		ReferenceSchema: string(renderedSchema),
+		ReferenceObject: string(responseBody),
+	}
+	return &errors.ValidationError{
+		ValidationType:    helpers.ResponseBodyValidation,
+		ValidationSubType: helpers.Schema,
+		Message: fmt.Sprintf("%s response body for '%s' failed to validate schema",
+			request.Method, request.URL.Path),
+		Reason:                 fmt.Sprintf("The response body cannot be decoded: %s", err.Error()),
+		SpecLine:               1,
+		SpecCol:                0,
+		SchemaValidationErrors: []*errors.SchemaValidationFailure{violation},
+		HowToFix:               errors.HowToFixInvalidSchema,
+		Context:                string(renderedSchema), // attach the rendered schema to the error
+	}
+}
+
+// readResponseBody will read the body of a response, then close it and replace it with a copy of what was read, so
+// the body can be re-read later by another player in the chain. An error is returned if the response is missing,
+// or the body cannot be read.
+func readResponseBody(request *http.Request, response *http.Response,
+	renderedSchema []byte) ([]byte, *errors.ValidationError) {
 	if response == nil || response.Body == nil {
 		// cannot decode the response body, so it's not valid
 		violation := &errors.SchemaValidationFailure{
@@ -46,7 +86,7 @@ func ValidateResponseSchema(
 			Location:        "unavailable",
 			ReferenceSchema: string(renderedSchema),
 		}
-		validationErrors = append(validationErrors, &errors.ValidationError{
+		return nil, &errors.ValidationError{
 			ValidationType:    "response",
 			ValidationSubType: "object",
 			Message: fmt.Sprintf("%s response object is missing for '%s'",
@@ -57,8 +97,7 @@ func ValidateResponseSchema(
 			SchemaValidationErrors: []*errors.SchemaValidationFailure{violation},
 			HowToFix:               "ensure response object has been set",
 			Context:                string(renderedSchema), // attach the rendered schema to the error
-		})
-		return false, validationErrors
+		}
 	}
 
 	responseBody, ioErr := io.ReadAll(response.Body)
@@ -70,7 +109,7 @@ func ValidateResponseSchema(
 			ReferenceSchema: string(renderedSchema),
 			ReferenceObject: string(responseBody),
 		}
-		validationErrors = append(validationErrors, &errors.ValidationError{
+		return nil, &errors.ValidationError{
 			ValidationType:    helpers.ResponseBodyValidation,
 			ValidationSubType: helpers.Schema,
 			Message: fmt.Sprintf("%s response body for '%s' cannot be read, it's empty or malformed",
@@ -81,122 +120,81 @@ func ValidateResponseSchema(
 			SchemaValidationErrors: []*errors.SchemaValidationFailure{violation},
 			HowToFix:               "ensure body is not empty",
 			Context:                string(renderedSchema), // attach the rendered schema to the error
-		})
-		return false, validationErrors
+		}
 	}
 
 	// close the request body, so it can be re-read later by another player in the chain
 	_ = response.Body.Close()
 	response.Body = io.NopCloser(bytes.NewBuffer(responseBody))
+	return responseBody, nil
+}
 
-	var decodedObj interface{}
-
-	if len(responseBody) > 0 {
-		err := json.Unmarshal(responseBody, &decodedObj)
+// validateDecodedResponseBody will validate a response body that has already been decoded, against a schema.
+// The raw response body is used as the reference object for any violations.
+func validateDecodedResponseBody(
+	request *http.Request,
+	response *http.Response,
+	schema *base.Schema,
+	renderedSchema,
+	jsonSchema,
+	responseBody []byte,
+	decodedObj interface{},
+	options *config.ValidationOptions) (bool, []*errors.ValidationError) {
 
-		if err != nil {
-			// cannot decode the response body, so it's not valid
-			violation := &errors.SchemaValidationFailure{
-				Reason:          err.Error(),
-				Location:        "unavailable",
-				ReferenceSchema: string(renderedSchema),
-				ReferenceObject: string(responseBody),
-			}
-			validationErrors = append(validationErrors, &errors.ValidationError{
-				ValidationType:    helpers.ResponseBodyValidation,
-				ValidationSubType: helpers.Schema,
-				Message: fmt.Sprintf("%s response body for '%s' failed to validate schema",
-					request.Method, request.URL.Path),
-				Reason:                 fmt.Sprintf("The response body cannot be decoded: %s", err.Error()),
-				SpecLine:               1,
-				SpecCol:                0,
-				SchemaValidationErrors: []*errors.SchemaValidationFailure{violation},
-				HowToFix:               errors.HowToFixInvalidSchema,
-				Context:                string(renderedSchema), // attach the rendered schema to the error
-			})
-			return false, validationErrors
-		}
-	}
+	var validationErrors []*errors.ValidationError
 
 	// no response body? failed to decode anything? nothing to do here.
 	if responseBody == nil || decodedObj == nil {
 		return true, nil
 	}
 
-	// create a new jsonschema compiler and add in the rendered JSON schema.
-	compiler := jsonschema.NewCompiler()
-	compiler.UseLoader(helpers.NewCompilerLoader())
-	fName := fmt.Sprintf("%s.json", helpers.ResponseBodyValidation)
-	decodedSchema, _ := jsonschema.UnmarshalJSON(strings.NewReader(string(jsonSchema)))
-	_ = compiler.AddResource(fName, decodedSchema)
-	jsch, _ := compiler.Compile(fName)
+	// if the schema uses a discriminator, validate against the schema it selects, so any violations are
+	// reported against that schema, rather than as a generic oneOf / anyOf failure.
+	if selected, value, ok := helpers.ResolveDiscriminator(schema, decodedObj); ok {
+		if selected == nil {
+			validationErrors = append(validationErrors, errors.UnknownDiscriminatorValue(schema, value,
+				fmt.Sprintf("%d response body", response.StatusCode), helpers.ResponseBodyValidation))
+			return false, validationErrors
+		}
+		schema = selected
+		renderedSchema, _ = helpers.RenderSchemaInline(selected)
+		jsonSchema, _ = utils.ConvertYAMLtoJSON(renderedSchema)
+	}
+
+	// compile the schema, or use the compiled schema from the cache if it has been compiled before.
+	jsch, decodedSchema, err := helpers.CompileSchema(helpers.ResponseBodyValidation, schema, jsonSchema,
+		helpers.ResponseBodyValidation, options)
+	if patternErr, ok := err.(*helpers.InvalidPatternError); ok {
+		validationErrors = append(validationErrors, errors.InvalidSchemaPattern(patternErr, schema,
+			fmt.Sprintf("%d response body", response.StatusCode), helpers.ResponseBodyValidation))
+		return false, validationErrors
+	}
+	if formatErr, ok := err.(*helpers.UnknownFormatError); ok {
+		validationErrors = append(validationErrors, errors.UnknownSchemaFormat(formatErr, schema,
+			fmt.Sprintf("%d response body", response.StatusCode), helpers.ResponseBodyValidation))
+		return false, validationErrors
+	}
+	if err != nil {
+		validationErrors = append(validationErrors, &errors.ValidationError{
+			ValidationType:    helpers.ResponseBodyValidation,
+			ValidationSubType: helpers.Schema,
+			Message:           err.Error(),
+			Reason:            "Failed to compile the response body schema.",
+			Context:           string(jsonSchema),
+		})
+		return false, validationErrors
+	}
 
 	// validate the object against the schema
 	scErrs := jsch.Validate(decodedObj)
 	if scErrs != nil {
 		jk := scErrs.(*jsonschema.ValidationError)
 
-		// flatten the validationErrors
-		schFlatErrs := jk.BasicOutput().Errors
-		var schemaValidationErrors []*errors.SchemaValidationFailure
-		for q := range schFlatErrs {
-			er := schFlatErrs[q]
-
-			errMsg := er.Error.Kind.LocalizedString(message.NewPrinter(language.Tag{}))
-			if er.KeywordLocation == "" || helpers.IgnoreRegex.MatchString(errMsg) {
-				continue // ignore this error, it's useless tbh, utter noise.
-			}
-			if er.Error != nil {
-
-				// re-encode the schema.
-				var renderedNode yaml.Node
-				_ = yaml.Unmarshal(renderedSchema, &renderedNode)
-
-				// locate the violated property in the schema
-				located := schema_validation.LocateSchemaPropertyNodeByJSONPath(renderedNode.Content[0], er.KeywordLocation)
-
-				// extract the element specified by the instance
-				val := instanceLocationRegex.FindStringSubmatch(er.InstanceLocation)
-				var referenceObject string
-
-				if len(val) > 0 {
-					referenceIndex, _ := strconv.Atoi(val[1])
-					if reflect.ValueOf(decodedObj).Type().Kind() == reflect.Slice {
-						found := decodedObj.([]any)[referenceIndex]
-						recoded, _ := json.MarshalIndent(found, "", "  ")
-						referenceObject = string(recoded)
-					}
-				}
-				if referenceObject == "" {
-					referenceObject = string(responseBody)
-				}
-
-				violation := &errors.SchemaValidationFailure{
-					Reason:          errMsg,
-					Location:        er.KeywordLocation,
-					ReferenceSchema: string(renderedSchema),
-					ReferenceObject: referenceObject,
-					OriginalError:   jk,
-				}
-				// if we have a location within the schema, add it to the error
-				if located != nil {
-
-					line := located.Line
-					// if the located node is a map or an array, then the actual human interpretable
-					// line on which the violation occurred is the line of the key, not the value.
-					if located.Kind == yaml.MappingNode || located.Kind == yaml.SequenceNode {
-						if line > 0 {
-							line--
-						}
-					}
-
-					// location of the violation within the rendered schema.
-					violation.Line = line
-					violation.Column = located.Column
-				}
-				schemaValidationErrors = append(schemaValidationErrors, violation)
-			}
-		}
+		schemaValidationErrors := errors.SchemaFailures(jk, decodedSchema,
+			schema_validation.DescribeSchemaFailure(renderedSchema, decodedObj, responseBody))
+
+		// locate each of the values that failed within the body, so they can be highlighted.
+		errors.PopulateInstanceLines(schemaValidationErrors, responseBody)
 
 		line := 1
 		col := 0
@@ -220,6 +218,15 @@ func ValidateResponseSchema(
 			Context:                string(renderedSchema), // attach the rendered schema to the error
 		})
 	}
+
+	// writeOnly properties should only be sent by the client, so report any that have been returned.
+	if options.StrictReadWriteOnly {
+		for _, pointer := range helpers.FindMarkedProperties(decodedSchema, decodedObj, helpers.WriteOnly) {
+			writeOnlyErr := errors.ResponseWriteOnlyProperty(request, response.StatusCode, pointer, renderedSchema)
+			errors.PopulateInstanceLines(writeOnlyErr.SchemaValidationErrors, responseBody)
+			validationErrors = append(validationErrors, writeOnlyErr)
+		}
+	}
 	if len(validationErrors) > 0 {
 		return false, validationErrors
 	}
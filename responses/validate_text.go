@@ -0,0 +1,38 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package responses
+
+import (
+	"net/http"
+
+	"github.com/pb33f/libopenapi-validator/config"
+	"github.com/pb33f/libopenapi-validator/errors"
+	"github.com/pb33f/libopenapi-validator/helpers"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// ValidateResponseTextSchema will validate the text response body (for example 'text/plain') for a http.Response
+// pointer against a schema. The raw body is validated as a single value, coerced into the type defined by the
+// schema if it's not a string.
+// If validation fails, it will return a list of validation errors as the second return value.
+func ValidateResponseTextSchema(
+	request *http.Request,
+	response *http.Response,
+	schema *base.Schema,
+	renderedSchema,
+	jsonSchema []byte,
+	opts ...config.Option) (bool, []*errors.ValidationError) {
+
+	responseBody, readErr := readResponseBody(request, response, renderedSchema)
+	if readErr != nil {
+		return false, []*errors.ValidationError{readErr}
+	}
+
+	var decodedObj interface{}
+	if len(responseBody) > 0 {
+		decodedObj = helpers.CoerceValue(string(responseBody), schema)
+	}
+	return validateDecodedResponseBody(request, response, schema, renderedSchema, jsonSchema, responseBody, decodedObj,
+		config.NewValidationOptions(opts...))
+}
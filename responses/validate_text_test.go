@@ -0,0 +1,60 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package responses
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi-validator/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateBody_TextPlainResponse(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}/name:
+    get:
+      responses:
+        '200':
+          content:
+            text/plain:
+              schema:
+                type: string
+                enum: [Big Mac, Whopper]`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	v := NewResponseBodyValidator(&m.Model)
+
+	for name, tc := range map[string]struct {
+		body  string
+		valid bool
+	}{
+		"Conforming":    {body: "Whopper", valid: true},
+		"NonConforming": {body: "Quarter Pounder"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/1/name", nil)
+
+			res := httptest.NewRecorder()
+			res.Header().Set(helpers.ContentTypeHeader, "text/plain; charset=utf-8")
+			res.WriteHeader(http.StatusOK)
+			_, _ = res.Write([]byte(tc.body))
+
+			valid, errors := v.ValidateResponseBody(request, res.Result())
+
+			assert.Equal(t, tc.valid, valid)
+			if tc.valid {
+				assert.Len(t, errors, 0)
+				return
+			}
+			assert.Len(t, errors, 1)
+			assert.Equal(t, "200 response body for '/burgers/1/name' failed to validate schema", errors[0].Message)
+			assert.Equal(t, "/enum", errors[0].SchemaValidationErrors[0].Location)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package responses
+
+import (
+	"net/http"
+
+	"github.com/pb33f/libopenapi-validator/config"
+	"github.com/pb33f/libopenapi-validator/errors"
+	"github.com/pb33f/libopenapi-validator/helpers"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// ValidateResponseXMLSchema will validate the XML response body for a http.Response pointer against a schema. The
+// body is decoded into an object using the 'xml' objects of the schema, to map elements and attributes to
+// properties, before it's validated.
+// If validation fails, it will return a list of validation errors as the second return value.
+func ValidateResponseXMLSchema(
+	request *http.Request,
+	response *http.Response,
+	schema *base.Schema,
+	renderedSchema,
+	jsonSchema []byte,
+	opts ...config.Option) (bool, []*errors.ValidationError) {
+
+	responseBody, readErr := readResponseBody(request, response, renderedSchema)
+	if readErr != nil {
+		return false, []*errors.ValidationError{readErr}
+	}
+
+	var decodedObj interface{}
+	if len(responseBody) > 0 {
+		var err error
+		decodedObj, err = helpers.DecodeXML(responseBody, schema)
+		if err != nil {
+			// cannot decode the response body, so it's not valid
+			return false, []*errors.ValidationError{responseBodyDecodingError(request, err, renderedSchema, responseBody)}
+		}
+	}
+	return validateDecodedResponseBody(request, response, schema, renderedSchema, jsonSchema, responseBody, decodedObj,
+		config.NewValidationOptions(opts...))
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package responses
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi-validator/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+var xmlResponseSpec = `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}:
+    get:
+      responses:
+        '200':
+          content:
+            application/xml:
+              schema:
+                type: object
+                required: [name, patties]
+                properties:
+                  id:
+                    type: integer
+                    xml:
+                      attribute: true
+                  name:
+                    type: string
+                  patties:
+                    type: integer
+                  toppings:
+                    type: array
+                    items:
+                      type: string
+                      xml:
+                        name: topping`
+
+func xmlResponse(body string) *http.Response {
+	res := httptest.NewRecorder()
+	res.Header().Set(helpers.ContentTypeHeader, "application/xml")
+	res.WriteHeader(http.StatusOK)
+	_, _ = res.WriteString(body)
+	return res.Result()
+}
+
+func TestValidateBody_XMLResponse(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(xmlResponseSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewResponseBodyValidator(&m.Model)
+
+	body := `<burger id="1"><name>Big Mac</name><patties>2</patties>` +
+		`<topping>pickles</topping><topping>onions</topping></burger>`
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/1", nil)
+	response := xmlResponse(body)
+
+	valid, errors := v.ValidateResponseBody(request, response)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	// the body can be re-read after validation.
+	reread, _ := io.ReadAll(response.Body)
+	assert.Equal(t, body, string(reread))
+}
+
+func TestValidateBody_XMLResponse_MissingRequiredElement(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(xmlResponseSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewResponseBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/1", nil)
+	response := xmlResponse(`<burger id="1"><patties>2</patties></burger>`)
+
+	valid, errors := v.ValidateResponseBody(request, response)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "200 response body for '/burgers/1' failed to validate schema", errors[0].Message)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "missing property 'name'", errors[0].SchemaValidationErrors[0].Reason)
+}
+
+func TestValidateBody_XMLResponse_Malformed(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(xmlResponseSpec))
+	m, _ := doc.BuildV3Model()
+	v := NewResponseBodyValidator(&m.Model)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/1", nil)
+	response := xmlResponse(`<burger><name>Big Mac</burger>`)
+
+	valid, errors := v.ValidateResponseBody(request, response)
+
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Contains(t, errors[0].Reason, "The response body cannot be decoded")
+}
@@ -4,6 +4,10 @@
 package schema_validation
 
 import (
+	"net/url"
+	"strings"
+
+	"github.com/pb33f/libopenapi-validator/helpers"
 	"github.com/pb33f/libopenapi/utils"
 	"github.com/vmware-labs/yaml-jsonpath/pkg/yamlpath"
 	"gopkg.in/yaml.v3"
@@ -12,6 +16,19 @@ import (
 // LocateSchemaPropertyNodeByJSONPath will locate a schema property node by a JSONPath. It converts something like
 // #/components/schemas/MySchema/properties/MyProperty to something like $.components.schemas.MySchema.properties.MyProperty
 func LocateSchemaPropertyNodeByJSONPath(doc *yaml.Node, JSONPath string) *yaml.Node {
+	if located := locateSchemaPropertyNodeByYAMLPath(doc, JSONPath); located != nil {
+		return located
+	}
+	// keywords such as patternProperties use keys (regular expressions) that cannot be converted into a path,
+	// so fall back to resolving the location as an (escaped) JSON pointer.
+	if pointer, err := url.PathUnescape(JSONPath); err == nil && doc != nil && pointer != "" {
+		return helpers.LocateJSONPointer(doc, strings.TrimPrefix(pointer, "#"))
+	}
+	return nil
+}
+
+// locateSchemaPropertyNodeByYAMLPath will locate a schema property node by converting a JSONPath into a YAMLPath.
+func locateSchemaPropertyNodeByYAMLPath(doc *yaml.Node, JSONPath string) *yaml.Node {
 	var locatedNode *yaml.Node
 	doneChan := make(chan bool)
 	locatedNodeChan := make(chan *yaml.Node)
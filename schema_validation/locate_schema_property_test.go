@@ -5,6 +5,7 @@ package schema_validation
 
 import (
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
 	"testing"
 )
 
@@ -13,3 +14,16 @@ func TestLocateSchemaPropertyNodeByJSONPath_BadNode(t *testing.T) {
 	assert.Nil(t, LocateSchemaPropertyNodeByJSONPath(nil, ""))
 
 }
+
+func TestLocateSchemaPropertyNodeByJSONPath_PatternProperties(t *testing.T) {
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte(`type: object
+patternProperties:
+  '^x-[a-z]+$':
+    type: integer
+    minimum: 1`), &node)
+
+	located := LocateSchemaPropertyNodeByJSONPath(node.Content[0], "/patternProperties/%5Ex-%5Ba-z%5D+$/minimum")
+	assert.NotNil(t, located)
+	assert.Equal(t, 5, located.Line)
+}
@@ -0,0 +1,55 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package schema_validation
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pb33f/libopenapi-validator/config"
+	liberrors "github.com/pb33f/libopenapi-validator/errors"
+	"github.com/pb33f/libopenapi-validator/helpers"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// ValidateComponentSchema will validate a value against a schema defined in the 'components' of a document, using
+// the name of the schema, for example 'Pet' for '#/components/schemas/Pet'. The value can be any Go value that can
+// be encoded as JSON (a struct, a map, a slice or a scalar), it is encoded and validated as it would be sent in a
+// request or response, so no *http.Request is needed.
+// It will return true if the value is valid, false if not and a slice of ValidationError pointers.
+func ValidateComponentSchema(document *v3.Document, name string, value interface{},
+	opts ...config.Option) (bool, []*liberrors.ValidationError) {
+
+	var schemaProxy *base.SchemaProxy
+	if document != nil && document.Components != nil && document.Components.Schemas != nil {
+		schemaProxy = document.Components.Schemas.GetOrZero(name)
+	}
+	if schemaProxy == nil {
+		return false, []*liberrors.ValidationError{liberrors.ComponentSchemaNotFound(name)}
+	}
+
+	schema, err := schemaProxy.BuildSchema()
+	if err != nil || schema == nil {
+		if err == nil {
+			err = fmt.Errorf("schema is empty")
+		}
+		location := fmt.Sprintf("/components/schemas/%s", helpers.EscapeJSONPointer(name))
+		return false, []*liberrors.ValidationError{liberrors.UnresolvedSchema(location, schemaProxy.GetValueNode(), err)}
+	}
+
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return false, []*liberrors.ValidationError{{
+			ValidationType:    helpers.Schema,
+			ValidationSubType: helpers.Schema,
+			Message:           fmt.Sprintf("value cannot be validated against schema '%s'", name),
+			Reason:            fmt.Sprintf("The value cannot be encoded as JSON: %s", err.Error()),
+			SpecLine:          -1,
+			SpecCol:           -1,
+			HowToFix:          liberrors.HowToFixInvalidEncoding,
+		}}
+	}
+	return NewSchemaValidator(opts...).ValidateSchemaBytes(schema, payload)
+}
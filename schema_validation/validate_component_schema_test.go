@@ -0,0 +1,124 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package schema_validation
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi-validator/config"
+	"github.com/pb33f/libopenapi-validator/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var componentSchemaSpec = `openapi: 3.1.0
+components:
+  schemas:
+    Burger:
+      type: object
+      required: [name, patties]
+      properties:
+        name:
+          type: string
+        patties:
+          type: integer
+          minimum: 1
+        sauce:
+          $ref: '#/components/schemas/Sauce'
+    Sauce:
+      type: string
+      enum: [ketchup, mustard]`
+
+type burger struct {
+	Name    string `json:"name"`
+	Patties int    `json:"patties"`
+	Sauce   string `json:"sauce,omitempty"`
+}
+
+func TestValidateComponentSchema_Valid(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(componentSchemaSpec))
+	m, _ := doc.BuildV3Model()
+
+	valid, errors := ValidateComponentSchema(&m.Model, "Burger", burger{Name: "Big Mac", Patties: 2, Sauce: "ketchup"})
+
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	valid, errors = ValidateComponentSchema(&m.Model, "Burger", map[string]interface{}{"name": "Whopper", "patties": 1})
+
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	valid, errors = ValidateComponentSchema(&m.Model, "Sauce", "mustard")
+
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+}
+
+func TestValidateComponentSchema_Invalid(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(componentSchemaSpec))
+	m, _ := doc.BuildV3Model()
+
+	valid, errors := ValidateComponentSchema(&m.Model, "Burger", burger{Name: "Big Mac", Patties: 0, Sauce: "mayo"})
+
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, helpers.Schema, errors[0].ValidationType)
+	assert.Len(t, errors[0].SchemaValidationErrors, 2)
+
+	valid, errors = ValidateComponentSchema(&m.Model, "Sauce", "mayo")
+
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+}
+
+func TestValidateComponentSchema_NotFound(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(componentSchemaSpec))
+	m, _ := doc.BuildV3Model()
+
+	valid, errors := ValidateComponentSchema(&m.Model, "Pizza", burger{})
+
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, helpers.MissingComponentSchema, errors[0].ValidationSubType)
+	assert.Equal(t, "schema component 'Pizza' not found", errors[0].Message)
+
+	valid, errors = ValidateComponentSchema(nil, "Burger", burger{})
+
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, helpers.MissingComponentSchema, errors[0].ValidationSubType)
+}
+
+func TestValidateComponentSchema_CannotEncode(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(componentSchemaSpec))
+	m, _ := doc.BuildV3Model()
+
+	valid, errors := ValidateComponentSchema(&m.Model, "Burger", make(chan int))
+
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "value cannot be validated against schema 'Burger'", errors[0].Message)
+}
+
+func TestValidateComponentSchema_Options(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Contact:
+      type: string
+      format: email`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	valid, _ := ValidateComponentSchema(&m.Model, "Contact", "not an email")
+	assert.True(t, valid)
+
+	valid, errors := ValidateComponentSchema(&m.Model, "Contact", "not an email", config.WithFormatAssertions())
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+}
@@ -0,0 +1,151 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package schema_validation
+
+import (
+	"fmt"
+
+	"github.com/pb33f/libopenapi-validator/config"
+	liberrors "github.com/pb33f/libopenapi-validator/errors"
+	"github.com/pb33f/libopenapi-validator/helpers"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ValidateDocumentSchemas will walk the parameters, request bodies and responses of every operation in a document,
+// and check each schema can be built and compiled. Schemas with a $ref that cannot be resolved, or that use a
+// keyword incorrectly, are reported with a JSON pointer to their location in the document. This is intended to be
+// run once, before any requests are validated, to catch mistakes in the specification.
+// It will return true if every schema is usable, false if not and a slice of ValidationError pointers.
+func ValidateDocumentSchemas(document *v3.Document, opts ...config.Option) (bool, []*liberrors.ValidationError) {
+	if document == nil || document.Paths == nil || document.Paths.PathItems == nil {
+		return true, nil
+	}
+	options := config.NewValidationOptions(opts...)
+
+	var validationErrors []*liberrors.ValidationError
+	for path, pathItem := range document.Paths.PathItems.FromOldest() {
+		pathLocation := fmt.Sprintf("/paths/%s", helpers.EscapeJSONPointer(path))
+		validationErrors = append(validationErrors,
+			checkParameterSchemas(pathLocation, pathItem.Parameters, options)...)
+
+		for method, operation := range pathItem.GetOperations().FromOldest() {
+			location := fmt.Sprintf("%s/%s", pathLocation, method)
+			validationErrors = append(validationErrors,
+				checkParameterSchemas(location, operation.Parameters, options)...)
+
+			if operation.RequestBody != nil {
+				validationErrors = append(validationErrors,
+					checkContentSchemas(location+"/requestBody", operation.RequestBody.Content, options)...)
+			}
+			if operation.Responses == nil {
+				continue
+			}
+			if operation.Responses.Default != nil {
+				validationErrors = append(validationErrors,
+					checkResponseSchemas(location+"/responses/default", operation.Responses.Default, options)...)
+			}
+			if operation.Responses.Codes != nil {
+				for code, response := range operation.Responses.Codes.FromOldest() {
+					responseLocation := fmt.Sprintf("%s/responses/%s", location, code)
+					validationErrors = append(validationErrors,
+						checkResponseSchemas(responseLocation, response, options)...)
+				}
+			}
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		return false, validationErrors
+	}
+	return true, nil
+}
+
+func checkParameterSchemas(location string, params []*v3.Parameter,
+	options *config.ValidationOptions) []*liberrors.ValidationError {
+	var validationErrors []*liberrors.ValidationError
+	for i, param := range params {
+		if param == nil {
+			continue
+		}
+		paramLocation := fmt.Sprintf("%s/parameters/%d", location, i)
+		validationErrors = append(validationErrors, checkSchema(paramLocation+"/schema", param.Schema, options)...)
+		validationErrors = append(validationErrors, checkContentSchemas(paramLocation, param.Content, options)...)
+	}
+	return validationErrors
+}
+
+func checkResponseSchemas(location string, response *v3.Response,
+	options *config.ValidationOptions) []*liberrors.ValidationError {
+	if response == nil {
+		return nil
+	}
+	validationErrors := checkContentSchemas(location, response.Content, options)
+	if response.Headers != nil {
+		for name, header := range response.Headers.FromOldest() {
+			if header == nil {
+				continue
+			}
+			headerLocation := fmt.Sprintf("%s/headers/%s/schema", location, helpers.EscapeJSONPointer(name))
+			validationErrors = append(validationErrors, checkSchema(headerLocation, header.Schema, options)...)
+		}
+	}
+	return validationErrors
+}
+
+func checkContentSchemas(location string, content *orderedmap.Map[string, *v3.MediaType],
+	options *config.ValidationOptions) []*liberrors.ValidationError {
+	if content == nil {
+		return nil
+	}
+	var validationErrors []*liberrors.ValidationError
+	for contentType, mediaType := range content.FromOldest() {
+		if mediaType == nil {
+			continue
+		}
+		mediaTypeLocation := fmt.Sprintf("%s/content/%s/schema", location, helpers.EscapeJSONPointer(contentType))
+		validationErrors = append(validationErrors, checkSchema(mediaTypeLocation, mediaType.Schema, options)...)
+	}
+	return validationErrors
+}
+
+// checkSchema will build the schema behind a proxy, then render and compile it, in the same way the validators
+// do when they validate a request or response.
+func checkSchema(location string, proxy *base.SchemaProxy,
+	options *config.ValidationOptions) []*liberrors.ValidationError {
+	if proxy == nil {
+		return nil
+	}
+	var node *yaml.Node
+	if proxy.GoLow() != nil {
+		node = proxy.GoLow().GetValueNode()
+	}
+
+	schema, err := proxy.BuildSchema()
+	if err != nil {
+		return []*liberrors.ValidationError{liberrors.UnresolvedSchema(location, node, err)}
+	}
+	if schema == nil {
+		return nil
+	}
+
+	renderedSchema, err := helpers.RenderSchemaInline(schema)
+	if err != nil {
+		return []*liberrors.ValidationError{liberrors.UnresolvedSchema(location, node, err)}
+	}
+	jsonSchema, _ := utils.ConvertYAMLtoJSON(renderedSchema)
+	_, _, err = helpers.CompileSchema(helpers.Schema, schema, jsonSchema, helpers.Schema, options)
+	if formatErr, ok := err.(*helpers.UnknownFormatError); ok {
+		validationError := liberrors.UnknownSchemaFormat(formatErr, schema, "", helpers.Schema)
+		validationError.SpecPath = location
+		return []*liberrors.ValidationError{validationError}
+	}
+	if err != nil {
+		return []*liberrors.ValidationError{liberrors.UncompilableSchema(location, node, schema, err)}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package schema_validation
+
+import (
+	"os"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi-validator/config"
+	"github.com/pb33f/libopenapi-validator/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateDocumentSchemas_Petstore(t *testing.T) {
+	petstore, _ := os.ReadFile("../test_specs/petstorev3.json")
+	doc, _ := libopenapi.NewDocument(petstore)
+	m, _ := doc.BuildV3Model()
+
+	valid, errors := ValidateDocumentSchemas(&m.Model)
+
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+}
+
+func TestValidateDocumentSchemas_NilDocument(t *testing.T) {
+	valid, errors := ValidateDocumentSchemas(nil)
+
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+}
+
+func TestValidateDocumentSchemas_UnresolvedRef(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                sauce:
+                  $ref: '#/components/schemas/Sauce'
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                type: object`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, errs := doc.BuildV3Model()
+	require.NotEmpty(t, errs) // libopenapi reports the dangling reference, but still builds the model.
+
+	valid, errors := ValidateDocumentSchemas(&m.Model)
+
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, helpers.Schema, errors[0].ValidationType)
+	assert.Equal(t, helpers.UnresolvedSchema, errors[0].ValidationSubType)
+	assert.Equal(t, "/paths/~1burgers/post/requestBody/content/application~1json/schema", errors[0].SpecPath)
+	assert.Equal(t, "schema at '/paths/~1burgers/post/requestBody/content/application~1json/schema' "+
+		"cannot be resolved", errors[0].Message)
+	assert.Contains(t, errors[0].Reason, "#/components/schemas/Sauce")
+	assert.Equal(t, 9, errors[0].SpecLine)
+}
+
+func TestValidateDocumentSchemas_InvalidKeyword(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}:
+    parameters:
+      - name: burgerId
+        in: path
+        required: true
+        schema:
+          type: integer
+    get:
+      parameters:
+        - name: fries
+          in: query
+          schema:
+            type: chips
+      responses:
+        '200':
+          headers:
+            X-Burger-Count:
+              schema:
+                type: integer
+                minimum: 1
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  name:
+                    type: string
+                    pattern: '[unclosed'`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	valid, errors := ValidateDocumentSchemas(&m.Model)
+
+	assert.False(t, valid)
+	require.Len(t, errors, 2)
+
+	assert.Equal(t, helpers.UncompilableSchema, errors[0].ValidationSubType)
+	assert.Equal(t, "/paths/~1burgers~1{burgerId}/get/parameters/0/schema", errors[0].SpecPath)
+	assert.Equal(t, 15, errors[0].SpecLine)
+	assert.NotNil(t, errors[0].Context)
+
+	assert.Equal(t, helpers.UncompilableSchema, errors[1].ValidationSubType)
+	assert.Equal(t, "/paths/~1burgers~1{burgerId}/get/responses/200/content/application~1json/schema",
+		errors[1].SpecPath)
+}
+
+func TestValidateDocumentSchemas_UnknownFormat(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    get:
+      parameters:
+        - name: bakedAfter
+          in: query
+          schema:
+            type: string
+            format: date-tim
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                type: string
+                format: uuid`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	valid, errors := ValidateDocumentSchemas(&m.Model)
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	valid, errors = ValidateDocumentSchemas(&m.Model, config.WithUnknownFormatError())
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, helpers.UnknownSchemaFormat, errors[0].ValidationSubType)
+	assert.Equal(t, "/paths/~1burgers/get/parameters/0/schema", errors[0].SpecPath)
+	assert.Equal(t, "schema format 'date-tim' is not known", errors[0].Message)
+	assert.Equal(t, 9, errors[0].SpecLine)
+}
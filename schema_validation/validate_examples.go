@@ -0,0 +1,186 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package schema_validation
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pb33f/libopenapi-validator/config"
+	liberrors "github.com/pb33f/libopenapi-validator/errors"
+	"github.com/pb33f/libopenapi-validator/helpers"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+)
+
+// ValidateExamples will walk the parameters, request bodies and responses of every operation in a document, and
+// validate each 'example' and 'examples' value against the schema it is an example of. Examples that do not match
+// their schema are reported with a JSON pointer to the example in the document. Like ValidateDocumentSchemas, this
+// is intended to be run once, before any requests are validated, to catch mistakes in the specification.
+//
+// Examples with an 'externalValue' are not fetched, and examples of schemas that cannot be built are skipped,
+// ValidateDocumentSchemas will report those schemas.
+// It will return true if every example is valid, false if not and a slice of ValidationError pointers.
+func ValidateExamples(document *v3.Document, opts ...config.Option) (bool, []*liberrors.ValidationError) {
+	if document == nil || document.Paths == nil || document.Paths.PathItems == nil {
+		return true, nil
+	}
+	validator := NewSchemaValidator(opts...)
+
+	var validationErrors []*liberrors.ValidationError
+	for path, pathItem := range document.Paths.PathItems.FromOldest() {
+		pathLocation := fmt.Sprintf("/paths/%s", helpers.EscapeJSONPointer(path))
+		validationErrors = append(validationErrors,
+			checkParameterExamples(pathLocation, pathItem.Parameters, validator)...)
+
+		for method, operation := range pathItem.GetOperations().FromOldest() {
+			location := fmt.Sprintf("%s/%s", pathLocation, method)
+			validationErrors = append(validationErrors,
+				checkParameterExamples(location, operation.Parameters, validator)...)
+
+			if operation.RequestBody != nil {
+				validationErrors = append(validationErrors,
+					checkContentExamples(location+"/requestBody", operation.RequestBody.Content, validator)...)
+			}
+			if operation.Responses == nil {
+				continue
+			}
+			if operation.Responses.Default != nil {
+				validationErrors = append(validationErrors,
+					checkResponseExamples(location+"/responses/default", operation.Responses.Default, validator)...)
+			}
+			if operation.Responses.Codes != nil {
+				for code, response := range operation.Responses.Codes.FromOldest() {
+					responseLocation := fmt.Sprintf("%s/responses/%s", location, code)
+					validationErrors = append(validationErrors,
+						checkResponseExamples(responseLocation, response, validator)...)
+				}
+			}
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		return false, validationErrors
+	}
+	return true, nil
+}
+
+func checkParameterExamples(location string, params []*v3.Parameter,
+	validator SchemaValidator) []*liberrors.ValidationError {
+	var validationErrors []*liberrors.ValidationError
+	for i, param := range params {
+		if param == nil {
+			continue
+		}
+		paramLocation := fmt.Sprintf("%s/parameters/%d", location, i)
+		validationErrors = append(validationErrors,
+			checkExamples(paramLocation, param.Schema, param.Example, param.Examples, validator)...)
+		validationErrors = append(validationErrors, checkContentExamples(paramLocation, param.Content, validator)...)
+	}
+	return validationErrors
+}
+
+func checkResponseExamples(location string, response *v3.Response,
+	validator SchemaValidator) []*liberrors.ValidationError {
+	if response == nil {
+		return nil
+	}
+	validationErrors := checkContentExamples(location, response.Content, validator)
+	if response.Headers != nil {
+		for name, header := range response.Headers.FromOldest() {
+			if header == nil {
+				continue
+			}
+			headerLocation := fmt.Sprintf("%s/headers/%s", location, helpers.EscapeJSONPointer(name))
+			validationErrors = append(validationErrors,
+				checkExamples(headerLocation, header.Schema, header.Example, header.Examples, validator)...)
+		}
+	}
+	return validationErrors
+}
+
+func checkContentExamples(location string, content *orderedmap.Map[string, *v3.MediaType],
+	validator SchemaValidator) []*liberrors.ValidationError {
+	if content == nil {
+		return nil
+	}
+	var validationErrors []*liberrors.ValidationError
+	for contentType, mediaType := range content.FromOldest() {
+		if mediaType == nil {
+			continue
+		}
+		mediaTypeLocation := fmt.Sprintf("%s/content/%s", location, helpers.EscapeJSONPointer(contentType))
+		validationErrors = append(validationErrors,
+			checkExamples(mediaTypeLocation, mediaType.Schema, mediaType.Example, mediaType.Examples, validator)...)
+	}
+	return validationErrors
+}
+
+// checkExamples will validate the 'example' and each of the 'examples' of a parameter, header or media type against
+// its schema. The location is a JSON pointer to the object that holds the examples.
+func checkExamples(location string, proxy *base.SchemaProxy, example *yaml.Node,
+	examples *orderedmap.Map[string, *base.Example], validator SchemaValidator) []*liberrors.ValidationError {
+	if proxy == nil {
+		return nil
+	}
+	schema, err := proxy.BuildSchema()
+	if err != nil || schema == nil {
+		return nil
+	}
+
+	var validationErrors []*liberrors.ValidationError
+	if example != nil {
+		validationErrors = append(validationErrors,
+			checkExample(location+"/example", schema, example, validator)...)
+	}
+	if examples != nil {
+		for name, ex := range examples.FromOldest() {
+			if ex == nil || ex.Value == nil {
+				continue
+			}
+			exampleLocation := fmt.Sprintf("%s/examples/%s/value", location, helpers.EscapeJSONPointer(name))
+			validationErrors = append(validationErrors, checkExample(exampleLocation, schema, ex.Value, validator)...)
+		}
+	}
+	return validationErrors
+}
+
+// checkExample will validate a single example value against a schema. The failures of the schema validation are
+// collected into a single error for the example, errors that are not about the example (such as a schema that
+// cannot be compiled) are returned as they are.
+func checkExample(location string, schema *base.Schema, example *yaml.Node,
+	validator SchemaValidator) []*liberrors.ValidationError {
+
+	// examples are written in YAML, they are re-encoded as JSON so values are decoded as they would be in a payload.
+	var value interface{}
+	if err := example.Decode(&value); err != nil {
+		return []*liberrors.ValidationError{liberrors.InvalidExample(location, example,
+			[]*liberrors.SchemaValidationFailure{{Reason: err.Error(), Location: "unavailable"}})}
+	}
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return []*liberrors.ValidationError{liberrors.InvalidExample(location, example,
+			[]*liberrors.SchemaValidationFailure{{Reason: err.Error(), Location: "unavailable"}})}
+	}
+
+	valid, errs := validator.ValidateSchemaBytes(schema, payload)
+	if valid {
+		return nil
+	}
+	var failures []*liberrors.SchemaValidationFailure
+	var validationErrors []*liberrors.ValidationError
+	for _, e := range errs {
+		if e.ValidationSubType == "" && len(e.SchemaValidationErrors) > 0 {
+			failures = append(failures, e.SchemaValidationErrors...)
+			continue
+		}
+		validationErrors = append(validationErrors, e)
+	}
+	if len(failures) > 0 {
+		validationErrors = append(validationErrors, liberrors.InvalidExample(location, example, failures))
+	}
+	return validationErrors
+}
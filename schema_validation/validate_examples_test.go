@@ -0,0 +1,124 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package schema_validation
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi-validator/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateExamples_NilDocument(t *testing.T) {
+	valid, errors := ValidateExamples(nil)
+
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+}
+
+func TestValidateExamples_Valid(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}:
+    parameters:
+      - name: burgerId
+        in: path
+        required: true
+        schema:
+          type: integer
+        example: 12
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+                patties:
+                  type: integer
+                  minimum: 1
+            examples:
+              double:
+                value:
+                  name: Double Stack
+                  patties: 2
+      responses:
+        '200':
+          headers:
+            X-Cook-Time:
+              schema:
+                type: number
+              example: 4.5
+          content:
+            application/json:
+              schema:
+                type: string
+              example: cooking`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	valid, errors := ValidateExamples(&m.Model)
+
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+}
+
+func TestValidateExamples_Invalid(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            maximum: 10
+          examples:
+            lots:
+              value: 50
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+            example:
+              patties: 2
+      responses:
+        '200':
+          description: ok`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+
+	valid, errors := ValidateExamples(&m.Model)
+
+	assert.False(t, valid)
+	require.Len(t, errors, 2)
+
+	assert.Equal(t, helpers.Schema, errors[0].ValidationType)
+	assert.Equal(t, helpers.InvalidExample, errors[0].ValidationSubType)
+	assert.Equal(t, "/paths/~1burgers/get/parameters/0/examples/lots/value", errors[0].SpecPath)
+	assert.Equal(t, 13, errors[0].SpecLine)
+	require.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Equal(t, "maximum", errors[0].SchemaValidationErrors[0].Keyword)
+
+	assert.Equal(t, helpers.InvalidExample, errors[1].ValidationSubType)
+	assert.Equal(t, "/paths/~1burgers/get/requestBody/content/application~1json/example", errors[1].SpecPath)
+	assert.Equal(t, "example at '/paths/~1burgers/get/requestBody/content/application~1json/example' "+
+		"does not match its schema", errors[1].Message)
+	assert.Equal(t, 24, errors[1].SpecLine)
+	require.Len(t, errors[1].SchemaValidationErrors, 1)
+	assert.Equal(t, "required", errors[1].SchemaValidationErrors[0].Keyword)
+}
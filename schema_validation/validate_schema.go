@@ -8,20 +8,17 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/pb33f/libopenapi-validator/config"
 	liberrors "github.com/pb33f/libopenapi-validator/errors"
 	"github.com/pb33f/libopenapi-validator/helpers"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	"github.com/pb33f/libopenapi/utils"
 	"github.com/santhosh-tekuri/jsonschema/v6"
-	"golang.org/x/text/language"
-	"golang.org/x/text/message"
 	"gopkg.in/yaml.v3"
 	"log/slog"
 	"os"
-	"reflect"
 	"regexp"
 	"strconv"
-	"strings"
 	"sync"
 )
 
@@ -48,21 +45,24 @@ type SchemaValidator interface {
 var instanceLocationRegex = regexp.MustCompile(`^/(\d+)`)
 
 type schemaValidator struct {
-	logger *slog.Logger
-	lock   sync.Mutex
+	logger  *slog.Logger
+	lock    sync.Mutex
+	options *config.ValidationOptions
 }
 
 // NewSchemaValidatorWithLogger will create a new SchemaValidator instance, ready to accept schemas and payloads to validate.
-func NewSchemaValidatorWithLogger(logger *slog.Logger) SchemaValidator {
-	return &schemaValidator{logger: logger, lock: sync.Mutex{}}
+// Options can be supplied to change the behavior of the validator, such as adding custom formats.
+func NewSchemaValidatorWithLogger(logger *slog.Logger, opts ...config.Option) SchemaValidator {
+	return &schemaValidator{logger: logger, lock: sync.Mutex{}, options: config.NewValidationOptions(opts...)}
 }
 
 // NewSchemaValidator will create a new SchemaValidator instance, ready to accept schemas and payloads to validate.
-func NewSchemaValidator() SchemaValidator {
+// Options can be supplied to change the behavior of the validator, such as adding custom formats.
+func NewSchemaValidator(opts ...config.Option) SchemaValidator {
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		Level: slog.LevelError,
 	}))
-	return NewSchemaValidatorWithLogger(logger)
+	return NewSchemaValidatorWithLogger(logger, opts...)
 }
 
 func (s *schemaValidator) ValidateSchemaString(schema *base.Schema, payload string) (bool, []*liberrors.ValidationError) {
@@ -93,7 +93,7 @@ func (s *schemaValidator) validateSchema(schema *base.Schema, payload []byte, de
 	// render the schema, to be used for validation, stop this from running concurrently, mutations are made to state
 	// and, it will cause async issues.
 	s.lock.Lock()
-	renderedSchema, _ = schema.RenderInline()
+	renderedSchema, _ = helpers.RenderSchemaInline(schema)
 	s.lock.Unlock()
 
 	jsonSchema, _ := utils.ConvertYAMLtoJSON(renderedSchema)
@@ -124,16 +124,18 @@ func (s *schemaValidator) validateSchema(schema *base.Schema, payload []byte, de
 		}
 
 	}
-	compiler := jsonschema.NewCompiler()
-	compiler.UseLoader(helpers.NewCompilerLoader())
-
-	decodedSchema, _ := jsonschema.UnmarshalJSON(strings.NewReader(string(jsonSchema)))
-	_ = compiler.AddResource("schema.json", decodedSchema)
-	jsch, err := compiler.Compile("schema.json")
+	// compile the schema, or use the compiled schema from the cache if it has been compiled before.
+	jsch, decodedSchema, err := helpers.CompileSchema("schema", schema, jsonSchema, helpers.Schema, s.options)
 
 	var schemaValidationErrors []*liberrors.SchemaValidationFailure
 
 	// is the schema even valid? did it compile?
+	if patternErr, ok := err.(*helpers.InvalidPatternError); ok {
+		return false, []*liberrors.ValidationError{liberrors.InvalidSchemaPattern(patternErr, schema, "", helpers.Schema)}
+	}
+	if formatErr, ok := err.(*helpers.UnknownFormatError); ok {
+		return false, []*liberrors.ValidationError{liberrors.UnknownSchemaFormat(formatErr, schema, "", helpers.Schema)}
+	}
 	if err != nil {
 		var ve *jsonschema.SchemaValidationError
 		if errors.As(err, &ve) {
@@ -173,10 +175,13 @@ func (s *schemaValidator) validateSchema(schema *base.Schema, payload []byte, de
 			var jk *jsonschema.ValidationError
 			if errors.As(scErrs, &jk) {
 
-				// flatten the validationErrors
-				schFlatErr := jk.BasicOutput().Errors
-				schemaValidationErrors = extractBasicErrors(schFlatErr, renderedSchema,
-					decodedObject, payload, jk, schemaValidationErrors)
+				// failures of a schema are located by the value that failed, rather than the keyword.
+				describe := DescribeSchemaFailure(renderedSchema, decodedObject, payload)
+				schemaValidationErrors = liberrors.SchemaFailures(jk, decodedSchema,
+					func(failure *liberrors.SchemaValidationFailure, unit jsonschema.OutputUnit) {
+						describe(failure, unit)
+						failure.Location = unit.InstanceLocation
+					})
 			}
 			line := 1
 			col := 0
@@ -204,68 +209,51 @@ func (s *schemaValidator) validateSchema(schema *base.Schema, payload []byte, de
 	return true, nil
 }
 
-func extractBasicErrors(schFlatErrs []jsonschema.OutputUnit,
-	renderedSchema []byte, decodedObject interface{},
-	payload []byte, jk *jsonschema.ValidationError,
-	schemaValidationErrors []*liberrors.SchemaValidationFailure) []*liberrors.SchemaValidationFailure {
-	for q := range schFlatErrs {
-		er := schFlatErrs[q]
-
-		errMsg := er.Error.Kind.LocalizedString(message.NewPrinter(language.Tag{}))
-		if helpers.IgnoreRegex.MatchString(errMsg) {
-			continue // ignore this error, it's useless tbh, utter noise.
-		}
-		if er.Error != nil {
-
-			// re-encode the schema.
-			var renderedNode yaml.Node
-			_ = yaml.Unmarshal(renderedSchema, &renderedNode)
-
-			// locate the violated property in the schema
-			located := LocateSchemaPropertyNodeByJSONPath(renderedNode.Content[0], er.KeywordLocation)
-
-			// extract the element specified by the instance
-			val := instanceLocationRegex.FindStringSubmatch(er.InstanceLocation)
-			var referenceObject string
-
-			if len(val) > 0 {
-				referenceIndex, _ := strconv.Atoi(val[1])
-				if reflect.ValueOf(decodedObject).Type().Kind() == reflect.Slice {
-					found := decodedObject.([]any)[referenceIndex]
-					recoded, _ := json.MarshalIndent(found, "", "  ")
-					referenceObject = string(recoded)
-				}
-			}
-			if referenceObject == "" {
-				referenceObject = string(payload)
-			}
-
-			violation := &liberrors.SchemaValidationFailure{
-				Reason:           errMsg,
-				Location:         er.InstanceLocation,
-				DeepLocation:     er.KeywordLocation,
-				AbsoluteLocation: er.AbsoluteKeywordLocation,
-				ReferenceSchema:  string(renderedSchema),
-				ReferenceObject:  referenceObject,
-				OriginalError:    jk,
+// DescribeSchemaFailure returns a function that describes a failure of a value validated against a rendered schema,
+// for use with errors.SchemaFailures. The location of the failing keyword within the rendered schema is added to
+// the failure, along with the value that failed: the element that failed when the value is an array, otherwise the
+// whole payload.
+func DescribeSchemaFailure(renderedSchema []byte, decodedObject any,
+	payload []byte) func(*liberrors.SchemaValidationFailure, jsonschema.OutputUnit) {
+
+	// re-encode the schema, once for all of the failures.
+	var renderedNode yaml.Node
+	_ = yaml.Unmarshal(renderedSchema, &renderedNode)
+
+	return func(failure *liberrors.SchemaValidationFailure, unit jsonschema.OutputUnit) {
+		failure.ReferenceSchema = string(renderedSchema)
+
+		// extract the element specified by the instance
+		if val := instanceLocationRegex.FindStringSubmatch(unit.InstanceLocation); len(val) > 0 {
+			referenceIndex, _ := strconv.Atoi(val[1])
+			if items, ok := decodedObject.([]any); ok && referenceIndex < len(items) {
+				recoded, _ := json.MarshalIndent(items[referenceIndex], "", "  ")
+				failure.ReferenceObject = string(recoded)
 			}
-			// if we have a location within the schema, add it to the error
-			if located != nil {
-				line := located.Line
-				// if the located node is a map or an array, then the actual human interpretable
-				// line on which the violation occurred is the line of the key, not the value.
-				if located.Kind == yaml.MappingNode || located.Kind == yaml.SequenceNode {
-					if line > 0 {
-						line--
-					}
-				}
+		}
+		if failure.ReferenceObject == "" {
+			failure.ReferenceObject = string(payload)
+		}
 
-				// location of the violation within the rendered schema.
-				violation.Line = line
-				violation.Column = located.Column
+		// if we have a location within the schema, add it to the error
+		if len(renderedNode.Content) == 0 {
+			return
+		}
+		located := LocateSchemaPropertyNodeByJSONPath(renderedNode.Content[0], unit.KeywordLocation)
+		if located == nil {
+			return
+		}
+		line := located.Line
+		// if the located node is a map or an array, then the actual human interpretable
+		// line on which the violation occurred is the line of the key, not the value.
+		if located.Kind == yaml.MappingNode || located.Kind == yaml.SequenceNode {
+			if line > 0 {
+				line--
 			}
-			schemaValidationErrors = append(schemaValidationErrors, violation)
 		}
+
+		// location of the violation within the rendered schema.
+		failure.Line = line
+		failure.Column = located.Column
 	}
-	return schemaValidationErrors
 }
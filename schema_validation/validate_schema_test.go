@@ -5,9 +5,12 @@ package schema_validation
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi-validator/config"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v3"
 )
@@ -44,6 +47,78 @@ paths:
 	assert.Nil(t, foundNode)
 }
 
+func TestValidateSchema_CustomFormat(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                code:
+                  type: string
+                  format: burger-code`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	sch := m.Model.Paths.PathItems.GetOrZero("/burgers/createBurger").Post.RequestBody.Content.GetOrZero("application/json").Schema
+
+	v := NewSchemaValidator(config.WithCustomFormat("burger-code", func(v any) error {
+		if s, ok := v.(string); ok && !strings.HasPrefix(s, "BGR") {
+			return fmt.Errorf("'%s' is not a burger code", s)
+		}
+		return nil
+	}))
+
+	valid, errors := v.ValidateSchemaString(sch.Schema(), `{"code": "BGR-123"}`)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	valid, errors = v.ValidateSchemaString(sch.Schema(), `{"code": "PZA-123"}`)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Contains(t, errors[0].SchemaValidationErrors[0].Reason, "is not a burger code")
+}
+
+func TestValidateSchema_UnsupportedPattern(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                code:
+                  type: string
+                  pattern: '^(?=.*[0-9]).+$'
+                  maxLength: 5`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	m, _ := doc.BuildV3Model()
+	sch := m.Model.Paths.PathItems.GetOrZero("/burgers/createBurger").Post.RequestBody.Content.GetOrZero("application/json").Schema
+
+	v := NewSchemaValidator()
+	valid, errors := v.ValidateSchemaString(sch.Schema(), `{"code": "BGR"}`)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "schema pattern '^(?=.*[0-9]).+$' cannot be compiled", errors[0].Message)
+
+	v = NewSchemaValidator(config.WithSkipInvalidPatterns())
+	valid, errors = v.ValidateSchemaString(sch.Schema(), `{"code": "BGR"}`)
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	valid, errors = v.ValidateSchemaString(sch.Schema(), `{"code": "BGR-123"}`)
+	assert.False(t, valid)
+	assert.Len(t, errors, 1)
+}
+
 func TestValidateSchema_SimpleValid_String(t *testing.T) {
 	spec := `openapi: 3.1.0
 paths:
@@ -397,13 +472,15 @@ paths:
 
 	assert.False(t, valid)
 	assert.Len(t, errors, 1)
-	assert.Len(t, errors[0].SchemaValidationErrors, 2)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors[0].Branches, 2)
 
 	valid, errors = v.ValidateSchemaObject(sch.Schema(), cakePlease)
 
 	assert.False(t, valid)
 	assert.Len(t, errors, 1)
-	assert.Len(t, errors[0].SchemaValidationErrors, 2)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors[0].Branches, 2)
 
 	// or death!
 	bodyBytes, _ = json.Marshal(death)
@@ -414,13 +491,15 @@ paths:
 
 	assert.False(t, valid)
 	assert.Len(t, errors, 1)
-	assert.Len(t, errors[0].SchemaValidationErrors, 2)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors[0].Branches, 2)
 
 	valid, errors = v.ValidateSchemaObject(sch.Schema(), death)
 
 	assert.False(t, valid)
 	assert.Len(t, errors, 1)
-	assert.Len(t, errors[0].SchemaValidationErrors, 2)
+	assert.Len(t, errors[0].SchemaValidationErrors, 1)
+	assert.Len(t, errors[0].SchemaValidationErrors[0].Branches, 2)
 }
 
 func TestValidateSchema_EmptySchema(t *testing.T) {
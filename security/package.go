@@ -0,0 +1,6 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package security contains all the logic, models and interfaces for validating the security requirements of
+// OpenAPI 3+ operations. The package depends on *http.Request
+package security
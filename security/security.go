@@ -0,0 +1,43 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package security
+
+import (
+	"net/http"
+
+	"github.com/pb33f/libopenapi-validator/config"
+	"github.com/pb33f/libopenapi-validator/errors"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// SecurityValidator is an interface that defines the methods for validating the security requirements of Operations.
+//
+//	ValidateSecurity method accepts an *http.Request and returns true if validation passed,
+//	                 false if validation failed and a slice of ValidationError pointers.
+type SecurityValidator interface {
+	// ValidateSecurity will validate the request against the security requirements of the operation it matches.
+	// Each security requirement is an alternative, the request is valid if it satisfies at least one of them, and
+	// a requirement is only satisfied when every scheme in it is satisfied. The operation's security requirements
+	// override the global security requirements of the document. The first return value will be true if the
+	// request is allowed, false if it is not. The second return value will be a slice of ValidationError pointers,
+	// one for each scheme that was not satisfied.
+	ValidateSecurity(request *http.Request) (bool, []*errors.ValidationError)
+
+	// ValidateSecurityWithPathItem will validate the request against the security requirements of the operation,
+	// using a path item that has already been found. The first return value will be true if the request is allowed,
+	// false if it is not. The second return value will be a slice of ValidationError pointers if it is not.
+	ValidateSecurityWithPathItem(request *http.Request, pathItem *v3.PathItem, pathValue string) (bool, []*errors.ValidationError)
+}
+
+// NewSecurityValidator will create a new SecurityValidator from an OpenAPI 3+ document. Options can be
+// supplied to change the behavior of the validator, by default no options are enabled. The validator does not hold
+// any state between calls, so it is safe for concurrent use.
+func NewSecurityValidator(document *v3.Document, opts ...config.Option) SecurityValidator {
+	return &securityValidator{document: document, options: config.NewValidationOptions(opts...)}
+}
+
+type securityValidator struct {
+	document *v3.Document
+	options  *config.ValidationOptions
+}
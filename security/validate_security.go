@@ -0,0 +1,151 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package security
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi-validator/errors"
+	"github.com/pb33f/libopenapi-validator/helpers"
+	"github.com/pb33f/libopenapi-validator/paths"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+func (v *securityValidator) ValidateSecurity(request *http.Request) (bool, []*errors.ValidationError) {
+	pathItem, errs, foundPath := paths.FindPath(request, v.document)
+	if len(errs) > 0 {
+		return false, errs
+	}
+	return v.ValidateSecurityWithPathItem(request, pathItem, foundPath)
+}
+
+func (v *securityValidator) ValidateSecurityWithPathItem(request *http.Request, pathItem *v3.PathItem, pathValue string) (bool, []*errors.ValidationError) {
+	if pathItem == nil {
+		return false, []*errors.ValidationError{{
+			ValidationType:    helpers.ParameterValidationPath,
+			ValidationSubType: "missing",
+			Message:           fmt.Sprintf("%s Path '%s' not found", request.Method, request.URL.Path),
+			Reason: fmt.Sprintf("The %s request contains a path of '%s' "+
+				"however that path, or the %s method for that path does not exist in the specification",
+				request.Method, request.URL.Path, request.Method),
+			SpecLine: -1,
+			SpecCol:  -1,
+			HowToFix: errors.HowToFixPath,
+		}}
+	}
+	security := v.extractSecurity(request, pathItem)
+	if len(security) == 0 {
+		return true, nil
+	}
+
+	// each security requirement is an alternative, the request only needs to satisfy one of them.
+	var validationErrors []*errors.ValidationError
+	for _, sec := range security {
+		requirementErrors := v.validateSecurityRequirement(request, sec)
+		if len(requirementErrors) == 0 {
+			return true, nil
+		}
+		validationErrors = append(validationErrors, requirementErrors...)
+	}
+
+	errors.PopulateValidationErrors(validationErrors, request, pathValue)
+	return false, validationErrors
+}
+
+// extractSecurity will return the security requirements of the operation, when the operation does not declare any
+// security requirements the global security requirements of the document are used. An operation declaring an empty
+// list of requirements removes the global requirements.
+func (v *securityValidator) extractSecurity(request *http.Request, pathItem *v3.PathItem) []*base.SecurityRequirement {
+	operation := helpers.ExtractOperation(request, pathItem)
+	if operation == nil {
+		return nil
+	}
+	if operation.Security != nil {
+		return operation.Security
+	}
+	return v.document.Security
+}
+
+// validateSecurityRequirement will check every scheme in a single security requirement is satisfied by the request.
+func (v *securityValidator) validateSecurityRequirement(request *http.Request,
+	sec *base.SecurityRequirement) []*errors.ValidationError {
+	var validationErrors []*errors.ValidationError
+	for pair := orderedmap.First(sec.Requirements); pair != nil; pair = pair.Next() {
+		secName := pair.Key()
+
+		// look up security from components
+		if v.document.Components == nil || v.document.Components.SecuritySchemes.GetOrZero(secName) == nil {
+			validationErrors = append(validationErrors, errors.SecuritySchemeMissing(secName, sec))
+			continue
+		}
+		secScheme := v.document.Components.SecuritySchemes.GetOrZero(secName)
+		switch strings.ToLower(secScheme.Type) {
+		case "http":
+			switch strings.ToLower(secScheme.Scheme) {
+			case "basic", "bearer", "digest":
+				// check for an authorization header, using the declared scheme
+				authorization, _ := helpers.FindHeaderValue(request.Header, helpers.AuthorizationHeader)
+				if authorization == "" {
+					validationErrors = append(validationErrors, errors.SecurityAuthMissing(secScheme, sec))
+					continue
+				}
+				if !strings.EqualFold(secScheme.Scheme, "digest") &&
+					!helpers.HasAuthorizationScheme(authorization, secScheme.Scheme) {
+					validationErrors = append(validationErrors,
+						errors.SecurityAuthWrongScheme(secScheme, sec, authorization))
+				}
+			}
+
+		case "oauth2", "openidconnect":
+			// scopes can only be checked when the caller can tell us which scopes the request has been granted
+			if v.options.ScopeExtractor != nil && len(pair.Value()) > 0 {
+				if missing := missingScopes(pair.Value(), v.options.ScopeExtractor(request)); len(missing) > 0 {
+					validationErrors = append(validationErrors,
+						errors.SecurityInsufficientScope(secName, secScheme, sec, missing))
+				}
+			}
+
+		case "apikey":
+			// check if the api key is in the declared location of the request
+			if !apiKeyPresent(request, secScheme) {
+				validationErrors = append(validationErrors, errors.SecurityApiKeyMissing(secScheme, sec, request))
+			}
+		}
+	}
+	return validationErrors
+}
+
+// missingScopes will return the required scopes that are not in the granted scopes.
+func missingScopes(required, granted []string) []string {
+	var missing []string
+	for _, scope := range required {
+		if !slices.Contains(granted, scope) {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
+
+func apiKeyPresent(request *http.Request, secScheme *v3.SecurityScheme) bool {
+	switch secScheme.In {
+	case "header":
+		key, _ := helpers.FindHeaderValue(request.Header, secScheme.Name)
+		return key != ""
+	case "query":
+		return request.URL.Query().Get(secScheme.Name) != ""
+	case "cookie":
+		for _, cookie := range request.Cookies() {
+			if cookie.Name == secScheme.Name {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
@@ -4,17 +4,23 @@
 package validator
 
 import (
+	"context"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi-validator/config"
 	"github.com/pb33f/libopenapi-validator/errors"
+	"github.com/pb33f/libopenapi-validator/helpers"
 	"github.com/pb33f/libopenapi-validator/parameters"
 	"github.com/pb33f/libopenapi-validator/paths"
 	"github.com/pb33f/libopenapi-validator/requests"
 	"github.com/pb33f/libopenapi-validator/responses"
 	"github.com/pb33f/libopenapi-validator/schema_validation"
+	"github.com/pb33f/libopenapi-validator/security"
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/utils"
 )
 
 // Validator provides a coarse grained interface for validating an OpenAPI 3+ documents.
@@ -23,34 +29,93 @@ import (
 // Validating *http.Request objects against and OpenAPI 3+ document
 // Validating *http.Response objects against an OpenAPI 3+ document
 // Validating an OpenAPI 3+ document against the OpenAPI 3+ specification
+//
+// A Validator is safe for concurrent use, errors are only ever returned to the caller and never stored.
+// Call WarmSchemaCache before sharing a Validator between goroutines, so the schemas of the document are built
+// up front, rather than lazily by concurrent requests.
 type Validator interface {
 
 	// ValidateHttpRequest will validate an *http.Request object against an OpenAPI 3+ document.
-	// The path, query, cookie and header parameters and request body are validated.
+	// The path, query, cookie and header parameters, security requirements and request body are validated, and
+	// the errors from each are returned together, in that order.
 	ValidateHttpRequest(request *http.Request) (bool, []*errors.ValidationError)
 	// ValidateHttpRequestSync will validate an *http.Request object against an OpenAPI 3+ document syncronously and without spawning any goroutines.
-	// The path, query, cookie and header parameters and request body are validated.
+	// The path, query, cookie and header parameters, security requirements and request body are validated, and
+	// the errors from each are returned together, in that order.
 	ValidateHttpRequestSync(request *http.Request) (bool, []*errors.ValidationError)
 
 	// ValidateHttpRequestWithPathItem will validate an *http.Request object against an OpenAPI 3+ document.
-	// The path, query, cookie and header parameters and request body are validated.
+	// The path, query, cookie and header parameters, security requirements and request body are validated, and
+	// the errors from each are returned together, in that order.
 	ValidateHttpRequestWithPathItem(request *http.Request, pathItem *v3.PathItem, pathValue string) (bool, []*errors.ValidationError)
 
 	// ValidateHttpRequestSyncWithPathItem will validate an *http.Request object against an OpenAPI 3+ document syncronously and without spawning any goroutines.
-	// The path, query, cookie and header parameters and request body are validated.
+	// The path, query, cookie and header parameters, security requirements and request body are validated, and
+	// the errors from each are returned together, in that order.
 	ValidateHttpRequestSyncWithPathItem(request *http.Request, pathItem *v3.PathItem, pathValue string) (bool, []*errors.ValidationError)
 
-	// ValidateHttpResponse will an *http.Response object against an OpenAPI 3+ document.
-	// The response body is validated. The request is only used to extract the correct reponse from the spec.
+	// ValidateHttpRequestCtx will validate an *http.Request object against an OpenAPI 3+ document synchronously,
+	// checking the context before each stage of the validation. If the context is cancelled, or its deadline passes,
+	// the remaining stages are not run, and the errors found so far are returned, followed by an error with a
+	// ValidationSubType of 'cancelled'.
+	ValidateHttpRequestCtx(ctx context.Context, request *http.Request) (bool, []*errors.ValidationError)
+
+	// ValidateHttpResponse will validate an *http.Response object against an OpenAPI 3+ document.
+	// The response status code, content type, headers and body are validated, and the errors from each are returned
+	// together. The request is only used to extract the correct reponse from the spec.
 	ValidateHttpResponse(request *http.Request, response *http.Response) (bool, []*errors.ValidationError)
 
+	// ValidateResponse will validate an *http.Response object against an OpenAPI 3+ document, using the Request of
+	// the response to extract the correct response from the spec, so a response can be validated on its own.
+	// The response status code, content type, headers and body are validated. An error is returned if the response
+	// is nil, or has no Request.
+	ValidateResponse(response *http.Response) (bool, []*errors.ValidationError)
+
 	// ValidateHttpRequestResponse will validate both the *http.Request and *http.Response objects against an OpenAPI 3+ document.
 	// The path, query, cookie and header parameters and request and response body are validated.
 	ValidateHttpRequestResponse(request *http.Request, response *http.Response) (bool, []*errors.ValidationError)
 
+	// ValidateWebhookRequest will validate an *http.Request object against the operation of a webhook defined in the
+	// 'webhooks' of an OpenAPI 3.1 document, using the name of the webhook rather than the path of the request.
+	// The query, cookie and header parameters, security requirements and request body are validated, and the name of
+	// the webhook is used as the SpecPath of any errors.
+	ValidateWebhookRequest(name string, request *http.Request) (bool, []*errors.ValidationError)
+
+	// ValidateCallbackRequest will validate an *http.Request object against the operation of a callback declared in
+	// the 'callbacks' of an operation, using the name of the callback. The runtime expression of the callback is not
+	// evaluated, so the request can be sent to any URL. The query, cookie and header parameters, security requirements
+	// and request body are validated, and the callback expression is used as the SpecPath of any errors.
+	ValidateCallbackRequest(name string, request *http.Request) (bool, []*errors.ValidationError)
+
+	// ValidateByOperationID will validate an *http.Request object, and the *http.Response to it when the response is
+	// not nil, against the operation with the supplied operationId. The request is not matched against the paths of
+	// the document, so tests do not depend on the path templates, however the method of the request must be the method
+	// of the operation. Path parameters are read from the segments of the request path, at the positions of the
+	// parameters in the path template of the operation.
+	ValidateByOperationID(operationID string, request *http.Request, response *http.Response) (bool, []*errors.ValidationError)
+
+	// LookupOperationByID will return the operation with the supplied operationId, or false if no operation in the
+	// document declares it.
+	LookupOperationByID(operationID string) (*v3.Operation, bool)
+
 	// ValidateDocument will validate an OpenAPI 3+ document against the 3.0 or 3.1 OpenAPI 3+ specification
 	ValidateDocument() (bool, []*errors.ValidationError)
 
+	// ValidateDocumentSchemas will check every schema used by the parameters, request bodies and responses of
+	// the document can be resolved and compiled, so mistakes in the specification are found before serving traffic.
+	ValidateDocumentSchemas() (bool, []*errors.ValidationError)
+
+	// ValidateComponentSchema will validate a value against a schema defined in the 'components' of the document,
+	// using the name of the schema, for example 'Pet' for '#/components/schemas/Pet'. The value can be any Go value
+	// that can be encoded as JSON, so values can be validated without an *http.Request.
+	ValidateComponentSchema(name string, value interface{}) (bool, []*errors.ValidationError)
+
+	// WarmSchemaCache will compile the request and response body schemas of every operation in the document
+	// and store them in the schema cache, so the first requests validated do not pay the cost of compiling them.
+	// Building the schemas up front also means they are not built lazily by concurrent requests. Parameter
+	// schemas are compiled and cached the first time they are used.
+	WarmSchemaCache()
+
 	// GetParameterValidator will return a parameters.ParameterValidator instance used to validate parameters
 	GetParameterValidator() parameters.ParameterValidator
 
@@ -59,35 +124,61 @@ type Validator interface {
 
 	// GetResponseBodyValidator will return a parameters.ResponseBodyValidator instance used to validate response bodies
 	GetResponseBodyValidator() responses.ResponseBodyValidator
+
+	// GetSecurityValidator will return a security.SecurityValidator instance used to validate security requirements
+	GetSecurityValidator() security.SecurityValidator
 }
 
-// NewValidator will create a new Validator from an OpenAPI 3+ document
-func NewValidator(document libopenapi.Document) (Validator, []error) {
+// ValidateHttpRequestSyncError will validate an *http.Request object with a Validator, synchronously, and return
+// every validation error combined into a single error, for call sites that only need to check 'if err != nil'.
+// The combined error is an errors.ValidationErrors, so the individual errors can still be extracted. A nil error is
+// returned if the request is valid.
+func ValidateHttpRequestSyncError(v Validator, request *http.Request) error {
+	_, validationErrors := v.ValidateHttpRequestSync(request)
+	return errors.Join(validationErrors)
+}
+
+// NewValidator will create a new Validator from an OpenAPI 3+ document. Options can be supplied to change the
+// behavior of the validators, for example:
+//
+//	NewValidator(document, config.WithStrictQueryParams(), config.WithFormatAssertions())
+//
+// By default, no options are enabled.
+func NewValidator(document libopenapi.Document, opts ...config.Option) (Validator, []error) {
 	m, errs := document.BuildV3Model()
 	if errs != nil {
 		return nil, errs
 	}
-	v := NewValidatorFromV3Model(&m.Model)
+	v := NewValidatorFromV3Model(&m.Model, opts...)
 	v.(*validator).document = document
 	return v, nil
 }
 
-// NewValidatorFromV3Model will create a new Validator from an OpenAPI Model
-func NewValidatorFromV3Model(m *v3.Document) Validator {
+// NewValidatorFromV3Model will create a new Validator from an OpenAPI Model. Options can be supplied to change the
+// behavior of the validators, by default no options are enabled.
+func NewValidatorFromV3Model(m *v3.Document, opts ...config.Option) Validator {
+	// all validators share the same options, so compiled schemas are cached once for the whole document.
+	options := config.NewValidationOptions(opts...)
+
 	// create a new parameter validator
-	paramValidator := parameters.NewParameterValidator(m)
+	paramValidator := parameters.NewParameterValidator(m, config.WithExistingOpts(options))
 
 	// create a new request body validator
-	reqBodyValidator := requests.NewRequestBodyValidator(m)
+	reqBodyValidator := requests.NewRequestBodyValidator(m, config.WithExistingOpts(options))
 
 	// create a response body validator
-	respBodyValidator := responses.NewResponseBodyValidator(m)
+	respBodyValidator := responses.NewResponseBodyValidator(m, config.WithExistingOpts(options))
+
+	// create a security validator
+	securityValidator := security.NewSecurityValidator(m, config.WithExistingOpts(options))
 
 	return &validator{
 		v3Model:           m,
+		options:           options,
 		requestValidator:  reqBodyValidator,
 		responseValidator: respBodyValidator,
 		paramValidator:    paramValidator,
+		securityValidator: securityValidator,
 	}
 }
 
@@ -100,11 +191,69 @@ func (v *validator) GetRequestBodyValidator() requests.RequestBodyValidator {
 func (v *validator) GetResponseBodyValidator() responses.ResponseBodyValidator {
 	return v.responseValidator
 }
+func (v *validator) GetSecurityValidator() security.SecurityValidator {
+	return v.securityValidator
+}
+
+func (v *validator) WarmSchemaCache() {
+	if v.v3Model == nil || v.v3Model.Paths == nil || v.v3Model.Paths.PathItems == nil {
+		return
+	}
+	for pathItem := range v.v3Model.Paths.PathItems.ValuesFromOldest() {
+		for operation := range pathItem.GetOperations().ValuesFromOldest() {
+			if operation.RequestBody != nil && operation.RequestBody.Content != nil {
+				for mediaType := range operation.RequestBody.Content.ValuesFromOldest() {
+					v.warmSchema("requestBody", mediaType, helpers.RequestBodyValidation)
+				}
+			}
+			if operation.Responses == nil {
+				continue
+			}
+			responses := []*v3.Response{operation.Responses.Default}
+			if operation.Responses.Codes != nil {
+				for response := range operation.Responses.Codes.ValuesFromOldest() {
+					responses = append(responses, response)
+				}
+			}
+			for _, response := range responses {
+				if response == nil || response.Content == nil {
+					continue
+				}
+				for mediaType := range response.Content.ValuesFromOldest() {
+					v.warmSchema(helpers.ResponseBodyValidation, mediaType, helpers.ResponseBodyValidation)
+				}
+			}
+		}
+	}
+}
+
+// warmSchema will compile the schema of a media type into the schema cache, using the same key the
+// request and response body validators use when they look it up.
+func (v *validator) warmSchema(name string, mediaType *v3.MediaType, validationType string) {
+	if mediaType == nil || mediaType.Schema == nil {
+		return
+	}
+	schema := mediaType.Schema.Schema()
+	if schema == nil {
+		return
+	}
+	renderedSchema, _ := helpers.RenderSchemaInline(schema)
+	jsonSchema, _ := utils.ConvertYAMLtoJSON(renderedSchema)
+	_, _, _ = helpers.CompileSchema(name, schema, jsonSchema, validationType, v.options)
+}
 
 func (v *validator) ValidateDocument() (bool, []*errors.ValidationError) {
 	return schema_validation.ValidateOpenAPIDocument(v.document)
 }
 
+func (v *validator) ValidateDocumentSchemas() (bool, []*errors.ValidationError) {
+	return schema_validation.ValidateDocumentSchemas(v.v3Model, config.WithExistingOpts(v.options))
+}
+
+func (v *validator) ValidateComponentSchema(name string, value interface{}) (bool, []*errors.ValidationError) {
+	return schema_validation.ValidateComponentSchema(v.v3Model, name, value, config.WithExistingOpts(v.options))
+}
+
 func (v *validator) ValidateHttpResponse(
 	request *http.Request,
 	response *http.Response) (bool, []*errors.ValidationError) {
@@ -113,15 +262,13 @@ func (v *validator) ValidateHttpResponse(
 	var pathValue string
 	var errs []*errors.ValidationError
 
-	pathItem, errs, pathValue = paths.FindPath(request, v.v3Model)
+	pathItem, errs, pathValue = v.findPath(request)
 	if pathItem == nil || errs != nil {
 		return false, errs
 	}
 
-	responseBodyValidator := v.responseValidator
-
 	// validate response
-	_, responseErrors := responseBodyValidator.ValidateResponseBodyWithPathItem(request, response, pathItem, pathValue)
+	_, responseErrors := v.validateResponse(request, response, pathItem, pathValue)
 
 	if len(responseErrors) > 0 {
 		return false, responseErrors
@@ -129,6 +276,16 @@ func (v *validator) ValidateHttpResponse(
 	return true, nil
 }
 
+func (v *validator) ValidateResponse(response *http.Response) (bool, []*errors.ValidationError) {
+	if response == nil {
+		return false, []*errors.ValidationError{errors.ResponseMissing()}
+	}
+	if response.Request == nil {
+		return false, []*errors.ValidationError{errors.ResponseRequestMissing(response)}
+	}
+	return v.ValidateHttpResponse(response.Request, response)
+}
+
 func (v *validator) ValidateHttpRequestResponse(
 	request *http.Request,
 	response *http.Response) (bool, []*errors.ValidationError) {
@@ -137,16 +294,17 @@ func (v *validator) ValidateHttpRequestResponse(
 	var pathValue string
 	var errs []*errors.ValidationError
 
-	pathItem, errs, pathValue = paths.FindPath(request, v.v3Model)
+	pathItem, errs, pathValue = v.findPath(request)
 	if pathItem == nil || errs != nil {
 		return false, errs
 	}
 
-	responseBodyValidator := v.responseValidator
-
-	// validate request and response
+	// validate request and response, the response is not validated if the request has failed when failing fast.
 	_, requestErrors := v.ValidateHttpRequestWithPathItem(request, pathItem, pathValue)
-	_, responseErrors := responseBodyValidator.ValidateResponseBodyWithPathItem(request, response, pathItem, pathValue)
+	if v.options.FailFast && len(requestErrors) > 0 {
+		return false, requestErrors
+	}
+	_, responseErrors := v.validateResponse(request, response, pathItem, pathValue)
 
 	if len(requestErrors) > 0 || len(responseErrors) > 0 {
 		return false, append(requestErrors, responseErrors...)
@@ -155,7 +313,7 @@ func (v *validator) ValidateHttpRequestResponse(
 }
 
 func (v *validator) ValidateHttpRequest(request *http.Request) (bool, []*errors.ValidationError) {
-	pathItem, errs, foundPath := paths.FindPath(request, v.v3Model)
+	pathItem, errs, foundPath := v.findPath(request)
 	if len(errs) > 0 {
 		return false, errs
 	}
@@ -163,100 +321,34 @@ func (v *validator) ValidateHttpRequest(request *http.Request) (bool, []*errors.
 }
 
 func (v *validator) ValidateHttpRequestWithPathItem(request *http.Request, pathItem *v3.PathItem, pathValue string) (bool, []*errors.ValidationError) {
-	// create a new parameter validator
-	paramValidator := v.paramValidator
-
-	// create a new request body validator
-	reqBodyValidator := v.requestValidator
-
-	// create some channels to handle async validation
-	doneChan := make(chan bool)
-	errChan := make(chan []*errors.ValidationError)
-	controlChan := make(chan bool)
-
-	// async param validation function.
-	parameterValidationFunc := func(control chan bool, errorChan chan []*errors.ValidationError) {
-		paramErrs := make(chan []*errors.ValidationError)
-		paramControlChan := make(chan bool)
-		paramFunctionControlChan := make(chan bool)
-		var paramValidationErrors []*errors.ValidationError
-
-		validations := []validationFunction{
-			paramValidator.ValidatePathParamsWithPathItem,
-			paramValidator.ValidateCookieParamsWithPathItem,
-			paramValidator.ValidateHeaderParamsWithPathItem,
-			paramValidator.ValidateQueryParamsWithPathItem,
-			paramValidator.ValidateSecurityWithPathItem,
-		}
-
-		// listen for validation errors on parameters. everything will run async.
-		paramListener := func(control chan bool, errorChan chan []*errors.ValidationError) {
-			completedValidations := 0
-			for {
-				select {
-				case vErrs := <-errorChan:
-					paramValidationErrors = append(paramValidationErrors, vErrs...)
-				case <-control:
-					completedValidations++
-					if completedValidations == len(validations) {
-						paramFunctionControlChan <- true
-						return
-					}
-				}
-			}
-		}
-
-		validateParamFunction := func(
-			control chan bool,
-			errorChan chan []*errors.ValidationError,
-			validatorFunc validationFunction) {
-			valid, pErrs := validatorFunc(request, pathItem, pathValue)
-			if !valid {
-				errorChan <- pErrs
-			}
-			control <- true
-		}
-		go paramListener(paramControlChan, paramErrs)
-		for i := range validations {
-			go validateParamFunction(paramControlChan, paramErrs, validations[i])
-		}
-
-		// wait for all the validations to complete
-		<-paramFunctionControlChan
-		if len(paramValidationErrors) > 0 {
-			errorChan <- paramValidationErrors
-		}
-
-		// let runValidation know we are done with this part.
-		controlChan <- true
+	// the validations must run in order to stop at the first error.
+	if v.options.FailFast {
+		return v.ValidateHttpRequestSyncWithPathItem(request, pathItem, pathValue)
 	}
 
-	requestBodyValidationFunc := func(control chan bool, errorChan chan []*errors.ValidationError) {
-		valid, pErrs := reqBodyValidator.ValidateRequestBodyWithPathItem(request, pathItem, pathValue)
-		if !valid {
-			errorChan <- pErrs
-		}
-		control <- true
+	validations := v.requestValidations()
+
+	// every validation runs async and writes its errors into its own slot, so nothing is shared between the
+	// goroutines, and the errors are returned in the same order as ValidateHttpRequestSync returns them.
+	results := make([][]*errors.ValidationError, len(validations))
+	var wg sync.WaitGroup
+	for i := range validations {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if valid, vErrs := validations[i](request, pathItem, pathValue); !valid {
+				results[i] = vErrs
+			}
+		}(i)
 	}
 
-	// build async functions
-	asyncFunctions := []validationFunctionAsync{
-		parameterValidationFunc,
-		requestBodyValidationFunc,
-	}
+	// wait for all the validations to complete
+	wg.Wait()
 
 	var validationErrors []*errors.ValidationError
-
-	// sit and wait for everything to report back.
-	go runValidation(controlChan, doneChan, errChan, &validationErrors, len(asyncFunctions))
-
-	// run async functions
-	for i := range asyncFunctions {
-		go asyncFunctions[i](controlChan, errChan)
+	for i := range results {
+		validationErrors = append(validationErrors, results[i]...)
 	}
-
-	// wait for all the validations to complete
-	<-doneChan
 	if len(validationErrors) > 0 {
 		return false, validationErrors
 	}
@@ -264,7 +356,7 @@ func (v *validator) ValidateHttpRequestWithPathItem(request *http.Request, pathI
 }
 
 func (v *validator) ValidateHttpRequestSync(request *http.Request) (bool, []*errors.ValidationError) {
-	pathItem, errs, foundPath := paths.FindPath(request, v.v3Model)
+	pathItem, errs, foundPath := v.findPath(request)
 	if len(errs) > 0 {
 		return false, errs
 	}
@@ -272,72 +364,147 @@ func (v *validator) ValidateHttpRequestSync(request *http.Request) (bool, []*err
 }
 
 func (v *validator) ValidateHttpRequestSyncWithPathItem(request *http.Request, pathItem *v3.PathItem, pathValue string) (bool, []*errors.ValidationError) {
-	// create a new parameter validator
-	paramValidator := v.paramValidator
+	return v.validateHttpRequestSync(context.Background(), request, pathItem, pathValue)
+}
 
-	// create a new request body validator
-	reqBodyValidator := v.requestValidator
-
-	validationErrors := make([]*errors.ValidationError, 0)
-
-	paramValidationErrors := make([]*errors.ValidationError, 0)
-	for _, validateFunc := range []validationFunction{
-		paramValidator.ValidatePathParamsWithPathItem,
-		paramValidator.ValidateCookieParamsWithPathItem,
-		paramValidator.ValidateHeaderParamsWithPathItem,
-		paramValidator.ValidateQueryParamsWithPathItem,
-		paramValidator.ValidateSecurityWithPathItem,
-	} {
-		valid, pErrs := validateFunc(request, pathItem, pathValue)
+func (v *validator) ValidateHttpRequestCtx(ctx context.Context, request *http.Request) (bool, []*errors.ValidationError) {
+	if err := ctx.Err(); err != nil {
+		return false, []*errors.ValidationError{errors.RequestValidationCancelled(request, err)}
+	}
+	pathItem, errs, foundPath := v.findPath(request)
+	if len(errs) > 0 {
+		return false, errs
+	}
+	return v.validateHttpRequestSync(ctx, request, pathItem, foundPath)
+}
+
+// validateHttpRequestSync will run each of the request validations in order, stopping before the next validation
+// once the context is done.
+func (v *validator) validateHttpRequestSync(ctx context.Context, request *http.Request, pathItem *v3.PathItem,
+	pathValue string) (bool, []*errors.ValidationError) {
+
+	var validationErrors []*errors.ValidationError
+	for _, validateFunc := range v.requestValidations() {
+		if err := ctx.Err(); err != nil {
+			return false, append(validationErrors, errors.RequestValidationCancelled(request, err))
+		}
+		valid, vErrs := validateFunc(request, pathItem, pathValue)
 		if !valid {
-			paramValidationErrors = append(paramValidationErrors, pErrs...)
+			validationErrors = append(validationErrors, vErrs...)
+			if v.options.FailFast && len(validationErrors) > 0 {
+				return false, validationErrors[:1]
+			}
 		}
 	}
+	if len(validationErrors) > 0 {
+		return false, validationErrors
+	}
+	return true, nil
+}
 
-	valid, pErrs := reqBodyValidator.ValidateRequestBodyWithPathItem(request, pathItem, pathValue)
-	if !valid {
-		paramValidationErrors = append(paramValidationErrors, pErrs...)
+func (v *validator) ValidateWebhookRequest(name string, request *http.Request) (bool, []*errors.ValidationError) {
+	pathItem, errs := paths.FindWebhook(name, request, v.v3Model)
+	if len(errs) > 0 {
+		return false, errs
 	}
+	return v.ValidateHttpRequestWithPathItem(request, pathItem, name)
+}
 
-	validationErrors = append(validationErrors, paramValidationErrors...)
+func (v *validator) ValidateCallbackRequest(name string, request *http.Request) (bool, []*errors.ValidationError) {
+	pathItem, errs, expression := paths.FindCallback(name, request, v.v3Model)
+	if len(errs) > 0 {
+		return false, errs
+	}
+	return v.ValidateHttpRequestWithPathItem(request, pathItem, expression)
+}
 
-	if len(validationErrors) > 0 {
-		return false, validationErrors
+func (v *validator) ValidateByOperationID(
+	operationID string,
+	request *http.Request,
+	response *http.Response) (bool, []*errors.ValidationError) {
+
+	pathItem, errs, pathValue := paths.FindOperationByID(operationID, request, v.v3Model)
+	if len(errs) > 0 {
+		return false, errs
 	}
 
+	// the response is only validated when there is one, and not if the request has failed when failing fast.
+	_, requestErrors := v.ValidateHttpRequestWithPathItem(request, pathItem, pathValue)
+	if response == nil || (v.options.FailFast && len(requestErrors) > 0) {
+		return len(requestErrors) == 0, requestErrors
+	}
+	_, responseErrors := v.validateResponse(request, response, pathItem, pathValue)
+
+	if len(requestErrors) > 0 || len(responseErrors) > 0 {
+		return false, append(requestErrors, responseErrors...)
+	}
 	return true, nil
 }
 
+func (v *validator) LookupOperationByID(operationID string) (*v3.Operation, bool) {
+	operation, _, _, ok := paths.LookupOperationByID(operationID, v.v3Model)
+	return operation, ok
+}
+
+// requestValidations will return the validations run against a request, in the order their errors are returned.
+func (v *validator) requestValidations() []validationFunction {
+	return []validationFunction{
+		v.observed(config.StagePathParams, v.paramValidator.ValidatePathParamsWithPathItem),
+		v.observed(config.StageCookieParams, v.paramValidator.ValidateCookieParamsWithPathItem),
+		v.observed(config.StageHeaderParams, v.paramValidator.ValidateHeaderParamsWithPathItem),
+		v.observed(config.StageQueryParams, v.paramValidator.ValidateQueryParamsWithPathItem),
+		v.observed(config.StageSecurity, v.securityValidator.ValidateSecurityWithPathItem),
+		v.observed(config.StageRequestBody, v.requestValidator.ValidateRequestBodyWithPathItem),
+	}
+}
+
+// observed will wrap a validation, so the observer of the options is called with its duration. The validation is
+// returned as it is when there is no observer, so nothing is timed.
+func (v *validator) observed(stage string, validate validationFunction) validationFunction {
+	observer := v.options.Observer
+	if observer == nil {
+		return validate
+	}
+	return func(request *http.Request, pathItem *v3.PathItem, pathValue string) (bool, []*errors.ValidationError) {
+		start := time.Now()
+		valid, errs := validate(request, pathItem, pathValue)
+		observer.OnStageComplete(stage, time.Since(start))
+		return valid, errs
+	}
+}
+
+// findPath will find the path item of a request, reporting the duration to the observer of the options.
+func (v *validator) findPath(request *http.Request) (*v3.PathItem, []*errors.ValidationError, string) {
+	if v.options.Observer == nil {
+		return paths.FindPath(request, v.v3Model)
+	}
+	start := time.Now()
+	pathItem, errs, pathValue := paths.FindPath(request, v.v3Model)
+	v.options.Observer.OnStageComplete(config.StagePath, time.Since(start))
+	return pathItem, errs, pathValue
+}
+
+// validateResponse will validate a response, reporting the duration to the observer of the options.
+func (v *validator) validateResponse(request *http.Request, response *http.Response, pathItem *v3.PathItem,
+	pathValue string) (bool, []*errors.ValidationError) {
+
+	if v.options.Observer == nil {
+		return v.responseValidator.ValidateResponseBodyWithPathItem(request, response, pathItem, pathValue)
+	}
+	start := time.Now()
+	valid, errs := v.responseValidator.ValidateResponseBodyWithPathItem(request, response, pathItem, pathValue)
+	v.options.Observer.OnStageComplete(config.StageResponse, time.Since(start))
+	return valid, errs
+}
+
 type validator struct {
 	v3Model           *v3.Document
 	document          libopenapi.Document
+	options           *config.ValidationOptions
 	paramValidator    parameters.ParameterValidator
 	requestValidator  requests.RequestBodyValidator
 	responseValidator responses.ResponseBodyValidator
-}
-
-func runValidation(control, doneChan chan bool,
-	errorChan chan []*errors.ValidationError,
-	validationErrors *[]*errors.ValidationError,
-	total int) {
-
-	var validationLock sync.Mutex
-	completedValidations := 0
-	for {
-		select {
-		case vErrs := <-errorChan:
-			validationLock.Lock()
-			*validationErrors = append(*validationErrors, vErrs...)
-			validationLock.Unlock()
-		case <-control:
-			completedValidations++
-			if completedValidations == total {
-				doneChan <- true
-				return
-			}
-		}
-	}
+	securityValidator security.SecurityValidator
 }
 
 type validationFunction func(request *http.Request, pathItem *v3.PathItem, pathValue string) (bool, []*errors.ValidationError)
-type validationFunctionAsync func(control chan bool, errorChan chan []*errors.ValidationError)
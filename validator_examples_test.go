@@ -84,8 +84,7 @@ func ExampleNewValidator_validateHttpRequest() {
 			fmt.Printf("Type: %s, Failure: %s\n", e.ValidationType, e.Message)
 		}
 	}
-	// Output: Type: security, Failure: API Key api_key not found in header
-	// Type: parameter, Failure: Path parameter 'petId' is not a valid number
+	// Output: Type: parameter, Failure: Path parameter 'petId' is not a valid integer
 }
 
 func ExampleNewValidator_validateHttpRequestSync() {
@@ -122,8 +121,7 @@ func ExampleNewValidator_validateHttpRequestSync() {
 			fmt.Printf("Type: %s, Failure: %s\n", e.ValidationType, e.Message)
 		}
 	}
-	// Type: parameter, Failure: Path parameter 'petId' is not a valid number
-	// Output: Type: security, Failure: API Key api_key not found in header
+	// Output: Type: parameter, Failure: Path parameter 'petId' is not a valid integer
 }
 
 func ExampleNewValidator_validateHttpRequestResponse() {
@@ -5,13 +5,20 @@ package validator
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi-validator/cache"
+	"github.com/pb33f/libopenapi-validator/config"
+	"github.com/pb33f/libopenapi-validator/errors"
 	"github.com/pb33f/libopenapi-validator/helpers"
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
 	"github.com/stretchr/testify/assert"
@@ -43,6 +50,7 @@ paths:
 	v, _ := NewValidator(doc)
 	assert.NotNil(t, v.GetParameterValidator())
 	assert.NotNil(t, v.GetResponseBodyValidator())
+	assert.NotNil(t, v.GetSecurityValidator())
 	assert.NotNil(t, v.GetRequestBodyValidator())
 }
 
@@ -1144,9 +1152,8 @@ func TestNewValidator_PetStore_PetGet200_PathNotFound(t *testing.T) {
 	valid, errors := v.ValidateHttpRequestResponse(request, res.Result())
 
 	assert.False(t, valid)
-	assert.Len(t, errors, 2)
-	assert.Equal(t, "API Key api_key not found in header", errors[0].Message)
-	assert.Equal(t, "Path parameter 'petId' is not a valid number", errors[1].Message)
+	assert.Len(t, errors, 1)
+	assert.Equal(t, "Path parameter 'petId' is not a valid integer", errors[0].Message)
 }
 
 func TestNewValidator_PetStore_PetGet200(t *testing.T) {
@@ -1561,3 +1568,1151 @@ paths:
 	assert.True(t, valid)
 	assert.Len(t, errors, 0)
 }
+
+func TestNewValidator_ValidateHttpRequest_BodyRestored(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/createBurger:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+                patties:
+                  type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	v, _ := NewValidator(doc)
+
+	for name, body := range map[string]string{
+		"valid":   `{"name":"Big Mac","patties":2}`,
+		"invalid": `{"name":"Big Mac","patties":"two"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+				bytes.NewBufferString(body))
+			request.Header.Set("Content-Type", "application/json")
+
+			_, _ = v.ValidateHttpRequest(request)
+			read, err := io.ReadAll(request.Body)
+			require.NoError(t, err)
+			assert.Equal(t, body, string(read))
+
+			request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers/createBurger",
+				bytes.NewBufferString(body))
+			request.Header.Set("Content-Type", "application/json")
+
+			_, _ = v.ValidateHttpRequestSync(request)
+			read, err = io.ReadAll(request.Body)
+			require.NoError(t, err)
+			assert.Equal(t, body, string(read))
+		})
+	}
+}
+
+func TestNewValidator_ValidateHttpResponse_AggregatedErrors(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}:
+    get:
+      parameters:
+        - name: burgerId
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        '200':
+          headers:
+            X-Rate-Limit:
+              required: true
+              schema:
+                type: integer
+            X-Burger-Style:
+              schema:
+                type: string
+                enum: [grilled, fried]
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  name:
+                    type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	v, _ := NewValidator(doc)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/1", nil)
+
+	res := httptest.NewRecorder()
+	res.Header().Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+	res.Header().Set("X-Burger-Style", "steamed")
+	res.WriteHeader(http.StatusOK)
+	_, _ = res.Write([]byte(`{"name":123}`))
+
+	valid, errors := v.ValidateHttpResponse(request, res.Result())
+
+	assert.False(t, valid)
+	require.Len(t, errors, 3)
+	assert.Equal(t, "GET / 200 operation response header 'X-Rate-Limit' is missing", errors[0].Message)
+	assert.Equal(t, "Response header 'X-Burger-Style' failed to validate", errors[1].Message)
+	assert.Equal(t, "200 response body for '/burgers/1' failed to validate schema", errors[2].Message)
+}
+
+func TestNewValidator_ValidateResponse(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}:
+    get:
+      responses:
+        '200':
+          headers:
+            X-Rate-Limit:
+              required: true
+              schema:
+                type: integer
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  name:
+                    type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	v, _ := NewValidator(doc)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/1", nil)
+
+	res := httptest.NewRecorder()
+	res.Header().Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+	res.Header().Set("X-Rate-Limit", "10")
+	res.WriteHeader(http.StatusOK)
+	_, _ = res.Write([]byte(`{"name":"Big Mac"}`))
+
+	response := res.Result()
+	response.Request = request
+
+	valid, errors := v.ValidateResponse(response)
+
+	assert.True(t, valid)
+	assert.Len(t, errors, 0)
+
+	res = httptest.NewRecorder()
+	res.Header().Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+	res.WriteHeader(http.StatusOK)
+	_, _ = res.Write([]byte(`{"name":123}`))
+
+	response = res.Result()
+	response.Request = request
+
+	valid, errors = v.ValidateResponse(response)
+
+	assert.False(t, valid)
+	require.Len(t, errors, 2)
+	assert.Equal(t, "GET / 200 operation response header 'X-Rate-Limit' is missing", errors[0].Message)
+	assert.Equal(t, "200 response body for '/burgers/1' failed to validate schema", errors[1].Message)
+}
+
+func TestNewValidator_ValidateResponse_NoRequest(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    get:
+      responses:
+        '200':
+          description: OK`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	v, _ := NewValidator(doc)
+
+	response := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+	}
+
+	valid, errors := v.ValidateResponse(response)
+
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, helpers.ResponseMissingRequest, errors[0].ValidationSubType)
+	assert.Equal(t, "200 response has no request", errors[0].Message)
+}
+
+func TestNewValidator_ValidateResponse_NilResponse(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    get:
+      responses:
+        '200':
+          description: OK`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	v, _ := NewValidator(doc)
+
+	valid, errors := v.ValidateResponse(nil)
+
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, helpers.ResponseMissing, errors[0].ValidationSubType)
+	assert.Equal(t, "response is missing", errors[0].Message)
+}
+
+func TestNewValidator_WarmSchemaCache(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  id:
+                    type: integer
+        default:
+          content:
+            application/json:
+              schema:
+                type: string
+    get:
+      responses:
+        '204':
+          description: no content`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	v, _ := NewValidator(doc)
+
+	schemaCache := v.(*validator).options.SchemaCache
+	assert.Equal(t, 0, schemaCache.Len())
+
+	v.WarmSchemaCache()
+	assert.Equal(t, 3, schemaCache.Len())
+
+	// validating uses the warmed schemas, rather than compiling them again.
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers",
+		bytes.NewBufferString(`{"name": "big mac"}`))
+	request.Header.Set("Content-Type", "application/json")
+	valid, errs := v.ValidateHttpRequest(request)
+	assert.True(t, valid)
+	assert.Empty(t, errs)
+	assert.Equal(t, 3, schemaCache.Len())
+}
+
+func TestNewValidator_SchemaCache_Concurrent(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	v, _ := NewValidator(doc)
+	v.WarmSchemaCache()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(valid bool) {
+			defer wg.Done()
+			body := `{"name": "big mac"}`
+			if !valid {
+				body = `{"name": 12}`
+			}
+			request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers", bytes.NewBufferString(body))
+			request.Header.Set("Content-Type", "application/json")
+			ok, errs := v.GetRequestBodyValidator().ValidateRequestBody(request)
+			assert.Equal(t, valid, ok)
+			if !valid {
+				assert.Len(t, errs, 1)
+			}
+		}(i%2 == 0)
+	}
+	wg.Wait()
+	assert.Equal(t, 1, v.(*validator).options.SchemaCache.Len())
+}
+
+func TestNewValidator_ValidateDocumentSchemas(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    get:
+      parameters:
+        - name: fries
+          in: query
+          schema:
+            type: chips`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	v, _ := NewValidator(doc)
+
+	valid, errs := v.ValidateDocumentSchemas()
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Equal(t, helpers.UncompilableSchema, errs[0].ValidationSubType)
+	assert.Equal(t, "/paths/~1burgers/get/parameters/0/schema", errs[0].SpecPath)
+}
+
+func TestNewValidator_ValidateComponentSchema(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Burger:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        patties:
+          type: integer
+          maximum: 3`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	v, _ := NewValidator(doc)
+
+	valid, errs := v.ValidateComponentSchema("Burger", map[string]any{"name": "Big Mac", "patties": 2})
+	assert.True(t, valid)
+	assert.Empty(t, errs)
+
+	valid, errs = v.ValidateComponentSchema("Burger", map[string]any{"patties": 5})
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Len(t, errs[0].SchemaValidationErrors, 2)
+
+	valid, errs = v.ValidateComponentSchema("Fries", map[string]any{})
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Equal(t, helpers.MissingComponentSchema, errs[0].ValidationSubType)
+}
+
+func TestNewValidator_ValidateHttpRequest_RequestBodyRef(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    post:
+      requestBody:
+        $ref: '#/components/requestBodies/Burger'
+components:
+  requestBodies:
+    Burger:
+      content:
+        application/json:
+          schema:
+            type: object
+            properties:
+              patties:
+                type: integer
+                maximum: 3`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+	v, _ := NewValidator(doc)
+	v.WarmSchemaCache()
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers", strings.NewReader(`{"patties": 5}`))
+	request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+
+	valid, errs := v.ValidateHttpRequest(request)
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Equal(t, helpers.RequestBodyValidation, errs[0].ValidationType)
+	assert.Equal(t, "maximum: got 5, want 3", errs[0].SchemaValidationErrors[0].Reason)
+}
+
+var optionsSpec = `openapi: 3.1.0
+paths:
+  /burgers:
+    post:
+      parameters:
+        - name: size
+          in: query
+          schema:
+            type: string
+      security:
+        - burger_auth: [write:burgers]
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                id:
+                  type: integer
+                  readOnly: true
+                name:
+                  type: string
+                contact:
+                  type: string
+                  format: email
+                sauce:
+                  type: string
+                  default: ketchup
+      responses:
+        '200':
+          description: OK
+components:
+  securitySchemes:
+    burger_auth:
+      type: oauth2
+      flows:
+        implicit:
+          authorizationUrl: https://things.com/oauth
+          scopes:
+            write:burgers: create burgers`
+
+// validateOptionsRequest will validate a request to the options spec, with a validator created with the options.
+func validateOptionsRequest(t *testing.T, url, body string, opts ...config.Option) (*http.Request, bool, []*errors.ValidationError) {
+	doc, err := libopenapi.NewDocument([]byte(optionsSpec))
+	require.NoError(t, err)
+	v, errs := NewValidator(doc, opts...)
+	require.Empty(t, errs)
+
+	request, _ := http.NewRequest(http.MethodPost, url, bytes.NewBufferString(body))
+	request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+	valid, validationErrors := v.ValidateHttpRequestSync(request)
+	return request, valid, validationErrors
+}
+
+func TestNewValidator_Options_Defaults(t *testing.T) {
+	// without any options, none of the optional behaviors are enabled.
+	_, valid, errs := validateOptionsRequest(t, "https://things.com/burgers?size=large&extra=cheese",
+		`{"id": 1, "name": "Big Mac", "contact": "not-an-email"}`)
+	assert.True(t, valid)
+	assert.Len(t, errs, 0)
+}
+
+func TestNewValidator_Options_StrictQueryParams(t *testing.T) {
+	_, valid, errs := validateOptionsRequest(t, "https://things.com/burgers?size=large&extra=cheese",
+		`{"name": "Big Mac"}`, config.WithStrictQueryParams())
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Equal(t, helpers.ParameterValidationQuery, errs[0].ValidationSubType)
+	assert.Contains(t, errs[0].Message, "extra")
+}
+
+func TestNewValidator_Options_FormatAssertions(t *testing.T) {
+	_, valid, errs := validateOptionsRequest(t, "https://things.com/burgers",
+		`{"name": "Big Mac", "contact": "not-an-email"}`, config.WithFormatAssertions())
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "/properties/contact/format", errs[0].SchemaValidationErrors[0].Location)
+}
+
+func TestNewValidator_Options_StrictReadWriteOnly(t *testing.T) {
+	_, valid, errs := validateOptionsRequest(t, "https://things.com/burgers",
+		`{"id": 1, "name": "Big Mac"}`, config.WithStrictReadWriteOnly())
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "POST request body for '/burgers' contains the read only property '/id'", errs[0].Message)
+}
+
+func TestNewValidator_Options_ApplyDefaults(t *testing.T) {
+	request, valid, errs := validateOptionsRequest(t, "https://things.com/burgers",
+		`{"name": "Big Mac"}`, config.WithApplyDefaults())
+	assert.True(t, valid)
+	assert.Len(t, errs, 0)
+
+	body, _ := io.ReadAll(request.Body)
+	assert.JSONEq(t, `{"name": "Big Mac", "sauce": "ketchup"}`, string(body))
+}
+
+func TestNewValidator_Options_ScopeExtractor(t *testing.T) {
+	extractor := config.WithScopeExtractor(func(request *http.Request) []string {
+		return nil
+	})
+	_, valid, errs := validateOptionsRequest(t, "https://things.com/burgers", `{"name": "Big Mac"}`, extractor)
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "Insufficient scope for security scheme 'burger_auth'", errs[0].Message)
+}
+
+func TestNewValidator_Options_NoSchemaCache(t *testing.T) {
+	schemaCache := cache.NewSchemaCache()
+	_, valid, _ := validateOptionsRequest(t, "https://things.com/burgers", `{"name": "Big Mac"}`,
+		config.WithSchemaCache(schemaCache))
+	assert.True(t, valid)
+	assert.Equal(t, 1, schemaCache.Len())
+
+	_, valid, _ = validateOptionsRequest(t, "https://things.com/burgers", `{"name": "Big Mac"}`,
+		config.WithSchemaCache(nil))
+	assert.True(t, valid)
+}
+
+func TestNewValidatorFromV3Model_Options(t *testing.T) {
+	doc, _ := libopenapi.NewDocument([]byte(optionsSpec))
+	m, _ := doc.BuildV3Model()
+
+	v := NewValidatorFromV3Model(&m.Model, config.WithStrictQueryParams())
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers?extra=cheese", nil)
+	valid, errs := v.GetParameterValidator().ValidateQueryParams(request)
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+}
+
+func TestNewValidator_ValidateHttpRequest_AggregatedErrors(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}:
+    post:
+      security:
+        - apiKey: []
+      parameters:
+        - name: burgerId
+          in: path
+          required: true
+          schema:
+            type: integer
+        - name: sauce
+          in: cookie
+          schema:
+            type: string
+            enum: [ketchup, mustard]
+        - name: X-Burger-Style
+          in: header
+          schema:
+            type: string
+            enum: [grilled, fried]
+        - name: patties
+          in: query
+          schema:
+            type: integer
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+components:
+  securitySchemes:
+    apiKey:
+      type: apiKey
+      in: header
+      name: X-API-Key`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	v, _ := NewValidator(doc)
+
+	newRequest := func() *http.Request {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers/one?patties=two",
+			bytes.NewBufferString(`{"name":123}`))
+		request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+		request.Header.Set("X-Burger-Style", "steamed")
+		request.AddCookie(&http.Cookie{Name: "sauce", Value: "mayo"})
+		return request
+	}
+
+	// every category is validated, and the errors are returned together, in the same order for both modes.
+	for name, validate := range map[string]func(*http.Request) (bool, []*errors.ValidationError){
+		"async": v.ValidateHttpRequest,
+		"sync":  v.ValidateHttpRequestSync,
+	} {
+		t.Run(name, func(t *testing.T) {
+			valid, errs := validate(newRequest())
+
+			assert.False(t, valid)
+			require.Len(t, errs, 6)
+			assert.Equal(t, helpers.ParameterValidationPath, errs[0].ValidationSubType)
+			assert.Equal(t, helpers.ParameterValidationCookie, errs[1].ValidationSubType)
+			assert.Equal(t, helpers.ParameterValidationHeader, errs[2].ValidationSubType)
+			assert.Equal(t, helpers.ParameterValidationQuery, errs[3].ValidationSubType)
+			assert.Equal(t, helpers.SecurityValidation, errs[4].ValidationType)
+			assert.Equal(t, helpers.RequestBodyValidation, errs[5].ValidationType)
+
+			// every error identifies the operation that produced it.
+			for _, err := range errs {
+				assert.Equal(t, http.MethodPost, err.RequestMethod)
+				assert.Equal(t, "/burgers/{burgerId}", err.SpecPath)
+				assert.Equal(t, "/burgers/one", err.RequestPath)
+			}
+		})
+	}
+}
+
+// trackingBody records whether a request or response body has been read, so tests can check a validator was skipped.
+type trackingBody struct {
+	io.Reader
+	read bool
+}
+
+func (b *trackingBody) Read(p []byte) (int, error) {
+	b.read = true
+	return b.Reader.Read(p)
+}
+
+func (b *trackingBody) Close() error {
+	return nil
+}
+
+func TestNewValidator_FailFast(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    post:
+      parameters:
+        - name: patties
+          in: query
+          schema:
+            type: integer
+        - name: cheese
+          in: query
+          schema:
+            type: boolean
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  id:
+                    type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	newRequest := func() (*http.Request, *trackingBody) {
+		body := &trackingBody{Reader: strings.NewReader(`{"name":123}`)}
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers?patties=two&cheese=maybe", body)
+		request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+		return request, body
+	}
+
+	// by default, every error is returned.
+	v, _ := NewValidator(doc)
+	request, _ := newRequest()
+	valid, errs := v.ValidateHttpRequest(request)
+	assert.False(t, valid)
+	assert.Len(t, errs, 3)
+
+	// only the first error is returned, and the request body is never read.
+	v, _ = NewValidator(doc, config.WithFailFast())
+	for name, validate := range map[string]func(*http.Request) (bool, []*errors.ValidationError){
+		"async": v.ValidateHttpRequest,
+		"sync":  v.ValidateHttpRequestSync,
+	} {
+		t.Run(name, func(t *testing.T) {
+			request, body := newRequest()
+			valid, errs := validate(request)
+			assert.False(t, valid)
+			require.Len(t, errs, 1)
+			assert.Equal(t, helpers.ParameterValidationQuery, errs[0].ValidationSubType)
+			assert.False(t, body.read)
+		})
+	}
+
+	// the response is not validated once the request has failed.
+	request, _ = newRequest()
+	responseBody := &trackingBody{Reader: strings.NewReader(`{"id":"one"}`)}
+	response := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{helpers.ContentTypeHeader: []string{helpers.JSONContentType}},
+		Body:       responseBody,
+	}
+	valid, errs = v.ValidateHttpRequestResponse(request, response)
+	assert.False(t, valid)
+	assert.Len(t, errs, 1)
+	assert.False(t, responseBody.read)
+}
+
+func TestNewValidator_ValidationErrorOperation(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}:
+    get:
+      parameters:
+        - name: burgerId
+          in: path
+          required: true
+          schema:
+            type: integer
+        - name: patties
+          in: query
+          schema:
+            type: integer
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  name:
+                    type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	v, _ := NewValidator(doc)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://things.com/burgers/1?patties=two", nil)
+
+	valid, errs := v.ValidateHttpRequest(request)
+
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Equal(t, helpers.ParameterValidationQuery, errs[0].ValidationSubType)
+	assert.Equal(t, http.MethodGet, errs[0].RequestMethod)
+	assert.Equal(t, "/burgers/{burgerId}", errs[0].SpecPath)
+	assert.Equal(t, "/burgers/1", errs[0].RequestPath)
+
+	res := httptest.NewRecorder()
+	res.Header().Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+	res.WriteHeader(http.StatusOK)
+	_, _ = res.Write([]byte(`{"name":123}`))
+
+	valid, errs = v.ValidateHttpResponse(request, res.Result())
+
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Equal(t, http.MethodGet, errs[0].RequestMethod)
+	assert.Equal(t, "/burgers/{burgerId}", errs[0].SpecPath)
+}
+
+func TestNewValidator_ValidateWebhookRequest(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+webhooks:
+  newBurger:
+    post:
+      parameters:
+        - name: X-Signature
+          in: header
+          required: true
+          schema:
+            type: string
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+                patties:
+                  type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	v, _ := NewValidator(doc)
+
+	newRequest := func(body string) *http.Request {
+		request, _ := http.NewRequest(http.MethodPost, "https://consumer.com/hooks/burgers", bytes.NewBufferString(body))
+		request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+		request.Header.Set("X-Signature", "abc123")
+		return request
+	}
+
+	valid, errs := v.ValidateWebhookRequest("newBurger", newRequest(`{"name": "big mac", "patties": 2}`))
+	assert.True(t, valid)
+	assert.Empty(t, errs)
+
+	valid, errs = v.ValidateWebhookRequest("newBurger", newRequest(`{"name": "big mac", "patties": "two"}`))
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Equal(t, helpers.RequestBodyValidation, errs[0].ValidationType)
+	assert.Equal(t, "POST request body for '/hooks/burgers' failed to validate schema", errs[0].Message)
+	assert.Equal(t, "newBurger", errs[0].SpecPath)
+
+	request := newRequest(`{"name": "big mac"}`)
+	request.Header.Del("X-Signature")
+	valid, errs = v.ValidateWebhookRequest("newBurger", request)
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Equal(t, helpers.ParameterValidationHeader, errs[0].ValidationSubType)
+
+	valid, errs = v.ValidateWebhookRequest("oldBurger", newRequest(`{"name": "big mac"}`))
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "Webhook 'oldBurger' not found", errs[0].Message)
+}
+
+func TestNewValidator_ValidateByOperationID(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}:
+    put:
+      operationId: updateBurger
+      parameters:
+        - name: burgerId
+          in: path
+          required: true
+          schema:
+            type: integer
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [id]
+                properties:
+                  id:
+                    type: integer`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	v, _ := NewValidator(doc)
+
+	operation, found := v.LookupOperationByID("updateBurger")
+	require.True(t, found)
+	assert.Equal(t, "updateBurger", operation.OperationId)
+
+	_, found = v.LookupOperationByID("deleteBurger")
+	assert.False(t, found)
+
+	newRequest := func(body string) *http.Request {
+		request, _ := http.NewRequest(http.MethodPut, "https://things.com/burgers/12", bytes.NewBufferString(body))
+		request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+		return request
+	}
+	newResponse := func(body string) *http.Response {
+		res := httptest.NewRecorder()
+		res.Header().Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+		res.WriteHeader(http.StatusOK)
+		_, _ = res.Write([]byte(body))
+		return res.Result()
+	}
+
+	valid, errs := v.ValidateByOperationID("updateBurger", newRequest(`{"name": "big mac"}`), nil)
+	assert.True(t, valid)
+	assert.Empty(t, errs)
+
+	valid, errs = v.ValidateByOperationID("updateBurger", newRequest(`{"name": "big mac"}`), newResponse(`{"id": 12}`))
+	assert.True(t, valid)
+	assert.Empty(t, errs)
+
+	// the request and response bodies are validated against the operation.
+	valid, errs = v.ValidateByOperationID("updateBurger", newRequest(`{"name": 12}`), newResponse(`{"id": "twelve"}`))
+	assert.False(t, valid)
+	require.Len(t, errs, 2)
+	assert.Equal(t, helpers.RequestBodyValidation, errs[0].ValidationType)
+	assert.Equal(t, "/burgers/{burgerId}", errs[0].SpecPath)
+	assert.Equal(t, helpers.ResponseBodyValidation, errs[1].ValidationType)
+
+	valid, errs = v.ValidateByOperationID("deleteBurger", newRequest(`{"name": "big mac"}`), nil)
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Equal(t, helpers.RequestMissingOperationID, errs[0].ValidationSubType)
+	assert.Equal(t, "Operation 'deleteBurger' not found", errs[0].Message)
+
+	request := newRequest(`{"name": "big mac"}`)
+	request.Method = http.MethodPost
+	valid, errs = v.ValidateByOperationID("updateBurger", request, nil)
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "POST operation 'updateBurger' does not exist", errs[0].Message)
+}
+
+func TestNewValidator_ValidateCallbackRequest(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                callbackUrl:
+                  type: string
+      callbacks:
+        burgerReady:
+          '{$request.body#/callbackUrl}':
+            post:
+              requestBody:
+                required: true
+                content:
+                  application/json:
+                    schema:
+                      type: object
+                      required: [burgerId]
+                      properties:
+                        burgerId:
+                          type: integer
+                        status:
+                          type: string
+                          enum: [cooking, ready]`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	v, _ := NewValidator(doc)
+
+	newRequest := func(method, body string) *http.Request {
+		request, _ := http.NewRequest(method, "https://consumer.com/notify/burgers", bytes.NewBufferString(body))
+		request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+		return request
+	}
+
+	valid, errs := v.ValidateCallbackRequest("burgerReady", newRequest(http.MethodPost, `{"burgerId": 1, "status": "ready"}`))
+	assert.True(t, valid)
+	assert.Empty(t, errs)
+
+	valid, errs = v.ValidateCallbackRequest("burgerReady", newRequest(http.MethodPost, `{"burgerId": 1, "status": "eaten"}`))
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Equal(t, helpers.RequestBodyValidation, errs[0].ValidationType)
+	assert.Equal(t, "POST request body for '/notify/burgers' failed to validate schema", errs[0].Message)
+	assert.Equal(t, "{$request.body#/callbackUrl}", errs[0].SpecPath)
+
+	valid, errs = v.ValidateCallbackRequest("burgerReady", newRequest(http.MethodPut, `{"burgerId": 1}`))
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "PUT operation for callback 'burgerReady' does not exist", errs[0].Message)
+
+	valid, errs = v.ValidateCallbackRequest("burgerEaten", newRequest(http.MethodPost, `{"burgerId": 1}`))
+	assert.False(t, valid)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "Callback 'burgerEaten' not found", errs[0].Message)
+}
+
+func TestValidateHttpRequestSyncError(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    post:
+      parameters:
+        - name: patties
+          in: query
+          schema:
+            type: integer
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	v, _ := NewValidator(doc)
+
+	request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers?patties=2",
+		bytes.NewBufferString(`{"name":"big mac"}`))
+	request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+
+	assert.NoError(t, ValidateHttpRequestSyncError(v, request))
+
+	request, _ = http.NewRequest(http.MethodPost, "https://things.com/burgers?patties=two",
+		bytes.NewBufferString(`{"name":123}`))
+	request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+
+	err := ValidateHttpRequestSyncError(v, request)
+	require.Error(t, err)
+
+	var validationErrors errors.ValidationErrors
+	require.ErrorAs(t, err, &validationErrors)
+	require.Len(t, validationErrors, 2)
+	assert.True(t, strings.HasPrefix(err.Error(), "2 validation errors:\n"))
+	for _, validationError := range validationErrors {
+		assert.Contains(t, err.Error(), validationError.Error())
+	}
+}
+
+func TestNewValidator_ValidateHttpRequestCtx(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers:
+    post:
+      security:
+        - burger_auth:
+          - write:burgers
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                patties:
+                  type: integer
+components:
+  securitySchemes:
+    burger_auth:
+      type: oauth2
+      flows:
+        implicit:
+          authorizationUrl: https://things.com/oauth/authorize
+          scopes:
+            write:burgers: make burgers`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the scopes are read by the security validation, which cancels the context before the body is validated.
+	v, _ := NewValidator(doc, config.WithScopeExtractor(func(request *http.Request) []string {
+		cancel()
+		return []string{"write:burgers"}
+	}))
+
+	newRequest := func() *http.Request {
+		request, _ := http.NewRequest(http.MethodPost, "https://things.com/burgers",
+			bytes.NewBufferString(`{"patties": "two"}`))
+		request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+		request.Header.Set("Authorization", "Bearer abc")
+		return request
+	}
+
+	valid, errors := v.ValidateHttpRequestCtx(ctx, newRequest())
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, helpers.RequestValidation, errors[0].ValidationType)
+	assert.Equal(t, helpers.RequestValidationCancelled, errors[0].ValidationSubType)
+	assert.Equal(t, "POST request validation for '/burgers' was cancelled", errors[0].Message)
+	assert.Contains(t, errors[0].Reason, context.Canceled.Error())
+
+	// a context that is already done stops the validation before it starts.
+	valid, errors = v.ValidateHttpRequestCtx(ctx, newRequest())
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, helpers.RequestValidationCancelled, errors[0].ValidationSubType)
+
+	// without cancelling, every stage is validated.
+	valid, errors = v.ValidateHttpRequestCtx(context.Background(), newRequest())
+	assert.False(t, valid)
+	require.Len(t, errors, 1)
+	assert.Equal(t, helpers.RequestBodyValidation, errors[0].ValidationType)
+}
+
+// recordingObserver records the stages reported by a validator.
+type recordingObserver struct {
+	mu     sync.Mutex
+	stages map[string]int
+}
+
+func (o *recordingObserver) OnStageComplete(stage string, duration time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.stages == nil {
+		o.stages = make(map[string]int)
+	}
+	o.stages[stage]++
+}
+
+func TestNewValidator_Observer(t *testing.T) {
+
+	spec := `openapi: 3.1.0
+paths:
+  /burgers/{burgerId}:
+    put:
+      parameters:
+        - name: burgerId
+          in: path
+          required: true
+          schema:
+            type: integer
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                type: object`
+
+	doc, _ := libopenapi.NewDocument([]byte(spec))
+
+	observer := &recordingObserver{}
+	v, _ := NewValidator(doc, config.WithObserver(observer))
+
+	request, _ := http.NewRequest(http.MethodPut, "https://things.com/burgers/1", bytes.NewBufferString(`{}`))
+	request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+
+	res := httptest.NewRecorder()
+	res.Header().Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+	res.WriteHeader(http.StatusOK)
+	_, _ = res.Write([]byte(`{}`))
+
+	valid, errors := v.ValidateHttpRequestResponse(request, res.Result())
+	assert.True(t, valid)
+	assert.Empty(t, errors)
+
+	assert.Equal(t, map[string]int{
+		config.StagePath:         1,
+		config.StagePathParams:   1,
+		config.StageCookieParams: 1,
+		config.StageHeaderParams: 1,
+		config.StageQueryParams:  1,
+		config.StageSecurity:     1,
+		config.StageRequestBody:  1,
+		config.StageResponse:     1,
+	}, observer.stages)
+
+	// the synchronous validation reports the same request stages.
+	observer.stages = nil
+	request, _ = http.NewRequest(http.MethodPut, "https://things.com/burgers/1", bytes.NewBufferString(`{}`))
+	request.Header.Set(helpers.ContentTypeHeader, helpers.JSONContentType)
+	valid, _ = v.ValidateHttpRequestSync(request)
+	assert.True(t, valid)
+	assert.Len(t, observer.stages, 7)
+	assert.NotContains(t, observer.stages, config.StageResponse)
+}